@@ -0,0 +1,251 @@
+package hermes
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	utils "github.com/realTristan/hermes/utils"
+)
+
+// Analyzer is a type that represents a language preset of the text analysis of the full-text index, which folds the diacritics
+// of the words, removes the stop words of the language and reduces the other words to their stem with the stemmer of the language.
+// An analyzer can be set for the whole index with FTSetAnalyzer, or for some of its fields with FTSetFieldAnalyzers,
+// so a multilingual cache can analyze each field in its own language.
+type Analyzer int
+
+const (
+	// AnalyzerNone analyzes the words with the stemmer and stop words set with FTSetStemmer and FTSetStopWords, without folding them.
+	AnalyzerNone Analyzer = iota
+	// AnalyzerEnglish folds the words, removes EnglishStopWords and stems the words with StemPorter.
+	AnalyzerEnglish
+	// AnalyzerGerman folds the words, so "ä" matches "a" and "ß" matches "ss", removes GermanStopWords and stems the words with StemGerman.
+	AnalyzerGerman
+	// AnalyzerFrench folds the words, so "é" matches "e" and "ç" matches "c", removes FrenchStopWords and stems the words with StemFrench.
+	AnalyzerFrench
+	// AnalyzerSpanish folds the words, so "á" matches "a" and "ñ" matches "n", removes SpanishStopWords and stems the words with StemSpanish.
+	AnalyzerSpanish
+	// AnalyzerRussian folds "ё" to "е", keeps the Cyrillic letters of the words, removes RussianStopWords and stems the words with StemRussian.
+	AnalyzerRussian
+)
+
+// analyzerPreset is a struct that represents the stemmer and stop words of an analyzer.
+//
+// Fields:
+//   - stemmer (Stemmer): The stemmer of the language.
+//   - stopWords (map[string]bool): The folded stop words of the language.
+type analyzerPreset struct {
+	stemmer   Stemmer
+	stopWords map[string]bool
+}
+
+// analyzerPresets maps each analyzer other than AnalyzerNone to its stemmer and stop words.
+var analyzerPresets map[Analyzer]analyzerPreset = map[Analyzer]analyzerPreset{
+	AnalyzerEnglish: {stemmer: StemPorter, stopWords: foldStopWords(EnglishStopWords)},
+	AnalyzerGerman:  {stemmer: StemGerman, stopWords: foldStopWords(GermanStopWords)},
+	AnalyzerFrench:  {stemmer: StemFrench, stopWords: foldStopWords(FrenchStopWords)},
+	AnalyzerSpanish: {stemmer: StemSpanish, stopWords: foldStopWords(SpanishStopWords)},
+	AnalyzerRussian: {stemmer: StemRussian, stopWords: foldStopWords(RussianStopWords)},
+}
+
+// foldStopWords is a function that returns the set of the folded forms of stop words, which is how the analyzers match them.
+//
+// Parameters:
+//   - words ([]string): The stop words.
+//
+// Returns:
+//   - map[string]bool: The folded stop words.
+func foldStopWords(words []string) map[string]bool {
+	var stopWords map[string]bool = make(map[string]bool, len(words))
+	for _, w := range words {
+		stopWords[utils.Fold(strings.ToLower(w))] = true
+	}
+	return stopWords
+}
+
+// validAnalyzer is a function that checks whether an analyzer is AnalyzerNone or one of the presets.
+//
+// Parameters:
+//   - analyzer (Analyzer): The analyzer.
+//
+// Returns:
+//   - bool: true if the analyzer is valid, false otherwise.
+func validAnalyzer(analyzer Analyzer) bool {
+	_, ok := analyzerPresets[analyzer]
+	return ok || analyzer == AnalyzerNone
+}
+
+// FTSetAnalyzer is a method of the Cache struct that sets the analyzer of the fields of the full-text index that don't have
+// their own analyzer, such as AnalyzerGerman, so the words are folded, stemmed and stripped of the stop words of that language
+// when values are indexed and when the index is searched. The full-text index is rebuilt, so the analyzer also applies to the values
+// that are already stored.
+// This method is thread-safe.
+//
+// Parameters:
+//   - analyzer (Analyzer): The analyzer, or AnalyzerNone to use the stemmer and stop words of the index.
+//
+// Returns:
+//   - error: An error if the full-text index is not initialized, the analyzer is invalid, or the index could not be rebuilt.
+func (c *Cache) FTSetAnalyzer(analyzer Analyzer) error {
+	if !validAnalyzer(analyzer) {
+		return errors.New("invalid analyzer")
+	}
+
+	// Lock the mutex
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	// Check if the ft is initialized
+	if c.ft == nil {
+		return errors.New("full text not initialized")
+	} else if c.ft.analyzer == analyzer {
+		return nil
+	}
+
+	// Rebuild the full-text index
+	var ft *FullText = c.ft.empty()
+	ft.analyzer = analyzer
+	return c.ftReindex("FTSetAnalyzer", ft)
+}
+
+// FTAnalyzer is a method of the Cache struct that returns the analyzer of the fields of the full-text index that don't have their own analyzer.
+// This method is thread-safe.
+//
+// Returns:
+//   - Analyzer: The analyzer.
+//   - error: An error if the full-text index is not initialized.
+func (c *Cache) FTAnalyzer() (Analyzer, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	// Check if the ft is initialized
+	if c.ft == nil {
+		return AnalyzerNone, errors.New("full text not initialized")
+	}
+	return c.ft.analyzer, nil
+}
+
+// FTSetFieldAnalyzers is a method of the Cache struct that sets the analyzer of some fields of the full-text index, such as
+// {"title_de": AnalyzerGerman, "title_fr": AnalyzerFrench}, so the values of each field are analyzed in their own language.
+// The other fields use the analyzer set with FTSetAnalyzer. A query is analyzed with every analyzer of the index, so it matches
+// the words of each field in that field's language. The analyzers replace the previous ones, and nil or an empty map removes them.
+// The full-text index is rebuilt, so the analyzers also apply to the values that are already stored.
+// This method is thread-safe.
+//
+// Parameters:
+//   - analyzers (map[string]Analyzer): The analyzer of each field.
+//
+// Returns:
+//   - error: An error if the full-text index is not initialized, an analyzer is invalid, or the index could not be rebuilt.
+func (c *Cache) FTSetFieldAnalyzers(analyzers map[string]Analyzer) error {
+	var fieldAnalyzers map[string]Analyzer = nil
+	for field, analyzer := range analyzers {
+		if !validAnalyzer(analyzer) {
+			return fmt.Errorf("invalid analyzer for field %s", field)
+		} else if fieldAnalyzers == nil {
+			fieldAnalyzers = make(map[string]Analyzer, len(analyzers))
+		}
+		fieldAnalyzers[field] = analyzer
+	}
+
+	// Lock the mutex
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	// Check if the ft is initialized
+	if c.ft == nil {
+		return errors.New("full text not initialized")
+	}
+
+	// Rebuild the full-text index
+	var ft *FullText = c.ft.empty()
+	ft.analyzers = fieldAnalyzers
+	return c.ftReindex("FTSetFieldAnalyzers", ft)
+}
+
+// FTFieldAnalyzers is a method of the Cache struct that returns a copy of the analyzers of the fields that have their own analyzer.
+// This method is thread-safe.
+//
+// Returns:
+//   - map[string]Analyzer: The analyzer of each field.
+//   - error: An error if the full-text index is not initialized.
+func (c *Cache) FTFieldAnalyzers() (map[string]Analyzer, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	// Check if the ft is initialized
+	if c.ft == nil {
+		return nil, errors.New("full text not initialized")
+	}
+
+	// Copy the analyzers
+	var analyzers map[string]Analyzer = make(map[string]Analyzer, len(c.ft.analyzers))
+	for k, v := range c.ft.analyzers {
+		analyzers[k] = v
+	}
+	return analyzers, nil
+}
+
+// fieldAnalyzer is a method of the FullText struct that returns the analyzer of a field.
+//
+// Parameters:
+//   - field (string): The name of the field.
+//
+// Returns:
+//   - Analyzer: The analyzer of the field, or the analyzer of the index if the field doesn't have its own.
+func (ft *FullText) fieldAnalyzer(field string) Analyzer {
+	if analyzer, ok := ft.analyzers[field]; ok {
+		return analyzer
+	}
+	return ft.analyzer
+}
+
+// analyzerSet is a method of the FullText struct that returns the distinct analyzers used by the fields, including the analyzer of the index.
+//
+// Returns:
+//   - []Analyzer: The analyzers, in ascending order.
+func (ft *FullText) analyzerSet() []Analyzer {
+	var result []Analyzer = []Analyzer{ft.analyzer}
+	for _, analyzer := range ft.analyzers {
+		var found bool = false
+		for _, a := range result {
+			if a == analyzer {
+				found = true
+				break
+			}
+		}
+		if !found {
+			result = append(result, analyzer)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i] < result[j]
+	})
+	return result
+}
+
+// wordForms is a method of the FullText struct that returns the distinct forms of a lowercase word that are stored in the full-text index
+// by each analyzer of the index, so a word of a query can be looked up in the fields of every language.
+//
+// Parameters:
+//   - word (string): The lowercase word.
+//
+// Returns:
+//   - []string: The folded stems of the word, one for each distinct form.
+func (ft *FullText) wordForms(word string) []string {
+	var forms []string = []string{}
+	for _, analyzer := range ft.analyzerSet() {
+		var (
+			w       string  = word
+			stemmer Stemmer = ft.stemmer
+		)
+		if preset, ok := analyzerPresets[analyzer]; ok {
+			w, stemmer = utils.Fold(word), preset.stemmer
+		}
+		if form := stemWords(stemmer, []string{w})[0]; !utils.SliceContains(forms, form) {
+			forms = append(forms, form)
+		}
+	}
+	return forms
+}
@@ -0,0 +1,367 @@
+package hermes
+
+import (
+	"errors"
+	"fmt"
+	"math/bits"
+	"strconv"
+)
+
+// bitmap is a set of slot numbers, stored as one bit per slot.
+type bitmap []uint64
+
+// boolIndex is a struct that represents the boolean indexes and the field presence bitmaps of a cache.
+// Every key is given a slot number, shared by all the bitmaps, so that the bitmaps of different fields can be intersected a word at a time.
+//
+// Fields:
+//   - fields (map[string]*[2]bitmap): The bitmaps of the slots whose field is false and true, keyed by field.
+//   - present (map[string]bitmap): The bitmaps of the slots whose field is set to a value other than nil, keyed by field.
+//   - all (bitmap): The bitmap of the slots of every key.
+//   - slots (map[string]int): The slot number of each key.
+//   - keys ([]string): The key of each slot, or an empty string if the slot is free.
+//   - free ([]int): The slot numbers of removed keys, to be reused.
+type boolIndex struct {
+	fields  map[string]*[2]bitmap
+	present map[string]bitmap
+	all     bitmap
+	slots   map[string]int
+	keys    []string
+	free    []int
+}
+
+// newBoolIndex is a function that returns an empty boolean index.
+//
+// Returns:
+//   - *boolIndex: The boolean index.
+func newBoolIndex() *boolIndex {
+	return &boolIndex{
+		fields:  make(map[string]*[2]bitmap),
+		present: make(map[string]bitmap),
+		all:     bitmap{},
+		slots:   make(map[string]int),
+		keys:    []string{},
+		free:    []int{},
+	}
+}
+
+// CreateBoolIndex is a method of the Cache struct that builds a boolean index for the provided field.
+// The index holds a bitmap of the keys whose field is true and another of the keys whose field is false, so that
+// `field:true` and `field:false` terms of Filter are intersected without comparing strings.
+// Only values of type bool are indexed, so a field holding the string "true" doesn't match.
+// This method is thread-safe.
+//
+// Parameters:
+//   - field (string): The boolean field to index.
+//
+// Returns:
+//   - error: An error if the field is invalid or already indexed.
+func (c *Cache) CreateBoolIndex(field string) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	// Verify the field
+	if len(field) == 0 {
+		return errors.New("invalid field")
+	} else if _, ok := c.booleans.fields[field]; ok {
+		return fmt.Errorf("boolean index on field %s already exists", field)
+	}
+
+	// Build the index from the current data
+	c.booleans.fields[field] = &[2]bitmap{}
+	for key, value := range c.data {
+		c.booleans.add(field, key, value)
+	}
+	return nil
+}
+
+// DropBoolIndex is a method of the Cache struct that removes the boolean index for the provided field.
+// This method is thread-safe.
+//
+// Parameters:
+//   - field (string): The indexed field.
+//
+// Returns:
+//   - error: An error if the field is not indexed.
+func (c *Cache) DropBoolIndex(field string) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	// Verify that the index exists
+	if _, ok := c.booleans.fields[field]; !ok {
+		return fmt.Errorf("boolean index on field %s does not exist", field)
+	}
+
+	// Delete the index
+	delete(c.booleans.fields, field)
+	return nil
+}
+
+// BoolIndexes is a method of the Cache struct that returns the fields that have a boolean index.
+// This method is thread-safe.
+//
+// Returns:
+//   - []string: The indexed fields.
+func (c *Cache) BoolIndexes() []string {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	// Copy the indexed fields
+	var fields []string = make([]string, 0, len(c.booleans.fields))
+	for field := range c.booleans.fields {
+		fields = append(fields, field)
+	}
+	return fields
+}
+
+// add is a method of the boolIndex struct that adds a value to the index of a field, if the field of the value is a bool.
+//
+// Parameters:
+//   - field (string): The indexed field.
+//   - key (string): The cache key of the value.
+//   - value (map[string]any): The value.
+//
+// Returns:
+//   - None
+func (bi *boolIndex) add(field string, key string, value map[string]any) {
+	b, ok := value[field].(bool)
+	if !ok {
+		return
+	}
+
+	// Set the bit of the slot
+	var bm *bitmap = &bi.fields[field][0]
+	if b {
+		bm = &bi.fields[field][1]
+	}
+	bm.set(bi.slot(key))
+}
+
+// slot is a method of the boolIndex struct that returns the slot number of a key, giving it a slot if it doesn't have one.
+//
+// Parameters:
+//   - key (string): The cache key.
+//
+// Returns:
+//   - int: The slot number of the key.
+func (bi *boolIndex) slot(key string) int {
+	if slot, ok := bi.slots[key]; ok {
+		return slot
+	}
+
+	// Reuse a free slot if there is one
+	var slot int
+	if n := len(bi.free); n > 0 {
+		slot, bi.free = bi.free[n-1], bi.free[:n-1]
+		bi.keys[slot] = key
+	} else {
+		slot = len(bi.keys)
+		bi.keys = append(bi.keys, key)
+	}
+	bi.slots[key] = slot
+	bi.all.set(slot)
+	return slot
+}
+
+// set is a method of the boolIndex struct that adds a value to the presence bitmaps of its fields and to the index of every boolean field.
+//
+// Parameters:
+//   - key (string): The cache key of the value.
+//   - value (map[string]any): The value.
+//
+// Returns:
+//   - None
+func (bi *boolIndex) set(key string, value map[string]any) {
+	var slot int = bi.slot(key)
+	for field, v := range value {
+		if v != nil {
+			var bm bitmap = bi.present[field]
+			bm.set(slot)
+			bi.present[field] = bm
+		}
+	}
+	for field := range bi.fields {
+		bi.add(field, key, value)
+	}
+}
+
+// delete is a method of the boolIndex struct that removes a key from the index of every field and frees its slot.
+//
+// Parameters:
+//   - key (string): The cache key to remove.
+//
+// Returns:
+//   - None
+func (bi *boolIndex) delete(key string) {
+	slot, ok := bi.slots[key]
+	if !ok {
+		return
+	}
+	for _, bms := range bi.fields {
+		bms[0].clear(slot)
+		bms[1].clear(slot)
+	}
+	for _, bm := range bi.present {
+		bm.clear(slot)
+	}
+	bi.all.clear(slot)
+	delete(bi.slots, key)
+	bi.keys[slot] = ""
+	bi.free = append(bi.free, slot)
+}
+
+// rebuild is a method of the boolIndex struct that returns a new index of the same fields, built from the provided data.
+//
+// Parameters:
+//   - data (map[string]map[string]any): The data to build the index from.
+//
+// Returns:
+//   - *boolIndex: The new index.
+func (bi *boolIndex) rebuild(data map[string]map[string]any) *boolIndex {
+	var index *boolIndex = newBoolIndex()
+	for field := range bi.fields {
+		index.fields[field] = &[2]bitmap{}
+	}
+	for key, value := range data {
+		index.set(key, value)
+	}
+	return index
+}
+
+// clone is a method of the boolIndex struct that returns a deep copy of the index.
+//
+// Returns:
+//   - *boolIndex: The copy of the index.
+func (bi *boolIndex) clone() *boolIndex {
+	var clone *boolIndex = &boolIndex{
+		fields:  make(map[string]*[2]bitmap, len(bi.fields)),
+		present: make(map[string]bitmap, len(bi.present)),
+		all:     append(bitmap{}, bi.all...),
+		slots:   make(map[string]int, len(bi.slots)),
+		keys:    append([]string{}, bi.keys...),
+		free:    append([]int{}, bi.free...),
+	}
+	for field, bms := range bi.fields {
+		clone.fields[field] = &[2]bitmap{append(bitmap{}, bms[0]...), append(bitmap{}, bms[1]...)}
+	}
+	for field, bm := range bi.present {
+		clone.present[field] = append(bitmap{}, bm...)
+	}
+	for key, slot := range bi.slots {
+		clone.slots[key] = slot
+	}
+	return clone
+}
+
+// filter is a method of the boolIndex struct that returns the bitmap of a `field:true` or `field:false` filter term.
+//
+// Parameters:
+//   - term (filterTerm): The filter term, whose field must be indexed.
+//
+// Returns:
+//   - bitmap: The bitmap of the matching slots. It must not be modified.
+//   - error: An error if the value of the term is not a boolean.
+func (bi *boolIndex) filter(term filterTerm) (bitmap, error) {
+	b, err := strconv.ParseBool(term.value)
+	if err != nil || term.op != '=' {
+		return nil, fmt.Errorf("invalid boolean %s for field %s", term.value, term.field)
+	}
+	if b {
+		return bi.fields[term.field][1], nil
+	}
+	return bi.fields[term.field][0], nil
+}
+
+// presence is a method of the boolIndex struct that returns the bitmap of an `exists:field` or `missing:field` filter term.
+//
+// Parameters:
+//   - term (filterTerm): The filter term.
+//
+// Returns:
+//   - bitmap: A new bitmap of the slots of the keys whose field is set, or isn't set or is nil, respectively.
+func (bi *boolIndex) presence(term filterTerm) bitmap {
+	if term.op == 'e' {
+		return and(bi.all, bi.present[term.field])
+	}
+	return andNot(bi.all, bi.present[term.field])
+}
+
+// keySet is a method of the boolIndex struct that returns the keys of the slots of a bitmap.
+//
+// Parameters:
+//   - bm (bitmap): The bitmap.
+//
+// Returns:
+//   - map[string]bool: The set of the keys.
+func (bi *boolIndex) keySet(bm bitmap) map[string]bool {
+	var keys map[string]bool = map[string]bool{}
+	for i, word := range bm {
+		for word != 0 {
+			var slot int = i*64 + bits.TrailingZeros64(word)
+			keys[bi.keys[slot]] = true
+			word &= word - 1
+		}
+	}
+	return keys
+}
+
+// set is a method of the bitmap type that adds a slot to the bitmap.
+//
+// Parameters:
+//   - slot (int): The slot number.
+//
+// Returns:
+//   - None
+func (bm *bitmap) set(slot int) {
+	for len(*bm) <= slot/64 {
+		*bm = append(*bm, 0)
+	}
+	(*bm)[slot/64] |= 1 << (slot % 64)
+}
+
+// clear is a method of the bitmap type that removes a slot from the bitmap.
+//
+// Parameters:
+//   - slot (int): The slot number.
+//
+// Returns:
+//   - None
+func (bm bitmap) clear(slot int) {
+	if slot/64 < len(bm) {
+		bm[slot/64] &^= 1 << (slot % 64)
+	}
+}
+
+// and is a function that returns the intersection of two bitmaps.
+//
+// Parameters:
+//   - a (bitmap): The first bitmap.
+//   - b (bitmap): The second bitmap.
+//
+// Returns:
+//   - bitmap: A new bitmap of the slots in both bitmaps.
+func and(a bitmap, b bitmap) bitmap {
+	if len(b) < len(a) {
+		a, b = b, a
+	}
+	var result bitmap = make(bitmap, len(a))
+	for i := range a {
+		result[i] = a[i] & b[i]
+	}
+	return result
+}
+
+// andNot is a function that returns the difference of two bitmaps.
+//
+// Parameters:
+//   - a (bitmap): The first bitmap.
+//   - b (bitmap): The bitmap of the slots to remove from the first bitmap.
+//
+// Returns:
+//   - bitmap: A new bitmap of the slots in the first bitmap but not in the second.
+func andNot(a bitmap, b bitmap) bitmap {
+	var result bitmap = append(bitmap{}, a...)
+	for i := 0; i < len(result) && i < len(b); i++ {
+		result[i] &^= b[i]
+	}
+	return result
+}
@@ -0,0 +1,94 @@
+package hermes
+
+import (
+	"errors"
+	"sync"
+)
+
+// bm25 is a struct that represents the parameters of the BM25 ranker, along with the average number of words of the values,
+// which is computed by the first BM25 search after the cache is modified.
+//
+// Fields:
+//   - mutex (sync.Mutex): The mutex that guards the average, since it's computed by searches that only hold the read lock of the cache.
+//   - k1 (float64): How quickly additional occurrences of a word stop adding to the score.
+//   - b (float64): How much the score of the values is normalized by their length, from 0 to 1.
+//   - average (float64): The average number of words in the full-text fields of the values.
+//   - generation (uint64): The generation of the cache at which the average was computed.
+//   - computed (bool): Whether the average has been computed.
+type bm25 struct {
+	mutex      sync.Mutex
+	k1         float64
+	b          float64
+	average    float64
+	generation uint64
+	computed   bool
+}
+
+// newBM25 is a function that returns the BM25 parameters with their usual default values, k1 = 1.2 and b = 0.75.
+//
+// Returns:
+//   - *bm25: The BM25 parameters.
+func newBM25() *bm25 {
+	return &bm25{k1: 1.2, b: 0.75}
+}
+
+// SetBM25 is a method of the Cache struct that sets the parameters of the RankBM25 ranker.
+// This method is thread-safe.
+//
+// Parameters:
+//   - k1 (float64): How quickly additional occurrences of a word stop adding to the score. The default is 1.2, and 0 ignores
+//     how many times a word occurs.
+//   - b (float64): How much the score of the values is normalized by their length, from 0 (not at all) to 1 (fully). The default is 0.75.
+//
+// Returns:
+//   - error: An error if k1 is negative or b is not between 0 and 1.
+func (c *Cache) SetBM25(k1 float64, b float64) error {
+	switch {
+	case k1 < 0:
+		return errors.New("invalid k1")
+	case b < 0 || b > 1:
+		return errors.New("invalid b")
+	}
+
+	// Set the parameters
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.bm25.k1, c.bm25.b = k1, b
+	for _, idx := range c.ftIndexes {
+		idx.bm25.k1, idx.bm25.b = k1, b
+	}
+
+	// The cached ranked results were scored with the previous parameters
+	c.generation++
+	return nil
+}
+
+// averageLength is a method of the bm25 struct that returns the average number of words in the full-text fields of the values of a cache.
+// The average is computed again once the cache is modified.
+// This method is not thread-safe, and should only be called from an exported function.
+//
+// Parameters:
+//   - c (*Cache): The cache.
+//
+// Returns:
+//   - float64: The average number of words.
+func (p *bm25) averageLength(c *Cache) float64 {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if p.computed && p.generation == c.generation {
+		return p.average
+	}
+
+	// Count the words of every value
+	var total, count int = 0, 0
+	for key := range c.ft.fields {
+		_, length := c.ft.termFrequencies(key, c.data[key], nil, SearchParams{})
+		total += length
+		count++
+	}
+	p.average, p.generation, p.computed = 0, c.generation, true
+	if count > 0 {
+		p.average = float64(total) / float64(count)
+	}
+	return p.average
+}
@@ -2,6 +2,9 @@ package hermes
 
 import (
 	"sync"
+	"time"
+
+	utils "github.com/realTristan/hermes/utils"
 )
 
 // Cache is a struct that represents an in-memory cache of key-value pairs.
@@ -11,8 +14,78 @@ import (
 //   - data (map[string]map[string]any): A map that stores the data in the cache. The keys of the map are strings that represent the cache keys, and the values are sub-maps that store the actual data under string keys.
 //   - mutex (*sync.RWMutex): A RWMutex that guards access to the cache data.
 //   - ft (*FullText): A FullText index that can be used for full-text search. If nil, full-text search is disabled.
+//   - ftIndexes (map[string]*namedIndex): The named full-text indexes created with FTCreateIndex, by name.
+//   - uniques (map[string]*unique): The unique constraints declared on the cache values, keyed by their comma-joined fields.
+//   - indexes (map[string]map[string]map[string]bool): The secondary indexes, keyed by field, then by field value, holding the set of keys.
+//   - booleans (*boolIndex): The boolean indexes, holding a bitmap of the keys whose field is true and another of those whose field is false.
+//   - numerics (map[string]*numericIndex): The numeric indexes, holding the keys sorted by the value of their field, keyed by field.
+//   - records (RecordMode): How malformed values are handled.
+//   - frozen (bool): Whether the writes to the data are rejected.
+//   - computed (map[string]computedField): The fields that are derived from the other fields of every value when it's set.
+//   - tolerances (map[string]Tolerance): The tolerance of the numeric filters on each field.
+//   - bm25 (*bm25): The parameters of the BM25 ranker.
+//   - keyTrie (*utils.Trie): The cache keys in sorted order, used for prefix scans.
+//   - persist (*persister): The background snapshot writer. If nil, auto-persist is disabled.
+//   - codec (Codec): The encoding of the snapshots.
+//   - compressAt (int): The minimum length of the string fields that are stored compressed. If 0, values are not compressed.
+//   - expiries (map[string]time.Time): The time at which each key with a time to live expires.
+//   - expiryField (string): The field from which every value takes its expiry when it's set. If empty, values only expire with a time to live.
+//   - onExpire ([]func(key string, value map[string]any)): The functions to call for every key that is removed because it expired.
+//   - namespaces (map[string]bool): The names of the created namespaces.
+//   - expirer (*expirer): The background goroutine that removes expired keys. If nil, no key has expired yet.
+//   - versions (map[string]uint64): The version of each key, which is the generation number at which its value was set.
+//   - writeLimit (*writeLimiter): The write rate limit of each key. If nil, writes are not limited.
+//   - limitWarn (*limitWarnings): The soft-limit thresholds of the full-text index and their listeners. If nil, no warning is sent.
+//   - loader (*loader): The function that loads the values of missing keys. If nil, missing keys are not loaded.
+//   - sinks ([]*sink): The backing stores that receive the writes of the cache.
+//   - history (*history): The retained revisions of each key. If nil, history is disabled.
+//   - subs (map[string]*subscription): The standing queries that are notified of the matching values that are set, keyed by their id.
+//   - subSeq (uint64): The number of the last registered subscription.
+//   - shadow (*searchShadow): The shadow cache that a percentage of the searches is mirrored to.
+//   - queries (*queryCache): The results of the most popular searches.
+//   - cursors (*cursors): The pinned results of the searches that are paged through, see SearchPage.
+//   - dups (*duplicates): The content hashes of the values, to detect the duplicates.
+//   - consistent (bool): Whether the full-text index is verified after every write, see SetConsistencyCheck.
+//   - expected (int): The number of values the maps are allocated for, see SetExpectedSize.
+//   - stats (*stats): The operation counters of the cache.
+//   - generation (uint64): A number that is incremented by every write to the cache data or full-text index.
 type Cache struct {
-	data  map[string]map[string]any
-	mutex *sync.RWMutex
-	ft    *FullText
+	data        map[string]map[string]any
+	mutex       *sync.RWMutex
+	ft          *FullText
+	ftIndexes   map[string]*namedIndex
+	uniques     map[string]*unique
+	indexes     map[string]map[string]map[string]bool
+	booleans    *boolIndex
+	numerics    map[string]*numericIndex
+	tolerances  map[string]Tolerance
+	records     RecordMode
+	frozen      bool
+	computed    map[string]computedField
+	bm25        *bm25
+	keyTrie     *utils.Trie
+	persist     *persister
+	codec       Codec
+	compressAt  int
+	expiries    map[string]time.Time
+	expiryField string
+	onExpire    []func(key string, value map[string]any)
+	namespaces  map[string]bool
+	expirer     *expirer
+	versions    map[string]uint64
+	writeLimit  *writeLimiter
+	limitWarn   *limitWarnings
+	loader      *loader
+	sinks       []*sink
+	history     *history
+	subs        map[string]*subscription
+	subSeq      uint64
+	shadow      *searchShadow
+	queries     *queryCache
+	cursors     *cursors
+	dups        *duplicates
+	consistent  bool
+	expected    int
+	stats       *stats
+	generation  uint64
 }
@@ -0,0 +1,101 @@
+package hermes
+
+import (
+	"errors"
+	"strings"
+	"unicode"
+)
+
+// FTSetCaseSensitive is a method of the Cache struct that sets whether the strict searches of the full-text index are case-sensitive.
+// The words are lowercased when they're stored in the index and when they're searched, so by default a strict search for "Tristan"
+// matches "tristan" and "TRISTAN". If the strict searches are case-sensitive, the words of the query must also appear with the same
+// case in the full-text fields of a value, so "Tristan" only matches "Tristan". The searches that aren't strict are not affected.
+// The named full-text indexes created after the call use the same setting. The index isn't rebuilt, since it still stores lowercase words.
+// This method is thread-safe.
+//
+// Parameters:
+//   - sensitive (bool): Whether the strict searches are case-sensitive.
+//
+// Returns:
+//   - error: An error if the full-text index is not initialized.
+func (c *Cache) FTSetCaseSensitive(sensitive bool) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	// Check if the ft is initialized
+	if c.ft == nil {
+		return errors.New("full text not initialized")
+	} else if sensitive == c.ft.caseSensitive {
+		return nil
+	}
+
+	// The cached results of the strict searches were matched with the previous setting
+	c.ft.caseSensitive = sensitive
+	c.generation++
+	return nil
+}
+
+// FTCaseSensitive is a method of the Cache struct that returns whether the strict searches of the full-text index are case-sensitive.
+// This method is thread-safe.
+//
+// Returns:
+//   - bool: Whether the strict searches are case-sensitive.
+//   - error: An error if the full-text index is not initialized.
+func (c *Cache) FTCaseSensitive() (bool, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	if c.ft == nil {
+		return false, errors.New("full text not initialized")
+	}
+	return c.ft.caseSensitive, nil
+}
+
+// matchesCase is a method of the Cache struct that checks whether the full-text fields of a value contain the words of a strict search
+// with the case they were typed with, if the strict searches of the full-text index are case-sensitive.
+// This method is not thread-safe, and should only be called from an exported function.
+//
+// Parameters:
+//   - sp (SearchParams): The search parameters, with the query as it was typed.
+//   - key (string): The key of the value.
+//
+// Returns:
+//   - bool: true if the value contains every word of the query with the same case, or the search isn't case-sensitive, false otherwise.
+func (c *Cache) matchesCase(sp SearchParams, key string) bool {
+	if !sp.Strict || c.ft == nil || !c.ft.caseSensitive || len(sp.typed) == 0 {
+		return true
+	}
+
+	// Collect the words of the full-text fields of the value
+	var (
+		value map[string]any  = c.expand(c.data[key])
+		words map[string]bool = make(map[string]bool)
+	)
+	for _, field := range c.ft.fields[key] {
+		if v, ok := fieldString(pathValue(value, field)); ok {
+			for _, w := range caseWords(v) {
+				words[w] = true
+			}
+		}
+	}
+
+	// Verify that every word of the query that can be stored is in the value
+	for _, w := range caseWords(sp.typed) {
+		if len(w) >= c.ft.minWordLength && !words[w] {
+			return false
+		}
+	}
+	return true
+}
+
+// caseWords is a function that splits a string into its words without changing their case.
+//
+// Parameters:
+//   - s (string): The string.
+//
+// Returns:
+//   - []string: The words of the string.
+func caseWords(s string) []string {
+	return strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
@@ -1,6 +1,11 @@
 package hermes
 
-import "errors"
+import (
+	"errors"
+	"time"
+
+	utils "github.com/realTristan/hermes/utils"
+)
 
 // Clean is a method of the Cache struct that clears the cache contents.
 // If the full-text index is initialized, it is also cleared.
@@ -14,7 +19,36 @@ import "errors"
 func (c *Cache) Clean() {
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
+	if !c.frozen {
+		c.clean()
+		c.walClear()
+	}
+}
+
+// Clear is a method of the Cache struct that atomically clears the cache contents and the full-text index, including its
+// word index counter. If keepFullText is true, the full-text configuration (limits, minimum word length and schema) and the named
+// full-text indexes are kept, so new values are indexed immediately, like with Clean. Otherwise, the full-text index and the named
+// full-text indexes are removed and FTInit must be called again.
+// The unique constraints, secondary and boolean indexes, namespaces and sinks are kept, and the removed keys are not sent to the sinks.
+// This method is thread-safe.
+//
+// Parameters:
+//   - keepFullText (bool): Whether to keep the full-text configuration.
+//
+// Returns:
+//   - None
+func (c *Cache) Clear(keepFullText bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.frozen {
+		return
+	}
 	c.clean()
+	c.walClear()
+	if !keepFullText {
+		c.ft = nil
+		c.ftIndexes = nil
+	}
 }
 
 // clean is a method of the Cache struct that clears the cache contents.
@@ -30,7 +64,28 @@ func (c *Cache) clean() {
 	if c.ft != nil {
 		c.ft.clean()
 	}
-	c.data = map[string]map[string]any{}
+	for _, idx := range c.ftIndexes {
+		idx.ft.clean()
+	}
+	for _, u := range c.uniques {
+		u.values = make(map[string]string)
+	}
+	for field := range c.indexes {
+		c.indexes[field] = make(map[string]map[string]bool)
+	}
+	c.booleans = c.booleans.rebuild(nil)
+	for field := range c.numerics {
+		c.numerics[field] = numericBuild(field, nil)
+	}
+	c.data = make(map[string]map[string]any, c.expected)
+	c.keyTrie = utils.NewTrie()
+	c.versions = make(map[string]uint64, c.expected)
+	c.expiries = map[string]time.Time{}
+	c.historyClean()
+	if c.writeLimit != nil {
+		c.writeLimit.buckets = make(map[string]*writeBucket)
+	}
+	c.generation++
 }
 
 // FTClean is a method of the Cache struct that clears the full-text cache contents.
@@ -51,13 +106,14 @@ func (c *Cache) FTClean() error {
 
 	// Clean the ft cache
 	c.ft.clean()
+	c.generation++
 
 	// Return no error
 	return nil
 }
 
 // clean is a method of the FullText struct that clears the full-text index storage and indices.
-// This method initializes a new empty storage map and indices map with the maximum length specified in the FullText struct.
+// This method initializes a new empty storage map and indices map, and resets the word index counter.
 //
 // Parameters:
 //   - None
@@ -67,4 +123,12 @@ func (c *Cache) FTClean() error {
 func (ft *FullText) clean() {
 	ft.storage = make(map[string]any)
 	ft.indices = make(map[int]string)
+	ft.keys = make(map[string]int)
+	ft.index = 0
+	ft.fields = make(map[string][]string)
+	ft.fieldStorage = make(fieldIndex)
+	ft.ngrams = newNgramIndex(ft.ngrams.size())
+	ft.suffixes = newSuffixIndex(ft.suffixes.enabled())
+	ft.partitions = newWordPartitions(ft.partitions.count())
+	ft.vocab = wordTrie{}
 }
@@ -0,0 +1,112 @@
+package hermes
+
+// Clone is a method of the Cache struct that returns a deep copy of the cache as of the time of the call, including the
+// full-text index, named full-text indexes, unique constraints, secondary and boolean indexes, computed fields, versions, expiries, namespaces and history. The copy can be written
+// to without affecting the original, for example to run a migration against it while the original keeps serving searches.
+// The background work, callbacks, subscriptions, loader, sinks, search shadow, query cache, pinned search results, duplicate detection and write rate limit are not copied, and the stats of the copy start at zero.
+// This method is thread-safe.
+//
+// Returns:
+//   - *Cache: The copy of the cache.
+func (c *Cache) Clone() *Cache {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	// Copy the data
+	var clone *Cache = InitCache()
+	for key, value := range c.data {
+		clone.data[key] = copyMap(value)
+		clone.keyTrie.Insert(key)
+	}
+	for key, version := range c.versions {
+		clone.versions[key] = version
+	}
+	for key, expiry := range c.expiries {
+		clone.expire(key, expiry)
+	}
+	for ns := range c.namespaces {
+		clone.namespaces[ns] = true
+	}
+	clone.expiryField = c.expiryField
+	clone.compressAt = c.compressAt
+	clone.expected = c.expected
+	clone.records = c.records
+	clone.bm25.k1, clone.bm25.b = c.bm25.k1, c.bm25.b
+	clone.generation = c.generation
+
+	// Copy the unique constraints and secondary indexes
+	for name, u := range c.uniques {
+		var values map[string]string = make(map[string]string, len(u.values))
+		for k, v := range u.values {
+			values[k] = v
+		}
+		clone.uniques[name] = &unique{fields: append([]string{}, u.fields...), values: values}
+	}
+	for field, index := range c.indexes {
+		clone.indexes[field] = make(map[string]map[string]bool, len(index))
+		for value, keys := range index {
+			clone.indexes[field][value] = make(map[string]bool, len(keys))
+			for key := range keys {
+				clone.indexes[field][value][key] = true
+			}
+		}
+	}
+
+	clone.booleans = c.booleans.clone()
+	for field, idx := range c.numerics {
+		clone.numerics[field] = idx.clone()
+	}
+	for field, cf := range c.computed {
+		clone.computed[field] = cf
+	}
+	for field, t := range c.tolerances {
+		clone.tolerances[field] = t
+	}
+
+	// Copy the full-text indexes and history
+	if c.ft != nil {
+		clone.ft = c.ft.clone()
+	}
+	for name, idx := range c.ftIndexes {
+		if clone.ftIndexes == nil {
+			clone.ftIndexes = make(map[string]*namedIndex, len(c.ftIndexes))
+		}
+		clone.ftIndexes[name] = &namedIndex{ft: idx.ft.clone(), bm25: &bm25{k1: idx.bm25.k1, b: idx.bm25.b}}
+	}
+	if c.history != nil {
+		clone.history = &history{limit: c.history.limit, revisions: make(map[string][]Revision, len(c.history.revisions))}
+		for key, revisions := range c.history.revisions {
+			clone.history.revisions[key] = append([]Revision{}, revisions...)
+		}
+	}
+	return clone
+}
+
+// clone is a method of the FullText struct that returns a deep copy of the full-text index.
+//
+// Returns:
+//   - *FullText: The copy of the full-text index.
+func (ft *FullText) clone() *FullText {
+	var clone *FullText = ft.empty()
+	clone.index = ft.index
+	for word, value := range ft.storage {
+		if indices, ok := value.(*roaring); ok {
+			clone.storage[word] = indices.clone()
+		} else {
+			clone.storage[word] = value
+		}
+	}
+	clone.ngrams.build(clone.storage)
+	clone.suffixes.build(clone.storage)
+	clone.partitions.build(clone.storage)
+	clone.vocab = buildTrie(clone.storage)
+	for index, key := range ft.indices {
+		clone.indices[index] = key
+		clone.keys[key] = index
+	}
+	for key, fields := range ft.fields {
+		clone.fields[key] = append([]string{}, fields...)
+	}
+	clone.fieldStorage = ft.fieldStorage.clone()
+	return clone
+}
@@ -0,0 +1,159 @@
+// Package apitest provides helpers for integration-testing code against the hermes Cache API
+// without binding a port. The full handler stack is served from an in-memory cache.
+package apitest
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+
+	"github.com/gofiber/fiber/v2"
+	hermes "github.com/realTristan/hermes"
+	"github.com/realTristan/hermes/cloud/api"
+)
+
+// Server is a struct that represents an in-memory hermes Cache API.
+// Fields:
+//   - Cache (*hermes.Cache): The cache that the API serves. It can be used to seed or inspect the data directly.
+//   - App (*fiber.App): The fiber app with the API routes.
+type Server struct {
+	Cache *hermes.Cache
+	App   *fiber.App
+}
+
+// Client is a struct that sends requests to an in-memory hermes Cache API.
+// Fields:
+//   - app (*fiber.App): The fiber app that handles the requests.
+type Client struct {
+	app *fiber.App
+}
+
+// New is a function that creates a new in-memory hermes Cache API with an empty cache.
+// Returns:
+//   - *Server: A pointer to the new Server.
+func New() *Server {
+	return NewWithCache(hermes.InitCache())
+}
+
+// NewWithCache is a function that creates a new in-memory hermes Cache API that serves the provided cache.
+// Parameters:
+//   - cache (*hermes.Cache): The cache to serve.
+//
+// Returns:
+//   - *Server: A pointer to the new Server.
+func NewWithCache(cache *hermes.Cache) *Server {
+	var app *fiber.App = fiber.New(fiber.Config{
+		Prefork:      false,
+		ServerHeader: "hermes",
+	})
+	api.SetRoutes(app, cache)
+	return &Server{
+		Cache: cache,
+		App:   app,
+	}
+}
+
+// Client is a method of the Server struct that returns a client for the API.
+// Returns:
+//   - *Client: A pointer to the new Client.
+func (s *Server) Client() *Client {
+	return &Client{app: s.App}
+}
+
+// Do is a method of the Client struct that sends the provided request to the API.
+// Parameters:
+//   - req (*http.Request): The request to send.
+//
+// Returns:
+//   - *http.Response: The response of the API.
+//   - error: An error if the request could not be handled.
+func (c *Client) Do(req *http.Request) (*http.Response, error) {
+	return c.app.Test(req, -1)
+}
+
+// Request is a method of the Client struct that sends a request with the provided method, path and query parameters to the API.
+// Parameters:
+//   - method (string): The HTTP method of the request.
+//   - path (string): The path of the request, for example "/cache/get".
+//   - params (url.Values): The query parameters of the request. May be nil.
+//
+// Returns:
+//   - *http.Response: The response of the API.
+//   - error: An error if the request could not be handled.
+func (c *Client) Request(method string, path string, params url.Values) (*http.Response, error) {
+	if len(params) > 0 {
+		path += "?" + params.Encode()
+	}
+	return c.Do(httptest.NewRequest(method, path, nil))
+}
+
+// Get is a method of the Client struct that sends a GET request to the API.
+// Parameters:
+//   - path (string): The path of the request.
+//   - params (url.Values): The query parameters of the request. May be nil.
+//
+// Returns:
+//   - *http.Response: The response of the API.
+//   - error: An error if the request could not be handled.
+func (c *Client) Get(path string, params url.Values) (*http.Response, error) {
+	return c.Request(http.MethodGet, path, params)
+}
+
+// Post is a method of the Client struct that sends a POST request to the API.
+// Parameters:
+//   - path (string): The path of the request.
+//   - params (url.Values): The query parameters of the request. May be nil.
+//
+// Returns:
+//   - *http.Response: The response of the API.
+//   - error: An error if the request could not be handled.
+func (c *Client) Post(path string, params url.Values) (*http.Response, error) {
+	return c.Request(http.MethodPost, path, params)
+}
+
+// Delete is a method of the Client struct that sends a DELETE request to the API.
+// Parameters:
+//   - path (string): The path of the request.
+//   - params (url.Values): The query parameters of the request. May be nil.
+//
+// Returns:
+//   - *http.Response: The response of the API.
+//   - error: An error if the request could not be handled.
+func (c *Client) Delete(path string, params url.Values) (*http.Response, error) {
+	return c.Request(http.MethodDelete, path, params)
+}
+
+// Encode is a function that encodes a value the way the API expects the value, json and schema parameters: JSON encoded, then base64 encoded.
+// Parameters:
+//   - v (any): The value to encode.
+//
+// Returns:
+//   - string: The encoded value.
+//   - error: An error if the value could not be encoded.
+func Encode(v any) (string, error) {
+	if data, err := json.Marshal(v); err != nil {
+		return "", err
+	} else {
+		return base64.StdEncoding.EncodeToString(data), nil
+	}
+}
+
+// Decode is a function that reads the body of a response and decodes it as JSON into the provided value.
+// The body is closed once it has been read.
+// Parameters:
+//   - resp (*http.Response): The response to decode.
+//   - v (any): A pointer to the value to decode the body into.
+//
+// Returns:
+//   - error: An error if the body could not be read or decoded.
+func Decode(resp *http.Response, v any) error {
+	defer resp.Body.Close()
+	if data, err := io.ReadAll(resp.Body); err != nil {
+		return err
+	} else {
+		return json.Unmarshal(data, v)
+	}
+}
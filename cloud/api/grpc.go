@@ -0,0 +1,61 @@
+package api
+
+import (
+	"strings"
+
+	hermes "github.com/realTristan/hermes"
+	"github.com/realTristan/hermes/cloud/api/handlers"
+	"google.golang.org/grpc"
+)
+
+// GRPCServiceName is the name of the gRPC service of the hermes Cache API.
+const GRPCServiceName = "hermes.Cache"
+
+// RegisterGRPC is a function that registers the hermes Cache API on a gRPC server, with the same handlers as the Fiber routes
+// set by SetRoutes. Each route is a server-streaming method of the hermes.Cache service, named after its HTTP method and path,
+// such as GetFtSearch for GET /ft/search:
+//
+//	rpc GetFtSearch(google.protobuf.StringValue) returns (stream google.protobuf.BytesValue);
+//
+// The request holds the encoded query string, and the request headers, such as Accept or If-None-Match, are read from the metadata.
+// The response headers are sent as the header metadata, with the status in the x-http-status key and the Content-Type header in the
+// x-content-type key, and the response body is the concatenation of the streamed chunks. Like with NewHTTPHandler, the Fiber
+// middlewares aren't applied and there's no /cache/metrics method. The searches and exports stop once the call is cancelled.
+// Parameters:
+//   - s (*grpc.Server): A pointer to the gRPC server.
+//   - cache (*hermes.Cache): A pointer to a hermes.Cache struct.
+//
+// Returns:
+//   - None
+func RegisterGRPC(s *grpc.Server, cache *hermes.Cache) {
+	var sd grpc.ServiceDesc = grpc.ServiceDesc{
+		ServiceName: GRPCServiceName,
+		HandlerType: (*any)(nil),
+	}
+	for _, r := range routes(cache) {
+		sd.Streams = append(sd.Streams, grpc.StreamDesc{
+			StreamName:    grpcMethod(r.method, r.path),
+			Handler:       handlers.GRPC(r.handler),
+			ServerStreams: true,
+		})
+	}
+	s.RegisterService(&sd, nil)
+}
+
+// grpcMethod is a function that returns the name of the gRPC method of a route, which is its HTTP method followed by
+// each segment of its path, capitalized.
+// Parameters:
+//   - method (string): The HTTP method of the route.
+//   - path (string): The path of the route.
+//
+// Returns:
+//   - string: The name of the gRPC method, such as GetFtSearchOneword for GET /ft/search/oneword.
+func grpcMethod(method string, path string) string {
+	var name strings.Builder
+	for _, part := range append([]string{method}, strings.Split(strings.Trim(path, "/"), "/")...) {
+		if len(part) > 0 {
+			name.WriteString(strings.ToUpper(part[:1]) + strings.ToLower(part[1:]))
+		}
+	}
+	return name.String()
+}
@@ -0,0 +1,213 @@
+package handlers
+
+import (
+	"bufio"
+	"context"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// MIMEGob is the media type of gob-encoded responses, which clients request with the Accept header.
+const MIMEGob = "application/x-gob"
+
+// Request is the interface of a request to the API. The handlers only depend on Request and Response,
+// so each frontend, such as Fiber, net/http or gRPC, serves the same handlers by adapting its requests to them.
+type Request interface {
+	// Context returns the context of the request, which is done once the request is cancelled or the client is gone.
+	Context() context.Context
+	// Query returns the value of a query parameter, or an empty string if it's not provided.
+	Query(name string) string
+	// RawQuery returns the encoded query string of the request, without the leading "?".
+	RawQuery() string
+	// Header returns the value of a request header, or an empty string if it's not provided.
+	Header(name string) string
+}
+
+// Response is the interface of the response to a request to the API.
+// The headers and the status must be set before the body is written with Writer or Stream.
+type Response interface {
+	// SetHeader sets a response header.
+	SetHeader(name string, value string)
+	// SetStatus sets the status code of the response, which is 200 OK by default.
+	SetStatus(status int)
+	// Writer returns the writer of the response body. What is written to it is sent to the client, without being buffered by the handler.
+	Writer() io.Writer
+	// Stream writes the response body with the provided function, flushing what it writes to the client as it goes.
+	// The function may be called after the handler returns, so it must not use the request, whose context might be reused by then.
+	// The function should stop once a write or a flush fails, since the client is gone.
+	Stream(write func(w *bufio.Writer))
+}
+
+// successMessage is a struct that represents the body of a successful response.
+// Fields:
+//   - Success (bool): Always true.
+//   - Data (any): The data of the response.
+type successMessage struct {
+	Success bool `json:"success"`
+	Data    any  `json:"data"`
+}
+
+// errorMessage is a struct that represents the body of a failed response.
+// Fields:
+//   - Success (bool): Always false.
+//   - Error (string): The error message.
+type errorMessage struct {
+	Success bool   `json:"success"`
+	Error   string `json:"error"`
+}
+
+// Handler is the type of the handlers of the API, which every frontend serves.
+// The returned error is an error of the transport, such as a failed write or a done request context. The errors of the request
+// itself are sent to the client in the response body.
+type Handler func(req Request, res Response) error
+
+// send is a function that streams a response body to the client with the encoding it requested, which is gob if the Accept header
+// includes the gob media type, and JSON otherwise. The value is encoded straight into the response writer, without an intermediate buffer.
+// Parameters:
+//   - req (Request): The request.
+//   - res (Response): The response.
+//   - v (any): The value to encode.
+//
+// Returns:
+//   - error: The context error if the request is done, or an error if the value could not be encoded or written.
+func send(req Request, res Response, v any) error {
+	if err := req.Context().Err(); err != nil {
+		return err
+	}
+	res.SetHeader(fiber.HeaderVary, fiber.HeaderAccept)
+	if wantsGob(req) {
+		res.SetHeader(fiber.HeaderContentType, MIMEGob)
+		return gob.NewEncoder(res.Writer()).Encode(v)
+	}
+	res.SetHeader(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	return json.NewEncoder(res.Writer()).Encode(v)
+}
+
+// succeed is a function that sends a success message with the provided data.
+// Parameters:
+//   - req (Request): The request.
+//   - res (Response): The response.
+//   - v (any): The data to include in the success message, or nil.
+//
+// Returns:
+//   - error: The context error if the request is done, or an error if the message could not be encoded or written.
+func succeed(req Request, res Response, v any) error {
+	return send(req, res, successMessage{Success: true, Data: v})
+}
+
+// fail is a function that sends an error message with the provided error.
+// Parameters:
+//   - req (Request): The request.
+//   - res (Response): The response.
+//   - err (T): The error to include in the error message.
+//
+// Returns:
+//   - error: The context error if the request is done, or an error if the message could not be encoded or written.
+func fail[T any](req Request, res Response, err T) error {
+	return send(req, res, errorMessage{Success: false, Error: fmt.Sprint(err)})
+}
+
+// failWith is a function that sends an error message with the provided error and status code.
+// Parameters:
+//   - req (Request): The request.
+//   - res (Response): The response.
+//   - status (int): The status code of the response.
+//   - err (T): The error to include in the error message.
+//
+// Returns:
+//   - error: The context error if the request is done, or an error if the message could not be encoded or written.
+func failWith[T any](req Request, res Response, status int, err T) error {
+	res.SetStatus(status)
+	return fail(req, res, err)
+}
+
+// wantsGob is a function that checks whether the client accepts gob-encoded responses.
+// Parameters:
+//   - req (Request): The request.
+//
+// Returns:
+//   - bool: true if the Accept header of the request includes the gob media type, false otherwise.
+func wantsGob(req Request) bool {
+	return strings.Contains(req.Header(fiber.HeaderAccept), MIMEGob)
+}
+
+// searchETag is a function that computes a weak ETag for a search request from the cache generation number, the request query string
+// and whether the client requested a gob-encoded response.
+// Parameters:
+//   - req (Request): The request.
+//   - generation (uint64): The generation number of the cache, read before the search is performed.
+//
+// Returns:
+//   - string: The weak ETag.
+func searchETag(req Request, generation uint64) string {
+	var checksum uint32 = crc32.ChecksumIEEE([]byte(req.RawQuery()))
+	if wantsGob(req) {
+		checksum = crc32.Update(checksum, crc32.IEEETable, []byte(MIMEGob))
+	}
+	return fmt.Sprintf(`W/"%d-%08x"`, generation, checksum)
+}
+
+// notModified is a function that sets the ETag header of the response and checks whether it matches the If-None-Match header of the request.
+// If it does, the status of the response is set to 304 Not Modified, and no body should be sent.
+// Parameters:
+//   - req (Request): The request.
+//   - res (Response): The response.
+//   - etag (string): The ETag of the response.
+//
+// Returns:
+//   - bool: true if the client already has the response, false otherwise.
+func notModified(req Request, res Response, etag string) bool {
+	res.SetHeader(fiber.HeaderETag, etag)
+
+	// Compare the ETag with each of the If-None-Match values
+	var match string = req.Header(fiber.HeaderIfNoneMatch)
+	if len(match) == 0 {
+		return false
+	}
+	for _, v := range strings.Split(match, ",") {
+		if v = strings.TrimSpace(v); v == "*" || strings.TrimPrefix(v, "W/") == strings.TrimPrefix(etag, "W/") {
+			res.SetStatus(fiber.StatusNotModified)
+			return true
+		}
+	}
+	return false
+}
+
+// versionETag is a function that returns the ETag for a document version.
+// Parameters:
+//   - version (uint64): The version of the document.
+//
+// Returns:
+//   - string: The strong ETag of the version.
+func versionETag(version uint64) string {
+	return fmt.Sprintf(`"%d"`, version)
+}
+
+// getIfMatchVersion is a function that retrieves the expected document version from the If-Match header of a request.
+// Parameters:
+//   - req (Request): The request.
+//   - version (*uint64): A pointer to an integer to store the version. It is set to 0 if the header is missing or "*".
+//
+// Returns:
+//   - error: An error message if the header is not a valid version ETag, or nil if the retrieval is successful.
+func getIfMatchVersion(req Request, version *uint64) error {
+	var s string = strings.TrimSpace(req.Header(fiber.HeaderIfMatch))
+	if len(s) == 0 || s == "*" {
+		*version = 0
+		return nil
+	}
+	if v, err := strconv.ParseUint(strings.Trim(s, `"`), 10, 64); err != nil {
+		return errors.New("invalid If-Match header")
+	} else {
+		*version = v
+	}
+	return nil
+}
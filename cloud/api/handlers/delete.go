@@ -1,27 +1,48 @@
 package handlers
 
 import (
-	"github.com/gofiber/fiber/v2"
 	hermes "github.com/realTristan/hermes"
-	utils "github.com/realTristan/hermes/cloud/api/utils"
 )
 
-// Delete is a handler function that returns a fiber context handler function for deleting a key from the cache.
+// Delete is a handler function that returns a handler for deleting a key from the cache.
 // Parameters:
 //   - c (*hermes.Cache): A pointer to a hermes.Cache struct.
 //
 // Returns:
-//   - func(ctx *fiber.Ctx) error: A fiber context handler function that deletes a key from the cache and returns a success message or an error message if the key is not provided.
-func Delete(c *hermes.Cache) func(ctx *fiber.Ctx) error {
-	return func(ctx *fiber.Ctx) error {
+//   - Handler: A handler that deletes a key from the cache and returns a success message or an error message if the key is not provided.
+func Delete(c *hermes.Cache) Handler {
+	return func(req Request, res Response) error {
 		// Get the key from the query
 		var key string
-		if key = ctx.Query("key"); len(key) == 0 {
-			return ctx.Send(utils.Error("key not provided"))
+		if key = req.Query("key"); len(key) == 0 {
+			return fail(req, res, "key not provided")
 		}
 
 		// Delete the key from the cache
 		c.Delete(key)
-		return ctx.Send(utils.Success("null"))
+		return succeed(req, res, nil)
+	}
+}
+
+// DeleteMatching is a handler function that returns a handler for deleting every key that matches a glob pattern from the cache.
+// Parameters:
+//   - c (*hermes.Cache): A pointer to a hermes.Cache struct.
+//
+// Returns:
+//   - Handler: A handler that deletes the matching keys from the cache and returns a JSON-encoded array of the deleted keys or an error message if the pattern is not provided or invalid.
+func DeleteMatching(c *hermes.Cache) Handler {
+	return func(req Request, res Response) error {
+		// Get the pattern from the query
+		var pattern string
+		if pattern = req.Query("pattern"); len(pattern) == 0 {
+			return fail(req, res, "pattern not provided")
+		}
+
+		// Delete the matching keys from the cache
+		if keys, err := c.DeleteMatching(pattern); err != nil {
+			return fail(req, res, err)
+		} else {
+			return send(req, res, keys)
+		}
 	}
 }
@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"bufio"
+	"encoding/gob"
+	"encoding/json"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	hermes "github.com/realTristan/hermes"
+)
+
+// Export is a handler function that returns a handler for exporting documents from the cache.
+// Parameters:
+//   - c (*hermes.Cache): A pointer to a hermes.Cache struct.
+//
+// Returns:
+//   - Handler: A handler that streams every document matching the optional query and strict
+//     parameters provided in the query string, or every document in the cache if no query is provided. The documents are written as
+//     newline-delimited JSON, as a JSON array if the format parameter is "json", or as a stream of gob-encoded documents if it's "gob".
+func Export(c *hermes.Cache) Handler {
+	return func(req Request, res Response) error {
+		var (
+			query  string = req.Query("query")
+			format string = req.Query("format")
+			strict bool
+			values []map[string]any
+		)
+
+		// Verify the format
+		if len(format) == 0 {
+			format = "ndjson"
+		} else if format != "ndjson" && format != "json" && format != "gob" {
+			return fail(req, res, "invalid format")
+		}
+
+		// Get the strict from the url params
+		if s := req.Query("strict"); len(s) > 0 {
+			if b, err := strconv.ParseBool(s); err != nil {
+				return fail(req, res, err)
+			} else {
+				strict = b
+			}
+		}
+
+		// Get the documents to export
+		if len(query) == 0 {
+			values = c.ValuesCopy()
+		} else if results, err := c.SearchCtx(req.Context(), hermes.SearchParams{
+			Query:  query,
+			Limit:  c.Length(),
+			Strict: strict,
+		}); err != nil {
+			return fail(req, res, err)
+		} else {
+			values = results
+		}
+
+		// Stream the documents
+		switch format {
+		case "json":
+			res.SetHeader(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		case "gob":
+			res.SetHeader(fiber.HeaderContentType, MIMEGob)
+		default:
+			res.SetHeader(fiber.HeaderContentType, "application/x-ndjson")
+		}
+		// The request can't be used by the stream, which runs after the handler returns
+		res.Stream(func(w *bufio.Writer) {
+			writeExport(w, values, format)
+		})
+		return nil
+	}
+}
+
+// writeExport is a function that writes the exported documents to the response body, until a write fails because the client is gone.
+// Parameters:
+//   - w (*bufio.Writer): The response body writer.
+//   - values ([]map[string]any): The documents to write.
+//   - format (string): The export format, either "ndjson", "json" or "gob".
+//
+// Returns:
+//   - None
+func writeExport(w *bufio.Writer, values []map[string]any, format string) {
+	var encoder interface{ Encode(v any) error } = json.NewEncoder(w)
+	if format == "gob" {
+		encoder = gob.NewEncoder(w)
+	}
+	if format == "json" {
+		_ = w.WriteByte('[')
+	}
+	for i, value := range values {
+		if format == "json" && i > 0 {
+			_ = w.WriteByte(',')
+		}
+		if err := encoder.Encode(value); err != nil {
+			return
+		}
+
+		// Flush periodically so that the client receives the documents as they're encoded
+		if i%100 == 99 {
+			if err := w.Flush(); err != nil {
+				return
+			}
+		}
+	}
+	if format == "json" {
+		_ = w.WriteByte(']')
+	}
+	_ = w.Flush()
+}
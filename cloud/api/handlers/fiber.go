@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"bufio"
+	"context"
+	"io"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// fiberRequest is a struct that adapts a Fiber request to the Request interface.
+// Fields:
+//   - ctx (*fiber.Ctx): A pointer to the Fiber context of the request.
+type fiberRequest struct {
+	ctx *fiber.Ctx
+}
+
+// fiberResponse is a struct that adapts a Fiber response to the Response interface.
+// Fields:
+//   - ctx (*fiber.Ctx): A pointer to the Fiber context of the request.
+type fiberResponse struct {
+	ctx *fiber.Ctx
+}
+
+// Fiber is a function that returns a fiber context handler function that serves a handler of the API.
+// The context of the request is the user context of the Fiber context, so middlewares can set a deadline with SetUserContext.
+// Parameters:
+//   - h (Handler): The handler to serve.
+//
+// Returns:
+//   - func(ctx *fiber.Ctx) error: A fiber context handler function that calls the handler with the request and response of the Fiber context.
+func Fiber(h Handler) func(ctx *fiber.Ctx) error {
+	return func(ctx *fiber.Ctx) error {
+		return h(fiberRequest{ctx: ctx}, fiberResponse{ctx: ctx})
+	}
+}
+
+// Context is a method of the fiberRequest struct that returns the user context of the Fiber context.
+// Returns:
+//   - context.Context: The context of the request.
+func (r fiberRequest) Context() context.Context {
+	return r.ctx.UserContext()
+}
+
+// Query is a method of the fiberRequest struct that returns the value of a query parameter.
+// Parameters:
+//   - name (string): The name of the query parameter.
+//
+// Returns:
+//   - string: The value of the query parameter, or an empty string if it's not provided.
+func (r fiberRequest) Query(name string) string {
+	return r.ctx.Query(name)
+}
+
+// RawQuery is a method of the fiberRequest struct that returns the encoded query string of the request.
+// Returns:
+//   - string: The query string, without the leading "?".
+func (r fiberRequest) RawQuery() string {
+	return string(r.ctx.Request().URI().QueryString())
+}
+
+// Header is a method of the fiberRequest struct that returns the value of a request header.
+// Parameters:
+//   - name (string): The name of the header.
+//
+// Returns:
+//   - string: The value of the header, or an empty string if it's not provided.
+func (r fiberRequest) Header(name string) string {
+	return r.ctx.Get(name)
+}
+
+// SetHeader is a method of the fiberResponse struct that sets a response header.
+// Parameters:
+//   - name (string): The name of the header.
+//   - value (string): The value of the header.
+//
+// Returns:
+//   - None
+func (r fiberResponse) SetHeader(name string, value string) {
+	r.ctx.Set(name, value)
+}
+
+// SetStatus is a method of the fiberResponse struct that sets the status code of the response.
+// Parameters:
+//   - status (int): The status code.
+//
+// Returns:
+//   - None
+func (r fiberResponse) SetStatus(status int) {
+	r.ctx.Status(status)
+}
+
+// Writer is a method of the fiberResponse struct that returns a writer that appends to the response body.
+// Returns:
+//   - io.Writer: The writer of the response body.
+func (r fiberResponse) Writer() io.Writer {
+	return r.ctx.Response().BodyWriter()
+}
+
+// Stream is a method of the fiberResponse struct that sets the function that writes the response body once the handler returns.
+// Parameters:
+//   - write (func(w *bufio.Writer)): The function that writes the response body.
+//
+// Returns:
+//   - None
+func (r fiberResponse) Stream(write func(w *bufio.Writer)) {
+	r.ctx.Context().SetBodyStreamWriter(write)
+}
@@ -0,0 +1,43 @@
+package handlers
+
+import (
+	hermes "github.com/realTristan/hermes"
+)
+
+// Freeze is a function that returns a handler for rejecting the writes to the cache.
+// Parameters:
+//   - c (*hermes.Cache): A pointer to a hermes.Cache struct.
+//
+// Returns:
+//   - Handler: A handler that freezes the cache and returns a success message.
+func Freeze(c *hermes.Cache) Handler {
+	return func(req Request, res Response) error {
+		c.Freeze()
+		return succeed(req, res, nil)
+	}
+}
+
+// Unfreeze is a function that returns a handler for accepting the writes to the cache again.
+// Parameters:
+//   - c (*hermes.Cache): A pointer to a hermes.Cache struct.
+//
+// Returns:
+//   - Handler: A handler that unfreezes the cache and returns a success message.
+func Unfreeze(c *hermes.Cache) Handler {
+	return func(req Request, res Response) error {
+		c.Unfreeze()
+		return succeed(req, res, nil)
+	}
+}
+
+// Frozen is a function that returns a handler for checking whether the cache is frozen.
+// Parameters:
+//   - c (*hermes.Cache): A pointer to a hermes.Cache struct.
+//
+// Returns:
+//   - Handler: A handler that returns a success message with a boolean value indicating whether the writes are rejected.
+func Frozen(c *hermes.Cache) Handler {
+	return func(req Request, res Response) error {
+		return succeed(req, res, c.Frozen())
+	}
+}
@@ -1,139 +1,243 @@
 package handlers
 
 import (
-	"encoding/json"
-
-	"github.com/gofiber/fiber/v2"
 	hermes "github.com/realTristan/hermes"
 	utils "github.com/realTristan/hermes/cloud/api/utils"
 )
 
-// FTIsInitialized is a handler function that returns a fiber context handler function for checking if the full-text search is initialized.
+// FTIsInitialized is a handler function that returns a handler for checking if the full-text search is initialized.
 // Parameters:
 //   - c (*hermes.Cache): A pointer to a hermes.Cache struct.
 //
 // Returns:
-//   - func(ctx *fiber.Ctx) error: A fiber context handler function that checks if the full-text search is initialized and returns a success message with a boolean value indicating whether it is initialized.
-func FTIsInitialized(c *hermes.Cache) func(ctx *fiber.Ctx) error {
-	return func(ctx *fiber.Ctx) error {
-		return ctx.Send(utils.Success(c.FTIsInitialized()))
+//   - Handler: A handler that checks if the full-text search is initialized and returns a success message with a boolean value indicating whether it is initialized.
+func FTIsInitialized(c *hermes.Cache) Handler {
+	return func(req Request, res Response) error {
+		return succeed(req, res, c.FTIsInitialized())
 	}
 }
 
-// FTSetMaxBytes is a handler function that returns a fiber context handler function for setting the maximum number of bytes for full-text search.
+// FTSetMaxBytes is a handler function that returns a handler for setting the maximum number of bytes for full-text search.
 // Parameters:
 //   - c (*hermes.Cache): A pointer to a hermes.Cache struct.
 //
 // Returns:
-//   - func(ctx *fiber.Ctx) error: A fiber context handler function that sets the maximum number of bytes for full-text search and returns a success message or an error message if the value is not provided or if the setting fails.
-func FTSetMaxBytes(c *hermes.Cache) func(ctx *fiber.Ctx) error {
-	return func(ctx *fiber.Ctx) error {
+//   - Handler: A handler that sets the maximum number of bytes for full-text search and returns a success message or an error message if the value is not provided or if the setting fails.
+func FTSetMaxBytes(c *hermes.Cache) Handler {
+	return func(req Request, res Response) error {
 		// Get the value from the query
 		var value int
-		if err := utils.GetMaxBytesParam(ctx, &value); err != nil {
-			return ctx.Send(utils.Error(err))
+		if err := utils.GetMaxBytesParam(req, &value); err != nil {
+			return fail(req, res, err)
 		}
 
 		// Set the max bytes
 		if err := c.FTSetMaxBytes(value); err != nil {
-			return ctx.Send(utils.Error(err))
+			return fail(req, res, err)
 		}
-		return ctx.Send(utils.Success("null"))
+		return succeed(req, res, nil)
 	}
 }
 
-// FTSetMaxSize is a handler function that returns a fiber context handler function for setting the maximum length for full-text search.
+// FTSetMaxSize is a handler function that returns a handler for setting the maximum length for full-text search.
 // Parameters:
 //   - c (*hermes.Cache): A pointer to a hermes.Cache struct.
 //
 // Returns:
-//   - func(ctx *fiber.Ctx) error: A fiber context handler function that sets the maximum length for full-text search and returns a success message or an error message if the value is not provided or if the setting fails.
-func FTSetMaxSize(c *hermes.Cache) func(ctx *fiber.Ctx) error {
-	return func(ctx *fiber.Ctx) error {
+//   - Handler: A handler that sets the maximum length for full-text search and returns a success message or an error message if the value is not provided or if the setting fails.
+func FTSetMaxSize(c *hermes.Cache) Handler {
+	return func(req Request, res Response) error {
 		// Get the value from the query
 		var value int
-		if err := utils.GetMaxSizeParam(ctx, &value); err != nil {
-			return ctx.Send(utils.Error(err))
+		if err := utils.GetMaxSizeParam(req, &value); err != nil {
+			return fail(req, res, err)
 		}
 
 		// Set the max length
 		if err := c.FTSetMaxSize(value); err != nil {
-			return ctx.Send(utils.Error(err))
+			return fail(req, res, err)
 		}
-		return ctx.Send(utils.Success("null"))
+		return succeed(req, res, nil)
 	}
 }
 
-// FTStorage is a handler function that returns a fiber context handler function for getting the full-text storage.
+// FTStorage is a handler function that returns a handler for getting the full-text storage.
 // Parameters:
 //   - c (*hermes.Cache): A pointer to a hermes.Cache struct.
 //
 // Returns:
-//   - func(ctx *fiber.Ctx) error: A fiber context handler function that gets the full-text storage and returns a JSON-encoded string of the data or an error message if the retrieval or encoding fails.
-func FTStorage(c *hermes.Cache) func(ctx *fiber.Ctx) error {
-	return func(ctx *fiber.Ctx) error {
+//   - Handler: A handler that gets the full-text storage and returns a JSON-encoded string of the data or an error message if the retrieval or encoding fails.
+func FTStorage(c *hermes.Cache) Handler {
+	return func(req Request, res Response) error {
 		if data, err := c.FTStorage(); err != nil {
-			return ctx.Send(utils.Error(err))
-		} else if data, err := json.Marshal(data); err != nil {
-			return ctx.Send(utils.Error(err))
+			return fail(req, res, err)
 		} else {
-			return ctx.Send(data)
+			return send(req, res, data)
 		}
 	}
 }
 
-// FTStorageLength is a handler function that returns a fiber context handler function for getting the length of the full-text storage.
+// FTStorageLength is a handler function that returns a handler for getting the length of the full-text storage.
 // Parameters:
 //   - c (*hermes.Cache): A pointer to a hermes.Cache struct.
 //
 // Returns:
-//   - func(ctx *fiber.Ctx) error: A fiber context handler function that gets the length of the full-text storage and returns a success message with the length or an error message if the retrieval fails.
-func FTStorageLength(c *hermes.Cache) func(ctx *fiber.Ctx) error {
-	return func(ctx *fiber.Ctx) error {
+//   - Handler: A handler that gets the length of the full-text storage and returns a success message with the length or an error message if the retrieval fails.
+func FTStorageLength(c *hermes.Cache) Handler {
+	return func(req Request, res Response) error {
 		if length, err := c.FTStorageLength(); err != nil {
-			return ctx.Send(utils.Error(err))
+			return fail(req, res, err)
 		} else {
-			return ctx.Send(utils.Success(length))
+			return succeed(req, res, length)
 		}
 	}
 }
 
-// FTStorageSize is a handler function that returns a fiber context handler function for getting the size of the full-text storage.
+// FTStorageSize is a handler function that returns a handler for getting the size of the full-text storage.
 // Parameters:
 //   - c (*hermes.Cache): A pointer to a hermes.Cache struct.
 //
 // Returns:
-//   - func(ctx *fiber.Ctx) error: A fiber context handler function that gets the size of the full-text storage and returns a success message with the size or an error message if the retrieval fails.
-func FTStorageSize(c *hermes.Cache) func(ctx *fiber.Ctx) error {
-	return func(ctx *fiber.Ctx) error {
+//   - Handler: A handler that gets the size of the full-text storage and returns a success message with the size or an error message if the retrieval fails.
+func FTStorageSize(c *hermes.Cache) Handler {
+	return func(req Request, res Response) error {
 		if size, err := c.FTStorageSize(); err != nil {
-			return ctx.Send(utils.Error(err))
+			return fail(req, res, err)
 		} else {
-			return ctx.Send(utils.Success(size))
+			return succeed(req, res, size)
 		}
 	}
 }
 
-// FTSetMinWordLength is a handler function that returns a fiber context handler function for setting the minimum word length for full-text search.
+// FTSetMinWordLength is a handler function that returns a handler for setting the minimum word length for full-text search.
 // Parameters:
 //   - c (*hermes.Cache): A pointer to a hermes.Cache struct.
 //
 // Returns:
-//   - func(ctx *fiber.Ctx) error: A fiber context handler function that sets the minimum word length for full-text search and returns a success message or an error message if the value is not provided or if the setting fails.
-func FTSetMinWordLength(c *hermes.Cache) func(ctx *fiber.Ctx) error {
-	return func(ctx *fiber.Ctx) error {
+//   - Handler: A handler that sets the minimum word length for full-text search and returns a success message or an error message if the value is not provided or if the setting fails.
+func FTSetMinWordLength(c *hermes.Cache) Handler {
+	return func(req Request, res Response) error {
 		// Get the min word length from the query
 		var minWordLength int
-		if err := utils.GetMinWordLengthParam(ctx, &minWordLength); err != nil {
-			return ctx.Send(utils.Error(err))
+		if err := utils.GetMinWordLengthParam(req, &minWordLength); err != nil {
+			return fail(req, res, err)
 		}
 
 		// Update the min word length
 		if err := c.FTSetMinWordLength(minWordLength); err != nil {
-			return ctx.Send(utils.Error(err))
+			return fail(req, res, err)
+		}
+
+		// Return null
+		return succeed(req, res, nil)
+	}
+}
+
+// FTSetMaxWordLength is a handler function that returns a handler for setting the maximum word length for full-text search.
+// Parameters:
+//   - c (*hermes.Cache): A pointer to a hermes.Cache struct.
+//
+// Returns:
+//   - Handler: A handler that sets the maximum word length for full-text search, or removes it if it's 0, and returns a success message or an error message if the value is not provided or if the setting fails.
+func FTSetMaxWordLength(c *hermes.Cache) Handler {
+	return func(req Request, res Response) error {
+		// Get the max word length from the query
+		var maxWordLength int
+		if err := utils.GetMaxWordLengthParam(req, &maxWordLength); err != nil {
+			return fail(req, res, err)
+		}
+
+		// Update the max word length
+		if err := c.FTSetMaxWordLength(maxWordLength); err != nil {
+			return fail(req, res, err)
 		}
 
 		// Return null
-		return ctx.Send(utils.Success("null"))
+		return succeed(req, res, nil)
+	}
+}
+
+// FTReindex is a handler function that returns a handler for rebuilding the full-text index with a new schema.
+// Parameters:
+//   - c (*hermes.Cache): A pointer to a hermes.Cache struct.
+//
+// Returns:
+//   - Handler: A handler that rebuilds the full-text index with the schema provided in the query string, while the cache keeps serving
+//     the other requests, and returns a success message or an error message if the schema is not provided or if the rebuild fails.
+func FTReindex(c *hermes.Cache) Handler {
+	return func(req Request, res Response) error {
+		// Get the schema from the query
+		var schema map[string]bool
+		if err := utils.GetSchemaParam(req, &schema); err != nil {
+			return fail(req, res, err)
+		}
+
+		// Rebuild the full-text index
+		if err := c.FTReindex(schema); err != nil {
+			return fail(req, res, err)
+		}
+		return succeed(req, res, nil)
+	}
+}
+
+// FTIndexes is a handler function that returns a handler for getting the named full-text indexes.
+// Parameters:
+//   - c (*hermes.Cache): A pointer to a hermes.Cache struct.
+//
+// Returns:
+//   - Handler: A handler that returns a JSON-encoded, or gob-encoded if the Accept header requests it, map of the fields of the schema of each named full-text index.
+func FTIndexes(c *hermes.Cache) Handler {
+	return func(req Request, res Response) error {
+		return send(req, res, c.FTIndexes())
+	}
+}
+
+// FTCreateIndex is a handler function that returns a handler for creating a named full-text index.
+// Parameters:
+//   - c (*hermes.Cache): A pointer to a hermes.Cache struct.
+//
+// Returns:
+//   - Handler: A handler that creates a named full-text index with the name and schema provided in the query string, and returns a success message
+//     or an error message if the parameters are not provided or if the index could not be created.
+func FTCreateIndex(c *hermes.Cache) Handler {
+	return func(req Request, res Response) error {
+		// Get the name and the schema from the query
+		var (
+			name   string = req.Query("name")
+			schema map[string]bool
+		)
+		if len(name) == 0 {
+			return fail(req, res, "name not provided")
+		} else if err := utils.GetSchemaParam(req, &schema); err != nil {
+			return fail(req, res, err)
+		}
+
+		// Create the index
+		if err := c.FTCreateIndex(name, schema); err != nil {
+			return fail(req, res, err)
+		}
+		return succeed(req, res, nil)
+	}
+}
+
+// FTDropIndex is a handler function that returns a handler for removing a named full-text index.
+// Parameters:
+//   - c (*hermes.Cache): A pointer to a hermes.Cache struct.
+//
+// Returns:
+//   - Handler: A handler that removes the named full-text index with the name provided in the query string, and returns a success message
+//     or an error message if the name is not provided or if the index doesn't exist.
+func FTDropIndex(c *hermes.Cache) Handler {
+	return func(req Request, res Response) error {
+		var name string = req.Query("name")
+		if len(name) == 0 {
+			return fail(req, res, "name not provided")
+		}
+
+		// Remove the index
+		if err := c.FTDropIndex(name); err != nil {
+			return fail(req, res, err)
+		}
+		return succeed(req, res, nil)
 	}
 }
@@ -0,0 +1,209 @@
+package handlers
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// MetadataStatus is the response header metadata key of the gRPC frontend that holds the HTTP status code of the response.
+const MetadataStatus = "x-http-status"
+
+// MetadataContentType is the response header metadata key of the gRPC frontend that holds the Content-Type header of the response,
+// since gRPC reserves the content-type key.
+const MetadataContentType = "x-content-type"
+
+// The maximum number of bytes of the response body sent in a single gRPC message
+const grpcChunkSize int = 32 << 10
+
+// grpcRequest is a struct that adapts a gRPC call to the Request interface.
+// Fields:
+//   - ctx (context.Context): The context of the call.
+//   - md (metadata.MD): The request headers, from the incoming metadata of the call.
+//   - raw (string): The encoded query string of the request.
+//   - query (url.Values): The parsed query parameters of the request.
+type grpcRequest struct {
+	ctx   context.Context
+	md    metadata.MD
+	raw   string
+	query url.Values
+}
+
+// grpcResponse is a struct that adapts the server stream of a gRPC call to the Response interface.
+// Fields:
+//   - stream (grpc.ServerStream): The server stream of the call.
+//   - header (metadata.MD): The response headers, sent as the header metadata of the call.
+//   - sent (bool): Whether the header metadata was sent, after which the headers and status can no longer change.
+type grpcResponse struct {
+	stream grpc.ServerStream
+	header metadata.MD
+	sent   bool
+}
+
+// grpcWriter is a struct that sends what is written to it as the chunks of the response body of a gRPC call.
+// Fields:
+//   - res (*grpcResponse): A pointer to the response.
+type grpcWriter struct {
+	res *grpcResponse
+}
+
+// GRPC is a function that returns a gRPC stream handler that serves a handler of the API.
+// The call receives a google.protobuf.StringValue with the encoded query string of the request, and the request headers are
+// read from its metadata. The response headers and the status are sent as the header metadata, with the status in the
+// x-http-status key and the Content-Type header in the x-content-type key, and the response body is streamed as
+// google.protobuf.BytesValue chunks.
+// Parameters:
+//   - h (Handler): The handler to serve.
+//
+// Returns:
+//   - grpc.StreamHandler: A gRPC stream handler that calls the handler with the request and response of the call.
+func GRPC(h Handler) grpc.StreamHandler {
+	return func(srv any, stream grpc.ServerStream) error {
+		// Receive the query string of the request
+		var raw wrapperspb.StringValue
+		if err := stream.RecvMsg(&raw); err != nil {
+			return err
+		}
+		query, err := url.ParseQuery(raw.Value)
+		if err != nil {
+			return status.Error(codes.InvalidArgument, "invalid query string")
+		}
+		md, _ := metadata.FromIncomingContext(stream.Context())
+
+		// Serve the request, then send the headers if no body was written
+		var res *grpcResponse = &grpcResponse{stream: stream, header: metadata.Pairs(MetadataStatus, "200")}
+		if err := h(&grpcRequest{ctx: stream.Context(), md: md, raw: raw.Value, query: query}, res); err != nil {
+			if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+				return status.FromContextError(err).Err()
+			}
+			return status.Error(codes.Internal, err.Error())
+		}
+		return res.sendHeader()
+	}
+}
+
+// Context is a method of the grpcRequest struct that returns the context of the call.
+// Returns:
+//   - context.Context: The context of the request.
+func (r *grpcRequest) Context() context.Context {
+	return r.ctx
+}
+
+// Query is a method of the grpcRequest struct that returns the value of a query parameter.
+// Parameters:
+//   - name (string): The name of the query parameter.
+//
+// Returns:
+//   - string: The value of the query parameter, or an empty string if it's not provided.
+func (r *grpcRequest) Query(name string) string {
+	return r.query.Get(name)
+}
+
+// RawQuery is a method of the grpcRequest struct that returns the encoded query string of the request.
+// Returns:
+//   - string: The query string, without the leading "?".
+func (r *grpcRequest) RawQuery() string {
+	return r.raw
+}
+
+// Header is a method of the grpcRequest struct that returns the value of a request header from the metadata of the call.
+// Parameters:
+//   - name (string): The name of the header.
+//
+// Returns:
+//   - string: The value of the header, or an empty string if it's not provided.
+func (r *grpcRequest) Header(name string) string {
+	if values := r.md.Get(name); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// SetHeader is a method of the grpcResponse struct that sets a response header. The Content-Type header is set in the x-content-type key.
+// Parameters:
+//   - name (string): The name of the header.
+//   - value (string): The value of the header.
+//
+// Returns:
+//   - None
+func (r *grpcResponse) SetHeader(name string, value string) {
+	if strings.EqualFold(name, "Content-Type") {
+		name = MetadataContentType
+	}
+	r.header.Set(name, value)
+}
+
+// SetStatus is a method of the grpcResponse struct that sets the status code of the response.
+// Parameters:
+//   - status (int): The status code.
+//
+// Returns:
+//   - None
+func (r *grpcResponse) SetStatus(status int) {
+	r.header.Set(MetadataStatus, strconv.Itoa(status))
+}
+
+// Writer is a method of the grpcResponse struct that returns a writer that sends the response body in chunks.
+// Returns:
+//   - io.Writer: The writer of the response body.
+func (r *grpcResponse) Writer() io.Writer {
+	return grpcWriter{res: r}
+}
+
+// Stream is a method of the grpcResponse struct that calls the function with a writer that sends the response body in chunks.
+// Parameters:
+//   - write (func(w *bufio.Writer)): The function that writes the response body.
+//
+// Returns:
+//   - None
+func (r *grpcResponse) Stream(write func(w *bufio.Writer)) {
+	var w *bufio.Writer = bufio.NewWriterSize(grpcWriter{res: r}, grpcChunkSize)
+	write(w)
+	_ = w.Flush()
+}
+
+// sendHeader is a method of the grpcResponse struct that sends the header metadata, unless it was already sent.
+// Returns:
+//   - error: An error if the header metadata could not be sent.
+func (r *grpcResponse) sendHeader() error {
+	if r.sent {
+		return nil
+	}
+	r.sent = true
+	return r.stream.SendHeader(r.header)
+}
+
+// Write is a method of the grpcWriter struct that sends the bytes as one or more chunks of the response body.
+// Parameters:
+//   - p ([]byte): The bytes to write.
+//
+// Returns:
+//   - int: The number of bytes written.
+//   - error: An error if the bytes could not be sent.
+func (w grpcWriter) Write(p []byte) (int, error) {
+	if err := w.res.sendHeader(); err != nil {
+		return 0, err
+	}
+	var n int = 0
+	for n < len(p) {
+		var end int = n + grpcChunkSize
+		if end > len(p) {
+			end = len(p)
+		}
+		if err := w.res.stream.SendMsg(&wrapperspb.BytesValue{Value: p[n:end]}); err != nil {
+			return n, err
+		}
+		n = end
+	}
+	return n, nil
+}
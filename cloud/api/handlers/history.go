@@ -0,0 +1,28 @@
+package handlers
+
+import (
+	hermes "github.com/realTristan/hermes"
+)
+
+// History is a handler function that returns a handler for getting the retained revisions of a key.
+// Parameters:
+//   - c (*hermes.Cache): A pointer to a hermes.Cache struct.
+//
+// Returns:
+//   - Handler: A handler that returns a JSON-encoded array of the revisions of the key, oldest first, or an error message if the key is not provided or history is not enabled.
+func History(c *hermes.Cache) Handler {
+	return func(req Request, res Response) error {
+		// Get the key from the query
+		var key string
+		if key = req.Query("key"); len(key) == 0 {
+			return fail(req, res, "key not provided")
+		}
+
+		// Get the revisions of the key
+		if revisions, err := c.History(key); err != nil {
+			return fail(req, res, err)
+		} else {
+			return send(req, res, revisions)
+		}
+	}
+}
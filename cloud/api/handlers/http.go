@@ -0,0 +1,155 @@
+package handlers
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// httpRequest is a struct that adapts a net/http request to the Request interface.
+// Fields:
+//   - r (*http.Request): A pointer to the request.
+//   - query (url.Values): The parsed query parameters of the request.
+type httpRequest struct {
+	r     *http.Request
+	query url.Values
+}
+
+// httpResponse is a struct that adapts a net/http response writer to the Response interface.
+// Fields:
+//   - w (http.ResponseWriter): The response writer.
+//   - status (int): The status code of the response.
+//   - written (bool): Whether the status code was written, after which the headers and status can no longer change.
+type httpResponse struct {
+	w       http.ResponseWriter
+	status  int
+	written bool
+}
+
+// flushWriter is a struct that flushes a response writer after each write, so that what is written is sent to the client.
+// Fields:
+//   - w (http.ResponseWriter): The response writer.
+type flushWriter struct {
+	w http.ResponseWriter
+}
+
+// HTTP is a function that returns a net/http handler that serves a handler of the API.
+// The context of the request is the context of the net/http request, which is cancelled once the client is gone.
+// Parameters:
+//   - h (Handler): The handler to serve.
+//
+// Returns:
+//   - http.Handler: A net/http handler that calls the handler with the request and its response writer.
+func HTTP(h Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var res *httpResponse = &httpResponse{w: w, status: http.StatusOK}
+		if err := h(&httpRequest{r: r, query: r.URL.Query()}, res); err != nil && !res.written {
+			res.status = http.StatusInternalServerError
+			res.SetHeader("Content-Type", "application/json")
+			_ = json.NewEncoder(res.Writer()).Encode(errorMessage{Success: false, Error: err.Error()})
+		}
+		res.writeHeader()
+	})
+}
+
+// Context is a method of the httpRequest struct that returns the context of the request.
+// Returns:
+//   - context.Context: The context of the request.
+func (r *httpRequest) Context() context.Context {
+	return r.r.Context()
+}
+
+// Query is a method of the httpRequest struct that returns the value of a query parameter.
+// Parameters:
+//   - name (string): The name of the query parameter.
+//
+// Returns:
+//   - string: The value of the query parameter, or an empty string if it's not provided.
+func (r *httpRequest) Query(name string) string {
+	return r.query.Get(name)
+}
+
+// RawQuery is a method of the httpRequest struct that returns the encoded query string of the request.
+// Returns:
+//   - string: The query string, without the leading "?".
+func (r *httpRequest) RawQuery() string {
+	return r.r.URL.RawQuery
+}
+
+// Header is a method of the httpRequest struct that returns the value of a request header.
+// Parameters:
+//   - name (string): The name of the header.
+//
+// Returns:
+//   - string: The value of the header, or an empty string if it's not provided.
+func (r *httpRequest) Header(name string) string {
+	return r.r.Header.Get(name)
+}
+
+// SetHeader is a method of the httpResponse struct that sets a response header.
+// Parameters:
+//   - name (string): The name of the header.
+//   - value (string): The value of the header.
+//
+// Returns:
+//   - None
+func (r *httpResponse) SetHeader(name string, value string) {
+	r.w.Header().Set(name, value)
+}
+
+// SetStatus is a method of the httpResponse struct that sets the status code of the response.
+// Parameters:
+//   - status (int): The status code.
+//
+// Returns:
+//   - None
+func (r *httpResponse) SetStatus(status int) {
+	r.status = status
+}
+
+// Writer is a method of the httpResponse struct that writes the status code and returns the response writer.
+// Returns:
+//   - io.Writer: The writer of the response body.
+func (r *httpResponse) Writer() io.Writer {
+	r.writeHeader()
+	return r.w
+}
+
+// Stream is a method of the httpResponse struct that writes the status code and calls the function with a writer that flushes to the client.
+// Parameters:
+//   - write (func(w *bufio.Writer)): The function that writes the response body.
+//
+// Returns:
+//   - None
+func (r *httpResponse) Stream(write func(w *bufio.Writer)) {
+	r.writeHeader()
+	write(bufio.NewWriter(flushWriter{w: r.w}))
+}
+
+// writeHeader is a method of the httpResponse struct that writes the status code, unless it was already written.
+// Returns:
+//   - None
+func (r *httpResponse) writeHeader() {
+	if !r.written {
+		r.written = true
+		r.w.WriteHeader(r.status)
+	}
+}
+
+// Write is a method of the flushWriter struct that writes to the response writer and flushes it.
+// Parameters:
+//   - p ([]byte): The bytes to write.
+//
+// Returns:
+//   - int: The number of bytes written.
+//   - error: An error if the bytes could not be written.
+func (fw flushWriter) Write(p []byte) (int, error) {
+	n, err := fw.w.Write(p)
+	if f, ok := fw.w.(http.Flusher); ok {
+		f.Flush()
+	}
+	return n, err
+}
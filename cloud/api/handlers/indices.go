@@ -1,20 +1,33 @@
 package handlers
 
 import (
-	"github.com/gofiber/fiber/v2"
 	hermes "github.com/realTristan/hermes"
-	utils "github.com/realTristan/hermes/cloud/api/utils"
 )
 
-// FTSequenceIndices is a handler function that returns a fiber context handler function for sequencing the full-text storage indices.
+// FTSequenceIndices is a handler function that returns a handler for sequencing the full-text storage indices.
 // Parameters:
 //   - c (*hermes.Cache): A pointer to a hermes.Cache struct.
 //
 // Returns:
-//   - func(ctx *fiber.Ctx) error: A fiber context handler function that sequences the full-text storage indices and returns a success message or an error message if the sequencing fails.
-func FTSequenceIndices(c *hermes.Cache) func(ctx *fiber.Ctx) error {
-	return func(ctx *fiber.Ctx) error {
+//   - Handler: A handler that sequences the full-text storage indices and returns a success message or an error message if the sequencing fails.
+func FTSequenceIndices(c *hermes.Cache) Handler {
+	return func(req Request, res Response) error {
 		c.FTSequenceIndices()
-		return ctx.Send(utils.Success("null"))
+		return succeed(req, res, nil)
+	}
+}
+
+// FTCompact is a handler function that returns a handler for compacting the full-text index.
+// Parameters:
+//   - c (*hermes.Cache): A pointer to a hermes.Cache struct.
+//
+// Returns:
+//   - Handler: A handler that compacts the full-text index and returns a success message or an error message if the compaction fails.
+func FTCompact(c *hermes.Cache) Handler {
+	return func(req Request, res Response) error {
+		if err := c.FTCompact(); err != nil {
+			return fail(req, res, err)
+		}
+		return succeed(req, res, nil)
 	}
 }
@@ -1,9 +1,7 @@
 package handlers
 
 import (
-	"github.com/gofiber/fiber/v2"
 	hermes "github.com/realTristan/hermes"
-	utils "github.com/realTristan/hermes/cloud/api/utils"
 )
 
 // Info is a function that returns information about the cache.
@@ -12,14 +10,13 @@ import (
 //   - c: A pointer to a hermes.Cache struct representing the cache to get information from.
 //
 // Returns:
-//   - A function that takes a pointer to a fiber.Ctx struct and returns an error.
-//     The function returns information about the cache.
-func Info(c *hermes.Cache) func(ctx *fiber.Ctx) error {
-	return func(ctx *fiber.Ctx) error {
+//   - A handler that returns information about the cache.
+func Info(c *hermes.Cache) Handler {
+	return func(req Request, res Response) error {
 		if info, err := c.Info(); err != nil {
-			return ctx.Send(utils.Error(err))
+			return fail(req, res, err)
 		} else {
-			return ctx.Send(utils.Success(info))
+			return succeed(req, res, info)
 		}
 	}
 }
@@ -30,14 +27,13 @@ func Info(c *hermes.Cache) func(ctx *fiber.Ctx) error {
 //   - c: A pointer to a hermes.Cache struct representing the cache to get information from.
 //
 // Returns:
-//   - A function that takes a pointer to a fiber.Ctx struct and returns an error.
-//     The function returns information about the cache for testing purposes.
-func InfoForTesting(c *hermes.Cache) func(ctx *fiber.Ctx) error {
-	return func(ctx *fiber.Ctx) error {
+//   - A handler that returns information about the cache for testing purposes.
+func InfoForTesting(c *hermes.Cache) Handler {
+	return func(req Request, res Response) error {
 		if info, err := c.InfoForTesting(); err != nil {
-			return ctx.Send(utils.Error(err))
+			return fail(req, res, err)
 		} else {
-			return ctx.Send(utils.Success(info))
+			return succeed(req, res, info)
 		}
 	}
 }
@@ -1,25 +1,33 @@
 package handlers
 
 import (
-	"encoding/json"
-
-	"github.com/gofiber/fiber/v2"
 	hermes "github.com/realTristan/hermes"
-	utils "github.com/realTristan/hermes/cloud/api/utils"
 )
 
-// Keys is a handler function that returns a fiber context handler function for getting all the keys from the cache.
+// Keys is a handler function that returns a handler for getting all the keys from the cache.
+// If the prefix query parameter is provided, only the keys that start with it are returned, in sorted order.
+// If the pattern query parameter is provided, only the keys that match the glob pattern are returned, in sorted order.
 // Parameters:
 //   - c (*hermes.Cache): A pointer to a hermes.Cache struct.
 //
 // Returns:
-//   - func(ctx *fiber.Ctx) error: A fiber context handler function that gets all the keys from the cache and returns a JSON-encoded string of the keys or an error message if the retrieval or encoding fails.
-func Keys(c *hermes.Cache) func(ctx *fiber.Ctx) error {
-	return func(ctx *fiber.Ctx) error {
-		if keys, err := json.Marshal(c.Keys()); err != nil {
-			return ctx.Send(utils.Error(err))
+//   - Handler: A handler that gets all the keys from the cache and returns a JSON-encoded string of the keys or an error message if the retrieval or encoding fails.
+func Keys(c *hermes.Cache) Handler {
+	return func(req Request, res Response) error {
+		// Get the keys, filtered by the pattern or prefix if one is provided
+		var keys []string
+		if pattern := req.Query("pattern"); len(pattern) > 0 {
+			var err error
+			if keys, err = c.KeysMatching(pattern); err != nil {
+				return fail(req, res, err)
+			}
+		} else if prefix := req.Query("prefix"); len(prefix) > 0 {
+			keys = c.KeysWithPrefix(prefix)
 		} else {
-			return ctx.Send(keys)
+			keys = c.Keys()
 		}
+
+		// Return the keys
+		return send(req, res, keys)
 	}
 }
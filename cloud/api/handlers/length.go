@@ -1,19 +1,17 @@
 package handlers
 
 import (
-	"github.com/gofiber/fiber/v2"
 	hermes "github.com/realTristan/hermes"
-	utils "github.com/realTristan/hermes/cloud/api/utils"
 )
 
-// Length is a handler function that returns a fiber context handler function for getting the length of the cache.
+// Length is a handler function that returns a handler for getting the length of the cache.
 // Parameters:
 //   - c (*hermes.Cache): A pointer to a hermes.Cache struct.
 //
 // Returns:
-//   - func(ctx *fiber.Ctx) error: A fiber context handler function that gets the length of the cache and returns a success message with the length or an error message if the retrieval fails.
-func Length(c *hermes.Cache) func(ctx *fiber.Ctx) error {
-	return func(ctx *fiber.Ctx) error {
-		return ctx.Send(utils.Success(c.Length()))
+//   - Handler: A handler that gets the length of the cache and returns a success message with the length or an error message if the retrieval fails.
+func Length(c *hermes.Cache) Handler {
+	return func(req Request, res Response) error {
+		return succeed(req, res, c.Length())
 	}
 }
@@ -0,0 +1,17 @@
+package handlers
+
+import (
+	utils "github.com/realTristan/hermes/cloud/api/utils"
+)
+
+// Metrics is a handler function that returns a handler for getting the request counts, error rates and latencies of the endpoints of the API.
+// Parameters:
+//   - m (*utils.Metrics): A pointer to the metrics of the API.
+//
+// Returns:
+//   - Handler: A handler that returns the JSON-encoded metrics of each endpoint, keyed by method and route path, or an error message if they could not be encoded.
+func Metrics(m *utils.Metrics) Handler {
+	return func(req Request, res Response) error {
+		return send(req, res, m.Endpoints())
+	}
+}
@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	hermes "github.com/realTristan/hermes"
+)
+
+// Namespaces is a handler function that returns a handler for listing the namespaces of the cache.
+// Parameters:
+//   - c (*hermes.Cache): A pointer to a hermes.Cache struct.
+//
+// Returns:
+//   - Handler: A handler that returns a JSON-encoded array of the sorted names of the created namespaces or an error message if the encoding fails.
+func Namespaces(c *hermes.Cache) Handler {
+	return func(req Request, res Response) error {
+		return send(req, res, c.Namespaces())
+	}
+}
+
+// CreateNamespace is a handler function that returns a handler for creating a namespace in the cache.
+// Parameters:
+//   - c (*hermes.Cache): A pointer to a hermes.Cache struct.
+//
+// Returns:
+//   - Handler: A handler that creates the namespace provided in the query string and returns a success message or an error message if the namespace is invalid or already exists.
+func CreateNamespace(c *hermes.Cache) Handler {
+	return func(req Request, res Response) error {
+		// Get the namespace from the query
+		var ns string
+		if ns = req.Query("namespace"); len(ns) == 0 {
+			return fail(req, res, "namespace not provided")
+		}
+
+		// Create the namespace
+		if _, err := c.CreateNamespace(ns); err != nil {
+			return fail(req, res, err)
+		}
+		return succeed(req, res, nil)
+	}
+}
+
+// DeleteNamespace is a handler function that returns a handler for deleting a namespace and all of its keys from the cache.
+// Parameters:
+//   - c (*hermes.Cache): A pointer to a hermes.Cache struct.
+//
+// Returns:
+//   - Handler: A handler that deletes the namespace provided in the query string and returns the number of deleted keys or an error message if the namespace doesn't exist.
+func DeleteNamespace(c *hermes.Cache) Handler {
+	return func(req Request, res Response) error {
+		// Get the namespace from the query
+		var ns string
+		if ns = req.Query("namespace"); len(ns) == 0 {
+			return fail(req, res, "namespace not provided")
+		}
+
+		// Delete the namespace
+		if n, err := c.DeleteNamespace(ns); err != nil {
+			return fail(req, res, err)
+		} else {
+			return succeed(req, res, n)
+		}
+	}
+}
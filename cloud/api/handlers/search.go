@@ -1,185 +1,553 @@
 package handlers
 
 import (
-	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 	hermes "github.com/realTristan/hermes"
 	utils "github.com/realTristan/hermes/cloud/api/utils"
 )
 
-// Search is a handler function that returns a fiber context handler function for searching the cache.
+// HeaderNextCursor is the response header of a search that holds the cursor of its next page, which is passed back with the cursor parameter.
+const HeaderNextCursor = "X-Next-Cursor"
+
+// HeaderTimedOut is the response header of a search that is set to "true" when the search stopped once its timeout parameter elapsed,
+// so the results are partial.
+const HeaderTimedOut = "X-Timed-Out"
+
+// The rankers that can be selected with the ranker parameter
+var rankers map[string]hermes.Ranker = map[string]hermes.Ranker{
+	"none":  hermes.RankNone,
+	"tfidf": hermes.RankTFIDF,
+	"bm25":  hermes.RankBM25,
+}
+
+// The sort orders that can be selected with the sortorder parameter
+var sortOrders map[string]hermes.SortOrder = map[string]hermes.SortOrder{
+	"asc":  hermes.SortAscending,
+	"desc": hermes.SortDescending,
+}
+
+// Search is a handler function that returns a handler for searching the cache.
+// The query parameter is required. The limit, strict, ranker ("none", "tfidf" or "bm25"), minscore, fuzziness, wildcards,
+// offset, cursor, index, sortby, sortorder and timeout (a duration such as "500ms") parameters are optional.
 // Parameters:
 //   - c (*hermes.Cache): A pointer to a hermes.Cache struct.
 //
 // Returns:
-//   - func(ctx *fiber.Ctx) error: A fiber context handler function that searches the cache using the query, limit, strict, and schema parameters provided in the query string and returns a JSON-encoded string of the search results or an error message if the search fails or if the parameters are not provided.
-func Search(c *hermes.Cache) func(ctx *fiber.Ctx) error {
-	return func(ctx *fiber.Ctx) error {
+//   - Handler: A handler that returns the search results, JSON-encoded or gob-encoded if the Accept header requests it, or an error message.
+//     The X-Next-Cursor header holds the cursor of the next page, and the X-Timed-Out header is "true" if the results are partial.
+//     It responds with 304 Not Modified if the If-None-Match header matches the search ETag, and with 410 Gone if the cache
+//     was written to since the cursor was returned.
+func Search(c *hermes.Cache) Handler {
+	return func(req Request, res Response) error {
 		var (
-			strict bool
-			query  string
-			limit  int
+			strict    bool
+			wildcards bool
+			query     string
+			limit     int
+			fuzziness int
+			offset    int
+			ranker    hermes.Ranker
+			minScore  float64
+			timeout   time.Duration
+			sortOrder hermes.SortOrder
 		)
 
+		// Check whether the client already has the search results
+		if notModified(req, res, searchETag(req, c.Generation())) {
+			return nil
+		}
+
 		// Get the query from the url params
-		if query = ctx.Query("query"); len(query) == 0 {
-			return ctx.Send(utils.Error("query not provided"))
+		if query = req.Query("query"); len(query) == 0 {
+			return fail(req, res, "query not provided")
 		}
 
-		// Get the limit from the url params
-		if err := utils.GetLimitParam(ctx, &limit); err != nil {
-			return ctx.Send(utils.Error(err))
+		// Get the ranker, the minimum score, the fuzziness and the sort order from the url params
+		if err := getRankerParam(req, &ranker); err != nil {
+			return fail(req, res, err)
+		} else if err := getMinScoreParam(req, &minScore); err != nil {
+			return fail(req, res, err)
+		} else if err := getSortOrderParam(req, &sortOrder); err != nil {
+			return fail(req, res, err)
+		} else if s := req.Query("fuzziness"); len(s) > 0 {
+			if i, err := strconv.Atoi(s); err != nil || i < 0 {
+				return fail(req, res, "invalid fuzziness")
+			} else {
+				fuzziness = i
+			}
 		}
 
-		// Get the strict from the url params
-		if err := utils.GetStrictParam(ctx, &strict); err != nil {
-			return ctx.Send(utils.Error(err))
+		// Get the limit and the optional offset from the url params
+		if err := utils.GetLimitParam(req, &limit); err != nil {
+			return fail(req, res, err)
+		} else if s := req.Query("offset"); len(s) > 0 {
+			if i, err := strconv.Atoi(s); err != nil || i < 0 {
+				return fail(req, res, "invalid offset")
+			} else {
+				offset = i
+			}
 		}
 
-		// Search for the query
-		if res, err := c.Search(hermes.SearchParams{
-			Query:  query,
-			Limit:  limit,
-			Strict: strict,
-		}); err != nil {
-			return ctx.Send(utils.Error(err))
-		} else if data, err := json.Marshal(res); err != nil {
-			return ctx.Send(utils.Error(err))
-		} else {
-			return ctx.Send(data)
+		// Get the strict and the optional wildcards and timeout from the url params
+		if err := utils.GetStrictParam(req, &strict); err != nil {
+			return fail(req, res, err)
+		} else if s := req.Query("wildcards"); len(s) > 0 {
+			if b, err := strconv.ParseBool(s); err != nil {
+				return fail(req, res, "invalid wildcards")
+			} else {
+				wildcards = b
+			}
+		}
+		if err := getTimeoutParam(req, &timeout); err != nil {
+			return fail(req, res, err)
 		}
+
+		// Search for the page of results
+		results, next, err := c.SearchCursorCtx(req.Context(), hermes.SearchParams{
+			Query:     query,
+			Index:     req.Query("index"),
+			Limit:     limit,
+			Offset:    offset,
+			Cursor:    req.Query("cursor"),
+			Strict:    strict,
+			Ranker:    ranker,
+			MinScore:  minScore,
+			Fuzziness: fuzziness,
+			Wildcards: wildcards,
+			SortBy:    req.Query("sortby"),
+			SortOrder: sortOrder,
+			Timeout:   timeout,
+		})
+		if errors.Is(err, hermes.ErrCursorGone) {
+			return failWith(req, res, fiber.StatusGone, err)
+		} else if errors.Is(err, hermes.ErrSearchTimeout) {
+			res.SetHeader(HeaderTimedOut, "true")
+		} else if err != nil {
+			return fail(req, res, err)
+		}
+		if len(next) > 0 {
+			res.SetHeader(HeaderNextCursor, next)
+		}
+		return send(req, res, results)
 	}
 }
 
-// SearchOneWord is a handler function that returns a fiber context handler function for searching the cache for a single word.
+// SearchOneWord is a handler function that returns a handler for searching the cache for a single word.
 // Parameters:
 //   - c (*hermes.Cache): A pointer to a hermes.Cache struct.
 //
 // Returns:
-//   - func(ctx *fiber.Ctx) error: A fiber context handler function that searches the cache for a single word using the query, limit, and strict parameters provided in the query string and returns a JSON-encoded string of the search results or an error message if the search fails or if the parameters are not provided.
-func SearchOneWord(c *hermes.Cache) func(ctx *fiber.Ctx) error {
-	return func(ctx *fiber.Ctx) error {
+//   - Handler: A handler that searches the cache for a single word using the query, limit, strict, and optional index parameters provided in the query string and returns a JSON-encoded, or gob-encoded if the Accept header requests it, string of the search results or an error message if the search fails or if the parameters are not provided.
+func SearchOneWord(c *hermes.Cache) Handler {
+	return func(req Request, res Response) error {
 		var (
 			strict bool
 			query  string
 			limit  int
 		)
 
+		// Check whether the client already has the search results
+		if notModified(req, res, searchETag(req, c.Generation())) {
+			return nil
+		}
+
 		// Get the query from the url params
-		if query = ctx.Query("query"); len(query) == 0 {
-			return ctx.Send(utils.Error("invalid query"))
+		if query = req.Query("query"); len(query) == 0 {
+			return fail(req, res, "invalid query")
 		}
 
 		// Get the limit from the url params
-		if err := utils.GetLimitParam(ctx, &limit); err != nil {
-			return ctx.Send(utils.Error(err))
+		if err := utils.GetLimitParam(req, &limit); err != nil {
+			return fail(req, res, err)
 		}
 
 		// Get the strict from the url params
-		if err := utils.GetStrictParam(ctx, &strict); err != nil {
-			return ctx.Send(utils.Error(err))
+		if err := utils.GetStrictParam(req, &strict); err != nil {
+			return fail(req, res, err)
 		}
 
 		// Search for the query
-		if res, err := c.SearchOneWord(hermes.SearchParams{
+		if results, err := c.SearchOneWord(hermes.SearchParams{
 			Query:  query,
+			Index:  req.Query("index"),
 			Limit:  limit,
 			Strict: strict,
 		}); err != nil {
-			return ctx.Send(utils.Error(err))
-		} else if data, err := json.Marshal(res); err != nil {
-			return ctx.Send(utils.Error(err))
+			return fail(req, res, err)
 		} else {
-			return ctx.Send(data)
+			return send(req, res, results)
 		}
 	}
 }
 
-// SearchValues is a handler function that returns a fiber context handler function for searching the cache for values.
+// SearchRegex is a handler function that returns a handler for searching the words of the full-text index with a regular expression.
 // Parameters:
 //   - c (*hermes.Cache): A pointer to a hermes.Cache struct.
 //
 // Returns:
-//   - func(ctx *fiber.Ctx) error: A fiber context handler function that searches the cache for values using the query, limit, and schema parameters provided in the query string and returns a JSON-encoded string of the search results or an error message if the search fails or if the parameters are not provided.
-func SearchValues(c *hermes.Cache) func(ctx *fiber.Ctx) error {
-	return func(ctx *fiber.Ctx) error {
+//   - Handler: A handler that responds with 304 Not Modified if the If-None-Match header matches the search ETag, otherwise it searches the cache for the values that contain a word matching the pattern parameter, up to the limit parameter, and returns a JSON-encoded, or gob-encoded if the Accept header requests it, string of the search results or an error message if the search fails or if the parameters are not provided.
+func SearchRegex(c *hermes.Cache) Handler {
+	return func(req Request, res Response) error {
+		var (
+			pattern string
+			limit   int
+		)
+
+		// Check whether the client already has the search results
+		if notModified(req, res, searchETag(req, c.Generation())) {
+			return nil
+		}
+
+		// Get the pattern and the limit from the url params
+		if pattern = req.Query("pattern"); len(pattern) == 0 {
+			return fail(req, res, "invalid pattern")
+		} else if err := utils.GetLimitParam(req, &limit); err != nil {
+			return fail(req, res, err)
+		}
+
+		// Search for the pattern
+		if results, err := c.SearchRegex(pattern, limit); err != nil {
+			return fail(req, res, err)
+		} else {
+			return send(req, res, results)
+		}
+	}
+}
+
+// SearchValues is a handler function that returns a handler for searching the cache for values.
+// Parameters:
+//   - c (*hermes.Cache): A pointer to a hermes.Cache struct.
+//
+// Returns:
+//   - Handler: A handler that searches the cache for values using the query, limit, and schema parameters provided in the query string and returns a JSON-encoded, or gob-encoded if the Accept header requests it, string of the search results or an error message if the search fails or if the parameters are not provided.
+func SearchValues(c *hermes.Cache) Handler {
+	return func(req Request, res Response) error {
 		var (
 			query  string
 			limit  int
 			schema map[string]bool
 		)
 
+		// Check whether the client already has the search results
+		if notModified(req, res, searchETag(req, c.Generation())) {
+			return nil
+		}
+
 		// Get the query from the url params
-		if query = ctx.Query("query"); len(query) == 0 {
-			return ctx.Send(utils.Error("invalid query"))
+		if query = req.Query("query"); len(query) == 0 {
+			return fail(req, res, "invalid query")
 		}
 
 		// Get the limit from the url params
-		if err := utils.GetLimitParam(ctx, &limit); err != nil {
-			return ctx.Send(utils.Error(err))
+		if err := utils.GetLimitParam(req, &limit); err != nil {
+			return fail(req, res, err)
 		}
 
 		// Get the schema from the url params
-		if err := utils.GetSchemaParam(ctx, &schema); err != nil {
-			return ctx.Send(utils.Error(err))
+		if err := utils.GetSchemaParam(req, &schema); err != nil {
+			return fail(req, res, err)
 		}
 
 		// Search for the query
-		if res, err := c.SearchValues(hermes.SearchParams{
+		if results, err := c.SearchValues(hermes.SearchParams{
 			Query:  query,
 			Limit:  limit,
 			Schema: schema,
 		}); err != nil {
-			return ctx.Send(utils.Error(err))
-		} else if data, err := json.Marshal(res); err != nil {
-			return ctx.Send(utils.Error(err))
+			return fail(req, res, err)
 		} else {
-			return ctx.Send(data)
+			return send(req, res, results)
 		}
 	}
 }
 
-// SearchWithKey is a handler function that returns a fiber context handler function for searching the cache with a specific key.
+// SearchWithKey is a handler function that returns a handler for searching the cache with a specific key.
 // Parameters:
 //   - c (*hermes.Cache): A pointer to a hermes.Cache struct.
 //
 // Returns:
-//   - func(ctx *fiber.Ctx) error: A fiber context handler function that searches the cache with a specific key using the query and limit parameters provided in the query string and returns a JSON-encoded string of the search results or an error message if the search fails or if the parameters are not provided.
-func SearchWithKey(c *hermes.Cache) func(ctx *fiber.Ctx) error {
-	return func(ctx *fiber.Ctx) error {
+//   - Handler: A handler that searches the cache with a specific key using the query, limit, and optional index parameters provided in the query string and returns a JSON-encoded, or gob-encoded if the Accept header requests it, string of the search results or an error message if the search fails or if the parameters are not provided.
+func SearchWithKey(c *hermes.Cache) Handler {
+	return func(req Request, res Response) error {
 		var (
 			key   string
 			query string
 			limit int
 		)
 
+		// Check whether the client already has the search results
+		if notModified(req, res, searchETag(req, c.Generation())) {
+			return nil
+		}
+
 		// Get the query from the url params
-		if query = ctx.Query("query"); len(query) == 0 {
-			return ctx.Send(utils.Error("invalid query"))
+		if query = req.Query("query"); len(query) == 0 {
+			return fail(req, res, "invalid query")
 		}
 
 		// Get the key from the url params
-		if key = ctx.Query("key"); len(key) == 0 {
-			return ctx.Send(utils.Error("invalid key"))
+		if key = req.Query("key"); len(key) == 0 {
+			return fail(req, res, "invalid key")
 		}
 
 		// Get the limit from the url params
-		if err := utils.GetLimitParam(ctx, &limit); err != nil {
-			return ctx.Send(utils.Error(err))
+		if err := utils.GetLimitParam(req, &limit); err != nil {
+			return fail(req, res, err)
 		}
 
 		// Search for the query
-		if res, err := c.SearchWithKey(hermes.SearchParams{
+		if results, err := c.SearchWithKey(hermes.SearchParams{
 			Key:   key,
+			Index: req.Query("index"),
 			Query: query,
 			Limit: limit,
 		}); err != nil {
-			return ctx.Send(utils.Error(err))
-		} else if data, err := json.Marshal(res); err != nil {
-			return ctx.Send(utils.Error(err))
+			return fail(req, res, err)
 		} else {
-			return ctx.Send(data)
+			return send(req, res, results)
+		}
+	}
+}
+
+// SearchGroups is a handler function that returns a handler for searching the cache with the results grouped by a field.
+// Parameters:
+//   - c (*hermes.Cache): A pointer to a hermes.Cache struct.
+//
+// Returns:
+//   - Handler: A handler that searches the cache using the query, limit, strict, groupby, and optional grouplimit and index parameters provided in the query string and returns a JSON-encoded, or gob-encoded if the Accept header requests it, string of the groups of search results or an error message if the search fails or if the parameters are not provided.
+func SearchGroups(c *hermes.Cache) Handler {
+	return func(req Request, res Response) error {
+		var (
+			strict     bool
+			query      string
+			groupBy    string
+			limit      int
+			groupLimit int
+		)
+
+		// Check whether the client already has the search results
+		if notModified(req, res, searchETag(req, c.Generation())) {
+			return nil
+		}
+
+		// Get the query and the group by field from the url params
+		if query = req.Query("query"); len(query) == 0 {
+			return fail(req, res, "query not provided")
+		} else if groupBy = req.Query("groupby"); len(groupBy) == 0 {
+			return fail(req, res, "groupby not provided")
+		}
+
+		// Get the limits from the url params
+		if err := utils.GetLimitParam(req, &limit); err != nil {
+			return fail(req, res, err)
+		} else if s := req.Query("grouplimit"); len(s) > 0 {
+			if i, err := strconv.Atoi(s); err != nil {
+				return fail(req, res, "invalid grouplimit")
+			} else {
+				groupLimit = i
+			}
+		}
+
+		// Get the strict from the url params
+		if err := utils.GetStrictParam(req, &strict); err != nil {
+			return fail(req, res, err)
 		}
+
+		// Search for the query
+		if groups, err := c.SearchGroups(hermes.SearchParams{
+			Query:      query,
+			Index:      req.Query("index"),
+			Limit:      limit,
+			Strict:     strict,
+			GroupBy:    groupBy,
+			GroupLimit: groupLimit,
+		}); err != nil {
+			return fail(req, res, err)
+		} else {
+			return send(req, res, groups)
+		}
+	}
+}
+
+// SearchPage is a handler function that returns a handler for paging through the results of a search.
+// Parameters:
+//   - c (*hermes.Cache): A pointer to a hermes.Cache struct.
+//
+// Returns:
+//   - Handler: A handler that returns the page of limit results that the cursor parameter points to, or if no cursor is provided, searches the cache using the query, strict, and optional ranker, minscore, fuzziness and index parameters provided in the query string and returns the first page, as a JSON-encoded, or gob-encoded if the Accept header requests it, string of the page with the cursor of the next page, or an error message if the search fails or if the parameters are not provided. It responds with 410 Gone if the results of the cursor are no longer pinned, in which case the search must be run again.
+func SearchPage(c *hermes.Cache) Handler {
+	return func(req Request, res Response) error {
+		var (
+			strict    bool
+			query     string
+			limit     int
+			fuzziness int
+			ranker    hermes.Ranker
+			minScore  float64
+			sortOrder hermes.SortOrder
+			page      hermes.Page
+			err       error
+		)
+
+		// Get the page size from the url params
+		if err := utils.GetLimitParam(req, &limit); err != nil {
+			return fail(req, res, err)
+		}
+
+		// Get the next page of the cursor
+		if cursor := req.Query("cursor"); len(cursor) > 0 {
+			if page, err = c.NextPage(cursor, limit); errors.Is(err, hermes.ErrCursorGone) {
+				return failWith(req, res, fiber.StatusGone, err)
+			}
+		} else {
+			// Get the query from the url params
+			if query = req.Query("query"); len(query) == 0 {
+				return fail(req, res, "query not provided")
+			}
+
+			// Get the ranker, the minimum score, the fuzziness and the sort order from the url params
+			if err := getRankerParam(req, &ranker); err != nil {
+				return fail(req, res, err)
+			} else if err := getMinScoreParam(req, &minScore); err != nil {
+				return fail(req, res, err)
+			} else if err := getSortOrderParam(req, &sortOrder); err != nil {
+				return fail(req, res, err)
+			} else if s := req.Query("fuzziness"); len(s) > 0 {
+				if i, err := strconv.Atoi(s); err != nil || i < 0 {
+					return fail(req, res, "invalid fuzziness")
+				} else {
+					fuzziness = i
+				}
+			}
+
+			// Get the strict from the url params
+			if err := utils.GetStrictParam(req, &strict); err != nil {
+				return fail(req, res, err)
+			}
+
+			// Search for the query
+			page, err = c.SearchPage(hermes.SearchParams{
+				Query:     query,
+				Index:     req.Query("index"),
+				Strict:    strict,
+				Ranker:    ranker,
+				MinScore:  minScore,
+				Fuzziness: fuzziness,
+				SortBy:    req.Query("sortby"),
+				SortOrder: sortOrder,
+			}, limit)
+		}
+
+		// Send the page
+		if err != nil {
+			return fail(req, res, err)
+		} else {
+			return send(req, res, page)
+		}
+	}
+}
+
+// getRankerParam is a function that retrieves the optional "ranker" query parameter of a request.
+// Parameters:
+//   - req (Request): The request.
+//   - ranker (*hermes.Ranker): A pointer to a ranker to store the "ranker" query parameter, which is left unchanged if the parameter is not provided.
+//
+// Returns:
+//   - error: An error message if the "ranker" query parameter is not the name of a ranker, or nil if the retrieval is successful.
+func getRankerParam(req Request, ranker *hermes.Ranker) error {
+	if s := req.Query("ranker"); len(s) == 0 {
+		return nil
+	} else if r, ok := rankers[s]; !ok {
+		return fmt.Errorf("invalid ranker %s", s)
+	} else {
+		*ranker = r
+	}
+	return nil
+}
+
+// getMinScoreParam is a function that retrieves the optional "minscore" query parameter of a request.
+// Parameters:
+//   - req (Request): The request.
+//   - minScore (*float64): A pointer to a float to store the "minscore" query parameter, which is left unchanged if the parameter is not provided.
+//
+// Returns:
+//   - error: An error message if the "minscore" query parameter is not a number that isn't negative, or nil if the retrieval is successful.
+func getMinScoreParam(req Request, minScore *float64) error {
+	if s := req.Query("minscore"); len(s) == 0 {
+		return nil
+	} else if f, err := strconv.ParseFloat(s, 64); err != nil || f < 0 || math.IsNaN(f) {
+		return fmt.Errorf("invalid minscore %s", s)
+	} else {
+		*minScore = f
+	}
+	return nil
+}
+
+// getTimeoutParam is a function that retrieves the optional "timeout" query parameter of a request and parses it as a duration, for example "500ms".
+// Parameters:
+//   - req (Request): The request.
+//   - timeout (*time.Duration): A pointer to a duration to store the "timeout" query parameter, which is left unchanged if the parameter is not provided.
+//
+// Returns:
+//   - error: An error message if the "timeout" query parameter can't be parsed or is not positive, or nil if the retrieval is successful.
+func getTimeoutParam(req Request, timeout *time.Duration) error {
+	if s := req.Query("timeout"); len(s) == 0 {
+		return nil
+	} else if d, err := time.ParseDuration(s); err != nil || d <= 0 {
+		return fmt.Errorf("invalid timeout %s", s)
+	} else {
+		*timeout = d
+	}
+	return nil
+}
+
+// getSortOrderParam is a function that retrieves the optional "sortorder" query parameter of a request.
+// Parameters:
+//   - req (Request): The request.
+//   - order (*hermes.SortOrder): A pointer to a sort order to store the "sortorder" query parameter, which is left unchanged if the parameter is not provided.
+//
+// Returns:
+//   - error: An error message if the "sortorder" query parameter is not "asc" or "desc", or nil if the retrieval is successful.
+func getSortOrderParam(req Request, order *hermes.SortOrder) error {
+	if s := req.Query("sortorder"); len(s) == 0 {
+		return nil
+	} else if o, ok := sortOrders[s]; !ok {
+		return fmt.Errorf("invalid sortorder %s", s)
+	} else {
+		*order = o
+	}
+	return nil
+}
+
+// Suggest is a handler function that returns a handler for autocompleting a search query.
+// Parameters:
+//   - c (*hermes.Cache): A pointer to a hermes.Cache struct.
+//
+// Returns:
+//   - Handler: A handler that returns a JSON-encoded array of the indexed words that start with the prefix parameter provided in the query string, up to the optional limit parameter, or an error message if the prefix is not provided.
+func Suggest(c *hermes.Cache) Handler {
+	return func(req Request, res Response) error {
+		var (
+			prefix string
+			limit  int
+		)
+
+		// Get the prefix and the optional limit from the url params
+		if prefix = req.Query("prefix"); len(prefix) == 0 {
+			return fail(req, res, "prefix not provided")
+		} else if len(req.Query("limit")) > 0 {
+			if err := utils.GetLimitParam(req, &limit); err != nil {
+				return fail(req, res, err)
+			}
+		}
+
+		// Get the suggestions
+		return send(req, res, c.Suggest(prefix, limit))
 	}
 }
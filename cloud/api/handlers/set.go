@@ -1,37 +1,46 @@
 package handlers
 
 import (
-	"github.com/gofiber/fiber/v2"
+	"time"
+
 	hermes "github.com/realTristan/hermes"
 	utils "github.com/realTristan/hermes/cloud/api/utils"
 )
 
-// Set is a handler function that returns a fiber context handler function for setting a value in the cache.
+// Set is a handler function that returns a handler for setting a value in the cache.
+// If the ttl query parameter is provided, the key expires after that duration.
 // Parameters:
 //   - c (*hermes.Cache): A pointer to a hermes.Cache struct.
 //
 // Returns:
-//   - func(ctx *fiber.Ctx) error: A fiber context handler function that sets a value in the cache using the key and value parameters provided in the query string and returns a success message or an error message if the set fails or if the parameters are not provided.
-func Set(c *hermes.Cache) func(ctx *fiber.Ctx) error {
-	return func(ctx *fiber.Ctx) error {
+//   - Handler: A handler that sets a value in the cache using the key and value parameters provided in the query string and returns a success message or an error message if the set fails or if the parameters are not provided.
+func Set(c *hermes.Cache) Handler {
+	return func(req Request, res Response) error {
 		var (
 			key   string
 			value map[string]interface{}
 		)
 		// Get the key from the query
-		if key = ctx.Query("key"); len(key) == 0 {
-			return ctx.Send(utils.Error("invalid key"))
+		if key = req.Query("key"); len(key) == 0 {
+			return fail(req, res, "invalid key")
 		}
 
 		// Get the value from the query
-		if err := utils.GetValueParam(ctx, &value); err != nil {
-			return ctx.Send(utils.Error(err))
+		if err := utils.GetValueParam(req, &value); err != nil {
+			return fail(req, res, err)
 		}
 
-		// Set the value in the cache
-		if err := c.Set(key, value); err != nil {
-			return ctx.Send(utils.Error(err))
+		// Set the value in the cache, with a time to live if one is provided
+		if len(req.Query("ttl")) > 0 {
+			var ttl time.Duration
+			if err := utils.GetTTLParam(req, &ttl); err != nil {
+				return fail(req, res, err)
+			} else if err := c.SetWithTTL(key, value, ttl); err != nil {
+				return sendWriteError(req, res, err)
+			}
+		} else if err := c.Set(key, value); err != nil {
+			return sendWriteError(req, res, err)
 		}
-		return ctx.Send(utils.Success("null"))
+		return succeed(req, res, nil)
 	}
 }
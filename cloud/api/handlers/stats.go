@@ -0,0 +1,38 @@
+package handlers
+
+import (
+	hermes "github.com/realTristan/hermes"
+)
+
+// Stats is a handler function that returns a handler for getting the operation counters and index sizes of the cache.
+// Parameters:
+//   - c (*hermes.Cache): A pointer to a hermes.Cache struct.
+//
+// Returns:
+//   - Handler: A handler that returns the JSON-encoded stats of the cache or an error message if they could not be computed or encoded.
+func Stats(c *hermes.Cache) Handler {
+	return func(req Request, res Response) error {
+		if stats, err := c.Stats(); err != nil {
+			return fail(req, res, err)
+		} else {
+			return send(req, res, stats)
+		}
+	}
+}
+
+// FTStats is a handler function that returns a handler for getting the statistics of the full-text index.
+// Parameters:
+//   - c (*hermes.Cache): A pointer to a hermes.Cache struct.
+//
+// Returns:
+//   - Handler: A handler that returns the JSON-encoded statistics of the full-text index or an error message if the full-text index
+//     is not initialized or the statistics could not be computed or encoded.
+func FTStats(c *hermes.Cache) Handler {
+	return func(req Request, res Response) error {
+		if stats, err := c.FTStats(); err != nil {
+			return fail(req, res, err)
+		} else {
+			return send(req, res, stats)
+		}
+	}
+}
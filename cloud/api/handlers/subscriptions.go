@@ -0,0 +1,144 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	hermes "github.com/realTristan/hermes"
+	utils "github.com/realTristan/hermes/cloud/api/utils"
+)
+
+// The timeout of the webhook requests
+const webhookTimeout time.Duration = 10 * time.Second
+
+// The client that sends the webhook requests
+var webhookClient *http.Client = &http.Client{Timeout: webhookTimeout}
+
+// Subscriptions is a handler function that returns a handler for listing the saved searches of the cache.
+// Parameters:
+//   - c (*hermes.Cache): A pointer to a hermes.Cache struct.
+//
+// Returns:
+//   - Handler: A handler that returns a JSON-encoded map of the ids of the saved searches to their search parameters or an error message if the encoding fails.
+func Subscriptions(c *hermes.Cache) Handler {
+	return func(req Request, res Response) error {
+		return send(req, res, c.Subscriptions())
+	}
+}
+
+// Subscribe is a handler function that returns a handler for saving a search whose matches are sent to a webhook.
+// Parameters:
+//   - c (*hermes.Cache): A pointer to a hermes.Cache struct.
+//
+// Returns:
+//   - Handler: A handler that saves a search with the query and strict parameters provided in the query string,
+//     and returns the id of the saved search or an error message if the parameters are invalid. Whenever a value that matches the search is set, a JSON object
+//     with the id of the saved search and the key and value is posted to the url provided in the query string.
+func Subscribe(c *hermes.Cache) Handler {
+	return func(req Request, res Response) error {
+		var (
+			strict bool
+			query  string
+			hook   string
+		)
+
+		// Get the query from the url params
+		if query = req.Query("query"); len(query) == 0 {
+			return fail(req, res, "query not provided")
+		}
+
+		// Get the webhook url from the url params
+		if hook = req.Query("url"); len(hook) == 0 {
+			return fail(req, res, "url not provided")
+		} else if u, err := url.Parse(hook); err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+			return fail(req, res, "invalid url")
+		}
+
+		// Get the strict from the url params
+		if err := utils.GetStrictParam(req, &strict); err != nil {
+			return fail(req, res, err)
+		}
+
+		// Save the search
+		if id, err := c.Subscribe(hermes.SearchParams{
+			Query:  query,
+			Strict: strict,
+		}, func(id string, key string, value map[string]any) {
+			postWebhook(hook, id, key, value)
+		}); err != nil {
+			return fail(req, res, err)
+		} else {
+			return succeed(req, res, id)
+		}
+	}
+}
+
+// Unsubscribe is a handler function that returns a handler for removing a saved search.
+// Parameters:
+//   - c (*hermes.Cache): A pointer to a hermes.Cache struct.
+//
+// Returns:
+//   - Handler: A handler that removes the saved search with the id provided in the query string and returns a success message or an error message if it doesn't exist.
+func Unsubscribe(c *hermes.Cache) Handler {
+	return func(req Request, res Response) error {
+		// Get the id from the query
+		var id string
+		if id = req.Query("id"); len(id) == 0 {
+			return fail(req, res, "id not provided")
+		}
+
+		// Remove the saved search
+		if err := c.Unsubscribe(id); err != nil {
+			return fail(req, res, err)
+		}
+		return succeed(req, res, nil)
+	}
+}
+
+// Percolate is a handler function that returns a handler for matching a document against the saved searches.
+// Parameters:
+//   - c (*hermes.Cache): A pointer to a hermes.Cache struct.
+//
+// Returns:
+//   - Handler: A handler that returns a JSON-encoded array of the ids of the saved searches that
+//     the document provided in the value parameter would match if it were set, or an error message if the value is invalid.
+func Percolate(c *hermes.Cache) Handler {
+	return func(req Request, res Response) error {
+		// Get the document from the query
+		var doc map[string]any
+		if err := utils.GetValueParam(req, &doc); err != nil {
+			return fail(req, res, err)
+		}
+
+		// Match the document
+		return send(req, res, c.Percolate(doc))
+	}
+}
+
+// postWebhook is a function that posts a match of a saved search to a webhook. Failures are logged, since there's no client to report them to.
+// Parameters:
+//   - hook (string): The url of the webhook.
+//   - id (string): The id of the saved search.
+//   - key (string): The key of the matching value.
+//   - value (map[string]any): The matching value.
+//
+// Returns:
+//   - None
+func postWebhook(hook string, id string, key string, value map[string]any) {
+	body, err := json.Marshal(map[string]any{"id": id, "key": key, "value": value})
+	if err != nil {
+		log.Println("hermes: webhook:", err)
+		return
+	}
+	res, err := webhookClient.Post(hook, fiber.MIMEApplicationJSON, bytes.NewReader(body))
+	if err != nil {
+		log.Println("hermes: webhook:", err)
+		return
+	}
+	res.Body.Close()
+}
@@ -0,0 +1,124 @@
+package handlers
+
+import (
+	"errors"
+	"math"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	hermes "github.com/realTristan/hermes"
+	utils "github.com/realTristan/hermes/cloud/api/utils"
+)
+
+// Replace is a handler function that returns a handler for replacing a value in the cache.
+// Parameters:
+//   - c (*hermes.Cache): A pointer to a hermes.Cache struct.
+//
+// Returns:
+//   - Handler: A handler that replaces the value of the key provided in the query string.
+//     If the If-Match header is provided, the value is only replaced if it matches the current version of the key, otherwise 412 Precondition Failed is returned.
+func Replace(c *hermes.Cache) Handler {
+	return func(req Request, res Response) error {
+		var (
+			key     string
+			value   map[string]any
+			version uint64
+		)
+
+		// Get the key from the query
+		if key = req.Query("key"); len(key) == 0 {
+			return fail(req, res, "invalid key")
+		}
+
+		// Get the value from the query
+		if err := utils.GetValueParam(req, &value); err != nil {
+			return fail(req, res, err)
+		}
+
+		// Get the expected version from the If-Match header
+		if err := getIfMatchVersion(req, &version); err != nil {
+			return fail(req, res, err)
+		}
+
+		// Replace the value in the cache
+		return sendWrite(req, res, c, key, c.Replace(key, value, version))
+	}
+}
+
+// Update is a handler function that returns a handler for updating the fields of a value in the cache.
+// Parameters:
+//   - c (*hermes.Cache): A pointer to a hermes.Cache struct.
+//
+// Returns:
+//   - Handler: A handler that merges the fields provided in the value query parameter into the value of the key.
+//     If the If-Match header is provided, the value is only updated if it matches the current version of the key, otherwise 412 Precondition Failed is returned.
+func Update(c *hermes.Cache) Handler {
+	return func(req Request, res Response) error {
+		var (
+			key     string
+			fields  map[string]any
+			version uint64
+		)
+
+		// Get the key from the query
+		if key = req.Query("key"); len(key) == 0 {
+			return fail(req, res, "invalid key")
+		}
+
+		// Get the fields from the query
+		if err := utils.GetValueParam(req, &fields); err != nil {
+			return fail(req, res, err)
+		}
+
+		// Get the expected version from the If-Match header
+		if err := getIfMatchVersion(req, &version); err != nil {
+			return fail(req, res, err)
+		}
+
+		// Update the value in the cache
+		return sendWrite(req, res, c, key, c.Update(key, fields, version))
+	}
+}
+
+// sendWrite is a function that sends the response of a conditional write.
+// Parameters:
+//   - req (Request): The request.
+//   - res (Response): The response.
+//   - c (*hermes.Cache): A pointer to a hermes.Cache struct.
+//   - key (string): The key that was written.
+//   - err (error): The error returned by the write.
+//
+// Returns:
+//   - error: The error returned by sending the response.
+func sendWrite(req Request, res Response, c *hermes.Cache, key string, err error) error {
+	if err != nil {
+		return sendWriteError(req, res, err)
+	}
+
+	// Send the new version of the key
+	if version, ok := c.Version(key); ok {
+		res.SetHeader(fiber.HeaderETag, versionETag(version))
+	}
+	return succeed(req, res, nil)
+}
+
+// sendWriteError is a function that sends the error of a failed write with the status that matches the error.
+// Version mismatches are sent with 412 Precondition Failed, and rate limited writes with 429 Too Many Requests and the Retry-After header.
+// Parameters:
+//   - req (Request): The request.
+//   - res (Response): The response.
+//   - err (error): The error returned by the write.
+//
+// Returns:
+//   - error: The error returned by sending the response.
+func sendWriteError(req Request, res Response, err error) error {
+	var rl *hermes.RateLimitError
+	switch {
+	case errors.Is(err, hermes.ErrVersionMismatch):
+		return failWith(req, res, fiber.StatusPreconditionFailed, err)
+	case errors.As(err, &rl):
+		res.SetHeader(fiber.HeaderRetryAfter, strconv.Itoa(int(math.Ceil(rl.RetryAfter.Seconds()))))
+		return failWith(req, res, fiber.StatusTooManyRequests, err)
+	}
+	return fail(req, res, err)
+}
@@ -0,0 +1,67 @@
+package api
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+
+	hermes "github.com/realTristan/hermes"
+	"github.com/realTristan/hermes/cloud/api/handlers"
+)
+
+// httpRouter is a type that routes the requests of the net/http frontend of the hermes Cache API, by path then by method.
+type httpRouter map[string]map[string]http.Handler
+
+// NewHTTPHandler is a function that returns a net/http handler that serves the hermes Cache API, with the same handlers as the
+// Fiber routes set by SetRoutes, so the API can be mounted on a net/http server or mux. The Fiber middlewares aren't applied:
+// the requests aren't recorded in metrics, retried writes aren't deduplicated by their Idempotency-Key header, the exports aren't
+// rate limited, and there's no /cache/metrics route. The searches and exports stop once the client is gone.
+// Parameters:
+//   - cache (*hermes.Cache): A pointer to a hermes.Cache struct.
+//
+// Returns:
+//   - http.Handler: The net/http handler of the API.
+func NewHTTPHandler(cache *hermes.Cache) http.Handler {
+	var router httpRouter = httpRouter{}
+	for _, r := range routes(cache) {
+		if router[r.path] == nil {
+			router[r.path] = map[string]http.Handler{}
+		}
+		router[r.path][r.method] = handlers.HTTP(r.handler)
+	}
+	return router
+}
+
+// ServeHTTP is a method of the httpRouter type that serves a request with the handler of its path and method.
+// The GET handlers also serve the HEAD requests. It responds with 404 Not Found if no route has the path, and
+// 405 Method Not Allowed, with the allowed methods in the Allow header, if no route of the path has the method.
+// Parameters:
+//   - w (http.ResponseWriter): The response writer.
+//   - r (*http.Request): A pointer to the request.
+//
+// Returns:
+//   - None
+func (router httpRouter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	methods, ok := router[r.URL.Path]
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	var method string = r.Method
+	if method == http.MethodHead {
+		method = http.MethodGet
+	}
+	if h, ok := methods[method]; ok {
+		h.ServeHTTP(w, r)
+		return
+	}
+
+	// List the allowed methods
+	var allowed []string = make([]string, 0, len(methods))
+	for m := range methods {
+		allowed = append(allowed, m)
+	}
+	sort.Strings(allowed)
+	w.Header().Set("Allow", strings.Join(allowed, ", "))
+	http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+}
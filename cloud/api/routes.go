@@ -1,11 +1,52 @@
 package api
 
 import (
+	"io"
+	"net/http"
+	"time"
+
 	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/limiter"
 	hermes "github.com/realTristan/hermes"
 	"github.com/realTristan/hermes/cloud/api/handlers"
+	utils "github.com/realTristan/hermes/cloud/api/utils"
+)
+
+// The maximum number of idempotency keys to remember, and for how long
+const (
+	idempotencyMaxEntries int           = 10000
+	idempotencyWindow     time.Duration = 24 * time.Hour
+)
+
+// The maximum number of export requests per client in each window
+const (
+	exportMaxRequests int           = 10
+	exportWindow      time.Duration = time.Minute
 )
 
+// The middlewares that a route is served with, on the frontends that support them
+const (
+	idempotent routeOption = 1 << iota
+	rateLimited
+)
+
+// routeOption is a type that represents a middleware that a route is served with.
+type routeOption int
+
+// route is a struct that represents a route of the hermes Cache API.
+// Fields:
+//   - method (string): The HTTP method of the route.
+//   - path (string): The path of the route.
+//   - handler (handlers.Handler): The handler of the route.
+//   - options (routeOption): The middlewares of the route. Retried requests of idempotent routes are deduplicated by their
+//     Idempotency-Key header, and the requests of rate limited routes are limited per client.
+type route struct {
+	method  string
+	path    string
+	handler handlers.Handler
+	options routeOption
+}
+
 // SetRoutes is a function that sets the routes for the hermes Cache API.
 // Parameters:
 //   - app (*fiber.App): A pointer to a fiber.App struct.
@@ -14,38 +55,132 @@ import (
 // Returns:
 //   - void: This function does not return anything.
 func SetRoutes(app *fiber.App, cache *hermes.Cache) {
-	// Dev Testing Handler
-	app.Get("/dev/hermes", func(c *fiber.Ctx) error {
-		return c.SendString("hermes Cache API Successfully Running!")
-	})
-
-	// Cache Handlers
-	app.Get("/cache/values", handlers.Values(cache))
-	app.Get("/cache/length", handlers.Length(cache))
-	app.Post("/cache/clean", handlers.Clean(cache))
-	app.Post("/cache/set", handlers.Set(cache))
-	app.Delete("/cache/delete", handlers.Delete(cache))
-	app.Get("/cache/get", handlers.Get(cache))
-	app.Get("/cache/get/all", handlers.GetAll(cache))
-	app.Get("/cache/keys", handlers.Keys(cache))
-	app.Get("/cache/info", handlers.Info(cache))
-	app.Get("/cache/info/testing", handlers.InfoForTesting(cache))
-	app.Get("/cache/exists", handlers.Exists(cache))
-
-	// Full-text Cache Handlers
-	app.Post("/ft/init", handlers.FTInit(cache))
-	app.Post("/ft/init/json", handlers.FTInitJson(cache))
-	app.Post("/ft/clean", handlers.FTClean(cache))
-	app.Get("/ft/search", handlers.Search(cache))
-	app.Get("/ft/search/oneword", handlers.SearchOneWord(cache))
-	app.Get("/ft/search/values", handlers.SearchValues(cache))
-	app.Get("/ft/search/withkey", handlers.SearchWithKey(cache))
-	app.Post("/ft/maxbytes", handlers.FTSetMaxBytes(cache))
-	app.Post("/ft/maxsize", handlers.FTSetMaxSize(cache))
-	app.Post("/ft/minwordlength", handlers.FTSetMinWordLength(cache))
-	app.Get("/ft/storage", handlers.FTStorage(cache))
-	app.Get("/ft/storage/size", handlers.FTStorageSize(cache))
-	app.Get("/ft/storage/length", handlers.FTStorageLength(cache))
-	app.Get("/ft/isinitialized", handlers.FTIsInitialized(cache))
-	app.Post("/ft/indices/sequence", handlers.FTSequenceIndices(cache))
+	SetRoutesWithMetrics(app, cache, utils.NewMetrics())
+}
+
+// SetRoutesWithMetrics is a function that sets the routes for the hermes Cache API like SetRoutes, recording the requests
+// of every endpoint in the provided metrics, so alarms can be registered on them with AddAlarm and checked with Watch.
+// Parameters:
+//   - app (*fiber.App): A pointer to a fiber.App struct.
+//   - cache (*hermes.Cache): A pointer to a hermes.Cache struct.
+//   - metrics (*utils.Metrics): A pointer to the metrics of the API.
+//
+// Returns:
+//   - void: This function does not return anything.
+func SetRoutesWithMetrics(app *fiber.App, cache *hermes.Cache, metrics *utils.Metrics) {
+	// Record the requests of every endpoint
+	app.Use(utils.Measure(metrics))
+
+	// Deduplicate retried write requests, and rate limit the exports
+	var (
+		idempotency = utils.Idempotent(utils.NewIdempotencyStore(idempotencyMaxEntries, idempotencyWindow))
+		exportLimit = limiter.New(limiter.Config{
+			Max:        exportMaxRequests,
+			Expiration: exportWindow,
+		})
+	)
+
+	// Set the handlers of every route, with their middlewares
+	for _, r := range routes(cache) {
+		var h []fiber.Handler
+		if r.options&idempotent != 0 {
+			h = append(h, idempotency)
+		}
+		if r.options&rateLimited != 0 {
+			h = append(h, exportLimit)
+		}
+		if h = append(h, handlers.Fiber(r.handler)); r.method == http.MethodGet {
+			app.Get(r.path, h...) // Also serves the HEAD requests
+		} else {
+			app.Add(r.method, r.path, h...)
+		}
+	}
+	app.Get("/cache/metrics", handlers.Fiber(handlers.Metrics(metrics)))
+}
+
+// routes is a function that returns the routes of the hermes Cache API, which every frontend serves.
+// Parameters:
+//   - cache (*hermes.Cache): A pointer to a hermes.Cache struct.
+//
+// Returns:
+//   - []route: The routes of the API.
+func routes(cache *hermes.Cache) []route {
+	return []route{
+		// Dev Testing Handler
+		{http.MethodGet, "/dev/hermes", running, 0},
+
+		// Cache Handlers
+		{http.MethodGet, "/cache/values", handlers.Values(cache), 0},
+		{http.MethodGet, "/cache/length", handlers.Length(cache), 0},
+		{http.MethodPost, "/cache/clean", handlers.Clean(cache), idempotent},
+		{http.MethodGet, "/cache/frozen", handlers.Frozen(cache), 0},
+		{http.MethodPost, "/cache/freeze", handlers.Freeze(cache), 0},
+		{http.MethodPost, "/cache/unfreeze", handlers.Unfreeze(cache), 0},
+		{http.MethodPost, "/cache/set", handlers.Set(cache), idempotent},
+		{http.MethodPut, "/cache/set", handlers.Replace(cache), idempotent},
+		{http.MethodPatch, "/cache/set", handlers.Update(cache), idempotent},
+		{http.MethodDelete, "/cache/delete", handlers.Delete(cache), idempotent},
+		{http.MethodDelete, "/cache/delete/matching", handlers.DeleteMatching(cache), idempotent},
+		{http.MethodGet, "/cache/get", handlers.Get(cache), 0},
+		{http.MethodGet, "/cache/get/all", handlers.GetAll(cache), 0},
+		{http.MethodGet, "/cache/keys", handlers.Keys(cache), 0},
+		{http.MethodGet, "/cache/info", handlers.Info(cache), 0},
+		{http.MethodGet, "/cache/info/testing", handlers.InfoForTesting(cache), 0},
+		{http.MethodGet, "/cache/stats", handlers.Stats(cache), 0},
+		{http.MethodGet, "/cache/exists", handlers.Exists(cache), 0},
+		{http.MethodGet, "/cache/history", handlers.History(cache), 0},
+
+		// Export Handlers
+		{http.MethodGet, "/export", handlers.Export(cache), rateLimited},
+
+		// Admin Handlers
+		{http.MethodGet, "/admin/namespaces", handlers.Namespaces(cache), 0},
+		{http.MethodPost, "/admin/namespaces", handlers.CreateNamespace(cache), idempotent},
+		{http.MethodDelete, "/admin/namespaces", handlers.DeleteNamespace(cache), idempotent},
+
+		// Full-text Cache Handlers
+		{http.MethodPost, "/ft/init", handlers.FTInit(cache), 0},
+		{http.MethodPost, "/ft/init/json", handlers.FTInitJson(cache), 0},
+		{http.MethodPost, "/ft/clean", handlers.FTClean(cache), 0},
+		{http.MethodGet, "/ft/search", handlers.Search(cache), 0},
+		{http.MethodGet, "/ft/search/oneword", handlers.SearchOneWord(cache), 0},
+		{http.MethodGet, "/ft/search/regex", handlers.SearchRegex(cache), 0},
+		{http.MethodGet, "/ft/search/values", handlers.SearchValues(cache), 0},
+		{http.MethodGet, "/ft/search/withkey", handlers.SearchWithKey(cache), 0},
+		{http.MethodGet, "/ft/search/groups", handlers.SearchGroups(cache), 0},
+		{http.MethodGet, "/ft/search/page", handlers.SearchPage(cache), 0},
+		{http.MethodGet, "/ft/suggest", handlers.Suggest(cache), 0},
+		{http.MethodGet, "/ft/subscriptions", handlers.Subscriptions(cache), 0},
+		{http.MethodPost, "/ft/subscriptions", handlers.Subscribe(cache), idempotent},
+		{http.MethodDelete, "/ft/subscriptions", handlers.Unsubscribe(cache), idempotent},
+		{http.MethodPost, "/ft/percolate", handlers.Percolate(cache), 0},
+		{http.MethodPost, "/ft/maxbytes", handlers.FTSetMaxBytes(cache), 0},
+		{http.MethodPost, "/ft/maxsize", handlers.FTSetMaxSize(cache), 0},
+		{http.MethodPost, "/ft/minwordlength", handlers.FTSetMinWordLength(cache), 0},
+		{http.MethodPost, "/ft/maxwordlength", handlers.FTSetMaxWordLength(cache), 0},
+		{http.MethodPost, "/ft/reindex", handlers.FTReindex(cache), 0},
+		{http.MethodGet, "/ft/indexes", handlers.FTIndexes(cache), 0},
+		{http.MethodPost, "/ft/indexes", handlers.FTCreateIndex(cache), 0},
+		{http.MethodDelete, "/ft/indexes", handlers.FTDropIndex(cache), 0},
+		{http.MethodGet, "/ft/storage", handlers.FTStorage(cache), 0},
+		{http.MethodGet, "/ft/storage/size", handlers.FTStorageSize(cache), 0},
+		{http.MethodGet, "/ft/storage/length", handlers.FTStorageLength(cache), 0},
+		{http.MethodGet, "/ft/stats", handlers.FTStats(cache), 0},
+		{http.MethodGet, "/ft/isinitialized", handlers.FTIsInitialized(cache), 0},
+		{http.MethodPost, "/ft/indices/sequence", handlers.FTSequenceIndices(cache), 0},
+		{http.MethodPost, "/ft/compact", handlers.FTCompact(cache), 0},
+	}
+}
+
+// running is a handler that responds to the dev testing route.
+// Parameters:
+//   - req (handlers.Request): The request.
+//   - res (handlers.Response): The response.
+//
+// Returns:
+//   - error: An error if the response could not be written.
+func running(req handlers.Request, res handlers.Response) error {
+	res.SetHeader(fiber.HeaderContentType, fiber.MIMETextPlainCharsetUTF8)
+	_, err := io.WriteString(res.Writer(), "hermes Cache API Successfully Running!")
+	return err
 }
@@ -0,0 +1,259 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"runtime"
+	"sort"
+	"time"
+)
+
+// How long a webhook request of an alarm may take
+const alarmWebhookTimeout time.Duration = 5 * time.Second
+
+// AlarmMetric is a type that represents the metric that an alarm watches.
+type AlarmMetric int
+
+const (
+	// AlarmErrorRate watches the fraction of the most recent requests of an endpoint that failed. Its threshold is from 0 to 1.
+	AlarmErrorRate AlarmMetric = iota
+	// AlarmP99Latency watches the 99th percentile latency of the most recent requests of an endpoint. Its threshold is in milliseconds.
+	AlarmP99Latency
+	// AlarmMemory watches the bytes of the heap objects of the process. Its threshold is in bytes.
+	AlarmMemory
+)
+
+// Alarm is a struct that represents a threshold on a metric of the API, whose callback and webhook are notified when the metric
+// crosses the threshold and again when it's back under it.
+// Fields:
+//   - Name (string): The name of the alarm, which identifies it in the events.
+//   - Metric (AlarmMetric): The metric that the alarm watches.
+//   - Endpoint (string): The method and route path of the endpoint to watch, for example "GET /ft/search". If empty, every endpoint
+//     is watched separately. Ignored by AlarmMemory.
+//   - Threshold (float64): The value above which the alarm fires, in the unit of the metric.
+//   - MinRequests (int): The number of recent requests an endpoint must have handled before the alarm fires for it, so a few
+//     requests can't fire it. Ignored by AlarmMemory.
+//   - Callback (func(event AlarmEvent)): The function called with each event. May be nil.
+//   - Webhook (string): The URL that each event is posted to as JSON. May be empty.
+type Alarm struct {
+	Name        string
+	Metric      AlarmMetric
+	Endpoint    string
+	Threshold   float64
+	MinRequests int
+	Callback    func(event AlarmEvent)
+	Webhook     string
+}
+
+// AlarmEvent is a struct that represents an alarm that started or stopped firing.
+// Fields:
+//   - Alarm (string): The name of the alarm.
+//   - Endpoint (string): The endpoint whose metric crossed the threshold, or an empty string for AlarmMemory.
+//   - Value (float64): The value of the metric, in the unit of its threshold.
+//   - Threshold (float64): The threshold of the alarm.
+//   - Firing (bool): true if the metric is above the threshold, false if it's back under it.
+//   - Time (time.Time): The time at which the metric was checked.
+type AlarmEvent struct {
+	Alarm     string    `json:"alarm"`
+	Endpoint  string    `json:"endpoint,omitempty"`
+	Value     float64   `json:"value"`
+	Threshold float64   `json:"threshold"`
+	Firing    bool      `json:"firing"`
+	Time      time.Time `json:"time"`
+}
+
+// alarmState is a struct that represents a registered alarm.
+// Fields:
+//   - alarm (Alarm): The alarm.
+//   - firing (map[string]bool): The endpoints for which the alarm is firing. AlarmMemory uses an empty endpoint.
+type alarmState struct {
+	alarm  Alarm
+	firing map[string]bool
+}
+
+// AddAlarm is a method of the Metrics struct that registers an alarm, which is checked by Check and Watch.
+// Parameters:
+//   - alarm (Alarm): The alarm.
+//
+// Returns:
+//   - error: An error if the alarm has no name, the name is already registered, or the metric or threshold is invalid.
+func (m *Metrics) AddAlarm(alarm Alarm) error {
+	switch {
+	case len(alarm.Name) == 0:
+		return errors.New("invalid alarm name")
+	case alarm.Metric < AlarmErrorRate || alarm.Metric > AlarmMemory:
+		return errors.New("invalid alarm metric")
+	case alarm.Threshold <= 0:
+		return errors.New("invalid alarm threshold")
+	}
+
+	// Lock the mutex
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	// Register the alarm
+	for _, a := range m.alarms {
+		if a.alarm.Name == alarm.Name {
+			return errors.New("alarm already exists")
+		}
+	}
+	m.alarms = append(m.alarms, &alarmState{alarm: alarm, firing: make(map[string]bool)})
+	return nil
+}
+
+// RemoveAlarm is a method of the Metrics struct that unregisters an alarm.
+// Parameters:
+//   - name (string): The name of the alarm.
+//
+// Returns:
+//   - bool: true if the alarm was registered, false otherwise.
+func (m *Metrics) RemoveAlarm(name string) bool {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	for i, a := range m.alarms {
+		if a.alarm.Name == name {
+			m.alarms = append(m.alarms[:i], m.alarms[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// Check is a method of the Metrics struct that compares the metrics with the thresholds of the alarms, and notifies the callbacks
+// and webhooks of the alarms that started or stopped firing since the previous check. The callbacks are called before Check returns,
+// and the webhooks are posted in the background.
+// Returns:
+//   - []AlarmEvent: The alarms that started or stopped firing.
+func (m *Metrics) Check() []AlarmEvent {
+	var memory runtime.MemStats
+	runtime.ReadMemStats(&memory)
+
+	// Lock the mutex
+	m.mutex.Lock()
+	var (
+		now    time.Time                = time.Now()
+		events []AlarmEvent             = []AlarmEvent{}
+		alarms map[string]Alarm         = make(map[string]Alarm)
+		stats  map[string]EndpointStats = make(map[string]EndpointStats, len(m.endpoints))
+	)
+	for endpoint, e := range m.endpoints {
+		stats[endpoint] = e.stats()
+	}
+
+	// Compare the metrics of each alarm with its threshold
+	for _, a := range m.alarms {
+		var values map[string]float64 = a.alarm.values(stats, memory.HeapAlloc)
+		for endpoint, value := range values {
+			if firing := value > a.alarm.Threshold; firing != a.firing[endpoint] {
+				if firing {
+					a.firing[endpoint] = true
+				} else {
+					delete(a.firing, endpoint)
+				}
+				events = append(events, AlarmEvent{Alarm: a.alarm.Name, Endpoint: endpoint, Value: value,
+					Threshold: a.alarm.Threshold, Firing: firing, Time: now})
+				alarms[a.alarm.Name] = a.alarm
+			}
+		}
+	}
+	m.mutex.Unlock()
+
+	// Notify the callbacks and webhooks, ordered by alarm and endpoint
+	sort.Slice(events, func(i, j int) bool {
+		if events[i].Alarm != events[j].Alarm {
+			return events[i].Alarm < events[j].Alarm
+		}
+		return events[i].Endpoint < events[j].Endpoint
+	})
+	for _, event := range events {
+		alarms[event.Alarm].notify(event)
+	}
+	return events
+}
+
+// Watch is a method of the Metrics struct that runs Check at every interval in the background.
+// Parameters:
+//   - interval (time.Duration): How often the alarms are checked.
+//
+// Returns:
+//   - func(): A function that stops the checks.
+func (m *Metrics) Watch(interval time.Duration) func() {
+	var (
+		ticker *time.Ticker  = time.NewTicker(interval)
+		done   chan struct{} = make(chan struct{})
+	)
+	go func() {
+		for {
+			select {
+			case <-ticker.C:
+				m.Check()
+			case <-done:
+				ticker.Stop()
+				return
+			}
+		}
+	}()
+	return func() {
+		close(done)
+	}
+}
+
+// values is a method of the Alarm struct that returns the values of the metric that the alarm watches.
+// Parameters:
+//   - stats (map[string]EndpointStats): The metrics of each endpoint.
+//   - heap (uint64): The bytes of the heap objects of the process.
+//
+// Returns:
+//   - map[string]float64: The value of the metric for each watched endpoint with enough requests, in the unit of the threshold.
+func (a Alarm) values(stats map[string]EndpointStats, heap uint64) map[string]float64 {
+	if a.Metric == AlarmMemory {
+		return map[string]float64{"": float64(heap)}
+	}
+	var values map[string]float64 = make(map[string]float64)
+	for endpoint, s := range stats {
+		if (len(a.Endpoint) > 0 && endpoint != a.Endpoint) || s.Window < a.MinRequests || s.Window == 0 {
+			continue
+		} else if a.Metric == AlarmErrorRate {
+			values[endpoint] = s.ErrorRate
+		} else {
+			values[endpoint] = float64(s.P99) / float64(time.Millisecond)
+		}
+	}
+	return values
+}
+
+// notify is a method of the Alarm struct that calls the callback of the alarm with an event and posts it to the webhook of the alarm.
+// The webhook is posted in the background, and its errors are logged.
+// Parameters:
+//   - event (AlarmEvent): The event.
+//
+// Returns:
+//   - None
+func (a Alarm) notify(event AlarmEvent) {
+	if a.Callback != nil {
+		a.Callback(event)
+	}
+	if len(a.Webhook) == 0 {
+		return
+	}
+	go func() {
+		data, err := json.Marshal(event)
+		if err != nil {
+			log.Printf("hermes: alarm %s: %v", event.Alarm, err)
+			return
+		}
+		var client *http.Client = &http.Client{Timeout: alarmWebhookTimeout}
+		resp, err := client.Post(a.Webhook, "application/json", bytes.NewReader(data))
+		if err != nil {
+			log.Printf("hermes: alarm %s: %v", event.Alarm, err)
+			return
+		}
+		resp.Body.Close()
+		if resp.StatusCode >= http.StatusBadRequest {
+			log.Printf("hermes: alarm %s: webhook responded with status %d", event.Alarm, resp.StatusCode)
+		}
+	}()
+}
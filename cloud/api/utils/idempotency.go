@@ -0,0 +1,160 @@
+package utils
+
+import (
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// HeaderIdempotencyKey is the request header that carries the idempotency key of a write request.
+const HeaderIdempotencyKey = "Idempotency-Key"
+
+// IdempotencyStore is a struct that remembers the responses of write requests by their idempotency key,
+// so that a retried request is answered with the original response instead of being applied twice.
+// Fields:
+//   - mutex (*sync.Mutex): A mutex that guards access to the entries.
+//   - entries (map[string]*idempotencyEntry): The stored responses, keyed by method, path and idempotency key.
+//   - order ([]string): The entry keys in insertion order, used to evict the oldest entries.
+//   - maxEntries (int): The maximum number of entries to remember.
+//   - window (time.Duration): How long an entry is remembered for.
+type IdempotencyStore struct {
+	mutex      *sync.Mutex
+	entries    map[string]*idempotencyEntry
+	order      []string
+	maxEntries int
+	window     time.Duration
+}
+
+// idempotencyEntry is a struct that represents a stored response.
+// Fields:
+//   - expires (time.Time): The time at which the entry expires.
+//   - done (bool): Whether the original request has completed. If false, the request is still in flight.
+//   - status (int): The status code of the response.
+//   - body ([]byte): The body of the response.
+type idempotencyEntry struct {
+	expires time.Time
+	done    bool
+	status  int
+	body    []byte
+}
+
+// NewIdempotencyStore is a function that creates a new IdempotencyStore.
+// Parameters:
+//   - maxEntries (int): The maximum number of entries to remember. Once reached, the oldest entries are evicted.
+//   - window (time.Duration): How long an entry is remembered for.
+//
+// Returns:
+//   - *IdempotencyStore: A pointer to the new IdempotencyStore.
+func NewIdempotencyStore(maxEntries int, window time.Duration) *IdempotencyStore {
+	return &IdempotencyStore{
+		mutex:      &sync.Mutex{},
+		entries:    make(map[string]*idempotencyEntry),
+		order:      []string{},
+		maxEntries: maxEntries,
+		window:     window,
+	}
+}
+
+// Idempotent is a function that returns a fiber middleware that deduplicates write requests that carry an Idempotency-Key header.
+// The first request with a key is handled normally and its response is stored. Retries with the same key within the window
+// receive the stored response, and retries that arrive while the first request is still being handled receive 409 Conflict.
+// Requests without the header are always handled.
+// Parameters:
+//   - store (*IdempotencyStore): The store that remembers the responses.
+//
+// Returns:
+//   - func(ctx *fiber.Ctx) error: A fiber middleware handler function.
+func Idempotent(store *IdempotencyStore) func(ctx *fiber.Ctx) error {
+	return func(ctx *fiber.Ctx) error {
+		var key string = ctx.Get(HeaderIdempotencyKey)
+		if len(key) == 0 {
+			return ctx.Next()
+		}
+		key = ctx.Method() + " " + ctx.Path() + " " + key
+
+		// Check whether the request has already been handled
+		if entry, ok := store.begin(key); ok {
+			if !entry.done {
+				return ctx.Status(fiber.StatusConflict).JSON(fiber.Map{
+					"success": false,
+					"error":   "a request with the same idempotency key is in progress",
+				})
+			}
+			ctx.Set("Idempotent-Replayed", "true")
+			return ctx.Status(entry.status).Send(entry.body)
+		}
+
+		// Handle the request and store the response
+		if err := ctx.Next(); err != nil {
+			store.abort(key)
+			return err
+		}
+		store.finish(key, ctx.Response().StatusCode(), ctx.Response().Body())
+		return nil
+	}
+}
+
+// begin is a method of the IdempotencyStore struct that returns the entry for the provided key, or reserves it if there is none.
+// Parameters:
+//   - key (string): The entry key.
+//
+// Returns:
+//   - idempotencyEntry: A copy of the existing entry.
+//   - bool: true if the entry already existed, false if it was reserved.
+func (s *IdempotencyStore) begin(key string) (idempotencyEntry, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	// Return the entry if it exists and hasn't expired
+	var now time.Time = time.Now()
+	if entry, ok := s.entries[key]; ok && now.Before(entry.expires) {
+		return *entry, true
+	}
+
+	// Evict the expired and the oldest entries
+	for len(s.order) > 0 {
+		if entry, ok := s.entries[s.order[0]]; ok && now.Before(entry.expires) && len(s.entries) < s.maxEntries {
+			break
+		}
+		delete(s.entries, s.order[0])
+		s.order = s.order[1:]
+	}
+
+	// Reserve the entry
+	s.entries[key] = &idempotencyEntry{expires: now.Add(s.window)}
+	s.order = append(s.order, key)
+	return idempotencyEntry{}, false
+}
+
+// finish is a method of the IdempotencyStore struct that stores the response for a reserved key.
+// Parameters:
+//   - key (string): The entry key.
+//   - status (int): The status code of the response.
+//   - body ([]byte): The body of the response.
+//
+// Returns:
+//   - None
+func (s *IdempotencyStore) finish(key string, status int, body []byte) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if entry, ok := s.entries[key]; ok {
+		entry.done = true
+		entry.status = status
+		entry.body = append([]byte{}, body...)
+	}
+}
+
+// abort is a method of the IdempotencyStore struct that releases a reserved key, so that the request can be retried.
+// Parameters:
+//   - key (string): The entry key.
+//
+// Returns:
+//   - None
+func (s *IdempotencyStore) abort(key string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if entry, ok := s.entries[key]; ok && !entry.done {
+		delete(s.entries, key)
+	}
+}
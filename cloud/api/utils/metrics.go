@@ -0,0 +1,168 @@
+package utils
+
+import (
+	"bytes"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// The number of the most recent requests of each endpoint that the error rate and latency percentile are computed from
+const metricsWindow int = 1000
+
+// Metrics is a struct that counts the requests, errors and latencies of each endpoint of the API,
+// and fires the registered alarms when they cross their thresholds.
+// Fields:
+//   - mutex (*sync.Mutex): A mutex that guards access to the endpoints and alarms.
+//   - endpoints (map[string]*endpointMetrics): The metrics of each endpoint, keyed by method and route path.
+//   - alarms ([]*alarmState): The registered alarms.
+type Metrics struct {
+	mutex     *sync.Mutex
+	endpoints map[string]*endpointMetrics
+	alarms    []*alarmState
+}
+
+// endpointMetrics is a struct that represents the metrics of an endpoint.
+// Fields:
+//   - requests (int64): The number of requests handled since the metrics were created.
+//   - errors (int64): The number of requests that failed since the metrics were created.
+//   - samples ([]requestSample): The most recent requests, used as a ring buffer once it holds metricsWindow samples.
+//   - next (int): The position of the next sample in the ring buffer.
+type endpointMetrics struct {
+	requests int64
+	errors   int64
+	samples  []requestSample
+	next     int
+}
+
+// requestSample is a struct that represents a handled request.
+// Fields:
+//   - latency (time.Duration): How long the request took to handle.
+//   - failed (bool): Whether the request failed.
+type requestSample struct {
+	latency time.Duration
+	failed  bool
+}
+
+// EndpointStats is a struct that represents the metrics of an endpoint of the API.
+// Fields:
+//   - Requests (int64): The number of requests handled since the metrics were created.
+//   - Errors (int64): The number of requests that failed since the metrics were created.
+//   - ErrorRate (float64): The fraction of the most recent requests that failed, from 0 to 1.
+//   - P50 (time.Duration): The median latency of the most recent requests.
+//   - P99 (time.Duration): The 99th percentile latency of the most recent requests.
+//   - Window (int): The number of most recent requests that the error rate and latencies are computed from.
+type EndpointStats struct {
+	Requests  int64         `json:"requests"`
+	Errors    int64         `json:"errors"`
+	ErrorRate float64       `json:"error_rate"`
+	P50       time.Duration `json:"p50"`
+	P99       time.Duration `json:"p99"`
+	Window    int           `json:"window"`
+}
+
+// NewMetrics is a function that creates a new Metrics with no requests and no alarms.
+// Returns:
+//   - *Metrics: A pointer to the new Metrics.
+func NewMetrics() *Metrics {
+	return &Metrics{
+		mutex:     &sync.Mutex{},
+		endpoints: make(map[string]*endpointMetrics),
+		alarms:    []*alarmState{},
+	}
+}
+
+// Measure is a function that returns a fiber middleware that records the latency and outcome of every request in the provided metrics,
+// under the method and route path of the endpoint. A request fails if its handler returns an error, its status code is 400 or higher,
+// or its body is an error message of the API.
+// Parameters:
+//   - m (*Metrics): The metrics to record the requests in.
+//
+// Returns:
+//   - func(ctx *fiber.Ctx) error: A fiber middleware handler function.
+func Measure(m *Metrics) func(ctx *fiber.Ctx) error {
+	return func(ctx *fiber.Ctx) error {
+		var (
+			start time.Time = time.Now()
+			err   error     = ctx.Next()
+		)
+		var failed bool = err != nil || ctx.Response().StatusCode() >= fiber.StatusBadRequest ||
+			bytes.HasPrefix(ctx.Response().Body(), []byte(`{"success":false`))
+		m.record(ctx.Method()+" "+ctx.Route().Path, time.Since(start), failed)
+		return err
+	}
+}
+
+// record is a method of the Metrics struct that records a handled request.
+// Parameters:
+//   - endpoint (string): The method and route path of the endpoint.
+//   - latency (time.Duration): How long the request took to handle.
+//   - failed (bool): Whether the request failed.
+//
+// Returns:
+//   - None
+func (m *Metrics) record(endpoint string, latency time.Duration, failed bool) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	e, ok := m.endpoints[endpoint]
+	if !ok {
+		e = &endpointMetrics{samples: make([]requestSample, 0, metricsWindow)}
+		m.endpoints[endpoint] = e
+	}
+	e.requests++
+	if failed {
+		e.errors++
+	}
+	var sample requestSample = requestSample{latency: latency, failed: failed}
+	if len(e.samples) < metricsWindow {
+		e.samples = append(e.samples, sample)
+	} else {
+		e.samples[e.next] = sample
+	}
+	e.next = (e.next + 1) % metricsWindow
+}
+
+// Endpoints is a method of the Metrics struct that returns the metrics of every endpoint that handled a request.
+// Returns:
+//   - map[string]EndpointStats: The metrics of each endpoint, keyed by method and route path, for example "GET /ft/search".
+func (m *Metrics) Endpoints() map[string]EndpointStats {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	var result map[string]EndpointStats = make(map[string]EndpointStats, len(m.endpoints))
+	for endpoint, e := range m.endpoints {
+		result[endpoint] = e.stats()
+	}
+	return result
+}
+
+// stats is a method of the endpointMetrics struct that computes the metrics of the endpoint.
+// Returns:
+//   - EndpointStats: The metrics of the endpoint.
+func (e *endpointMetrics) stats() EndpointStats {
+	var (
+		latencies []time.Duration = make([]time.Duration, len(e.samples))
+		failed    int             = 0
+	)
+	for i, s := range e.samples {
+		latencies[i] = s.latency
+		if s.failed {
+			failed++
+		}
+	}
+	sort.Slice(latencies, func(i, j int) bool {
+		return latencies[i] < latencies[j]
+	})
+	var stats EndpointStats = EndpointStats{
+		Requests: e.requests,
+		Errors:   e.errors,
+		Window:   len(e.samples),
+	}
+	if len(latencies) > 0 {
+		stats.ErrorRate = float64(failed) / float64(len(latencies))
+		stats.P50 = latencies[(len(latencies)-1)*50/100]
+		stats.P99 = latencies[(len(latencies)-1)*99/100]
+	}
+	return stats
+}
@@ -2,21 +2,25 @@ package utils
 
 import (
 	"errors"
-	"fmt"
 	"strconv"
-
-	"github.com/gofiber/fiber/v2"
+	"time"
 )
 
-// GetValueParam is a function that retrieves a value from a query parameter in a Fiber context and decodes it into a value of type T.
+// Params is the interface of the query parameters of a request, which the requests of every frontend of the API implement.
+type Params interface {
+	// Query returns the value of a query parameter, or an empty string if it's not provided.
+	Query(name string) string
+}
+
+// GetValueParam is a function that retrieves a value from a query parameter of a request and decodes it into a value of type T.
 // Parameters:
-//   - ctx (*fiber.Ctx): A pointer to a Fiber context.
+//   - p (Params): The query parameters of a request.
 //   - value (*T): A pointer to a value of type T to store the decoded value.
 //
 // Returns:
 //   - error: An error message if the decoding fails or the query parameter is invalid, or nil if the decoding is successful.
-func GetValueParam[T any](ctx *fiber.Ctx, value *T) error {
-	if v := ctx.Query("value"); len(v) == 0 {
+func GetValueParam[T any](p Params, value *T) error {
+	if v := p.Query("value"); len(v) == 0 {
 		return errors.New("invalid value")
 	} else if err := Decode(v, &value); err != nil {
 		return err
@@ -24,16 +28,15 @@ func GetValueParam[T any](ctx *fiber.Ctx, value *T) error {
 	return nil
 }
 
-// GetMaxSizeParam is a function that retrieves the "maxsize" query parameter from a Fiber context and stores it in an integer pointer.
+// GetMaxSizeParam is a function that retrieves the "maxsize" query parameter of a request and stores it in an integer pointer.
 // Parameters:
-//   - ctx (*fiber.Ctx): A pointer to a Fiber context.
+//   - p (Params): The query parameters of a request.
 //   - maxSize (*int): A pointer to an integer to store the "maxsize" query parameter.
 //
 // Returns:
 //   - error: An error message if the "maxsize" query parameter is invalid or cannot be converted to an integer, or nil if the retrieval is successful.
-func GetMaxSizeParam(ctx *fiber.Ctx, maxSize *int) error {
-	if s := ctx.Query("maxsize"); len(s) == 0 {
-		fmt.Println(s)
+func GetMaxSizeParam(p Params, maxSize *int) error {
+	if s := p.Query("maxsize"); len(s) == 0 {
 		return errors.New("invalid maxsize")
 	} else if i, err := strconv.Atoi(s); err != nil {
 		return err
@@ -43,15 +46,15 @@ func GetMaxSizeParam(ctx *fiber.Ctx, maxSize *int) error {
 	return nil
 }
 
-// GetMaxBytesParam is a function that retrieves the "maxbytes" query parameter from a Fiber context and stores it in an integer pointer.
+// GetMaxBytesParam is a function that retrieves the "maxbytes" query parameter of a request and stores it in an integer pointer.
 // Parameters:
-//   - ctx (*fiber.Ctx): A pointer to a Fiber context.
+//   - p (Params): The query parameters of a request.
 //   - maxBytes (*int): A pointer to an integer to store the "maxbytes" query parameter.
 //
 // Returns:
 //   - error: An error message if the "maxbytes" query parameter is invalid or cannot be converted to an integer, or nil if the retrieval is successful.
-func GetMaxBytesParam(ctx *fiber.Ctx, maxBytes *int) error {
-	if s := ctx.Query("maxbytes"); len(s) == 0 {
+func GetMaxBytesParam(p Params, maxBytes *int) error {
+	if s := p.Query("maxbytes"); len(s) == 0 {
 		return errors.New("invalid maxbytes")
 	} else if i, err := strconv.Atoi(s); err != nil {
 		return err
@@ -62,8 +65,8 @@ func GetMaxBytesParam(ctx *fiber.Ctx, maxBytes *int) error {
 }
 
 // Get the min word length url parameter
-func GetMinWordLengthParam(ctx *fiber.Ctx, minWordLength *int) error {
-	if s := ctx.Query("minwordlength"); len(s) == 0 {
+func GetMinWordLengthParam(p Params, minWordLength *int) error {
+	if s := p.Query("minwordlength"); len(s) == 0 {
 		return errors.New("invalid minwordlength")
 	} else if i, err := strconv.Atoi(s); err != nil {
 		return err
@@ -73,15 +76,27 @@ func GetMinWordLengthParam(ctx *fiber.Ctx, minWordLength *int) error {
 	return nil
 }
 
-// GetJSONParam is a function that retrieves a JSON-encoded value from a query parameter in a Fiber context and decodes it into a value of type T.
+// Get the max word length url parameter
+func GetMaxWordLengthParam(p Params, maxWordLength *int) error {
+	if s := p.Query("maxwordlength"); len(s) == 0 {
+		return errors.New("invalid maxwordlength")
+	} else if i, err := strconv.Atoi(s); err != nil {
+		return err
+	} else {
+		*maxWordLength = i
+	}
+	return nil
+}
+
+// GetJSONParam is a function that retrieves a JSON-encoded value from a query parameter of a request and decodes it into a value of type T.
 // Parameters:
-//   - ctx (*fiber.Ctx): A pointer to a Fiber context.
+//   - p (Params): The query parameters of a request.
 //   - json (*T): A pointer to a value of type T to store the decoded JSON.
 //
 // Returns:
 //   - error: An error message if the decoding fails or the query parameter is invalid, or nil if the decoding is successful.
-func GetJSONParam[T any](ctx *fiber.Ctx, json *T) error {
-	if s := ctx.Query("json"); len(s) == 0 {
+func GetJSONParam[T any](p Params, json *T) error {
+	if s := p.Query("json"); len(s) == 0 {
 		return errors.New("invalid json")
 	} else if err := Decode(s, &json); err != nil {
 		return err
@@ -89,16 +104,16 @@ func GetJSONParam[T any](ctx *fiber.Ctx, json *T) error {
 	return nil
 }
 
-// GetSchemaParam is a function that retrieves a schema from a query parameter in a Fiber context and decodes it into a map of string keys and boolean values.
+// GetSchemaParam is a function that retrieves a schema from a query parameter of a request and decodes it into a map of string keys and boolean values.
 // Parameters:
-//   - ctx (*fiber.Ctx): A pointer to a Fiber context.
+//   - p (Params): The query parameters of a request.
 //   - schema (*map[string]bool): A pointer to a map of string keys and boolean values to store the decoded schema.
 //
 // Returns:
 //   - error: An error message if the decoding fails or the query parameter is invalid, or nil if the decoding is successful.
-func GetSchemaParam(ctx *fiber.Ctx, schema *map[string]bool) error {
+func GetSchemaParam(p Params, schema *map[string]bool) error {
 	// Get the schema from the url params
-	if s := ctx.Query("schema"); len(s) == 0 {
+	if s := p.Query("schema"); len(s) == 0 {
 		return errors.New("invalid schema")
 	} else if err := Decode(s, schema); err != nil {
 		return err
@@ -106,16 +121,16 @@ func GetSchemaParam(ctx *fiber.Ctx, schema *map[string]bool) error {
 	return nil
 }
 
-// GetLimitParam is a function that retrieves the "limit" query parameter from a Fiber context and stores it in an integer pointer.
+// GetLimitParam is a function that retrieves the "limit" query parameter of a request and stores it in an integer pointer.
 // Parameters:
-//   - ctx (*fiber.Ctx): A pointer to a Fiber context.
+//   - p (Params): The query parameters of a request.
 //   - limit (*int): A pointer to an integer to store the "limit" query parameter.
 //
 // Returns:
 //   - error: An error message if the "limit" query parameter is invalid or cannot be converted to an integer, or nil if the retrieval is successful.
-func GetLimitParam(ctx *fiber.Ctx, limit *int) error {
+func GetLimitParam(p Params, limit *int) error {
 	// Get the limit from the url params
-	if s := ctx.Query("limit"); len(s) == 0 {
+	if s := p.Query("limit"); len(s) == 0 {
 		return errors.New("invalid limit")
 	} else if i, err := strconv.Atoi(s); err != nil {
 		return err
@@ -125,16 +140,16 @@ func GetLimitParam(ctx *fiber.Ctx, limit *int) error {
 	return nil
 }
 
-// GetLimitParam is a function that retrieves the "limit" query parameter from a Fiber context and stores it in an integer pointer.
+// GetLimitParam is a function that retrieves the "limit" query parameter of a request and stores it in an integer pointer.
 // Parameters:
-//   - ctx (*fiber.Ctx): A pointer to a Fiber context.
+//   - p (Params): The query parameters of a request.
 //   - limit (*int): A pointer to an integer to store the "limit" query parameter.
 //
 // Returns:
 //   - error: An error message if the "limit" query parameter is invalid or cannot be converted to an integer, or nil if the retrieval is successful.
-func GetStrictParam(ctx *fiber.Ctx, strict *bool) error {
+func GetStrictParam(p Params, strict *bool) error {
 	// Get whether strict mode is enabled/disabled
-	if s := ctx.Query("strict"); len(s) == 0 {
+	if s := p.Query("strict"); len(s) == 0 {
 		return errors.New("invalid strict")
 	} else if b, err := strconv.ParseBool(s); err != nil {
 		return err
@@ -143,3 +158,23 @@ func GetStrictParam(ctx *fiber.Ctx, strict *bool) error {
 	}
 	return nil
 }
+
+// GetTTLParam is a function that retrieves the "ttl" query parameter of a request and parses it as a duration, for example "10m".
+// Parameters:
+//   - p (Params): The query parameters of a request.
+//   - ttl (*time.Duration): A pointer to a duration to store the "ttl" query parameter.
+//
+// Returns:
+//   - error: An error message if the "ttl" query parameter is missing, can't be parsed, or is not positive, or nil if the retrieval is successful.
+func GetTTLParam(p Params, ttl *time.Duration) error {
+	if s := p.Query("ttl"); len(s) == 0 {
+		return errors.New("invalid ttl")
+	} else if d, err := time.ParseDuration(s); err != nil {
+		return err
+	} else if d <= 0 {
+		return errors.New("invalid ttl")
+	} else {
+		*ttl = d
+	}
+	return nil
+}
@@ -11,11 +11,14 @@ import (
 	Socket "github.com/realTristan/hermes/cloud/socket"
 )
 
+// The number of times to retry the download of the snapshot
+const snapshotRetries int = 4
+
 // Main function
 func main() {
 	// Verify that the user is trying to serve the cache
 	if len(os.Args) < 1 || os.Args[1] != "serve" {
-		panic("incorrect usage. example: ./hermes serve -p {port}")
+		panic("incorrect usage. example: ./hermes serve -p {port} [-snapshot-url {url} -snapshot-sha256 {checksum}]")
 	}
 
 	// Get the arg data
@@ -27,6 +30,13 @@ func main() {
 	// Get the port and json file
 	var cache *hermes.Cache = hermes.InitCache()
 
+	// Load the prebuilt snapshot
+	if url := args.SnapshotURL(); len(url) > 0 {
+		if err := cache.LoadURL(url, args.SnapshotSHA256(), snapshotRetries); err != nil {
+			log.Fatal("failed to load the snapshot: ", err)
+		}
+	}
+
 	// Initialize a new fiber app
 	var app *fiber.App = fiber.New(fiber.Config{
 		Prefork:      false,
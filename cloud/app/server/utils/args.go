@@ -7,7 +7,9 @@ import (
 
 // Data struct
 type Data struct {
-	port any
+	port           any
+	snapshotURL    string
+	snapshotSHA256 string
 }
 
 // Get the port
@@ -16,6 +18,16 @@ func (d *Data) Port() any {
 	return copy
 }
 
+// Get the url of the snapshot to load at startup
+func (d *Data) SnapshotURL() string {
+	return d.snapshotURL
+}
+
+// Get the SHA-256 checksum of the snapshot to load at startup
+func (d *Data) SnapshotSHA256() string {
+	return d.snapshotSHA256
+}
+
 // Get the argument data in a map
 func GetArgData(args []string) (*Data, error) {
 	var data *Data = &Data{
@@ -42,6 +54,22 @@ func GetArgData(args []string) (*Data, error) {
 			i = i + 1
 			continue
 		}
+
+		// Snapshot url and checksum args
+		if args[i] == "-snapshot-url" || args[i] == "-snapshot-sha256" {
+			if i+1 >= len(args) {
+				return data, errors.New("invalid " + args[i][1:])
+			}
+			if args[i] == "-snapshot-url" {
+				data.snapshotURL = args[i+1]
+			} else {
+				data.snapshotSHA256 = args[i+1]
+			}
+
+			// Increment i then continue
+			i = i + 1
+			continue
+		}
 	}
 	return data, nil
 }
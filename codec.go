@@ -0,0 +1,99 @@
+package hermes
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+)
+
+// Codec is the encoding of the snapshots written by Save, SaveSnapshot, FTSave and auto-persist.
+type Codec int
+
+// The snapshot encodings
+const (
+	// CodecJSON encodes the snapshots as JSON, which is readable but slow and large for big caches. This is the default.
+	CodecJSON Codec = iota
+	// CodecGob encodes the snapshots with encoding/gob, which is faster and smaller, and keeps the integer types of the values.
+	// Values holding types other than the basic types, maps and slices must be registered with gob.Register.
+	CodecGob
+)
+
+// Register the types that the cache values hold when they're decoded from JSON, so they can be encoded as interface values
+func init() {
+	gob.Register(map[string]any{})
+	gob.Register([]any{})
+	gob.Register(&WFT{})
+	gob.Register(&roaring{})
+}
+
+// gobHeader is written before every gob-encoded snapshot to record its codec
+var gobHeader []byte = []byte("hermes-gob\n")
+
+// SetSnapshotCodec is a method of the Cache struct that sets the encoding of the snapshots written by Save, SaveSnapshot, FTSave and auto-persist.
+// Snapshots are decoded with the encoding they were written with, so a cache can load the snapshots written before the codec was changed.
+// The timestamped snapshots written by SaveSnapshot are named with the extension of the codec.
+// This method is thread-safe.
+//
+// Parameters:
+//   - codec (Codec): The snapshot encoding.
+//
+// Returns:
+//   - error: An error if the codec is invalid.
+func (c *Cache) SetSnapshotCodec(codec Codec) error {
+	if codec != CodecJSON && codec != CodecGob {
+		return errors.New("invalid codec")
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.codec = codec
+	return nil
+}
+
+// ext is a method of the Codec type that returns the extension of the timestamped snapshot files written with the codec.
+//
+// Returns:
+//   - string: The file extension.
+func (codec Codec) ext() string {
+	if codec == CodecGob {
+		return ".gob"
+	}
+	return ".json"
+}
+
+// encode is a method of the Codec type that encodes a snapshot.
+// A gob snapshot starts with a header that records its codec.
+//
+// Parameters:
+//   - v (any): The snapshot to encode.
+//
+// Returns:
+//   - []byte: The encoded snapshot.
+//   - error: An error if the snapshot could not be encoded.
+func (codec Codec) encode(v any) ([]byte, error) {
+	if codec != CodecGob {
+		return json.Marshal(v)
+	}
+	var buf bytes.Buffer
+	buf.Write(gobHeader)
+	if err := gob.NewEncoder(&buf).Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// decodeSnapshot is a function that decodes a snapshot written with any codec.
+// A snapshot that starts with the gob header is a gob snapshot, any other snapshot is JSON.
+//
+// Parameters:
+//   - data ([]byte): The encoded snapshot.
+//   - v (any): A pointer to the snapshot to decode into.
+//
+// Returns:
+//   - error: An error if the snapshot could not be decoded.
+func decodeSnapshot(data []byte, v any) error {
+	if bytes.HasPrefix(data, gobHeader) {
+		return gob.NewDecoder(bytes.NewReader(data[len(gobHeader):])).Decode(v)
+	}
+	return json.Unmarshal(data, v)
+}
@@ -0,0 +1,28 @@
+package hermes
+
+import "errors"
+
+// FTCompact is a method of the Cache struct that reclaims the memory that the full-text index keeps after many deletes.
+// Go maps don't shrink when their entries are deleted, and the posting lists keep the capacity of their removed indices,
+// so the index is copied into maps and posting lists of the exact size, its indices are made sequential like with FTSequenceIndices,
+// and the copy replaces the index once it's complete. Searches return the same results in the same order once it's compacted.
+// Compacting copies the whole index, so this method shouldn't be called in a hot path.
+// This method is thread-safe.
+//
+// Returns:
+//   - error: An error if the full-text index is not initialized.
+func (c *Cache) FTCompact() error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	// Check if the ft is initialized
+	if c.ft == nil {
+		return errors.New("full text not initialized")
+	}
+
+	// Copy the index and swap the copy in
+	var ft *FullText = c.ft.clone()
+	ft.sequenceIndices()
+	c.ft = ft
+	return nil
+}
@@ -0,0 +1,208 @@
+package compare
+
+import (
+	"encoding/json"
+	"fmt"
+
+	hermes "github.com/realTristan/hermes"
+)
+
+// Searcher is an interface of anything that can be searched like a cache, such as a *hermes.Cache,
+// a *hermes.ReadOnlyCache, a *hermes.ShardedCache or a *hermes.Namespace.
+type Searcher interface {
+	Search(sp hermes.SearchParams) ([]map[string]any, error)
+}
+
+// QueryResult is a struct that contains the comparison of the results of a query on two caches.
+// Results are identified by their JSON encoding, and a result returned more than once only counts at its first rank.
+//
+// Fields:
+//   - Params (hermes.SearchParams): The search parameters of the query.
+//   - A ([]map[string]any): The results of the first cache.
+//   - B ([]map[string]any): The results of the second cache.
+//   - ErrorA (error): The error returned by the first cache.
+//   - ErrorB (error): The error returned by the second cache.
+//   - Overlap (float64): The number of results returned by both caches divided by the number of results returned by either, from 0 to 1. It's 1 if neither returned a result.
+//   - RankCorrelation (float64): The Kendall rank correlation of the results returned by both caches, from -1 to 1. It's 1 if fewer than two results are returned by both.
+//   - Missing ([]map[string]any): The results of the first cache that the second cache didn't return.
+//   - Extra ([]map[string]any): The results of the second cache that the first cache didn't return.
+type QueryResult struct {
+	Params          hermes.SearchParams
+	A               []map[string]any
+	B               []map[string]any
+	ErrorA          error
+	ErrorB          error
+	Overlap         float64
+	RankCorrelation float64
+	Missing         []map[string]any
+	Extra           []map[string]any
+}
+
+// Report is a struct that contains the comparison of the results of a query workload on two caches.
+//
+// Fields:
+//   - Queries ([]QueryResult): The comparison of each query, in the order of the workload.
+//   - Overlap (float64): The mean overlap of the queries.
+//   - RankCorrelation (float64): The mean rank correlation of the queries.
+//   - Missing (int): The total number of results of the first cache that the second cache didn't return.
+//   - Extra (int): The total number of results of the second cache that the first cache didn't return.
+//   - Errors (int): The number of queries for which the caches returned different errors.
+type Report struct {
+	Queries         []QueryResult
+	Overlap         float64
+	RankCorrelation float64
+	Missing         int
+	Extra           int
+	Errors          int
+}
+
+// String is a method of the Report struct that returns a one-line summary of the report.
+//
+// Returns:
+//   - string: The summary of the report.
+func (r Report) String() string {
+	return fmt.Sprintf("%d queries: overlap %.3f, rank correlation %.3f, %d missing, %d extra, %d errors",
+		len(r.Queries), r.Overlap, r.RankCorrelation, r.Missing, r.Extra, r.Errors)
+}
+
+// Run is a function that runs every query of a workload on two caches and compares the results.
+// The queries are run sequentially, so the caches should not be written to while the workload runs.
+//
+// Parameters:
+//   - a (Searcher): The first cache, e.g. the current index configuration.
+//   - b (Searcher): The second cache, e.g. the new index configuration.
+//   - workload ([]hermes.SearchParams): The queries to run.
+//
+// Returns:
+//   - Report: The comparison of the results.
+func Run(a Searcher, b Searcher, workload []hermes.SearchParams) Report {
+	var r Report = Report{Queries: make([]QueryResult, 0, len(workload))}
+	for _, sp := range workload {
+		var q QueryResult = QueryResult{Params: sp}
+		q.A, q.ErrorA = a.Search(sp)
+		q.B, q.ErrorB = b.Search(sp)
+		q.compare()
+
+		// Add the query to the totals
+		r.Overlap += q.Overlap
+		r.RankCorrelation += q.RankCorrelation
+		r.Missing += len(q.Missing)
+		r.Extra += len(q.Extra)
+		if errorMessage(q.ErrorA) != errorMessage(q.ErrorB) {
+			r.Errors++
+		}
+		r.Queries = append(r.Queries, q)
+	}
+
+	// Compute the means
+	if len(r.Queries) > 0 {
+		r.Overlap /= float64(len(r.Queries))
+		r.RankCorrelation /= float64(len(r.Queries))
+	}
+	return r
+}
+
+// compare is a method of the QueryResult struct that computes the overlap, rank correlation, missing and extra results.
+//
+// Returns:
+//   - None
+func (q *QueryResult) compare() {
+	var (
+		idsA, ranksA = ranks(q.A)
+		idsB, ranksB = ranks(q.B)
+		common       []string
+	)
+
+	// Find the missing and common results
+	q.Missing, q.Extra = []map[string]any{}, []map[string]any{}
+	for _, id := range idsA {
+		if _, ok := ranksB[id]; ok {
+			common = append(common, id)
+		} else {
+			q.Missing = append(q.Missing, q.A[ranksA[id]])
+		}
+	}
+
+	// Find the extra results
+	for _, id := range idsB {
+		if _, ok := ranksA[id]; !ok {
+			q.Extra = append(q.Extra, q.B[ranksB[id]])
+		}
+	}
+
+	// Compute the overlap
+	var union int = len(idsA) + len(idsB) - len(common)
+	q.Overlap = 1
+	if union > 0 {
+		q.Overlap = float64(len(common)) / float64(union)
+	}
+
+	// Compute the Kendall rank correlation of the common results
+	q.RankCorrelation = 1
+	if len(common) < 2 {
+		return
+	}
+	var concordant, discordant int
+	for i := 0; i < len(common); i++ {
+		for j := i + 1; j < len(common); j++ {
+			// The common results are in the order of the first cache, so only the order in the second cache matters
+			if ranksB[common[i]] < ranksB[common[j]] {
+				concordant++
+			} else {
+				discordant++
+			}
+		}
+	}
+	q.RankCorrelation = float64(concordant-discordant) / float64(concordant+discordant)
+}
+
+// ranks is a function that identifies the results of a query.
+//
+// Parameters:
+//   - results ([]map[string]any): The results of the query.
+//
+// Returns:
+//   - []string: The identifiers of the distinct results, in the order of the results.
+//   - map[string]int: The first index of each identifier in the results.
+func ranks(results []map[string]any) ([]string, map[string]int) {
+	var (
+		ids   []string       = make([]string, 0, len(results))
+		index map[string]int = make(map[string]int, len(results))
+	)
+	for i, value := range results {
+		var id string = identify(value)
+		if _, ok := index[id]; !ok {
+			index[id] = i
+			ids = append(ids, id)
+		}
+	}
+	return ids, index
+}
+
+// identify is a function that returns a string that identifies a result, so results of different caches can be compared.
+//
+// Parameters:
+//   - value (map[string]any): The result.
+//
+// Returns:
+//   - string: The JSON encoding of the result, which has sorted keys.
+func identify(value map[string]any) string {
+	if data, err := json.Marshal(value); err == nil {
+		return string(data)
+	}
+	return fmt.Sprint(value)
+}
+
+// errorMessage is a function that returns the message of an error, or an empty string if the error is nil.
+//
+// Parameters:
+//   - err (error): The error.
+//
+// Returns:
+//   - string: The message of the error.
+func errorMessage(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
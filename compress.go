@@ -0,0 +1,176 @@
+package hermes
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/realTristan/hermes/compression/zlib"
+)
+
+// compressed is a struct that represents a string field of a value that is stored compressed to save memory.
+//
+// Fields:
+//   - data ([]byte): The zlib-compressed string.
+type compressed struct {
+	data []byte
+}
+
+// String is a method of the compressed struct that returns the decompressed string.
+//
+// Returns:
+//   - string: The decompressed string, or an empty string if it could not be decompressed.
+func (cv *compressed) String() string {
+	s, _ := zlib.Decompress(cv.data)
+	return s
+}
+
+// MarshalJSON is a method of the compressed struct that encodes the decompressed string.
+//
+// Returns:
+//   - []byte: The JSON-encoded string.
+//   - error: An error if the string could not be encoded.
+func (cv *compressed) MarshalJSON() ([]byte, error) {
+	return json.Marshal(cv.String())
+}
+
+// EnableCompression is a method of the Cache struct that stores the string fields of the values that are at least threshold bytes long
+// compressed with zlib, to reduce the memory used by large documents. The fields are stored in the full-text index before they're
+// compressed, and they're decompressed when the values are read, so compression is transparent to the other methods, at the cost of
+// the time it takes to decompress the values that are read or searched. The current values are compressed when compression is enabled.
+// If threshold is 0, compression is disabled and the current values are decompressed.
+// This method is thread-safe.
+//
+// Parameters:
+//   - threshold (int): The minimum length, in bytes, of the string fields to compress.
+//
+// Returns:
+//   - error: An error if the threshold is negative.
+func (c *Cache) EnableCompression(threshold int) error {
+	if threshold < 0 {
+		return errors.New("invalid compression threshold")
+	}
+
+	// Lock the mutex
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	// Decompress the current values, then compress them with the new threshold
+	for key, value := range c.data {
+		c.data[key] = c.expand(value)
+	}
+	c.compressAt = threshold
+	for key, value := range c.data {
+		c.data[key] = c.compress(value)
+	}
+	return nil
+}
+
+// compress is a method of the Cache struct that returns a value with its long string fields compressed, if compression is enabled.
+// The value is not modified, since it may have been returned to a caller. A field that could not be compressed is kept as is.
+// This method is not thread-safe, and should only be called from an exported function.
+//
+// Parameters:
+//   - value (map[string]any): The value.
+//
+// Returns:
+//   - map[string]any: A copy of the value with its long string fields compressed, or the value itself if it has none.
+func (c *Cache) compress(value map[string]any) map[string]any {
+	if c.compressAt <= 0 {
+		return value
+	}
+	var (
+		result map[string]any = value
+		copied bool           = false
+	)
+	for field, v := range value {
+		if s, ok := v.(string); ok && len(s) >= c.compressAt {
+			data, err := zlib.Compress([]byte(s))
+			if err != nil {
+				continue
+			}
+			if !copied {
+				result, copied = copyFields(value), true
+			}
+			result[field] = &compressed{data}
+		}
+	}
+	return result
+}
+
+// expand is a method of the Cache struct that returns a value with its compressed fields decompressed.
+// This method is not thread-safe, and should only be called from an exported function.
+//
+// Parameters:
+//   - value (map[string]any): The value.
+//
+// Returns:
+//   - map[string]any: A copy of the value with its compressed fields decompressed, or the value itself if it has none.
+func (c *Cache) expand(value map[string]any) map[string]any {
+	if c.compressAt <= 0 || value == nil {
+		return value
+	}
+	var (
+		result map[string]any = value
+		copied bool           = false
+	)
+	for field, v := range value {
+		if cv, ok := v.(*compressed); ok {
+			if !copied {
+				result, copied = copyFields(value), true
+			}
+			result[field] = cv.String()
+		}
+	}
+	return result
+}
+
+// expandAll is a method of the Cache struct that decompresses the compressed fields of a slice of values in place.
+// This method is not thread-safe, and should only be called from an exported function.
+//
+// Parameters:
+//   - values ([]map[string]any): The values.
+//
+// Returns:
+//   - []map[string]any: The values, with their compressed fields decompressed.
+func (c *Cache) expandAll(values []map[string]any) []map[string]any {
+	if c.compressAt <= 0 {
+		return values
+	}
+	for i, value := range values {
+		values[i] = c.expand(value)
+	}
+	return values
+}
+
+// fieldString is a function that returns the string held by a field of a stored value, which may be compressed.
+//
+// Parameters:
+//   - value (any): The field value.
+//
+// Returns:
+//   - string: The string held by the field.
+//   - bool: false if the field doesn't hold a string.
+func fieldString(value any) (string, bool) {
+	switch v := value.(type) {
+	case string:
+		return v, true
+	case *compressed:
+		return v.String(), true
+	}
+	return "", false
+}
+
+// copyFields is a function that returns a shallow copy of a value.
+//
+// Parameters:
+//   - value (map[string]any): The value.
+//
+// Returns:
+//   - map[string]any: A new map with the same fields.
+func copyFields(value map[string]any) map[string]any {
+	var result map[string]any = make(map[string]any, len(value))
+	for field, v := range value {
+		result[field] = v
+	}
+	return result
+}
@@ -0,0 +1,189 @@
+package hermes
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// computedField is a struct that represents a field whose value is derived from the other fields of a value when it's set.
+//
+// Fields:
+//   - fn (func(value map[string]any) any): The function that derives the field from the value.
+//   - fullText (bool): Whether string results are stored in the full-text index.
+type computedField struct {
+	fn       func(value map[string]any) any
+	fullText bool
+}
+
+// SetComputedField is a method of the Cache struct that declares a field whose value is derived from the other fields of every
+// value when it's set, such as a lowercase SKU, a full name concatenated from a first and last name, or the domain of a URL.
+// The derived field is stored in the value like any other field, so it can be searched, filtered and indexed directly. If fn returns
+// nil, the field is removed from the value. If fullText is true and fn returns a string, it's stored in the full-text index.
+// The field is derived for the current values when it's declared, and for every value set afterwards, including the values
+// of FTInitWithMap and FTInitWithJson. The function is called while the cache is locked, so it must not call methods of the cache.
+// If fn is nil, the declaration is removed, but the derived values are kept.
+// This method is thread-safe.
+//
+// Parameters:
+//   - field (string): The name of the derived field.
+//   - fn (func(value map[string]any) any): The function that derives the field from a value, which must not be modified.
+//   - fullText (bool): Whether to store the derived strings in the full-text index.
+//
+// Returns:
+//   - error: An error if the field is empty, fn panics for a current value, or the derived values violate a unique constraint or the full-text limits.
+func (c *Cache) SetComputedField(field string, fn func(value map[string]any) any, fullText bool) error {
+	if len(field) == 0 {
+		return errors.New("invalid field")
+	}
+
+	// Lock the mutex
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	// Remove the declaration
+	if fn == nil {
+		delete(c.computed, field)
+		return nil
+	}
+
+	// Verify that the cache isn't frozen, since the field is derived for every current value
+	if err := c.writable("SetComputedField"); err != nil {
+		return err
+	}
+	var cf computedField = computedField{fn: fn, fullText: fullText}
+
+	// Derive the field for the current values
+	var data map[string]map[string]any = make(map[string]map[string]any, len(c.data))
+	for key, value := range c.data {
+		data[key] = copyMap(value)
+		if v, err := cf.derive(key, field, c.expand(value)); err != nil {
+			return err
+		} else if v != nil {
+			data[key][field] = v
+		} else {
+			delete(data[key], field)
+		}
+	}
+
+	// Verify that the derived values don't violate any unique constraints
+	uniques, err := c.uniqueBuild(data)
+	if err != nil {
+		return err
+	}
+
+	// Store the derived strings in the full-text index
+	var previous map[string]map[string]any = c.data
+	c.data = data
+	if c.ft != nil {
+		var fields map[string][]string = c.ft.fields
+		c.ft.fields = computedFTFields(fields, data, field, fullText)
+		if err := c.ftReindex("SetComputedField", c.ft.empty()); err != nil {
+			c.data, c.ft.fields = previous, fields
+			return err
+		}
+	}
+
+	// Update the unique constraint and secondary indexes
+	c.uniques = uniques
+	c.indexRebuild(data)
+	c.namedRebuild()
+	c.computed[field] = cf
+	c.generation++
+	c.versionsReset(data)
+	return nil
+}
+
+// ComputedFields is a method of the Cache struct that returns the names of the declared computed fields, in sorted order.
+// This method is thread-safe.
+//
+// Returns:
+//   - []string: The names of the computed fields.
+func (c *Cache) ComputedFields() []string {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	// Copy the field names
+	var fields []string = make([]string, 0, len(c.computed))
+	for field := range c.computed {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// compute is a method of the Cache struct that derives the computed fields of a value that is being set.
+// The derived strings of the full-text fields are wrapped with WFT, so they're stored in the full-text index along with the value.
+// This method is not thread-safe, and should only be called from an exported function.
+//
+// Parameters:
+//   - key (string): The key of the value.
+//   - value (map[string]any): The value, which is modified in place.
+//
+// Returns:
+//   - *RecordError: An error if a computed field panicked, or nil.
+func (c *Cache) compute(key string, value map[string]any) *RecordError {
+	for field, cf := range c.computed {
+		v, err := cf.derive(key, field, value)
+		if err != nil {
+			return err
+		}
+		if s, ok := v.(string); ok && cf.fullText {
+			value[field] = &WFT{s}
+		} else if v != nil {
+			value[field] = v
+		} else {
+			delete(value, field)
+		}
+	}
+	return nil
+}
+
+// derive is a method of the computedField struct that calls the function of the field, recovering from a panic of the function,
+// since malformed values can make it fail a type assertion while the cache is locked.
+//
+// Parameters:
+//   - key (string): The key of the value.
+//   - field (string): The name of the computed field.
+//   - value (map[string]any): The value.
+//
+// Returns:
+//   - any: The derived field.
+//   - *RecordError: An error if the function panicked, or nil.
+func (cf computedField) derive(key string, field string, value map[string]any) (v any, err *RecordError) {
+	defer func() {
+		if r := recover(); r != nil {
+			v, err = nil, &RecordError{Key: key, Field: field, Reason: fmt.Sprintf("the computed field panicked: %v", r)}
+		}
+	}()
+	return cf.fn(value), nil
+}
+
+// computedFTFields is a function that returns a copy of the full-text fields of every key, with a computed field added to or removed from them.
+//
+// Parameters:
+//   - fields (map[string][]string): The full-text fields of every key.
+//   - data (map[string]map[string]any): The values, with the computed field derived.
+//   - field (string): The name of the computed field.
+//   - fullText (bool): Whether the string values of the computed field are stored in the full-text index.
+//
+// Returns:
+//   - map[string][]string: The new full-text fields of every key.
+func computedFTFields(fields map[string][]string, data map[string]map[string]any, field string, fullText bool) map[string][]string {
+	var result map[string][]string = make(map[string][]string, len(fields))
+	for key, value := range data {
+		var keyFields []string = []string{}
+		for _, f := range fields[key] {
+			if f != field {
+				keyFields = append(keyFields, f)
+			}
+		}
+		if _, ok := value[field].(string); ok && fullText {
+			keyFields = append(keyFields, field)
+		}
+		if len(keyFields) > 0 {
+			result[key] = keyFields
+		}
+	}
+	return result
+}
@@ -0,0 +1,188 @@
+package hermes
+
+import (
+	"fmt"
+	"log"
+)
+
+// ConsistencyError is the error returned by Verify when the full-text index doesn't describe the same set of values as the cache data,
+// so Get and Values could observe a value that Search can't find, or the other way around.
+//
+// Fields:
+//   - Key (string): The key whose value and full-text entries differ.
+//   - Reason (string): How they differ.
+type ConsistencyError struct {
+	Key    string
+	Reason string
+}
+
+// Error is a method of the ConsistencyError struct that returns the error message.
+//
+// Returns:
+//   - string: The error message.
+func (e *ConsistencyError) Error() string {
+	return fmt.Sprintf("inconsistent full-text index for key %s: %s", e.Key, e.Reason)
+}
+
+// Verify is a method of the Cache struct that checks that the full-text index describes the same set of values as the cache data:
+// every key of the index is in the cache, every word of the full-text fields of a value is stored for its key,
+// and no other word is stored for it, so a value that was deleted or replaced can't be found with its former words.
+// Verifying the index scans every value and word, so this method shouldn't be called in a hot path.
+// This method is thread-safe.
+//
+// Returns:
+//   - error: A *ConsistencyError describing the first difference, or nil if the index is consistent or not initialized.
+func (c *Cache) Verify() error {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	if err := c.verify(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// SetConsistencyCheck is a method of the Cache struct that verifies the full-text index after every write to the cache, like Verify,
+// and rebuilds it from the cache data when it doesn't describe the same set of values, so that Get, Values and Search always observe
+// the same values. The repairs are logged. Verifying the index after every write is slow for large caches, so the check is meant for
+// tests and for debugging a divergence.
+// This method is thread-safe.
+//
+// Parameters:
+//   - enabled (bool): Whether to verify the index after every write.
+//
+// Returns:
+//   - None
+func (c *Cache) SetConsistencyCheck(enabled bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.consistent = enabled
+}
+
+// consistencyCheck is a method of the Cache struct that verifies the full-text index if the consistency check is enabled,
+// and rebuilds the index from the cache data if it's inconsistent.
+// This method is not thread-safe, and should only be called from an exported function.
+//
+// Returns:
+//   - None
+func (c *Cache) consistencyCheck() {
+	if !c.consistent || c.ft == nil {
+		return
+	}
+	var err *ConsistencyError = c.verify()
+	if err == nil {
+		return
+	}
+
+	// Rebuild the index from the fields of the keys that are still in the cache
+	log.Printf("hermes: %v, rebuilding the full-text index", err)
+	var fields map[string][]string = make(map[string][]string, len(c.ft.fields))
+	for key, f := range c.ft.fields {
+		if _, ok := c.data[key]; ok {
+			fields[key] = f
+		}
+	}
+	c.ft.fields = fields
+	if err := c.ftRebuild(c.ft.empty()); err != nil {
+		log.Printf("hermes: the full-text index could not be rebuilt: %v", err)
+	}
+}
+
+// verify is a method of the Cache struct that checks that the full-text index describes the same set of values as the cache data.
+// This method is not thread-safe, and should only be called from an exported function.
+//
+// Returns:
+//   - *ConsistencyError: The first difference, or nil if the index is consistent or not initialized.
+func (c *Cache) verify() *ConsistencyError {
+	if c.ft == nil {
+		return nil
+	}
+
+	// Verify that the index only references keys of the cache
+	var indices map[string]int = make(map[string]int, len(c.ft.indices))
+	for index, key := range c.ft.indices {
+		if _, ok := c.data[key]; !ok {
+			return &ConsistencyError{Key: key, Reason: "the key is indexed but not in the cache"}
+		}
+		if i, ok := c.ft.keys[key]; !ok || i != index {
+			return &ConsistencyError{Key: key, Reason: fmt.Sprintf("the key is indexed at %d but its reverse index is missing or different", index)}
+		}
+		indices[key] = index
+	}
+	for key, index := range c.ft.keys {
+		if c.ft.indices[index] != key {
+			return &ConsistencyError{Key: key, Reason: fmt.Sprintf("the key is reverse indexed at %d but not indexed there", index)}
+		}
+	}
+	for key := range c.ft.fields {
+		if _, ok := indices[key]; !ok {
+			return &ConsistencyError{Key: key, Reason: "the key has full-text fields but no index"}
+		}
+	}
+
+	// Collect the words stored for each index
+	var words map[int]map[string]bool = make(map[int]map[string]bool, len(c.ft.indices))
+	for word, v := range c.ft.storage {
+		for _, index := range postingsOf(v) {
+			if _, ok := c.ft.indices[index]; !ok {
+				return &ConsistencyError{Key: word, Reason: fmt.Sprintf("the word references the unknown index %d", index)}
+			}
+			if words[index] == nil {
+				words[index] = make(map[string]bool)
+			}
+			words[index][word] = true
+		}
+	}
+
+	// Count the words of the fields stored for each index
+	var fieldWords map[int]int = make(map[int]int, len(c.ft.indices))
+	for field, words := range c.ft.fieldStorage {
+		for word, postings := range words {
+			for _, index := range postings.slice() {
+				if _, ok := c.ft.indices[index]; !ok {
+					return &ConsistencyError{Key: word, Reason: fmt.Sprintf("the word of field %s references the unknown index %d", field, index)}
+				}
+				fieldWords[index]++
+			}
+		}
+	}
+
+	// Verify that every word of the full-text fields of a value is stored for its key
+	var expected, expectedFields map[string]bool = make(map[string]bool), make(map[string]bool)
+	for key, index := range indices {
+		var value map[string]any = c.expand(c.data[key])
+		for word := range expected {
+			delete(expected, word)
+		}
+		for word := range expectedFields {
+			delete(expectedFields, word)
+		}
+		for _, field := range c.ft.fields[key] {
+			v, ok := fieldString(pathValue(value, field))
+			if !ok {
+				return &ConsistencyError{Key: key, Reason: fmt.Sprintf("the full-text field %s doesn't hold a string", field)}
+			}
+			for _, word := range c.ft.fieldWords(field, v) {
+				var postings *roaring = c.ft.fieldStorage[field][word]
+				if !words[index][word] {
+					return &ConsistencyError{Key: key, Reason: fmt.Sprintf("the word %q of field %s is not indexed", word, field)}
+				} else if postings == nil || !postings.contains(index) {
+					return &ConsistencyError{Key: key, Reason: fmt.Sprintf("the word %q of field %s is not in the field index", word, field)}
+				}
+				expected[word] = true
+				expectedFields[field+"\x00"+word] = true
+			}
+		}
+		if len(expectedFields) != fieldWords[index] {
+			return &ConsistencyError{Key: key, Reason: fmt.Sprintf("the field index has %d words of the key, but its full-text fields have %d",
+				fieldWords[index], len(expectedFields))}
+		}
+
+		// Verify that no stale word is stored for the key
+		for word := range words[index] {
+			if !expected[word] {
+				return &ConsistencyError{Key: key, Reason: fmt.Sprintf("the word %q is indexed but not in the full-text fields", word)}
+			}
+		}
+	}
+	return nil
+}
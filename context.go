@@ -0,0 +1,153 @@
+package hermes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrSearchTimeout is the error returned by the searches that don't complete within the Timeout of their search parameters,
+// along with the results that were found in time. It also wraps context.DeadlineExceeded.
+var ErrSearchTimeout = errors.New("search timed out")
+
+// The number of iterations between context checks in long scans
+const ctxCheckInterval int = 256
+
+// The maximum time to wait between attempts to acquire the mutex with a context
+const ctxLockMaxWait time.Duration = time.Millisecond
+
+// SetCtx is a method of the Cache struct that sets a value in the cache for the specified key, honoring the cancellation
+// and deadline of the provided context while waiting for the cache mutex.
+// This function is thread-safe.
+//
+// Parameters:
+//   - ctx (context.Context): The context of the request.
+//   - key (string): The key to set the value for.
+//   - value (map[string]any): The value to set.
+//
+// Returns:
+//   - error: The context error if the context is done before the value is set, or an error from the set.
+func (c *Cache) SetCtx(ctx context.Context, key string, value map[string]any) error {
+	defer c.stats.setTimes.since(time.Now())
+	if err := c.lockCtx(ctx); err != nil {
+		return err
+	}
+	defer c.mutex.Unlock()
+
+	// Verify that the context wasn't cancelled while acquiring the lock
+	if err := ctx.Err(); err != nil {
+		return err
+	} else if err := c.writable("SetCtx"); err != nil {
+		return err
+	} else if err := c.writeAllow(key); err != nil {
+		return err
+	}
+	return c.setDone(key, c.set(key, value))
+}
+
+// lockCtx is a method of the Cache struct that acquires the write lock, giving up once the context is done.
+//
+// Parameters:
+//   - ctx (context.Context): The context of the request.
+//
+// Returns:
+//   - error: The context error if the context is done before the lock is acquired.
+func (c *Cache) lockCtx(ctx context.Context) error {
+	return lockCtx(ctx, c.mutex.Lock, c.mutex.TryLock)
+}
+
+// rlockCtx is a method of the Cache struct that acquires the read lock, giving up once the context is done.
+//
+// Parameters:
+//   - ctx (context.Context): The context of the request.
+//
+// Returns:
+//   - error: The context error if the context is done before the lock is acquired.
+func (c *Cache) rlockCtx(ctx context.Context) error {
+	return lockCtx(ctx, c.mutex.RLock, c.mutex.TryRLock)
+}
+
+// lockCtx is a function that acquires a lock, giving up once the context is done.
+// If the context can never be done, the lock is acquired by blocking.
+//
+// Parameters:
+//   - ctx (context.Context): The context of the request.
+//   - lock (func()): The blocking lock function.
+//   - tryLock (func() bool): The non-blocking lock function.
+//
+// Returns:
+//   - error: The context error if the context is done before the lock is acquired.
+func lockCtx(ctx context.Context, lock func(), tryLock func() bool) error {
+	if ctx.Done() == nil {
+		lock()
+		return nil
+	}
+
+	// Try to acquire the lock until the context is done
+	var wait time.Duration = time.Microsecond
+	for !tryLock() {
+		var timer *time.Timer = time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+		if wait < ctxLockMaxWait {
+			wait *= 2
+		}
+	}
+	return nil
+}
+
+// searchTimeout is a function that returns a context that's done once the Timeout of the search parameters elapses.
+//
+// Parameters:
+//   - ctx (context.Context): The context of the request.
+//   - sp (SearchParams): The search parameters.
+//
+// Returns:
+//   - context.Context: The context of the search, or ctx if the search parameters have no timeout.
+//   - context.CancelFunc: The function that releases the resources of the context.
+func searchTimeout(ctx context.Context, sp SearchParams) (context.Context, context.CancelFunc) {
+	if sp.Timeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, sp.Timeout)
+}
+
+// timeoutErr is a function that wraps the error of a search with ErrSearchTimeout if it was stopped by the Timeout of its search parameters,
+// rather than by the context of the request, and records it in the explanation of the search.
+//
+// Parameters:
+//   - ctx (context.Context): The context of the request, before the timeout was added.
+//   - sp (SearchParams): The search parameters.
+//   - err (error): The error of the search.
+//
+// Returns:
+//   - error: The error wrapping ErrSearchTimeout and the context error, or err if the search didn't time out.
+func timeoutErr(ctx context.Context, sp SearchParams, err error) error {
+	if sp.Timeout <= 0 || !errors.Is(err, context.DeadlineExceeded) || ctx.Err() != nil {
+		return err
+	}
+	if sp.Explain != nil {
+		sp.Explain.TimedOut = true
+	}
+	return fmt.Errorf("%w after %s: %w", ErrSearchTimeout, sp.Timeout, err)
+}
+
+// ctxDone is a function that checks whether the context is done every ctxCheckInterval iterations of a scan.
+//
+// Parameters:
+//   - ctx (context.Context): The context of the request.
+//   - i (int): The current iteration of the scan.
+//
+// Returns:
+//   - error: The context error if the context is done.
+func ctxDone(ctx context.Context, i int) error {
+	if i%ctxCheckInterval != 0 {
+		return nil
+	}
+	return ctx.Err()
+}
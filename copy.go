@@ -0,0 +1,90 @@
+package hermes
+
+// GetCopy is a method of the Cache struct that retrieves a deep copy of the value associated with the given key from the cache.
+// Unlike Get, the returned value can be modified freely without affecting the cache or its full-text index.
+// If a loader was set with SetLoader and the key doesn't exist, the value is loaded like with Get.
+// This method is thread-safe.
+//
+// Parameters:
+//   - key: A string representing the key to retrieve the value for.
+//
+// Returns:
+//   - A copy of the map[string]any associated with the given key in the cache, or nil if the key doesn't exist.
+func (c *Cache) GetCopy(key string) map[string]any {
+	value, _ := c.GetOrLoad(key)
+	if value == nil {
+		return nil
+	}
+
+	// Copy the value while holding the lock, since it may be replaced concurrently
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return copyMap(value)
+}
+
+// ValuesCopy is a method of the Cache struct that returns a deep copy of all the values in the cache.
+// Unlike Values, the returned values can be modified freely without affecting the cache or its full-text index.
+// This method is thread-safe.
+//
+// Returns:
+//   - A slice of map[string]any containing a copy of every value in the cache.
+func (c *Cache) ValuesCopy() []map[string]any {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	// Copy the values
+	var values []map[string]any = make([]map[string]any, 0, len(c.data))
+	for _, value := range c.data {
+		values = append(values, copyMap(c.expand(value)))
+	}
+	return values
+}
+
+// copyMap is a function that returns a deep copy of the provided map.
+//
+// Parameters:
+//   - m (map[string]any): The map to copy.
+//
+// Returns:
+//   - map[string]any: The copy of the map, or nil if the map is nil.
+func copyMap(m map[string]any) map[string]any {
+	if m == nil {
+		return nil
+	}
+	var result map[string]any = make(map[string]any, len(m))
+	for k, v := range m {
+		result[k] = copyValue(v)
+	}
+	return result
+}
+
+// copyValue is a function that returns a deep copy of the provided value.
+// Maps, slices and full-text values are copied recursively, every other value is returned as is.
+//
+// Parameters:
+//   - value (any): The value to copy.
+//
+// Returns:
+//   - any: The copy of the value.
+func copyValue(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		return copyMap(v)
+	case []any:
+		var result []any = make([]any, len(v))
+		for i := 0; i < len(v); i++ {
+			result[i] = copyValue(v[i])
+		}
+		return result
+	case []string:
+		return append([]string{}, v...)
+	case []int:
+		return append([]int{}, v...)
+	case []float64:
+		return append([]float64{}, v...)
+	case *WFT:
+		return &WFT{v.value}
+	default:
+		return value
+	}
+}
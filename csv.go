@@ -0,0 +1,125 @@
+package hermes
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	utils "github.com/realTristan/hermes/utils"
+)
+
+// FTInitWithCSV is a method of the Cache struct that initializes the full-text index with the rows of a CSV file.
+// The first row of the file is the header, whose fields become the fields of every value, and the values are keyed by
+// the column named keyColumn. Every field is a string, like the cells of the file, and the cells of the fullText columns
+// are stored in the full-text index.
+// This method is thread-safe.
+// If the full-text index is already initialized, an error is returned.
+//
+// Parameters:
+//   - file (string): The path to the CSV file to initialize the full-text index with.
+//   - keyColumn (string): The header of the column that holds the key of each row.
+//   - maxSize (int): The maximum number of words to store in the full-text index, or Unlimited.
+//   - maxBytes (int): The maximum size, in bytes, of the full-text index, or Unlimited.
+//   - minWordLength (int): The minimum length of the words to index.
+//   - fullText (...string): The headers of the columns to store in the full-text index.
+//
+// Returns:
+//   - error: If the full-text is already initialized, or the file can't be read.
+func (c *Cache) FTInitWithCSV(file string, keyColumn string, maxSize int, maxBytes int, minWordLength int, fullText ...string) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	// Verify that the ft cache is not initialized
+	if c.ft != nil {
+		return errors.New("full-text cache already initialized")
+	}
+
+	// Initialize the FT
+	if data, err := readCSV(file, keyColumn, fullText); err != nil {
+		return err
+	} else {
+		return c.ftInitWithMap(data, maxSize, maxBytes, minWordLength)
+	}
+}
+
+// ImportCSV is a method of the Cache struct that sets a value for every row of a CSV file, like Set.
+// The first row of the file is the header, whose fields become the fields of every value, and the values are keyed by
+// the column named keyColumn. Every field is a string, like the cells of the file, and the cells of the fullText columns
+// are stored in the full-text index, if it's initialized. The other columns are also indexed if they're part of the full-text schema.
+// The file is read before any value is set, then the rows are set in the order of their keys, stopping at the first row that can't be set.
+// This method is thread-safe.
+//
+// Parameters:
+//   - file (string): The path to the CSV file to import.
+//   - keyColumn (string): The header of the column that holds the key of each row.
+//   - fullText (...string): The headers of the columns to store in the full-text index.
+//
+// Returns:
+//   - int: The number of rows that were set.
+//   - error: An error if the file can't be read, or a row can't be set.
+func (c *Cache) ImportCSV(file string, keyColumn string, fullText ...string) (int, error) {
+	data, err := readCSV(file, keyColumn, fullText)
+	if err != nil {
+		return 0, err
+	}
+
+	// Lock the mutex
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if err := c.writable("ImportCSV"); err != nil {
+		return 0, err
+	}
+
+	// Size the maps for the rows if the cache is pre-sized
+	if c.expected > 0 {
+		c.reserve(len(c.data) + len(data))
+	}
+
+	// Sort the keys
+	var keys []string = make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	// Set the rows
+	var count int = 0
+	for _, key := range keys {
+		if err := c.writeAllow(key); err != nil {
+			return count, err
+		} else if err := c.set(key, data[key]); err == errSkipped {
+			continue
+		} else if err := c.setDone(key, err); err != nil {
+			return count, err
+		}
+		count++
+	}
+	return count, nil
+}
+
+// readCSV is a function that reads the rows of a CSV file, and wraps the cells of the full-text columns with WFT.
+//
+// Parameters:
+//   - file (string): The path to the CSV file to read.
+//   - keyColumn (string): The header of the column that holds the key of each row.
+//   - fullText ([]string): The headers of the columns to store in the full-text index.
+//
+// Returns:
+//   - map[string]map[string]any: The values of the rows, keyed by the value of the key column.
+//   - error: An error if the file can't be read, or a full-text column doesn't exist.
+func readCSV(file string, keyColumn string, fullText []string) (map[string]map[string]any, error) {
+	data, err := utils.ReadCSV(file, keyColumn)
+	if err != nil {
+		return nil, err
+	}
+	for key, value := range data {
+		for _, field := range fullText {
+			v, ok := value[field].(string)
+			if !ok {
+				return nil, fmt.Errorf("csv file %s has no column %s", file, field)
+			}
+			data[key][field] = &WFT{v}
+		}
+	}
+	return data, nil
+}
@@ -0,0 +1,459 @@
+package hermes
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrCursorGone is the error returned by NextPage when the results that a cursor pages through are no longer pinned,
+// because they were unused for too long or released to make room for newer searches, and by Search and SearchCursor when the cache
+// was written to since the cursor of the search parameters was returned. The search must be run again from its first page.
+var ErrCursorGone = errors.New("search cursor is gone")
+
+// The maximum number of pinned searches, and how long a pinned search is kept once its cursors are no longer used
+const (
+	cursorsMax int           = 256
+	cursorTTL  time.Duration = 10 * time.Minute
+)
+
+// Page is a struct that represents a page of the results of a search.
+//
+// Fields:
+//   - Results ([]map[string]any): The results of the page.
+//   - Cursor (string): The cursor of the next page, to pass to NextPage, or an empty string if this is the last page.
+//   - Generation (uint64): The generation of the cache at which the search was run. Every page of a search has the same generation,
+//     so a client can tell whether the cache was written to since it started paging.
+//   - Total (int): The number of results of the search, over all of its pages.
+type Page struct {
+	Results    []map[string]any `json:"results"`
+	Cursor     string           `json:"cursor,omitempty"`
+	Generation uint64           `json:"generation"`
+	Total      int              `json:"total"`
+}
+
+// cursors is a struct that holds the pinned results of the searches that are paged through, so the pages of a search are taken
+// from the results as of the generation at which it was run, and the writes in between pages don't cause duplicates or gaps.
+//
+// Fields:
+//   - mutex (sync.Mutex): A Mutex that guards access to the fields. It's separate from the cache mutex, since searches only hold the read lock.
+//   - seq (uint64): The id of the last pinned search.
+//   - pins (map[uint64]*pin): The pinned searches, keyed by their id.
+type cursors struct {
+	mutex sync.Mutex
+	seq   uint64
+	pins  map[uint64]*pin
+}
+
+// pin is a struct that represents the pinned results of a search.
+//
+// Fields:
+//   - generation (uint64): The generation of the cache at which the search was run.
+//   - results ([]map[string]any): Every result of the search, in order.
+//   - used (time.Time): The time at which a page of the search was last returned.
+type pin struct {
+	generation uint64
+	results    []map[string]any
+	used       time.Time
+}
+
+// SearchPage is a method of the Cache struct that searches for a query like Search and returns the first page of the results.
+// Every result of the search is pinned, and the following pages are returned by NextPage with the cursor of the page, so they're
+// taken from the results as of the generation at which the search was run, even if the cache is written to between pages.
+// The results of the searches that aren't paged through for 10 minutes are released, and only the 256 most recently used searches are
+// kept pinned, so their cursors fail with ErrCursorGone. The Limit, Offset and Cursor of the search parameters are ignored.
+// This method is thread-safe.
+//
+// Parameters:
+//   - sp (SearchParams): The search parameters.
+//   - size (int): The number of results of each page.
+//
+// Returns:
+//   - Page: The first page of the results.
+//   - error: An error if the query or page size is invalid, or the search failed.
+func (c *Cache) SearchPage(sp SearchParams, size int) (Page, error) {
+	defer c.stats.search(time.Now(), sp.Strict)
+	if len(sp.Query) == 0 {
+		return Page{}, errors.New("invalid query")
+	} else if size <= 0 {
+		return Page{}, errors.New("invalid page size")
+	}
+
+	// Lock the mutex, and stop the search once its timeout elapses
+	sp.Explain.reset(strings.ToLower(sp.Query))
+	var start time.Time = time.Now()
+	ctx, cancel := searchTimeout(context.Background(), sp)
+	defer cancel()
+	if err := c.rlockCtx(ctx); err != nil {
+		return Page{}, timeoutErr(context.Background(), sp, err)
+	}
+	defer c.mutex.RUnlock()
+	sp.Explain.phase("lock", start)
+
+	// Check if the FT index is initialized, and search the named index
+	if c.ft == nil {
+		return Page{}, errors.New("full-text not initialized")
+	}
+	view, err := c.ftView(sp.Index)
+	if err != nil {
+		return Page{}, err
+	}
+
+	// Search every result, and pin them
+	sp = sp.lower()
+	sp.Offset, sp.Cursor = 0, ""
+	if sp.Limit = len(c.data); sp.Limit == 0 {
+		sp.Limit = 1
+	}
+	results, err := view.searchCached(ctx, sp)
+	if err != nil && ctx.Err() == nil {
+		return Page{}, err
+	}
+	var (
+		p    *pin = &pin{generation: c.generation, results: results, used: time.Now()}
+		page Page = p.page(c.cursors.pin(p), 0, size)
+	)
+	view.explain(ctx, sp, len(page.Results))
+	return page, timeoutErr(context.Background(), sp, err)
+}
+
+// SearchCursor is a method of the Cache struct that searches for a query like Search, and also returns the cursor of the next page of results.
+// Passing the cursor as the Cursor of the same search parameters returns the next page, without pinning the results like SearchPage does.
+// The cursor is only valid for the same search parameters, apart from the Limit.
+// The cursor fails with ErrCursorGone once the cache is written to, since the positions of the results may have changed, so the pages of a
+// search never skip or repeat a result. The results are paged through by offset, so each page searches the results of the pages before it.
+// This method is thread-safe.
+//
+// Parameters:
+//   - sp (SearchParams): The search parameters, with the Limit as the page size.
+//
+// Returns:
+//   - []map[string]any: The results of the page.
+//   - string: The cursor of the next page, or an empty string if this is the last page.
+//   - error: An error if the query, offset or cursor is invalid, or the search failed, or an error wrapping ErrCursorGone if the cache was written to
+//     since the cursor was returned.
+func (c *Cache) SearchCursor(sp SearchParams) ([]map[string]any, string, error) {
+	return c.searchCursor(context.Background(), sp)
+}
+
+// SearchCursorCtx is a method of the Cache struct that searches for a page of results like SearchCursor, honoring the cancellation
+// and deadline of the provided context like SearchCtx.
+// This method is thread-safe.
+//
+// Parameters:
+//   - ctx (context.Context): The context of the request.
+//   - sp (SearchParams): The search parameters, with the Limit as the page size.
+//
+// Returns:
+//   - []map[string]any: The results of the page.
+//   - string: The cursor of the next page, or an empty string if this is the last page.
+//   - error: An error like SearchCursor, or the context error if the context is done before the search completes.
+func (c *Cache) SearchCursorCtx(ctx context.Context, sp SearchParams) ([]map[string]any, string, error) {
+	return c.searchCursor(ctx, sp)
+}
+
+// searchCursor is a method of the Cache struct that searches for the page of results at the Offset or Cursor of the search parameters,
+// and returns the cursor of the next page. It's the implementation of SearchCtx and SearchCursorCtx.
+// This method is thread-safe.
+//
+// Parameters:
+//   - ctx (context.Context): The context of the request.
+//   - sp (SearchParams): The search parameters.
+//
+// Returns:
+//   - []map[string]any: The results of the page.
+//   - string: The cursor of the next page, or an empty string if this is the last page.
+//   - error: An error if the search parameters are invalid or the search failed, or the context error if the context is done before the search completes.
+func (c *Cache) searchCursor(ctx context.Context, sp SearchParams) (result []map[string]any, next string, err error) {
+	defer c.stats.search(time.Now(), sp.Strict)
+
+	// Stop the search once its timeout elapses
+	var parent context.Context = ctx
+	ctx, cancel := searchTimeout(ctx, sp)
+	defer cancel()
+	defer func() {
+		err = timeoutErr(parent, sp, err)
+	}()
+
+	// Get the position of the page from the offset or the cursor
+	var (
+		cursor     bool = len(sp.Cursor) > 0
+		offset     int  = sp.Offset
+		generation uint64
+		checksum   uint32
+	)
+	switch {
+	case offset < 0:
+		return []map[string]any{}, "", errors.New("invalid offset")
+	case cursor && offset > 0:
+		return []map[string]any{}, "", errors.New("a search can't have both an offset and a cursor")
+	case cursor:
+		if offset, generation, checksum, err = decodeSearchCursor(sp.Cursor); err != nil {
+			return []map[string]any{}, "", err
+		}
+	}
+
+	// Mirror the search at the position of the page
+	sp.Offset, sp.Cursor = offset, ""
+	defer func(sp SearchParams) {
+		c.shadow.mirror("Search", sp, result, err, func(shadow *Cache) ([]map[string]any, error) {
+			return shadow.Search(sp)
+		})
+	}(sp)
+
+	// If the query is empty, return an error
+	if len(sp.Query) == 0 {
+		return []map[string]any{}, "", errors.New("invalid query")
+	}
+
+	// If no limit is provided, set it to 10
+	if sp.Limit == 0 {
+		sp.Limit = 10
+	} else if sp.Limit < 0 {
+		return []map[string]any{}, "", errors.New("invalid limit")
+	}
+
+	// Lock the mutex
+	sp.Explain.reset(strings.ToLower(sp.Query))
+	var start time.Time = time.Now()
+	if err := c.rlockCtx(ctx); err != nil {
+		return []map[string]any{}, "", err
+	}
+	defer c.mutex.RUnlock()
+	sp.Explain.phase("lock", start)
+
+	// Check if the FT index is initialized, and search the named index
+	if c.ft == nil {
+		return []map[string]any{}, "", errors.New("full-text not initialized")
+	}
+	view, err := c.ftView(sp.Index)
+	if err != nil {
+		return []map[string]any{}, "", err
+	}
+
+	// Verify that the cursor was returned for the same search, at the current generation
+	var limit int = sp.Limit
+	sp = sp.lower()
+	sp.Offset, sp.Limit = 0, 0
+	var sum uint32 = searchChecksum(sp)
+	if cursor && checksum != sum {
+		return []map[string]any{}, "", errors.New("invalid cursor")
+	} else if cursor && generation != c.generation {
+		return []map[string]any{}, "", fmt.Errorf("%w: the cache was written to since generation %d", ErrCursorGone, generation)
+	}
+
+	// Search up to the result after the page, to know whether there's a next page
+	sp.Limit = offset + limit + 1
+	results, err := view.searchCached(ctx, sp)
+	if offset >= len(results) {
+		view.explain(ctx, sp, 0)
+		return []map[string]any{}, "", err
+	} else if len(results) > offset+limit {
+		view.explain(ctx, sp, limit)
+		return results[offset : offset+limit], encodeSearchCursor(offset+limit, c.generation, sum), err
+	}
+	view.explain(ctx, sp, len(results)-offset)
+	return results[offset:], "", err
+}
+
+// NextPage is a method of the Cache struct that returns the page of the results of a search that a cursor points to.
+// This method is thread-safe.
+//
+// Parameters:
+//   - cursor (string): The cursor of the next page, returned with the previous page by SearchPage or NextPage.
+//   - size (int): The number of results of the page.
+//
+// Returns:
+//   - Page: The page of the results.
+//   - error: An error if the cursor or page size is invalid, or an error wrapping ErrCursorGone if the results of the search are no longer pinned.
+func (c *Cache) NextPage(cursor string, size int) (Page, error) {
+	if size <= 0 {
+		return Page{}, errors.New("invalid page size")
+	}
+	id, offset, generation, err := decodeCursor(cursor)
+	if err != nil {
+		return Page{}, err
+	}
+
+	// Get the pinned results
+	p, ok := c.cursors.get(id, generation)
+	if !ok {
+		return Page{}, fmt.Errorf("%w: the results at generation %d were released", ErrCursorGone, generation)
+	} else if offset > len(p.results) {
+		return Page{}, errors.New("invalid cursor")
+	}
+	return p.page(id, offset, size), nil
+}
+
+// pin is a method of the cursors struct that pins the results of a search, releasing the unused and least recently used searches.
+//
+// Parameters:
+//   - p (*pin): The pinned results.
+//
+// Returns:
+//   - uint64: The id of the pinned search.
+func (cs *cursors) pin(p *pin) uint64 {
+	cs.mutex.Lock()
+	defer cs.mutex.Unlock()
+	if cs.pins == nil {
+		cs.pins = make(map[uint64]*pin)
+	}
+
+	// Release the unused searches, then the least recently used one if there's no room left
+	var oldest uint64
+	for id, q := range cs.pins {
+		if p.used.Sub(q.used) > cursorTTL {
+			delete(cs.pins, id)
+		} else if oldest == 0 || q.used.Before(cs.pins[oldest].used) {
+			oldest = id
+		}
+	}
+	if len(cs.pins) >= cursorsMax {
+		delete(cs.pins, oldest)
+	}
+
+	// Pin the search
+	cs.seq++
+	cs.pins[cs.seq] = p
+	return cs.seq
+}
+
+// get is a method of the cursors struct that returns the pinned results of a search, and marks them as used.
+//
+// Parameters:
+//   - id (uint64): The id of the pinned search.
+//   - generation (uint64): The generation at which the search was run, which must match the pinned results.
+//
+// Returns:
+//   - *pin: The pinned results.
+//   - bool: true if the search is pinned, false otherwise.
+func (cs *cursors) get(id uint64, generation uint64) (*pin, bool) {
+	cs.mutex.Lock()
+	defer cs.mutex.Unlock()
+	p, ok := cs.pins[id]
+	if !ok || p.generation != generation {
+		return nil, false
+	} else if now := time.Now(); now.Sub(p.used) > cursorTTL {
+		delete(cs.pins, id)
+		return nil, false
+	} else {
+		p.used = now
+	}
+	return p, true
+}
+
+// page is a method of the pin struct that returns a page of the pinned results.
+//
+// Parameters:
+//   - id (uint64): The id of the pinned search.
+//   - offset (int): The position of the first result of the page.
+//   - size (int): The number of results of the page.
+//
+// Returns:
+//   - Page: The page of the results.
+func (p *pin) page(id uint64, offset int, size int) Page {
+	var end int = offset + size
+	if end > len(p.results) {
+		end = len(p.results)
+	}
+	var page Page = Page{
+		Results:    append([]map[string]any{}, p.results[offset:end]...),
+		Generation: p.generation,
+		Total:      len(p.results),
+	}
+	if end < len(p.results) {
+		page.Cursor = encodeCursor(id, end, p.generation)
+	}
+	return page
+}
+
+// encodeCursor is a function that encodes the position of a page of a pinned search into an opaque cursor.
+//
+// Parameters:
+//   - id (uint64): The id of the pinned search.
+//   - offset (int): The position of the first result of the page.
+//   - generation (uint64): The generation at which the search was run.
+//
+// Returns:
+//   - string: The cursor, which is safe to use in a URL.
+func encodeCursor(id uint64, offset int, generation uint64) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf("%d.%d.%d", id, offset, generation)))
+}
+
+// encodeSearchCursor is a function that encodes the position of a page of a search into an opaque cursor, which SearchCursor returns.
+//
+// Parameters:
+//   - offset (int): The position of the first result of the page.
+//   - generation (uint64): The generation at which the search was run.
+//   - checksum (uint32): The checksum of the search parameters, see searchChecksum.
+//
+// Returns:
+//   - string: The cursor, which is safe to use in a URL.
+func encodeSearchCursor(offset int, generation uint64, checksum uint32) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf("%d:%d:%08x", offset, generation, checksum)))
+}
+
+// decodeSearchCursor is a function that decodes a cursor returned by encodeSearchCursor.
+//
+// Parameters:
+//   - cursor (string): The cursor.
+//
+// Returns:
+//   - int: The position of the first result of the page.
+//   - uint64: The generation at which the search was run.
+//   - uint32: The checksum of the search parameters.
+//   - error: An error if the cursor is invalid.
+func decodeSearchCursor(cursor string) (int, uint64, uint32, error) {
+	var (
+		offset     int
+		generation uint64
+		checksum   uint32
+	)
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, 0, 0, errors.New("invalid cursor")
+	} else if n, err := fmt.Sscanf(string(data), "%d:%d:%x", &offset, &generation, &checksum); err != nil || n != 3 || offset < 0 {
+		return 0, 0, 0, errors.New("invalid cursor")
+	}
+	return offset, generation, checksum, nil
+}
+
+// searchChecksum is a function that returns the checksum of search parameters, so a cursor is only used with the search that returned it.
+//
+// Parameters:
+//   - sp (SearchParams): The search parameters, with a lowercase query and without a limit, offset or cursor.
+//
+// Returns:
+//   - uint32: The CRC-32 checksum of the encoding of the search parameters.
+func searchChecksum(sp SearchParams) uint32 {
+	return crc32.ChecksumIEEE(sp.encode())
+}
+
+// decodeCursor is a function that decodes a cursor returned by encodeCursor.
+//
+// Parameters:
+//   - cursor (string): The cursor.
+//
+// Returns:
+//   - uint64: The id of the pinned search.
+//   - int: The position of the first result of the page.
+//   - uint64: The generation at which the search was run.
+//   - error: An error if the cursor is invalid.
+func decodeCursor(cursor string) (uint64, int, uint64, error) {
+	var (
+		id, generation uint64
+		offset         int
+	)
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, 0, 0, errors.New("invalid cursor")
+	} else if n, err := fmt.Sscanf(string(data), "%d.%d.%d", &id, &offset, &generation); err != nil || n != 3 || offset < 0 {
+		return 0, 0, 0, errors.New("invalid cursor")
+	}
+	return id, offset, generation, nil
+}
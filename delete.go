@@ -1,5 +1,7 @@
 package hermes
 
+import utils "github.com/realTristan/hermes/utils"
+
 // Delete is a method of the Cache struct that removes a key from the cache.
 // If the full-text index is initialized, it is also removed from there.
 // This method is thread-safe.
@@ -12,11 +14,65 @@ package hermes
 func (c *Cache) Delete(key string) {
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
-	c.delete(key)
+	if _, ok := c.data[key]; ok && !c.frozen {
+		c.delete(key)
+		c.deleteDone(key)
+	}
+}
+
+// DeleteMatching is a method of the Cache struct that removes every key that matches the provided glob pattern.
+// See KeysMatching for the pattern syntax. The keys are removed atomically.
+// This method is thread-safe.
+//
+// Parameters:
+//   - pattern (string): The glob pattern, for example "session:*".
+//
+// Returns:
+//   - []string: The removed keys, in sorted order.
+//   - error: An error if the pattern is invalid.
+func (c *Cache) DeleteMatching(pattern string) ([]string, error) {
+	g, err := utils.CompileGlob(pattern)
+	if err != nil {
+		return []string{}, err
+	}
+
+	// Lock the mutex
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if err := c.writable("DeleteMatching"); err != nil {
+		return []string{}, err
+	}
+
+	// Delete the matching keys
+	var keys []string = c.keysMatching(g)
+	for _, key := range keys {
+		c.delete(key)
+	}
+	c.deleteDone(keys...)
+	return keys, nil
+}
+
+// deleteDone is a method of the Cache struct that is called by the exported methods once they have removed keys from the cache.
+// It counts the deletes, records the removals in the history of the keys and sends them to the sinks.
+// This method is not thread-safe and should only be called from an exported function.
+//
+// Parameters:
+//   - keys: The removed keys.
+//
+// Returns:
+//   - None
+func (c *Cache) deleteDone(keys ...string) {
+	c.stats.deletes.Add(uint64(len(keys)))
+	for _, key := range keys {
+		c.historyAdd(key, nil)
+		c.walAdd(key)
+		_ = c.sinkSend(key, nil)
+	}
 }
 
 // delete is a method of the Cache struct that removes a key from the cache.
 // If the full-text index is initialized, it is also removed from there.
+// Every removal goes through deleteKeys, so the cache data and the full-text index are always updated together.
 // This method is not thread-safe and should only be called from an exported function.
 //
 // Parameters:
@@ -25,50 +81,106 @@ func (c *Cache) Delete(key string) {
 // Returns:
 //   - None
 func (c *Cache) delete(key string) {
-	// Delete the key from the FT cache
+	c.deleteKeys([]string{key})
+}
+
+// deleteKeys is a method of the Cache struct that removes multiple keys from the cache.
+// Each key is removed from the postings of the words of its value only, so removing a key doesn't scan the full-text index.
+// This method is not thread-safe and should only be called from an exported function.
+//
+// Parameters:
+//   - keys ([]string): The keys to remove from the cache.
+//
+// Returns:
+//   - None
+func (c *Cache) deleteKeys(keys []string) {
+	// Delete the keys from the FT cache
 	if c.ft != nil {
-		c.ft.delete(key)
+		for _, key := range keys {
+			c.ft.removeKey(key, c.ft.keyWords(key, c.expand(c.data[key])))
+		}
 	}
 
-	// Delete the key from the cache
-	delete(c.data, key)
+	// Delete the keys from the cache
+	for _, key := range keys {
+		if value, ok := c.data[key]; ok {
+			c.namedDelete(key, c.expand(value))
+			c.uniqueDelete(key, value)
+			c.indexDelete(key, value)
+		}
+		delete(c.data, key)
+		delete(c.versions, key)
+		delete(c.expiries, key)
+		c.keyTrie.Delete(key)
+	}
+	c.generation++
+	c.consistencyCheck()
 }
 
-// delete is a method of the FullText struct that removes a key from the full-text storage.
+// keyWords is a method of the FullText struct that returns the words stored for a key, which are the words of its full-text fields.
 // This function is not thread-safe and should only be called from an exported function.
 //
 // Parameters:
-//   - key: A string representing the key to remove from the full-text storage.
+//   - key (string): The key.
+//   - value (map[string]any): The expanded value of the key.
 //
 // Returns:
-//   - None
-func (ft *FullText) delete(key string) {
-	// Remove the key from the ft.storage
-	for word, data := range ft.storage {
-		// Check if the data is []int or int
-		if _, ok := data.(int); ok {
-			delete(ft.storage, word)
-			continue
+//   - []string: The words, which may contain duplicates.
+func (ft *FullText) keyWords(key string, value map[string]any) []string {
+	var words []string = []string{}
+	for _, field := range ft.fields[key] {
+		if v, ok := fieldString(pathValue(value, field)); ok {
+			words = append(words, ft.fieldWords(field, v)...)
 		}
+	}
+	return words
+}
 
-		// If the data is []int, loop through the slice
-		if keys, ok := data.([]int); !ok {
-			for i := 0; i < len(keys); i++ {
-				if key != ft.indices[keys[i]] {
-					continue
-				}
+// removeKey is a method of the FullText struct that removes a key from the full-text storage.
+// The index of the key is only removed from the postings of the provided words, and the words that have no postings left
+// are removed from the storage, the n-gram and suffix indexes and the vocabulary.
+// This function is not thread-safe and should only be called from an exported function.
+//
+// Parameters:
+//   - key (string): The key to remove from the full-text storage.
+//   - words ([]string): The words stored for the key. The words that aren't stored for it are ignored.
+//
+// Returns:
+//   - None
+func (ft *FullText) removeKey(key string, words []string) {
+	delete(ft.fields, key)
+	index, ok := ft.keys[key]
+	if !ok {
+		return
+	}
+	delete(ft.keys, key)
+	delete(ft.indices, index)
 
-				// Remove the key from the ft.storage slice
-				keys = append(keys[:i], keys[i+1:]...)
-				ft.storage[word] = keys
-				break
+	// Remove the index from the postings of the words
+	for _, word := range words {
+		ft.fieldStorage.remove(word, index)
+		switch v := ft.storage[word].(type) {
+		case int:
+			if v == index {
+				delete(ft.storage, word)
+				ft.ngrams.remove(word)
+				ft.suffixes.remove(word)
+				ft.partitions.remove(word)
+				ft.vocab.remove(word)
 			}
+		case *roaring:
+			v.remove(index)
 
-			// If keys is empty, remove it from the storage
-			if len(keys) == 0 {
+			// If there are no indices left, remove the word from the storage
+			switch v.len() {
+			case 0:
 				delete(ft.storage, word)
-			} else if len(keys) == 1 {
-				ft.storage[word] = keys[0]
+				ft.ngrams.remove(word)
+				ft.suffixes.remove(word)
+				ft.partitions.remove(word)
+				ft.vocab.remove(word)
+			case 1:
+				ft.storage[word] = v.slice()[0]
 			}
 		}
 	}
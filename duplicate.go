@@ -0,0 +1,220 @@
+package hermes
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"sort"
+)
+
+// DuplicateMode is a type that represents how the cache handles a value whose full-text content is identical to the one of
+// another value, so that identical records don't flood the search results.
+type DuplicateMode int
+
+const (
+	// DuplicateAllow stores the duplicates like any other value. This is the default mode.
+	DuplicateAllow DuplicateMode = iota
+	// DuplicateSkip doesn't store the duplicates. The writes of a duplicate return no error without setting it.
+	DuplicateSkip
+	// DuplicateLink stores the duplicates without indexing their full-text fields, so they can be read with Get but aren't
+	// returned by the searches, and links them to the value they duplicate, see DuplicateOf.
+	DuplicateLink
+	// DuplicateFlag stores and indexes the duplicates like any other value, and links them to the value they duplicate, see DuplicateOf.
+	DuplicateFlag
+)
+
+// duplicates is a struct that holds the content hashes of the values, to detect the duplicates when they're set.
+// The hashes are not removed when a value is deleted, so they're verified against the current values when they're looked up.
+//
+// Fields:
+//   - mode (DuplicateMode): How the duplicates are handled.
+//   - hashes (map[string]string): The key of the first value with each content hash.
+//   - keys (map[string]string): The content hash of each value.
+//   - links (map[string]string): The key of the value that each linked or flagged duplicate duplicates.
+type duplicates struct {
+	mode   DuplicateMode
+	hashes map[string]string
+	keys   map[string]string
+	links  map[string]string
+}
+
+// SetDuplicateMode is a method of the Cache struct that sets how the values whose full-text fields hold the same content as another value are
+// handled when they're set. The content of a value is the set of its full-text fields and their strings, so values that only differ by their
+// other fields are duplicates. When the detection is enabled, the current values are hashed, and the current duplicates are linked to the first
+// of their values in the order of their keys, but they're kept and stay indexed. The values without full-text fields are never duplicates.
+// The duplicates that are detected when values are set are counted in Stats.Duplicates.
+// This method is thread-safe.
+//
+// Parameters:
+//   - mode (DuplicateMode): Either DuplicateAllow, DuplicateSkip, DuplicateLink or DuplicateFlag.
+//
+// Returns:
+//   - error: An error if the mode is invalid.
+func (c *Cache) SetDuplicateMode(mode DuplicateMode) error {
+	if mode < DuplicateAllow || mode > DuplicateFlag {
+		return errors.New("invalid duplicate mode")
+	}
+
+	// Lock the mutex
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	// Set the mode, clearing the hashes when the detection is disabled
+	c.dups.mode = mode
+	if mode == DuplicateAllow {
+		c.dups.hashes, c.dups.keys, c.dups.links = nil, nil, nil
+	} else if c.dups.hashes == nil {
+		c.dups.hashes = make(map[string]string)
+		c.dups.keys = make(map[string]string)
+		c.dups.links = make(map[string]string)
+		c.duplicateBuild()
+	}
+	return nil
+}
+
+// duplicateBuild is a method of the Cache struct that hashes the full-text content of the current values once the detection is enabled.
+// This method is not thread-safe, and should only be called from an exported function.
+//
+// Returns:
+//   - None
+func (c *Cache) duplicateBuild() {
+	if c.ft == nil {
+		return
+	}
+
+	// Hash the values in the order of their keys, so the first key of the same content is the one that the others duplicate
+	var keys []string = make([]string, 0, len(c.ft.fields))
+	for key := range c.ft.fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		var (
+			value   map[string]any    = c.expand(c.data[key])
+			content map[string]string = make(map[string]string, len(c.ft.fields[key]))
+		)
+		for _, field := range c.ft.fields[key] {
+			if v, ok := fieldString(pathValue(value, field)); ok {
+				content[field] = v
+			}
+		}
+		if len(content) == 0 {
+			continue
+		}
+		var hash string = duplicateHash(content)
+		if original, ok := c.dups.hashes[hash]; ok {
+			c.duplicateSet(key, hash, original)
+		} else {
+			c.duplicateSet(key, hash, "")
+		}
+	}
+}
+
+// DuplicateOf is a method of the Cache struct that returns the key of the value that a value linked or flagged as a duplicate duplicates.
+// This method is thread-safe.
+//
+// Parameters:
+//   - key (string): The key of the value.
+//
+// Returns:
+//   - string: The key of the duplicated value.
+//   - bool: true if the value is a duplicate of a value that still exists, false otherwise.
+func (c *Cache) DuplicateOf(key string) (string, bool) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	original, ok := c.dups.links[key]
+	if !ok {
+		return "", false
+	} else if _, ok := c.data[key]; !ok {
+		return "", false
+	} else if _, ok := c.data[original]; !ok {
+		return "", false
+	}
+	return original, true
+}
+
+// duplicateCheck is a method of the Cache struct that hashes the full-text content of a value being set and looks up the value it duplicates.
+// This method is not thread-safe, and should only be called from an exported function.
+//
+// Parameters:
+//   - key (string): The key of the value.
+//   - value (map[string]any): The value, with its full-text fields not yet indexed.
+//
+// Returns:
+//   - string: The content hash of the value, or an empty string if duplicates are allowed or the value has no full-text fields.
+//   - string: The key of the value it duplicates, or an empty string if it's not a duplicate.
+func (c *Cache) duplicateCheck(key string, value map[string]any) (string, string) {
+	if c.dups.mode == DuplicateAllow || c.ft == nil {
+		return "", ""
+	}
+
+	// Hash the full-text fields of the value
+	var content map[string]string = c.ft.nestedValues(value)
+	for k, v := range value {
+		if ftv := c.ft.value(k, v); len(ftv) > 0 {
+			content[k] = ftv
+		}
+	}
+	if len(content) == 0 {
+		return "", ""
+	}
+	var hash string = duplicateHash(content)
+
+	// Verify that the value with the same hash still exists with the same content
+	if original, ok := c.dups.hashes[hash]; ok && original != key {
+		if _, ok := c.data[original]; ok && c.dups.keys[original] == hash {
+			return hash, original
+		}
+	}
+	return hash, ""
+}
+
+// duplicateHash is a function that hashes the full-text content of a value.
+//
+// Parameters:
+//   - content (map[string]string): The strings of the full-text fields of the value, keyed by field.
+//
+// Returns:
+//   - string: The content hash.
+func duplicateHash(content map[string]string) string {
+	var fields []string = make([]string, 0, len(content))
+	for field := range content {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	var h = sha256.New()
+	for _, field := range fields {
+		h.Write([]byte(field))
+		h.Write([]byte{0})
+		h.Write([]byte(content[field]))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// duplicateSet is a method of the Cache struct that records the content hash of a value that was set, and links it to the value it duplicates.
+// This method is not thread-safe, and should only be called from an exported function.
+//
+// Parameters:
+//   - key (string): The key of the value.
+//   - hash (string): The content hash of the value, or an empty string if it has none.
+//   - original (string): The key of the value it duplicates, or an empty string if it's not a duplicate.
+//
+// Returns:
+//   - None
+func (c *Cache) duplicateSet(key string, hash string, original string) {
+	if c.dups.mode == DuplicateAllow {
+		return
+	}
+	delete(c.dups.links, key)
+	delete(c.dups.keys, key)
+	if len(hash) == 0 {
+		return
+	}
+	c.dups.keys[key] = hash
+	if len(original) > 0 {
+		c.dups.links[key] = original
+	} else {
+		c.dups.hashes[hash] = key
+	}
+}
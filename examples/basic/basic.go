@@ -19,7 +19,7 @@ var cache *hermes.Cache = hermes.InitCache()
 
 func main() {
 	// Initialize the full-text cache
-	cache.FTInitWithJson("../../testing/data/data_hash.json", -1, -1, 3)
+	cache.FTInitWithJson("../../testing/data/data_hash.json", hermes.Unlimited, hermes.Unlimited, 3)
 
 	// Search for a word in the cache
 	var startTime time.Time = time.Now()
@@ -23,7 +23,7 @@ var cache *hermes.Cache
 // Main function
 func main() {
 	cache = hermes.InitCache()
-	cache.FTInitWithJson("../../testing/data/data_hash.json", -1, -1, 3)
+	cache.FTInitWithJson("../../testing/data/data_hash.json", hermes.Unlimited, hermes.Unlimited, 3)
 
 	// Print host
 	fmt.Println(" >> Listening on: http://localhost:8000/")
@@ -24,5 +24,5 @@ func (c *Cache) Exists(key string) bool {
 //   - A boolean value indicating whether the key exists in the cache or not.
 func (c *Cache) exists(key string) bool {
 	_, ok := c.data[key]
-	return ok
+	return ok && !c.expired(key)
 }
@@ -0,0 +1,226 @@
+package hermes
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+)
+
+// The maximum number of matching words of a term listed in an explanation
+const explainWords int = 10
+
+// Explanation is a struct that describes how a search found its results, to debug why a value did or didn't match a query.
+// It's filled in by Search, SearchCtx, SearchCursor, SearchPage and SearchScored when the search parameters have an Explain.
+// An explained search skips the query cache, and finds every matching value before the limit is applied.
+// The searches of a ShardedCache don't fill it in.
+//
+// Fields:
+//   - Query (string): The lowercase query that was searched.
+//   - Strategy (string): How the words of the query were matched: "word" for a single word, "phrase" for the words of a phrase in order,
+//     "fuzzy" for the words of a fuzzy search in any order, or "wildcards" for the words of a search with wildcards in any order.
+//   - Terms ([]ExplainedTerm): The terms of the query that were looked up in the full-text index.
+//   - Filters ([]ExplainedFilter): The filters that pruned the values that matched the query, in order.
+//   - Matched (int): The number of values that matched the query and the filters, before the limit and offset.
+//   - Results (int): The number of returned results.
+//   - TimedOut (bool): Whether the search was stopped by the Timeout of the search parameters, so the results are partial.
+//   - Phases ([]ExplainedPhase): The time spent in each phase of the search, in order.
+type Explanation struct {
+	Query    string            `json:"query"`
+	Strategy string            `json:"strategy"`
+	Terms    []ExplainedTerm   `json:"terms"`
+	Filters  []ExplainedFilter `json:"filters"`
+	Matched  int               `json:"matched"`
+	Results  int               `json:"results"`
+	TimedOut bool              `json:"timed_out"`
+	Phases   []ExplainedPhase  `json:"phases"`
+}
+
+// ExplainedTerm is a struct that describes how a term of a query was looked up in the full-text index.
+//
+// Fields:
+//   - Term (string): The term, as it's stored in the full-text index.
+//   - Words (int): The number of stored words that match the term.
+//   - Examples ([]string): The first of these words, in alphabetical order.
+//   - Candidates (int): The number of values that contain one of these words.
+type ExplainedTerm struct {
+	Term       string   `json:"term"`
+	Words      int      `json:"words"`
+	Examples   []string `json:"examples"`
+	Candidates int      `json:"candidates"`
+}
+
+// ExplainedFilter is a struct that describes how many values a filter of a search pruned.
+//
+// Fields:
+//   - Filter (string): The filter, "key prefix" for the KeyPrefix, "range filter" for the RangeFilter or "min score" for the MinScore
+//     of the search parameters.
+//   - Before (int): The number of values before the filter.
+//   - After (int): The number of values that passed the filter.
+type ExplainedFilter struct {
+	Filter string `json:"filter"`
+	Before int    `json:"before"`
+	After  int    `json:"after"`
+}
+
+// ExplainedPhase is a struct that describes the time spent in a phase of a search.
+//
+// Fields:
+//   - Phase (string): The phase: "lock" to acquire the read lock, "search" to find the matching values, "range filter" to filter them,
+//     "score" to score them, "sort" to sort them, or "explain" to look up the terms of the explanation.
+//   - Duration (time.Duration): The time spent in the phase.
+type ExplainedPhase struct {
+	Phase    string        `json:"phase"`
+	Duration time.Duration `json:"duration"`
+}
+
+// reset is a method of the Explanation struct that clears the explanation before a search.
+//
+// Parameters:
+//   - query (string): The lowercase query.
+//
+// Returns:
+//   - None
+func (e *Explanation) reset(query string) {
+	if e != nil {
+		*e = Explanation{Query: query, Terms: []ExplainedTerm{}, Filters: []ExplainedFilter{}, Phases: []ExplainedPhase{}}
+	}
+}
+
+// phase is a method of the Explanation struct that records the time spent in a phase since it started.
+//
+// Parameters:
+//   - name (string): The phase.
+//   - start (time.Time): The time at which the phase started.
+//
+// Returns:
+//   - None
+func (e *Explanation) phase(name string, start time.Time) {
+	if e != nil {
+		e.Phases = append(e.Phases, ExplainedPhase{Phase: name, Duration: time.Since(start)})
+	}
+}
+
+// filter is a method of the Explanation struct that records how many values a filter pruned.
+//
+// Parameters:
+//   - name (string): The filter.
+//   - before (int): The number of values before the filter.
+//   - after (int): The number of values that passed the filter.
+//
+// Returns:
+//   - None
+func (e *Explanation) filter(name string, before int, after int) {
+	if e != nil {
+		e.Filters = append(e.Filters, ExplainedFilter{Filter: name, Before: before, After: after})
+	}
+}
+
+// strategy is a method of the Explanation struct that records how the words of the query were matched.
+//
+// Parameters:
+//   - strategy (string): The strategy.
+//
+// Returns:
+//   - None
+func (e *Explanation) strategy(strategy string) {
+	if e != nil {
+		e.Strategy = strategy
+	}
+}
+
+// matched is a method of the Explanation struct that records the number of values that matched the query and the filters.
+//
+// Parameters:
+//   - n (int): The number of values.
+//
+// Returns:
+//   - None
+func (e *Explanation) matched(n int) {
+	if e != nil {
+		e.Matched = n
+	}
+}
+
+// explainKeyPrefix is a method of the Cache struct that records how many values the key prefix of a search pruned in its explanation,
+// by searching for the query again without the key prefix.
+// This method is not thread-safe, and should only be called from an exported function.
+//
+// Parameters:
+//   - ctx (context.Context): The context of the request.
+//   - sp (SearchParams): The search parameters, with a lowercase query.
+//   - after (int): The number of values that matched the query with the key prefix.
+//
+// Returns:
+//   - None
+func (c *Cache) explainKeyPrefix(ctx context.Context, sp SearchParams, after int) {
+	if sp.Explain == nil || len(sp.KeyPrefix) == 0 {
+		return
+	}
+	var e *Explanation = sp.Explain
+	sp.KeyPrefix, sp.Explain, sp.Limit = "", nil, len(c.data)
+	values, err := searchVariants(sp, func(sp SearchParams) ([]map[string]any, error) {
+		return c.search(ctx, sp)
+	})
+	if err == nil {
+		e.filter("key prefix", len(values), after)
+	}
+}
+
+// explain is a method of the Cache struct that completes the explanation of a search with the terms of its query and its number of results.
+// The terms that are looked up once the context is done only count the words that were scanned before.
+// This method is not thread-safe, and should only be called from an exported function.
+//
+// Parameters:
+//   - ctx (context.Context): The context of the request.
+//   - sp (SearchParams): The search parameters, with a lowercase query and an Explain.
+//   - results (int): The number of returned results.
+//
+// Returns:
+//   - None
+func (c *Cache) explain(ctx context.Context, sp SearchParams, results int) {
+	var (
+		e     *Explanation = sp.Explain
+		start time.Time    = time.Now()
+	)
+	if e == nil {
+		return
+	}
+	e.Results = results
+
+	// The words of a search with wildcards are matched as they're written
+	var (
+		wildcards bool     = sp.Wildcards && hasWildcards(sp.Query)
+		terms     []string = c.ft.queryTerms(sp.Query)
+	)
+	if wildcards {
+		terms = strings.Fields(sp.Query)
+	}
+	for _, term := range terms {
+		var words map[string]any
+		if wildcards {
+			words, _ = c.ft.wildcardWords(ctx, term, sp)
+		} else {
+			words, _ = c.ft.matchingWords(ctx, term, sp)
+		}
+
+		// Count the values that contain the matching words
+		var (
+			examples []string     = make([]string, 0, len(words))
+			indices  map[int]bool = map[int]bool{}
+		)
+		for word, v := range words {
+			examples = append(examples, word)
+			eachPosting(v, func(index int) bool {
+				indices[index] = true
+				return true
+			})
+		}
+		sort.Strings(examples)
+		if len(examples) > explainWords {
+			examples = examples[:explainWords]
+		}
+		e.Terms = append(e.Terms, ExplainedTerm{Term: term, Words: len(words), Examples: examples, Candidates: len(indices)})
+	}
+	e.phase("explain", start)
+}
@@ -0,0 +1,118 @@
+package hermes
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+)
+
+// ExportJson is a method of the Cache struct that writes the current values of the cache to w as a JSON object of keys to values,
+// in the format read by FTInitWithJson. The fields stored in the full-text index are written in the full-text map form, so they're
+// indexed again when the export is loaded. Expired keys are skipped, and the values are written in the order of their keys.
+// The cache is locked for reading while the values are written.
+// This method is thread-safe.
+//
+// Parameters:
+//   - w (io.Writer): The writer to write the JSON object to.
+//
+// Returns:
+//   - error: An error if a value could not be encoded or written.
+func (c *Cache) ExportJson(w io.Writer) error {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	// Write the values between the braces of the object
+	var bw *bufio.Writer = bufio.NewWriter(w)
+	bw.WriteByte('{')
+	var first bool = true
+	if err := c.export(func(key string, value []byte) error {
+		if !first {
+			bw.WriteByte(',')
+		}
+		first = false
+		k, err := json.Marshal(key)
+		if err != nil {
+			return err
+		}
+		bw.Write(k)
+		bw.WriteByte(':')
+		_, err = bw.Write(value)
+		return err
+	}); err != nil {
+		return err
+	}
+	bw.WriteString("}\n")
+	return bw.Flush()
+}
+
+// ExportNDJson is a method of the Cache struct that writes the current values of the cache to w as newline-delimited JSON,
+// one `{"key": ..., "value": ...}` object per line, so the export can be streamed and processed line by line.
+// The fields stored in the full-text index are written in the full-text map form, like with ExportJson. Expired keys are
+// skipped, and the values are written in the order of their keys.
+// The cache is locked for reading while the values are written.
+// This method is thread-safe.
+//
+// Parameters:
+//   - w (io.Writer): The writer to write the lines to.
+//
+// Returns:
+//   - error: An error if a value could not be encoded or written.
+func (c *Cache) ExportNDJson(w io.Writer) error {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	// Write a line for each value
+	var bw *bufio.Writer = bufio.NewWriter(w)
+	if err := c.export(func(key string, value []byte) error {
+		line, err := json.Marshal(struct {
+			Key   string          `json:"key"`
+			Value json.RawMessage `json:"value"`
+		}{key, value})
+		if err != nil {
+			return err
+		}
+		bw.Write(line)
+		return bw.WriteByte('\n')
+	}); err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+// export is a method of the Cache struct that encodes every value of the cache that hasn't expired, in the order of their keys.
+// This method is not thread-safe, and should only be called from an exported function.
+//
+// Parameters:
+//   - fn (func(key string, value []byte) error): The function to call with the key and JSON-encoded value of each value.
+//     If it returns an error, the export stops and the error is returned.
+//
+// Returns:
+//   - error: An error if a value could not be encoded, or the error returned by fn.
+func (c *Cache) export(fn func(key string, value []byte) error) error {
+	for _, key := range c.keyTrie.WithPrefix("", 0) {
+		if c.expired(key) {
+			continue
+		}
+
+		// Wrap the fields stored in the full-text index
+		var value map[string]any = c.expand(c.data[key])
+		if c.ft != nil && len(c.ft.fields[key]) > 0 {
+			value = copyMap(value)
+			for _, field := range c.ft.fields[key] {
+				if v, ok := value[field].(string); ok {
+					value[field] = &WFT{v}
+				}
+			}
+		}
+
+		// Encode the value
+		data, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		if err := fn(key, data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package hermes
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FacetCounts is a method of the Cache struct that counts the values of a field among the values that match a filter expression,
+// for showing the number of results of each option of a filter panel. A value whose field is an array counts once for each
+// of its distinct elements. The filter uses the syntax of Filter, so the facets can be drilled down with any (`tags:a|b`) or
+// all (`tags:a&b`) semantics chosen per request.
+// The field must have been indexed with CreateIndex.
+// This method is thread-safe.
+//
+// Parameters:
+//   - field (string): The indexed field to count the values of.
+//   - expr (string): The filter expression, or an empty string to count over every value.
+//
+// Returns:
+//   - map[string]int: The number of matching values holding each value of the field.
+//   - error: An error if the field is not indexed or the expression is invalid.
+func (c *Cache) FacetCounts(field string, expr string) (map[string]int, error) {
+	var terms []filterTerm
+	if len(strings.TrimSpace(expr)) > 0 {
+		var err error
+		if terms, err = parseFilter(expr); err != nil {
+			return map[string]int{}, err
+		}
+	}
+
+	// Lock the mutex
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	// Verify that the index exists
+	index, ok := c.indexes[field]
+	if !ok {
+		return map[string]int{}, fmt.Errorf("index on field %s does not exist", field)
+	}
+
+	// Find the matching keys
+	var matches map[string]bool
+	if terms != nil {
+		keys, err := c.filterKeys(terms)
+		if err != nil {
+			return map[string]int{}, err
+		}
+		matches = make(map[string]bool, len(keys))
+		for _, key := range keys {
+			matches[key] = true
+		}
+	}
+
+	// Count the matching keys of each value of the field
+	var counts map[string]int = make(map[string]int, len(index))
+	for value, keys := range index {
+		var count int = 0
+		for key := range keys {
+			if (matches == nil || matches[key]) && !c.expired(key) {
+				count++
+			}
+		}
+		if count > 0 {
+			counts[value] = count
+		}
+	}
+	return counts, nil
+}
@@ -0,0 +1,199 @@
+package hermes
+
+// fieldIndex is a type that represents the inverted index of each field of the full-text index. It maps each field to its words,
+// and each word to the roaring bitmap of the indices of the keys whose value of the field contains the word, so a search in a single field
+// only looks up the posting lists of that field instead of filtering the matches of every field.
+type fieldIndex map[string]map[string]*roaring
+
+// add is a method of the fieldIndex type that adds the index of a key to the posting list of a word of a field.
+//
+// Parameters:
+//   - field (string): The field.
+//   - word (string): The word.
+//   - index (int): The index of the key.
+//
+// Returns:
+//   - None
+func (fi fieldIndex) add(field string, word string, index int) {
+	var words map[string]*roaring = fi[field]
+	if words == nil {
+		words = make(map[string]*roaring)
+		fi[field] = words
+	}
+	if postings := words[word]; postings != nil {
+		postings.add(index)
+	} else {
+		words[word] = newRoaring(index)
+	}
+}
+
+// merge is a method of the fieldIndex type that adds the sorted indices of keys to the posting list of a word of a field.
+//
+// Parameters:
+//   - field (string): The field.
+//   - word (string): The word.
+//   - indices ([]int): The sorted indices of the keys.
+//
+// Returns:
+//   - None
+func (fi fieldIndex) merge(field string, word string, indices []int) {
+	if fi[field][word] == nil {
+		if fi[field] == nil {
+			fi[field] = make(map[string]*roaring)
+		}
+		fi[field][word] = newRoaring(indices...)
+		return
+	}
+	for _, index := range indices {
+		fi[field][word].add(index)
+	}
+}
+
+// remove is a method of the fieldIndex type that removes the index of a key from the posting lists of a word in every field.
+// The words and fields that have no postings left are removed.
+//
+// Parameters:
+//   - word (string): The word.
+//   - index (int): The index of the key.
+//
+// Returns:
+//   - None
+func (fi fieldIndex) remove(word string, index int) {
+	for field, words := range fi {
+		var postings *roaring = words[word]
+		if postings == nil || !postings.remove(index) {
+			continue
+		} else if postings.len() > 0 {
+			continue
+		}
+		delete(words, word)
+		if len(words) == 0 {
+			delete(fi, field)
+		}
+	}
+}
+
+// clone is a method of the fieldIndex type that returns a deep copy of the index, with posting lists of the exact size.
+//
+// Returns:
+//   - fieldIndex: The copy of the index.
+func (fi fieldIndex) clone() fieldIndex {
+	var clone fieldIndex = make(fieldIndex, len(fi))
+	for field, words := range fi {
+		clone[field] = make(map[string]*roaring, len(words))
+		for word, postings := range words {
+			clone[field][word] = postings.clone()
+		}
+	}
+	return clone
+}
+
+// renumber is a method of the fieldIndex type that replaces the indices of the posting lists with new indices.
+//
+// Parameters:
+//   - indices (map[int]int): The new index of each index.
+//
+// Returns:
+//   - None
+func (fi fieldIndex) renumber(indices map[int]int) {
+	for _, words := range fi {
+		for word, postings := range words {
+			var renumbered []int = postings.slice()
+			for i, index := range renumbered {
+				renumbered[i] = indices[index]
+			}
+			words[word] = newRoaring(renumbered...)
+		}
+	}
+}
+
+// buildFieldIndex is a method of the Cache struct that builds the field index of a full-text index from the cache data,
+// for the full-text indexes that were restored from a snapshot written before the field index was recorded.
+// This method is not thread-safe, and should only be called from an exported function.
+//
+// Parameters:
+//   - ft (*FullText): The full-text index, whose field index is nil if it has to be built.
+//
+// Returns:
+//   - None
+func (c *Cache) buildFieldIndex(ft *FullText) {
+	if ft.fieldStorage != nil {
+		return
+	}
+	ft.fieldStorage = make(fieldIndex)
+	for key, fields := range ft.fields {
+		index, ok := ft.keys[key]
+		if !ok {
+			continue
+		}
+		var value map[string]any = c.expand(c.data[key])
+		for _, field := range fields {
+			if v, ok := fieldString(pathValue(value, field)); ok {
+				for _, word := range ft.fieldWords(field, v) {
+					ft.fieldStorage.add(field, word, index)
+				}
+			}
+		}
+	}
+}
+
+// fieldIndices is a method of the FullText struct that returns the indices of the values whose field contains a stored word
+// that matches each term, looked up in the posting lists of the field.
+//
+// Parameters:
+//   - field (string): The field.
+//   - terms ([]string): The terms, in the form that is stored in the full-text index.
+//   - sp (SearchParams): The search parameters, which decide how the stored words match the terms.
+//
+// Returns:
+//   - map[int]bool: The set of the indices.
+func (ft *FullText) fieldIndices(field string, terms []string, sp SearchParams) map[int]bool {
+	var (
+		words   map[string]*roaring = ft.fieldStorage[field]
+		indices map[int]bool
+	)
+	for _, term := range terms {
+		var (
+			matching   map[int]bool         = map[int]bool{}
+			candidates map[string]any       = nil
+			add        func(index int) bool = func(index int) bool {
+				matching[index] = true
+				return true
+			}
+		)
+		if !sp.Strict || sp.Fuzziness > 0 {
+			candidates, _ = ft.candidateWords(term, sp)
+		}
+		switch {
+		case sp.Strict && sp.Fuzziness == 0:
+			if postings := words[term]; postings != nil {
+				postings.each(add)
+			}
+		case candidates != nil:
+			// Only look up the words of the field that may match the term
+			for word := range candidates {
+				if postings := words[word]; postings != nil && sp.matchesWord(word, term) {
+					postings.each(add)
+				}
+			}
+		default:
+			for word, postings := range words {
+				if sp.matchesWord(word, term) {
+					postings.each(add)
+				}
+			}
+		}
+
+		// Keep the indices that match every term
+		if indices == nil {
+			indices = matching
+			continue
+		}
+		for index := range indices {
+			if !matching[index] {
+				delete(indices, index)
+			}
+		}
+	}
+	return indices
+}
@@ -0,0 +1,252 @@
+package hermes
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Tolerance is a struct that represents how far a numeric value may be from the value of a `field:~value` filter to match it.
+// The larger of the two tolerances is used.
+//
+// Fields:
+//   - Absolute (float64): The maximum absolute difference, e.g. 5 to match 94 to 104 for ~99.
+//   - Percent (float64): The maximum difference as a percentage of the filter value, e.g. 10 to match 89.1 to 108.9 for ~99.
+type Tolerance struct {
+	Absolute float64
+	Percent  float64
+}
+
+// filterTerm is a struct that represents a term of a filter expression.
+//
+// Fields:
+//   - field (string): The field to filter on.
+//   - op (byte): The operator of the term, '=' for equality, '~' for numeric tolerance, and 'e' and 'm' for the presence of the field.
+//   - value (string): The value of the term.
+type filterTerm struct {
+	field string
+	op    byte
+	value string
+}
+
+// SetTolerance is a method of the Cache struct that sets the tolerance of the `field:~value` filters on a field.
+// Fields without a tolerance only match numeric values equal to the filter value.
+// This method is thread-safe.
+//
+// Parameters:
+//   - field (string): The field.
+//   - t (Tolerance): The tolerance of the field.
+//
+// Returns:
+//   - error: An error if the field is empty or the tolerance is negative.
+func (c *Cache) SetTolerance(field string, t Tolerance) error {
+	switch {
+	case len(field) == 0:
+		return errors.New("invalid field")
+	case t.Absolute < 0 || t.Percent < 0:
+		return errors.New("invalid tolerance")
+	}
+
+	// Set the tolerance
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.tolerances[field] = t
+	return nil
+}
+
+// Filter is a method of the Cache struct that returns the values that match every term of a filter expression, in the order of their keys.
+// The terms are separated by spaces, and each term is either `field:value`, which matches the values whose field is equal to the value,
+// or `field:~value`, which matches the numeric values of the field within the tolerance set with SetTolerance.
+// For drilling down on facets, `field:a|b` matches the values whose field is any of a or b, and `field:a&b` the values whose
+// field is an array holding both a and b.
+// To find incomplete values, `exists:field` matches the values whose field is set to a value other than null, and `missing:field`
+// the values whose field isn't set or is null. These terms don't need an index.
+// Every field of the expression must have been indexed with CreateIndex, or with CreateBoolIndex for `field:true` and `field:false` terms.
+// This method is thread-safe.
+//
+// Parameters:
+//   - expr (string): The filter expression, e.g. "category:books price:~20 tags:sale|new".
+//
+// Returns:
+//   - []map[string]any: The matching values.
+//   - error: An error if the expression is invalid or a field is not indexed.
+func (c *Cache) Filter(expr string) ([]map[string]any, error) {
+	terms, err := parseFilter(expr)
+	if err != nil {
+		return []map[string]any{}, err
+	}
+
+	// Lock the mutex
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	// Find the matching keys
+	keys, err := c.filterKeys(terms)
+	if err != nil {
+		return []map[string]any{}, err
+	}
+
+	// Get the values of the keys
+	var result []map[string]any = make([]map[string]any, 0, len(keys))
+	for _, key := range keys {
+		if !c.expired(key) {
+			result = append(result, c.expand(c.data[key]))
+		}
+	}
+	return result, nil
+}
+
+// filterKeys is a method of the Cache struct that returns the keys that match every term of a filter, in sorted order.
+// The presence terms and the terms on fields with a boolean index are intersected as bitmaps before the other terms are applied.
+// This method is not thread-safe, and should only be called from an exported function.
+//
+// Parameters:
+//   - terms ([]filterTerm): The terms of the filter.
+//
+// Returns:
+//   - []string: The matching keys.
+//   - error: An error if a field is not indexed or a value is invalid.
+func (c *Cache) filterKeys(terms []filterTerm) ([]string, error) {
+	var (
+		result map[string]bool
+		bools  bitmap
+		others []filterTerm = make([]filterTerm, 0, len(terms))
+	)
+
+	// Intersect the bitmaps of the presence and boolean terms
+	for _, term := range terms {
+		var bm bitmap
+		if term.op == 'e' || term.op == 'm' {
+			bm = c.booleans.presence(term)
+		} else if _, ok := c.booleans.fields[term.field]; !ok {
+			others = append(others, term)
+			continue
+		} else if b, err := c.booleans.filter(term); err != nil {
+			return []string{}, err
+		} else {
+			bm = b
+		}
+		if result == nil {
+			bools, result = bm, map[string]bool{}
+		} else {
+			bools = and(bools, bm)
+		}
+	}
+	if result != nil {
+		result = c.booleans.keySet(bools)
+	}
+
+	// Apply the other terms
+	for _, term := range others {
+		index, ok := c.indexes[term.field]
+		if !ok {
+			return []string{}, fmt.Errorf("index on field %s does not exist", term.field)
+		}
+
+		// Find the keys of the term
+		keys, err := c.filterTermKeys(index, term)
+		if err != nil {
+			return []string{}, err
+		}
+
+		// Intersect the keys with the keys of the previous terms
+		if result == nil {
+			result = keys
+			continue
+		}
+		for key := range result {
+			if !keys[key] {
+				delete(result, key)
+			}
+		}
+	}
+
+	// Sort the keys
+	var sorted []string = make([]string, 0, len(result))
+	for key := range result {
+		sorted = append(sorted, key)
+	}
+	sort.Strings(sorted)
+	return sorted, nil
+}
+
+// filterTermKeys is a method of the Cache struct that returns the keys of the secondary index of a field that match a filter term.
+// This method is not thread-safe, and should only be called from an exported function.
+//
+// Parameters:
+//   - index (map[string]map[string]bool): The secondary index of the field of the term.
+//   - term (filterTerm): The term.
+//
+// Returns:
+//   - map[string]bool: A new set of the matching keys.
+//   - error: An error if the value of a numeric term is not a number.
+func (c *Cache) filterTermKeys(index map[string]map[string]bool, term filterTerm) (map[string]bool, error) {
+	var result map[string]bool = map[string]bool{}
+	if term.op == '=' {
+		for _, value := range strings.Split(term.value, "|") {
+			for key := range index[value] {
+				result[key] = true
+			}
+		}
+		return result, nil
+	}
+
+	// Compute the range of the numeric term
+	target, err := strconv.ParseFloat(term.value, 64)
+	if err != nil {
+		return result, fmt.Errorf("invalid number %s for field %s", term.value, term.field)
+	}
+	var t Tolerance = c.tolerances[term.field]
+	var delta float64 = math.Max(t.Absolute, math.Abs(target)*t.Percent/100)
+
+	// Find the indexed values within the range
+	for value, keys := range index {
+		if v, err := strconv.ParseFloat(value, 64); err == nil && math.Abs(v-target) <= delta {
+			for key := range keys {
+				result[key] = true
+			}
+		}
+	}
+	return result, nil
+}
+
+// parseFilter is a function that parses a filter expression into its terms.
+//
+// Parameters:
+//   - expr (string): The filter expression.
+//
+// Returns:
+//   - []filterTerm: The terms of the expression.
+//   - error: An error if the expression is empty or a term is invalid.
+func parseFilter(expr string) ([]filterTerm, error) {
+	var terms []filterTerm = []filterTerm{}
+	for _, s := range strings.Fields(expr) {
+		field, value, ok := strings.Cut(s, ":")
+		if !ok || len(field) == 0 {
+			return terms, fmt.Errorf("invalid filter term %s", s)
+		}
+		if field == "exists" || field == "missing" {
+			if len(value) == 0 {
+				return terms, fmt.Errorf("invalid filter term %s", s)
+			}
+			terms = append(terms, filterTerm{field: value, op: field[0]})
+			continue
+		}
+		if strings.HasPrefix(value, "~") {
+			terms = append(terms, filterTerm{field: field, op: '~', value: value[1:]})
+			continue
+		}
+
+		// Split the values that must all match into separate terms
+		for _, v := range strings.Split(value, "&") {
+			terms = append(terms, filterTerm{field: field, op: '=', value: v})
+		}
+	}
+	if len(terms) == 0 {
+		return terms, errors.New("invalid filter")
+	}
+	return terms, nil
+}
@@ -0,0 +1,81 @@
+package hermes
+
+import "fmt"
+
+// FrozenError is the error returned by the writes of a frozen cache.
+//
+// Fields:
+//   - Op (string): The name of the rejected write, e.g. "Set".
+type FrozenError struct {
+	Op string
+}
+
+// Error is a method of the FrozenError struct that returns the error message.
+//
+// Returns:
+//   - string: The error message.
+func (e *FrozenError) Error() string {
+	return fmt.Sprintf("cache is frozen: %s rejected", e.Op)
+}
+
+// Freeze is a method of the Cache struct that rejects the writes to the data of the cache with a *FrozenError until Unfreeze is called,
+// while the reads and searches keep working, e.g. to take a consistent snapshot or to debug a stable dataset.
+// Delete, Clean and Clear, which don't return an error, do nothing while the cache is frozen, the expired keys are hidden but not
+// removed, and GetOrLoad returns the loaded values without storing them. The configuration of the cache can still be changed,
+// but FTReindex, SetComputedField and the full-text settings that rebuild the index, such as FTSetStemmer, FTSetStopWords,
+// FTSetAnalyzer or FTSetMinWordLength, are rejected.
+// This method is thread-safe.
+//
+// Parameters:
+//   - None
+//
+// Returns:
+//   - None
+func (c *Cache) Freeze() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.frozen = true
+}
+
+// Unfreeze is a method of the Cache struct that accepts the writes again after Freeze.
+// This method is thread-safe.
+//
+// Parameters:
+//   - None
+//
+// Returns:
+//   - None
+func (c *Cache) Unfreeze() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.frozen = false
+}
+
+// Frozen is a method of the Cache struct that returns whether the cache is frozen.
+// This method is thread-safe.
+//
+// Parameters:
+//   - None
+//
+// Returns:
+//   - bool: Whether the writes are rejected.
+func (c *Cache) Frozen() bool {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return c.frozen
+}
+
+// writable is a method of the Cache struct that verifies that the data of the cache can be written.
+// This method is not thread-safe, and should only be called from an exported function.
+//
+// Parameters:
+//   - op (string): The name of the write.
+//
+// Returns:
+//   - error: A *FrozenError if the cache is frozen.
+func (c *Cache) writable(op string) error {
+	if c.frozen {
+		return &FrozenError{Op: op}
+	}
+	return nil
+}
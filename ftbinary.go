@@ -0,0 +1,584 @@
+package hermes
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"hash/crc32"
+	"io"
+	"math"
+	"sort"
+)
+
+// ftBinaryMagic is the header that starts the binary encoding of a full-text index written by WriteTo.
+var ftBinaryMagic []byte = []byte("HMFT")
+
+// The version of the binary encoding of the full-text index
+// 1: the first format.
+// 2: adds whether the index has a suffix index.
+// 3: adds the number of word partitions.
+// 4: adds the maximum word length and whether the numeric words are skipped.
+// 5: adds whether the strict searches are case-sensitive.
+const ftBinaryFormat uint64 = 5
+
+// WriteTo is a method of the FullText struct that writes the full-text index to w in a compact binary encoding, which ReadFrom
+// reads back without tokenizing the values again. The encoding is a header with the format version and the configuration of the index,
+// followed by the keys in the order of their indices, the words in sorted order with the prefix they share with the previous word
+// written once, and the posting list of each word as the gaps between its sorted indices. The field index is encoded like the words,
+// and a CRC-32 checksum of the encoding ends it, so a truncated or corrupted encoding is detected.
+// The index is only read, but it must not be modified while it's written.
+//
+// Parameters:
+//   - w (io.Writer): The writer.
+//
+// Returns:
+//   - int64: The number of bytes written.
+//   - error: An error if the index could not be written.
+func (ft *FullText) WriteTo(w io.Writer) (int64, error) {
+	var (
+		cw *ftCountWriter = &ftCountWriter{w: w, crc: crc32.NewIEEE()}
+		e  *ftEncoder     = &ftEncoder{w: bufio.NewWriter(cw)}
+		s  *ftSnapshot    = ft.snapshot()
+	)
+
+	// Write the header and the configuration
+	e.w.Write(ftBinaryMagic)
+	e.uvarint(ftBinaryFormat)
+	for _, v := range []int{s.MaxSize, s.MaxBytes, s.MinWordLength, int(s.Stemmer), int(s.Analyzer), s.NgramSize, s.Index} {
+		e.varint(v)
+	}
+	if s.SuffixIndex {
+		e.uvarint(1)
+	} else {
+		e.uvarint(0)
+	}
+	e.varint(s.Partitions)
+	if s.SkipNumbers {
+		e.uvarint(1)
+	} else {
+		e.uvarint(0)
+	}
+	e.varint(s.MaxWordLength)
+	if s.CaseSensitive {
+		e.uvarint(1)
+	} else {
+		e.uvarint(0)
+	}
+	e.string(s.SchemaHash)
+	e.string(s.AnalyzerHash)
+	e.strings(sortedKeys(s.Schema))
+	e.uvarint(uint64(len(s.TokenRules)))
+	for _, field := range sortedKeys(s.TokenRules) {
+		e.string(field)
+		e.varint(int(s.TokenRules[field]))
+	}
+	e.strings(s.StopWords)
+	e.uvarint(uint64(len(s.FieldAnalyzers)))
+	for _, field := range sortedKeys(s.FieldAnalyzers) {
+		e.string(field)
+		e.varint(int(s.FieldAnalyzers[field]))
+	}
+	e.uvarint(uint64(len(s.FieldWeights)))
+	for _, field := range sortedKeys(s.FieldWeights) {
+		e.string(field)
+		e.uvarint(math.Float64bits(s.FieldWeights[field]))
+	}
+
+	// Write the keys in the order of their indices, with their full-text fields
+	var indices []int = make([]int, 0, len(s.Indices))
+	for index := range s.Indices {
+		indices = append(indices, index)
+	}
+	sort.Ints(indices)
+	e.uvarint(uint64(len(indices)))
+	e.postings(indices)
+	for _, index := range indices {
+		var key string = s.Indices[index]
+		e.string(key)
+		e.strings(s.Fields[key])
+	}
+
+	// Write the words and their posting lists, then the words of each field
+	var storage map[string][]int = make(map[string][]int, len(s.Storage))
+	for word, v := range s.Storage {
+		storage[word] = postingsOf(v)
+	}
+	e.words(storage)
+	e.uvarint(uint64(len(s.FieldStorage)))
+	for _, field := range sortedKeys(s.FieldStorage) {
+		var words map[string][]int = make(map[string][]int, len(s.FieldStorage[field]))
+		for word, postings := range s.FieldStorage[field] {
+			words[word] = postings.slice()
+		}
+		e.string(field)
+		e.words(words)
+	}
+
+	// Write the checksum of everything before it
+	if err := e.w.Flush(); err != nil {
+		return cw.n, err
+	}
+	var sum [4]byte
+	binary.LittleEndian.PutUint32(sum[:], cw.crc.Sum32())
+	_, err := cw.w.Write(sum[:])
+	if err == nil {
+		cw.n += int64(len(sum))
+	}
+	return cw.n, err
+}
+
+// ReadFrom is a method of the FullText struct that replaces the full-text index with the index read from r, which must have been written
+// by WriteTo. The encoding is read with a single sequential read, and its checksum and configuration hashes are verified before it's decoded.
+// The cache values are not read, so the keys of the index must be verified against the cache data before it's used, like FTReadFrom does.
+//
+// Parameters:
+//   - r (io.Reader): The reader.
+//
+// Returns:
+//   - int64: The number of bytes read.
+//   - error: An error if the encoding could not be read, or an error wrapping ErrIncompatibleSnapshot if it's truncated, corrupted,
+//     or was written with a newer format.
+func (ft *FullText) ReadFrom(r io.Reader) (int64, error) {
+	data, err := io.ReadAll(r)
+	var n int64 = int64(len(data))
+	if err != nil {
+		return n, err
+	}
+
+	// Verify the header and the checksum
+	if !bytes.HasPrefix(data, ftBinaryMagic) || len(data) < len(ftBinaryMagic)+4 {
+		return n, fmt.Errorf("%w: not a binary full-text index", ErrIncompatibleSnapshot)
+	}
+	var body []byte = data[:len(data)-4]
+	if crc32.ChecksumIEEE(body) != binary.LittleEndian.Uint32(data[len(data)-4:]) {
+		return n, fmt.Errorf("%w: the checksum of the binary full-text index doesn't match", ErrIncompatibleSnapshot)
+	}
+	var (
+		d      *ftDecoder = &ftDecoder{data: body[len(ftBinaryMagic):]}
+		format uint64     = d.uvarint()
+	)
+	if d.err == nil && format > ftBinaryFormat {
+		return n, fmt.Errorf("%w: binary full-text index format %d is newer than the supported format %d", ErrIncompatibleSnapshot,
+			format, ftBinaryFormat)
+	}
+
+	// Read the configuration
+	var s *ftSnapshot = &ftSnapshot{}
+	s.MaxSize, s.MaxBytes, s.MinWordLength = d.varint(), d.varint(), d.varint()
+	s.Stemmer, s.Analyzer = Stemmer(d.varint()), Analyzer(d.varint())
+	s.NgramSize, s.Index = d.varint(), d.varint()
+	if format >= 2 {
+		s.SuffixIndex = d.uvarint() == 1
+	}
+	if format >= 3 {
+		s.Partitions = d.varint()
+	}
+	if format >= 4 {
+		s.SkipNumbers, s.MaxWordLength = d.uvarint() == 1, d.varint()
+	}
+	if format >= 5 {
+		s.CaseSensitive = d.uvarint() == 1
+	}
+	s.SchemaHash, s.AnalyzerHash = d.string(), d.string()
+	if schema := d.strings(); len(schema) > 0 {
+		s.Schema = make(map[string]bool, len(schema))
+		for _, field := range schema {
+			s.Schema[field] = true
+		}
+	}
+	if count := d.count(); count > 0 {
+		s.TokenRules = make(map[string]TokenRule, count)
+		for i := 0; i < count; i++ {
+			s.TokenRules[d.string()] = TokenRule(d.varint())
+		}
+	}
+	if s.StopWords = d.strings(); len(s.StopWords) == 0 {
+		s.StopWords = nil
+	}
+	if count := d.count(); count > 0 {
+		s.FieldAnalyzers = make(map[string]Analyzer, count)
+		for i := 0; i < count; i++ {
+			s.FieldAnalyzers[d.string()] = Analyzer(d.varint())
+		}
+	}
+	if count := d.count(); count > 0 {
+		s.FieldWeights = make(map[string]float64, count)
+		for i := 0; i < count; i++ {
+			s.FieldWeights[d.string()] = math.Float64frombits(d.uvarint())
+		}
+	}
+
+	// Read the keys, with their full-text fields
+	var indices []int = d.postings(d.count())
+	s.Indices = make(map[int]string, len(indices))
+	s.Fields = make(map[string][]string, len(indices))
+	for _, index := range indices {
+		var key string = d.string()
+		s.Indices[index] = key
+		if fields := d.strings(); len(fields) > 0 {
+			s.Fields[key] = fields
+		}
+	}
+
+	// Read the words and their posting lists, then the words of each field
+	var words map[string][]int = d.words()
+	s.Storage = make(map[string]any, len(words))
+	for word, postings := range words {
+		if len(postings) == 1 {
+			s.Storage[word] = postings[0]
+		} else {
+			s.Storage[word] = newRoaring(postings...)
+		}
+	}
+	s.FieldStorage = make(fieldIndex)
+	for i, count := 0, d.count(); i < count; i++ {
+		var field string = d.string()
+		s.FieldStorage[field] = make(map[string]*roaring)
+		for word, postings := range d.words() {
+			s.FieldStorage[field][word] = newRoaring(postings...)
+		}
+	}
+
+	// Verify that the whole encoding was decoded, and its configuration
+	if d.err == nil && len(d.data) > 0 {
+		d.err = errors.New("trailing data")
+	}
+	if d.err != nil {
+		return n, fmt.Errorf("%w: invalid binary full-text index: %v", ErrIncompatibleSnapshot, d.err)
+	} else if err := s.verify(); err != nil {
+		return n, err
+	}
+	loaded, err := s.fullText()
+	if err != nil {
+		return n, err
+	}
+	*ft = *loaded
+	return n, nil
+}
+
+// FTWriteTo is a method of the Cache struct that writes the full-text index, without the cache data, to w in the compact binary
+// encoding of FullText.WriteTo, so a large index can be restored with FTReadFrom or FTLoad without tokenizing the values again.
+// The read lock of the cache is held while the index is written.
+// This method is thread-safe.
+//
+// Parameters:
+//   - w (io.Writer): The writer.
+//
+// Returns:
+//   - int64: The number of bytes written.
+//   - error: An error if the full-text index is not initialized, or it could not be written.
+func (c *Cache) FTWriteTo(w io.Writer) (int64, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	// Check if the ft is initialized
+	if c.ft == nil {
+		return 0, errors.New("full-text is not initialized")
+	}
+	return c.ft.WriteTo(w)
+}
+
+// FTReadFrom is a method of the Cache struct that initializes the full-text index from the binary encoding written by FTWriteTo,
+// like FTLoad does with a file. The cache data must be set before the index is read, and every key in the index must exist in the cache.
+// This method is thread-safe.
+//
+// Parameters:
+//   - r (io.Reader): The reader.
+//
+// Returns:
+//   - int64: The number of bytes read.
+//   - error: An error if the encoding could not be read or decoded, the full-text index is already initialized, the index references
+//     a key that is not in the cache, or the full-text storage limit is reached.
+func (c *Cache) FTReadFrom(r io.Reader) (int64, error) {
+	var ft *FullText = &FullText{}
+	n, err := ft.ReadFrom(r)
+	if err != nil {
+		return n, err
+	}
+	return n, c.ftLoad(ft)
+}
+
+// ftCountWriter is a struct that counts the bytes written to a writer, and computes their checksum.
+//
+// Fields:
+//   - w (io.Writer): The writer.
+//   - crc (hash.Hash32): The checksum of the written bytes.
+//   - n (int64): The number of written bytes.
+type ftCountWriter struct {
+	w   io.Writer
+	crc hash.Hash32
+	n   int64
+}
+
+// Write is a method of the ftCountWriter struct that writes p to the writer, and adds the written bytes to the count and checksum.
+//
+// Parameters:
+//   - p ([]byte): The bytes to write.
+//
+// Returns:
+//   - int: The number of bytes written.
+//   - error: The error of the writer.
+func (cw *ftCountWriter) Write(p []byte) (int, error) {
+	n, err := cw.w.Write(p)
+	cw.crc.Write(p[:n])
+	cw.n += int64(n)
+	return n, err
+}
+
+// ftEncoder is a struct that writes the values of the binary encoding of a full-text index. The errors are kept by the buffered writer,
+// and returned when it's flushed.
+//
+// Fields:
+//   - w (*bufio.Writer): The buffered writer.
+//   - buf ([binary.MaxVarintLen64]byte): The buffer of the encoded varints.
+type ftEncoder struct {
+	w   *bufio.Writer
+	buf [binary.MaxVarintLen64]byte
+}
+
+// uvarint is a method of the ftEncoder struct that writes an unsigned varint.
+//
+// Parameters:
+//   - v (uint64): The value.
+//
+// Returns:
+//   - None
+func (e *ftEncoder) uvarint(v uint64) {
+	e.w.Write(e.buf[:binary.PutUvarint(e.buf[:], v)])
+}
+
+// varint is a method of the ftEncoder struct that writes a signed varint, such as a limit that can be Unlimited.
+//
+// Parameters:
+//   - v (int): The value.
+//
+// Returns:
+//   - None
+func (e *ftEncoder) varint(v int) {
+	e.w.Write(e.buf[:binary.PutVarint(e.buf[:], int64(v))])
+}
+
+// string is a method of the ftEncoder struct that writes a string, prefixed with its length.
+//
+// Parameters:
+//   - s (string): The string.
+//
+// Returns:
+//   - None
+func (e *ftEncoder) string(s string) {
+	e.uvarint(uint64(len(s)))
+	e.w.WriteString(s)
+}
+
+// strings is a method of the ftEncoder struct that writes a list of strings, prefixed with their count.
+//
+// Parameters:
+//   - list ([]string): The strings.
+//
+// Returns:
+//   - None
+func (e *ftEncoder) strings(list []string) {
+	e.uvarint(uint64(len(list)))
+	for _, s := range list {
+		e.string(s)
+	}
+}
+
+// postings is a method of the ftEncoder struct that writes sorted indices as the gaps between them, without their count.
+//
+// Parameters:
+//   - indices ([]int): The sorted indices.
+//
+// Returns:
+//   - None
+func (e *ftEncoder) postings(indices []int) {
+	var previous int = 0
+	for _, index := range indices {
+		e.uvarint(uint64(index - previous))
+		previous = index
+	}
+}
+
+// words is a method of the ftEncoder struct that writes words with their posting lists. The words are sorted, and each word is written as the
+// length of the prefix it shares with the previous word and the rest of the word. The posting lists are sorted and written as their gaps.
+//
+// Parameters:
+//   - words (map[string][]int): The posting list of each word.
+//
+// Returns:
+//   - None
+func (e *ftEncoder) words(words map[string][]int) {
+	var previous string = ""
+	e.uvarint(uint64(len(words)))
+	for _, word := range sortedKeys(words) {
+		var shared int = 0
+		for shared < len(word) && shared < len(previous) && word[shared] == previous[shared] {
+			shared++
+		}
+		e.uvarint(uint64(shared))
+		e.string(word[shared:])
+		previous = word
+
+		// Sort the posting list, which is usually sorted already
+		var postings []int = words[word]
+		if !sort.IntsAreSorted(postings) {
+			postings = append([]int{}, postings...)
+			sort.Ints(postings)
+		}
+		e.uvarint(uint64(len(postings)))
+		e.postings(postings)
+	}
+}
+
+// ftDecoder is a struct that reads the values of the binary encoding of a full-text index. Once a value can't be read, the error is kept
+// and the following values are read as zero values.
+//
+// Fields:
+//   - data ([]byte): The bytes that haven't been read yet.
+//   - err (error): The first error.
+type ftDecoder struct {
+	data []byte
+	err  error
+}
+
+// uvarint is a method of the ftDecoder struct that reads an unsigned varint.
+//
+// Returns:
+//   - uint64: The value.
+func (d *ftDecoder) uvarint() uint64 {
+	if d.err != nil {
+		return 0
+	}
+	v, n := binary.Uvarint(d.data)
+	if n <= 0 {
+		d.err = errors.New("invalid varint")
+		return 0
+	}
+	d.data = d.data[n:]
+	return v
+}
+
+// varint is a method of the ftDecoder struct that reads a signed varint.
+//
+// Returns:
+//   - int: The value.
+func (d *ftDecoder) varint() int {
+	if d.err != nil {
+		return 0
+	}
+	v, n := binary.Varint(d.data)
+	if n <= 0 {
+		d.err = errors.New("invalid varint")
+		return 0
+	}
+	d.data = d.data[n:]
+	return int(v)
+}
+
+// count is a method of the ftDecoder struct that reads the count of a list, which can't be larger than the number of bytes left,
+// since every element takes at least one byte.
+//
+// Returns:
+//   - int: The count.
+func (d *ftDecoder) count() int {
+	var count uint64 = d.uvarint()
+	if count > uint64(len(d.data)) {
+		d.err = errors.New("invalid count")
+		return 0
+	}
+	return int(count)
+}
+
+// string is a method of the ftDecoder struct that reads a string prefixed with its length.
+//
+// Returns:
+//   - string: The string.
+func (d *ftDecoder) string() string {
+	var length int = d.count()
+	if d.err != nil {
+		return ""
+	}
+	var s string = string(d.data[:length])
+	d.data = d.data[length:]
+	return s
+}
+
+// strings is a method of the ftDecoder struct that reads a list of strings prefixed with their count.
+//
+// Returns:
+//   - []string: The strings.
+func (d *ftDecoder) strings() []string {
+	var list []string = make([]string, d.count())
+	for i := range list {
+		list[i] = d.string()
+	}
+	return list
+}
+
+// postings is a method of the ftDecoder struct that reads sorted indices written as the gaps between them.
+//
+// Parameters:
+//   - count (int): The number of indices.
+//
+// Returns:
+//   - []int: The indices.
+func (d *ftDecoder) postings(count int) []int {
+	var (
+		indices  []int = make([]int, count)
+		previous int   = 0
+	)
+	for i := range indices {
+		var gap uint64 = d.uvarint()
+		if gap > math.MaxInt32 || (i > 0 && gap == 0) {
+			d.err = errors.New("invalid posting list")
+			return indices[:0]
+		}
+		previous += int(gap)
+		indices[i] = previous
+	}
+	return indices
+}
+
+// words is a method of the ftDecoder struct that reads words with their posting lists, written by the words method of the ftEncoder struct.
+//
+// Returns:
+//   - map[string][]int: The posting list of each word.
+func (d *ftDecoder) words() map[string][]int {
+	var (
+		count    int              = d.count()
+		words    map[string][]int = make(map[string][]int, count)
+		previous string           = ""
+	)
+	for i := 0; i < count && d.err == nil; i++ {
+		var shared uint64 = d.uvarint()
+		if shared > uint64(len(previous)) {
+			d.err = errors.New("invalid word prefix")
+			break
+		}
+		var word string = previous[:shared] + d.string()
+		if postings := d.postings(d.count()); len(postings) > 0 {
+			words[word] = postings
+		}
+		previous = word
+	}
+	return words
+}
+
+// sortedKeys is a function that returns the keys of a map with string keys in sorted order.
+//
+// Parameters:
+//   - m (map[string]V): The map.
+//
+// Returns:
+//   - []string: The sorted keys.
+func sortedKeys[V any](m map[string]V) []string {
+	var keys []string = make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,236 @@
+package hermes
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// namedIndex is a struct that represents a named full-text index, created with FTCreateIndex.
+//
+// Fields:
+//   - ft (*FullText): The full-text index, which only stores the fields of its schema.
+//   - bm25 (*bm25): The BM25 parameters of the cache, along with the average number of words of the values in this index.
+type namedIndex struct {
+	ft   *FullText
+	bm25 *bm25
+}
+
+// FTCreateIndex is a method of the Cache struct that creates a named full-text index over the values of the cache with its own schema,
+// such as an index of the "title" field next to an index of the "body" field, so different searches can use different schemas over the
+// same values. A search uses the named index by setting the Index of its search parameters to its name.
+// The named index stores the string fields of the schema, and is updated with the default full-text index whenever a value is set or deleted.
+// It uses the token rules, stemmer, stop words, analyzers and weights of the default index at the time it's created, but not its limits,
+// so it never rejects a value. The named indexes aren't written to the snapshots, and are rebuilt from the values when a snapshot is loaded.
+// This method is thread-safe.
+//
+// Parameters:
+//   - name (string): The name of the index.
+//   - schema (map[string]bool): The fields whose string values are stored in the index, which can be dot paths to nested fields.
+//
+// Returns:
+//   - error: An error if the name or schema is empty, the full-text index is not initialized, or an index with the name already exists.
+func (c *Cache) FTCreateIndex(name string, schema map[string]bool) error {
+	if len(name) == 0 {
+		return errors.New("invalid index name")
+	}
+
+	// Copy the fields of the schema
+	var fields map[string]bool = make(map[string]bool, len(schema))
+	for field, ok := range schema {
+		if ok {
+			fields[field] = true
+		}
+	}
+	if len(fields) == 0 {
+		return errors.New("invalid index schema")
+	}
+
+	// Lock the mutex
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	// Check if the ft is initialized, and the name isn't used
+	if c.ft == nil {
+		return errors.New("full text not initialized")
+	} else if _, ok := c.ftIndexes[name]; ok {
+		return fmt.Errorf("full-text index %s already exists", name)
+	}
+
+	// Build the index from the current values
+	var ft *FullText = c.ft.empty()
+	ft.schema = fields
+	ft.maxSize, ft.maxBytes = Unlimited, Unlimited
+	c.namedBuild(ft)
+	if c.ftIndexes == nil {
+		c.ftIndexes = make(map[string]*namedIndex)
+	}
+	c.ftIndexes[name] = &namedIndex{ft: ft, bm25: &bm25{k1: c.bm25.k1, b: c.bm25.b}}
+	c.generation++
+	return nil
+}
+
+// FTDropIndex is a method of the Cache struct that removes a named full-text index created with FTCreateIndex.
+// This method is thread-safe.
+//
+// Parameters:
+//   - name (string): The name of the index.
+//
+// Returns:
+//   - error: An error if the index doesn't exist.
+func (c *Cache) FTDropIndex(name string) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	// Verify that the index exists
+	if _, ok := c.ftIndexes[name]; !ok {
+		return fmt.Errorf("full-text index %s does not exist", name)
+	}
+	delete(c.ftIndexes, name)
+	c.generation++
+	return nil
+}
+
+// FTIndexes is a method of the Cache struct that returns the schema of each named full-text index created with FTCreateIndex.
+// This method is thread-safe.
+//
+// Returns:
+//   - map[string][]string: The sorted fields of the schema of each index, by name.
+func (c *Cache) FTIndexes() map[string][]string {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	// Copy the schemas
+	var indexes map[string][]string = make(map[string][]string, len(c.ftIndexes))
+	for name, idx := range c.ftIndexes {
+		var fields []string = make([]string, 0, len(idx.ft.schema))
+		for field := range idx.ft.schema {
+			fields = append(fields, field)
+		}
+		sort.Strings(fields)
+		indexes[name] = fields
+	}
+	return indexes
+}
+
+// ftView is a method of the Cache struct that returns the cache to run a search on, which uses the named full-text index of the search
+// instead of the default one. The returned cache shares everything else with the cache, and must only be used while its lock is held.
+// This method is not thread-safe, and should only be called from an exported function.
+//
+// Parameters:
+//   - name (string): The name of the index, or an empty string for the default index.
+//
+// Returns:
+//   - *Cache: The cache to search.
+//   - error: An error if the index doesn't exist.
+func (c *Cache) ftView(name string) (*Cache, error) {
+	if len(name) == 0 {
+		return c, nil
+	}
+	idx, ok := c.ftIndexes[name]
+	if !ok {
+		return nil, fmt.Errorf("full-text index %s does not exist", name)
+	}
+	var view Cache = *c
+	view.ft, view.bm25 = idx.ft, idx.bm25
+	return &view, nil
+}
+
+// namedBuild is a method of the Cache struct that stores the fields of the schema of an empty named full-text index for every value of the cache.
+// This method is not thread-safe, and should only be called from an exported function.
+//
+// Parameters:
+//   - ft (*FullText): The empty named full-text index.
+//
+// Returns:
+//   - None
+func (c *Cache) namedBuild(ft *FullText) {
+	var (
+		ts      *TempStorage = NewTempStorage(ft)
+		entries []ftEntry    = []ftEntry{}
+	)
+	for key, value := range c.data {
+		for _, entry := range ft.schemaEntries(c.expand(value)) {
+			entries = append(entries, ts.entry(key, entry.field, entry.value))
+			ft.fields[key] = append(ft.fields[key], entry.field)
+		}
+	}
+
+	// The named indexes are unlimited, so the values can't be rejected
+	_ = ts.insertAll(ft, entries)
+	ts.cleanSingleArrays()
+	ts.updateFullText(ft)
+}
+
+// namedRebuild is a method of the Cache struct that rebuilds every named full-text index from the values of the cache,
+// once the values were replaced without being set one by one.
+// This method is not thread-safe, and should only be called from an exported function.
+//
+// Returns:
+//   - None
+func (c *Cache) namedRebuild() {
+	for _, idx := range c.ftIndexes {
+		var ft *FullText = idx.ft.empty()
+		c.namedBuild(ft)
+		idx.ft = ft
+	}
+}
+
+// namedSet is a method of the Cache struct that stores the fields of a value that was set in every named full-text index.
+// This method is not thread-safe, and should only be called from an exported function.
+//
+// Parameters:
+//   - key (string): The key of the value.
+//   - value (map[string]any): The value, whose full-text fields are plain strings.
+//
+// Returns:
+//   - None
+func (c *Cache) namedSet(key string, value map[string]any) {
+	for _, idx := range c.ftIndexes {
+		var ts *TempStorage = NewTempStorage(idx.ft)
+		for _, entry := range idx.ft.schemaEntries(value) {
+			// The named indexes are unlimited, so the value can't be rejected
+			_ = ts.insert(idx.ft, key, entry.field, entry.value)
+			idx.ft.fields[key] = append(idx.ft.fields[key], entry.field)
+		}
+		ts.cleanInserted()
+		ts.updateFullText(idx.ft)
+	}
+}
+
+// namedDelete is a method of the Cache struct that removes a key from every named full-text index.
+// This method is not thread-safe, and should only be called from an exported function.
+//
+// Parameters:
+//   - key (string): The key.
+//   - value (map[string]any): The expanded value of the key.
+//
+// Returns:
+//   - None
+func (c *Cache) namedDelete(key string, value map[string]any) {
+	for _, idx := range c.ftIndexes {
+		idx.ft.removeKey(key, idx.ft.keyWords(key, value))
+	}
+}
+
+// schemaEntries is a method of the FullText struct that returns the field values of a cache value that are in the schema of the index.
+//
+// Parameters:
+//   - value (map[string]any): The expanded value.
+//
+// Returns:
+//   - []ftEntry: The field values, whose indices are not set.
+func (ft *FullText) schemaEntries(value map[string]any) []ftEntry {
+	var entries []ftEntry = []ftEntry{}
+	for field, v := range value {
+		if !ft.schema[field] {
+			continue
+		} else if ftv := ft.value(field, v); len(ftv) > 0 {
+			entries = append(entries, ftEntry{field: field, value: ftv})
+		}
+	}
+	for field, ftv := range ft.nestedValues(value) {
+		entries = append(entries, ftEntry{field: field, value: ftv})
+	}
+	return entries
+}
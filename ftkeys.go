@@ -0,0 +1,66 @@
+package hermes
+
+import (
+	"errors"
+	"strings"
+
+	utils "github.com/realTristan/hermes/utils"
+)
+
+// FTKeysForWord is a method of the Cache struct that returns the keys in the posting list of the provided word,
+// along with the fields of each key's value that contain the word.
+// This is useful for debugging the contents of the full-text index.
+// This method is thread-safe.
+//
+// Parameters:
+//   - word (string): The word to look up in the full-text index.
+//
+// Returns:
+//   - map[string][]string: A map of the keys that contain the word to the fields that the word was found in.
+//   - error: An error if the full-text index is not initialized.
+func (c *Cache) FTKeysForWord(word string) (map[string][]string, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	// Check if the ft is initialized
+	if c.ft == nil {
+		return nil, errors.New("full-text is not initialized")
+	}
+
+	// Look up the keys for the word
+	return c.ftKeysForWord(strings.ToLower(word)), nil
+}
+
+// ftKeysForWord is a method of the Cache struct that returns the keys in the posting list of the provided word,
+// along with the fields of each key's value that contain the word.
+// This method is not thread-safe, and should only be called from an exported function.
+//
+// Parameters:
+//   - word (string): The lowercase word to look up in the full-text index.
+//
+// Returns:
+//   - map[string][]string: A map of the keys that contain the word to the fields that the word was found in.
+func (c *Cache) ftKeysForWord(word string) map[string][]string {
+	var result map[string][]string = map[string][]string{}
+
+	// Get the posting list of the word
+	var v, ok = c.ft.storage[word]
+	if !ok {
+		return result
+	}
+
+	// Find the fields that contain the word for each key
+	for _, index := range postingsOf(v) {
+		var key string = c.ft.indices[index]
+		var fields []string = []string{}
+		for field, value := range c.data[key] {
+			if v, ok := fieldString(value); ok && utils.SliceContains(c.ft.fieldWords(field, v), word) {
+				fields = append(fields, field)
+			}
+		}
+		result[key] = fields
+	}
+
+	// Return the result
+	return result
+}
@@ -0,0 +1,139 @@
+package hermes
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	utils "github.com/realTristan/hermes/utils"
+)
+
+// FTSave is a method of the Cache struct that writes the full-text index, without the cache data, to the provided file,
+// so it can be restored with FTLoad when the data is loaded from another source.
+// The file is written to a temporary file first and then renamed, so an existing file is never left half-written.
+// This method is thread-safe.
+//
+// Parameters:
+//   - path (string): The path of the file.
+//
+// Returns:
+//   - error: An error if the full-text index is not initialized, or the file could not be encoded or written.
+func (c *Cache) FTSave(path string) error {
+	c.mutex.RLock()
+	if c.ft == nil {
+		c.mutex.RUnlock()
+		return errors.New("full-text is not initialized")
+	}
+	data, err := c.codec.encode(c.ft.snapshot())
+	c.mutex.RUnlock()
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(path, data)
+}
+
+// FTLoad is a method of the Cache struct that initializes the full-text index from a file written by FTSave, or with the binary encoding
+// of FTWriteTo, instead of rebuilding it from the cache data. The cache data must be set before the index is loaded, and every key in the index must
+// exist in the cache. Values that are not in the index, such as the ones set with WithFT after the index was saved, are indexed.
+// This method is thread-safe.
+//
+// Parameters:
+//   - path (string): The path of the file.
+//
+// Returns:
+//   - error: An error if the full-text index is already initialized, the file could not be read or decoded,
+//     the index references a key that is not in the cache, or the full-text storage limit is reached.
+func (c *Cache) FTLoad(path string) error {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return err
+	}
+
+	// Decode the binary encoding
+	if bytes.HasPrefix(data, ftBinaryMagic) {
+		_, err := c.FTReadFrom(bytes.NewReader(data))
+		return err
+	}
+
+	// Decode the snapshot
+	var s ftSnapshot
+	if err := decodeSnapshot(data, &s); err != nil {
+		return err
+	} else if err := s.verify(); err != nil {
+		return err
+	}
+	ft, err := s.fullText()
+	if err != nil {
+		return err
+	}
+	return c.ftLoad(ft)
+}
+
+// ftLoad is a method of the Cache struct that initializes the full-text index with a decoded index, once it's verified against the cache data.
+// The values that are not in the index are indexed.
+// This method is thread-safe.
+//
+// Parameters:
+//   - ft (*FullText): The decoded full-text index.
+//
+// Returns:
+//   - error: An error if the full-text index is already initialized, the index references a key that is not in the cache,
+//     or the full-text storage limit is reached.
+func (c *Cache) ftLoad(ft *FullText) error {
+	// Lock the mutex
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	// Verify that the ft is not initialized
+	if c.ft != nil {
+		return errors.New("full-text already initialized")
+	}
+
+	// Verify that the index matches the cache data
+	for _, key := range ft.indices {
+		if _, ok := c.data[key]; !ok {
+			return fmt.Errorf("full-text index references key %s that is not in the cache", key)
+		}
+	}
+
+	// Build the field index of the older files, then index the values that are not in the index
+	c.buildFieldIndex(ft)
+	var ts *TempStorage = NewTempStorage(ft)
+	for key, value := range c.data {
+		for field, v := range value {
+			var ftv string = ft.value(field, v)
+			if len(ftv) == 0 || utils.SliceContains(ft.fields[key], field) {
+				continue
+			} else if err := ts.insert(ft, key, field, ftv); err != nil {
+				return err
+			}
+			ft.fields[key] = append(ft.fields[key], field)
+		}
+		for field, ftv := range ft.nestedValues(value) {
+			if utils.SliceContains(ft.fields[key], field) {
+				continue
+			} else if err := ts.insert(ft, key, field, ftv); err != nil {
+				return err
+			}
+			ft.fields[key] = append(ft.fields[key], field)
+		}
+	}
+	ts.cleanSingleArrays()
+	ts.updateFullText(ft)
+
+	// Store the full-text values as plain strings, like when they're set, once the index can't fail anymore
+	for key, fields := range ft.fields {
+		for _, field := range fields {
+			if ftv := WFTGetValue(c.data[key][field]); len(ftv) > 0 {
+				c.data[key][field] = ftv
+			}
+		}
+	}
+
+	// Update the cache full-text
+	c.ft = ft
+	c.generation++
+	return nil
+}
@@ -0,0 +1,110 @@
+package hermes
+
+import (
+	"errors"
+
+	utils "github.com/realTristan/hermes/utils"
+)
+
+// The number of largest posting lists returned by FTStats
+const ftStatsLargest int = 10
+
+// FTStats is a struct that contains the statistics of the full-text index, which help tune its limits with real data.
+//
+// Fields:
+//   - Words (int): The number of words in the index, which is limited by the max size.
+//   - Keys (int): The number of keys whose values are stored in the index.
+//   - Postings (int): The total number of keys in the posting lists of the words.
+//   - AveragePostings (float64): The average number of keys in the posting list of a word, or 0 if the index is empty.
+//   - Bytes (int): The size of the index in bytes, which is limited by the max bytes.
+//   - MaxSize (int): The maximum number of words in the index, or Unlimited.
+//   - MaxBytes (int): The maximum size of the index in bytes, or Unlimited.
+//   - LargestPostings ([]WordPostings): The words with the most keys in their posting lists, from the largest.
+type FTStats struct {
+	Words           int            `json:"words"`
+	Keys            int            `json:"keys"`
+	Postings        int            `json:"postings"`
+	AveragePostings float64        `json:"average_postings"`
+	Bytes           int            `json:"bytes"`
+	MaxSize         int            `json:"max_size"`
+	MaxBytes        int            `json:"max_bytes"`
+	LargestPostings []WordPostings `json:"largest_postings"`
+}
+
+// WordPostings is a struct that contains the size of the posting list of a word of the full-text index.
+//
+// Fields:
+//   - Word (string): The word.
+//   - Postings (int): The number of keys in the posting list of the word.
+type WordPostings struct {
+	Word     string `json:"word"`
+	Postings int    `json:"postings"`
+}
+
+// FTStats is a method of the Cache struct that returns the statistics of the full-text index: the number of words and postings,
+// the size of the index compared to its limits, and the words with the largest posting lists.
+// Computing the size of the full-text index requires encoding it, so this method shouldn't be called in a hot path.
+// This method is thread-safe.
+//
+// Returns:
+//   - FTStats: The statistics of the full-text index.
+//   - error: An error if the full-text index is not initialized or its size could not be computed.
+func (c *Cache) FTStats() (FTStats, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	// Check if the ft is initialized
+	if c.ft == nil {
+		return FTStats{}, errors.New("full text not initialized")
+	}
+
+	// Get the size of the storage
+	size, err := utils.Size(c.ft.storage)
+	if err != nil {
+		return FTStats{}, err
+	}
+	var s FTStats = FTStats{
+		Words:           len(c.ft.storage),
+		Keys:            len(c.ft.indices),
+		Bytes:           size,
+		MaxSize:         c.ft.maxSize,
+		MaxBytes:        c.ft.maxBytes,
+		LargestPostings: []WordPostings{},
+	}
+
+	// Count the postings, and keep the largest posting lists
+	for word, v := range c.ft.storage {
+		var postings int = postingsLen(v)
+		s.Postings += postings
+		s.LargestPostings = largestPostings(s.LargestPostings, WordPostings{Word: word, Postings: postings})
+	}
+	if s.Words > 0 {
+		s.AveragePostings = float64(s.Postings) / float64(s.Words)
+	}
+	return s, nil
+}
+
+// largestPostings is a function that inserts the posting list of a word in the largest posting lists, if it's one of them.
+//
+// Parameters:
+//   - largest ([]WordPostings): The largest posting lists, sorted from the largest, and then by word.
+//   - wp (WordPostings): The posting list of a word.
+//
+// Returns:
+//   - []WordPostings: The largest posting lists, with at most ftStatsLargest of them.
+func largestPostings(largest []WordPostings, wp WordPostings) []WordPostings {
+	var i int = len(largest)
+	for i > 0 && (largest[i-1].Postings < wp.Postings || (largest[i-1].Postings == wp.Postings && largest[i-1].Word > wp.Word)) {
+		i--
+	}
+	if i >= ftStatsLargest {
+		return largest
+	}
+	largest = append(largest, WordPostings{})
+	copy(largest[i+1:], largest[i:])
+	largest[i] = wp
+	if len(largest) > ftStatsLargest {
+		largest = largest[:ftStatsLargest]
+	}
+	return largest
+}
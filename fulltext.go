@@ -10,19 +10,55 @@ import (
 // The index is used to enable full-text search on the data in the cache.
 //
 // Fields:
-//   - storage (map[string]any): A map that stores the indices of the entries in the cache that contain each word in the full-text index. The keys of the map are strings that represent the words in the index, and the values are slices of integers that represent the indices of the entries in the cache that contain the word.
+//   - storage (map[string]any): A map that stores the indices of the entries in the cache that contain each word in the full-text index. The keys of the map are strings that represent the words in the index, and the values are roaring bitmaps of the indices of the entries in the cache that contain the word, or the index itself if only one entry contains it.
 //   - indices (map[int]string): A map that stores the words in the full-text index. The keys of the map are integers that represent the indices of the words in the index, and the values are strings that represent the words.
+//   - keys (map[string]int): The index of each cache key in the full-text index, the reverse of indices, so a key can be removed without scanning the index.
 //   - index (int): An integer that represents the current index of the full-text index. This is used to assign unique indices to new words as they are added to the index.
 //   - maxSize (int): An integer that represents the maximum number of words that can be stored in the full-text index.
 //   - maxBytes (int): An integer that represents the maximum size of the text that can be stored in the full-text index, in bytes.
 //   - minWordLength (int): An integer that represents the minimum length of a word that can be stored in the full-text index.
+//   - maxWordLength (int): The maximum length of a word that can be stored in the full-text index, or 0 if there's no maximum.
+//   - skipNumbers (bool): Whether the numeric words, such as "2024" and "3.14", are not stored in the full-text index.
+//   - caseSensitive (bool): Whether the words of a strict search must have the same case in the values as in the query.
+//   - tokenFilter (TokenFilter): The function that decides which other words are stored in the full-text index. May be nil.
+//   - schema (map[string]bool): The fields whose string values are stored in the full-text index without having to be wrapped with WithFT. May be nil.
+//   - fields (map[string][]string): The fields of each cache key whose values are stored in the full-text index.
+//   - fieldStorage (fieldIndex): The words of each field, with the sorted indices of the keys whose value of the field contains them.
+//   - tokenRules (map[string]TokenRule): The token rule of each field that doesn't use TokenDefault. May be nil.
+//   - stemmer (Stemmer): The algorithm that reduces the stored and searched words to their stem.
+//   - stopWords (map[string]bool): The words that are not stored in the full-text index. May be nil.
+//   - analyzer (Analyzer): The analyzer of the fields that don't have their own analyzer.
+//   - analyzers (map[string]Analyzer): The analyzer of each field that has its own analyzer. May be nil.
+//   - ngrams (*ngramIndex): The words that contain each character n-gram. If nil, substring searches scan every word.
+//   - suffixes (*suffixIndex): The words in a suffix array. If nil, substring searches use the n-gram index.
+//   - partitions (*wordPartitions): The words split into partitions that are matched concurrently. If nil, the words are scanned by the search.
+//   - vocab (wordTrie): The stored words, in a radix trie for the prefix, wildcard and fuzzy lookups.
+//   - weights (map[string]float64): The weight of each field in the scores of the ranked searches that isn't 1. May be nil.
 type FullText struct {
-	storage       map[string]any // either []int or int
+	storage       map[string]any // either *roaring or int
 	indices       map[int]string
+	keys          map[string]int
 	index         int
 	maxSize       int
 	maxBytes      int
 	minWordLength int
+	maxWordLength int
+	skipNumbers   bool
+	caseSensitive bool
+	tokenFilter   TokenFilter
+	schema        map[string]bool
+	fields        map[string][]string
+	fieldStorage  fieldIndex
+	tokenRules    map[string]TokenRule
+	stemmer       Stemmer
+	stopWords     map[string]bool
+	analyzer      Analyzer
+	analyzers     map[string]Analyzer
+	ngrams        *ngramIndex
+	suffixes      *suffixIndex
+	partitions    *wordPartitions
+	vocab         wordTrie
+	weights       map[string]float64
 }
 
 // FTIsInitialized is a method of the Cache struct that returns a boolean value indicating whether the full-text index is initialized.
@@ -47,7 +83,7 @@ func (c *Cache) FTIsInitialized() bool {
 // This method is thread-safe.
 //
 // Parameters:
-//   - maxBytes (int): An integer that represents the new maximum size of the full-text index, in bytes.
+//   - maxBytes (int): An integer that represents the new maximum size of the full-text index, in bytes, or Unlimited to remove the limit.
 //
 // Returns:
 //   - error: An error object. If no error occurs, this will be nil.
@@ -63,17 +99,22 @@ func (c *Cache) FTSetMaxBytes(maxBytes int) error {
 	// Check if the current size of the storage is the same as the new max size
 	if c.ft.maxBytes == maxBytes {
 		return nil
+	} else if maxBytes < Unlimited {
+		return errors.New("invalid max bytes")
 	}
 
 	// Check if the current size of the storage is greater than the new max size
-	if i, err := utils.Size(c.ft.storage); err != nil {
-		return err
-	} else if i > maxBytes {
-		return errors.New("the current size of the full-text storage is greater than the new max size")
+	if limited(maxBytes) {
+		if i, err := utils.Size(c.ft.storage); err != nil {
+			return err
+		} else if i > maxBytes {
+			return errors.New("the current size of the full-text storage is greater than the new max size")
+		}
 	}
 
 	// Set the maxBytes field
 	c.ft.maxBytes = maxBytes
+	c.limitCheck()
 
 	// Return no error
 	return nil
@@ -86,7 +127,7 @@ func (c *Cache) FTSetMaxBytes(maxBytes int) error {
 // This method is thread-safe.
 //
 // Parameters:
-//   - maxSize (int): An integer that represents the new maximum number of words in the full-text index.
+//   - maxSize (int): An integer that represents the new maximum number of words in the full-text index, or Unlimited to remove the limit.
 //
 // Returns:
 //   - error: An error object. If no error occurs, this will be nil.
@@ -102,20 +143,106 @@ func (c *Cache) FTSetMaxSize(maxSize int) error {
 	// Check if the current size of the storage is the same as the new max size
 	if maxSize == c.ft.maxSize {
 		return nil
+	} else if maxSize < Unlimited {
+		return errors.New("invalid max size")
 	}
 
 	// Check if the current size of the storage is greater than the new max size
-	if len(c.ft.storage) > maxSize {
+	if limited(maxSize) && len(c.ft.storage) > maxSize {
 		return errors.New("the current size of the full-text storage is greater than the new max size")
 	}
 
 	// Set the maxSize field
 	c.ft.maxSize = maxSize
+	c.limitCheck()
 
 	// Return no error
 	return nil
 }
 
+// empty is a method of the FullText struct that returns a new empty full-text index with the same configuration.
+//
+// Returns:
+//   - *FullText: The empty full-text index.
+func (ft *FullText) empty() *FullText {
+	return &FullText{
+		storage:       make(map[string]any),
+		indices:       make(map[int]string),
+		keys:          make(map[string]int),
+		index:         0,
+		maxSize:       ft.maxSize,
+		maxBytes:      ft.maxBytes,
+		minWordLength: ft.minWordLength,
+		maxWordLength: ft.maxWordLength,
+		skipNumbers:   ft.skipNumbers,
+		caseSensitive: ft.caseSensitive,
+		tokenFilter:   ft.tokenFilter,
+		schema:        ft.schema,
+		fields:        make(map[string][]string),
+		fieldStorage:  make(fieldIndex),
+		tokenRules:    ft.tokenRules,
+		stemmer:       ft.stemmer,
+		stopWords:     ft.stopWords,
+		analyzer:      ft.analyzer,
+		analyzers:     ft.analyzers,
+		ngrams:        newNgramIndex(ft.ngrams.size()),
+		suffixes:      newSuffixIndex(ft.suffixes.enabled()),
+		partitions:    newWordPartitions(ft.partitions.count()),
+		weights:       ft.weights,
+	}
+}
+
+// ftReindex is a method of the Cache struct that rebuilds the full-text index with ftRebuild for a setting, unless the cache is frozen.
+// This method is not thread-safe, and should only be called from an exported function.
+//
+// Parameters:
+//   - op (string): The name of the exported method that changes the setting.
+//   - ft (*FullText): The empty full-text index.
+//
+// Returns:
+//   - error: A *FrozenError if the cache is frozen, or an error if the full-text storage limit or byte-size limit is reached.
+func (c *Cache) ftReindex(op string, ft *FullText) error {
+	if err := c.writable(op); err != nil {
+		return err
+	}
+	return c.ftRebuild(ft)
+}
+
+// ftRebuild is a method of the Cache struct that stores the values of the current full-text index in an empty full-text index
+// and replaces the current index with it. The values that were set with WithFT are stored as plain strings once they're indexed,
+// so the fields to store are taken from the current index. If the values could not be stored, the current index is kept.
+// This method is not thread-safe, and should only be called from an exported function.
+//
+// Parameters:
+//   - ft (*FullText): The empty full-text index.
+//
+// Returns:
+//   - error: An error if the full-text storage limit or byte-size limit is reached.
+func (c *Cache) ftRebuild(ft *FullText) error {
+	var (
+		ts      *TempStorage = NewTempStorage(ft)
+		entries []ftEntry    = []ftEntry{}
+	)
+	for key, fields := range c.ft.fields {
+		for _, field := range fields {
+			if v, ok := fieldString(pathValue(c.data[key], field)); ok {
+				entries = append(entries, ts.entry(key, field, v))
+				ft.fields[key] = append(ft.fields[key], field)
+			}
+		}
+	}
+	if err := ts.insertAll(ft, entries); err != nil {
+		return err
+	}
+
+	// Replace the full-text index
+	ts.cleanSingleArrays()
+	ts.updateFullText(ft)
+	c.ft = ft
+	c.generation++
+	return nil
+}
+
 // FTSetMinWordLength is a method of the Cache struct that sets the minimum word length for the full-text search.
 // Parameters:
 //   - minWordLength (int): An integer representing the minimum word length.
@@ -134,28 +261,54 @@ func (c *Cache) FTSetMinWordLength(minWordLength int) error {
 	// If they're the same
 	if minWordLength == c.ft.minWordLength {
 		return nil
+	} else if minWordLength < 0 {
+		return errors.New("invalid min word length")
+	} else if c.ft.maxWordLength > 0 && minWordLength > c.ft.maxWordLength {
+		return errors.New("min word length is greater than the max word length")
 	}
 
-	// If the new min word length is greater than the max
-	// word length, reset the ft
-	if minWordLength > c.ft.minWordLength {
-		return c.ftInit(c.ft.maxBytes, c.ft.maxSize, minWordLength)
-	}
+	// Rebuild the full-text index, since a shorter minimum word length stores words that were left out,
+	// and a longer one removes words
+	var ft *FullText = c.ft.empty()
+	ft.minWordLength = minWordLength
+	return c.ftReindex("FTSetMinWordLength", ft)
+}
+
+// FTSetMaxWordLength is a method of the Cache struct that sets the maximum word length for the full-text search, so long tokens
+// such as base64 blobs and hashes don't use up the maximum number of words of the index. The longer words of a query are ignored
+// when the index is looked up, but a query with several words still only matches the values that contain the whole phrase.
+// The full-text index is rebuilt, so the longer words are also removed from the values that are already stored.
+// This method is thread-safe.
+//
+// Parameters:
+//   - maxWordLength (int): The maximum length of a word, in bytes, or 0 to store the words of any length.
+//
+// Returns:
+//   - error: An error if the full-text search is not initialized, if the maximum word length is negative or less than the minimum word length,
+//     or if the index could not be rebuilt.
+func (c *Cache) FTSetMaxWordLength(maxWordLength int) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
 
-	// Set the minWordLength field
-	c.ft.minWordLength = minWordLength
+	// Check if the ft is initialized
+	if c.ft == nil {
+		return errors.New("full text not initialized")
+	}
 
-	// Iterate over the ft storage
-	for word := range c.ft.storage {
-		// Check if the word length is less than the min word length
-		if len(word) < minWordLength {
-			// Delete the word from the ft storage
-			delete(c.ft.storage, word)
-		}
+	// If they're the same
+	if maxWordLength == c.ft.maxWordLength {
+		return nil
+	} else if maxWordLength < 0 {
+		return errors.New("invalid max word length")
+	} else if maxWordLength > 0 && maxWordLength < c.ft.minWordLength {
+		return errors.New("max word length is less than the min word length")
 	}
 
-	// Return no error
-	return nil
+	// Rebuild the full-text index, since a longer maximum word length stores words that were left out,
+	// and a shorter one removes words
+	var ft *FullText = c.ft.empty()
+	ft.maxWordLength = maxWordLength
+	return c.ftReindex("FTSetMaxWordLength", ft)
 }
 
 // FTStorage is a method of the Cache struct that returns a copy of the full-text index storage map.
@@ -164,7 +317,7 @@ func (c *Cache) FTSetMinWordLength(minWordLength int) error {
 // This method is thread-safe.
 //
 // Returns:
-//   - map[string][]int: A copy of the full-text index storage map.
+//   - map[string]any: A copy of the full-text index storage map, with the indices of each word as an int or a slice of ints.
 //   - error: An error object. If no error occurs, this will be nil.
 func (c *Cache) FTStorage() (map[string]any, error) {
 	c.mutex.RLock()
@@ -175,8 +328,15 @@ func (c *Cache) FTStorage() (map[string]any, error) {
 		return nil, errors.New("full text is not initialized")
 	}
 
-	// Copy the storage map
-	var copy map[string]any = c.ft.storage
+	// Copy the storage map, with the bitmaps as slices of indices
+	var copy map[string]any = make(map[string]any, len(c.ft.storage))
+	for word, v := range c.ft.storage {
+		if postings, ok := v.(*roaring); ok {
+			copy[word] = postings.slice()
+		} else {
+			copy[word] = v
+		}
+	}
 
 	// Return the copy
 	return copy, nil
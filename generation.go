@@ -0,0 +1,14 @@
+package hermes
+
+// Generation is a method of the Cache struct that returns the generation number of the cache.
+// The generation number is incremented by every write to the cache data or full-text index, so two
+// reads that observe the same generation number are guaranteed to observe the same data.
+// This method is thread-safe.
+//
+// Returns:
+//   - uint64: The generation number of the cache.
+func (c *Cache) Generation() uint64 {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return c.generation
+}
@@ -1,6 +1,8 @@
 package hermes
 
 // Get is a method of the Cache struct that retrieves the value associated with the given key from the cache.
+// The returned map is the one stored in the cache, so it must not be modified. Use GetCopy to get a copy that can be.
+// If a loader was set with SetLoader and the key doesn't exist, the value is loaded. Errors of the loader are ignored, use GetOrLoad to get them.
 // This method is thread-safe.
 //
 // Parameters:
@@ -9,9 +11,8 @@ package hermes
 // Returns:
 //   - A map[string]any representing the value associated with the given key in the cache.
 func (c *Cache) Get(key string) map[string]any {
-	c.mutex.RLock()
-	defer c.mutex.RUnlock()
-	return c.get(key)
+	value, _ := c.GetOrLoad(key)
+	return value
 }
 
 // get is a method of the Cache struct that retrieves the value associated with the given key from the cache.
@@ -23,5 +24,11 @@ func (c *Cache) Get(key string) map[string]any {
 // Returns:
 //   - A map[string]any representing the value associated with the given key in the cache.
 func (c *Cache) get(key string) map[string]any {
-	return c.data[key]
+	if c.expired(key) {
+		c.stats.get(false)
+		return nil
+	}
+	value, ok := c.data[key]
+	c.stats.get(ok)
+	return c.expand(value)
 }
@@ -0,0 +1,75 @@
+package hermes
+
+import (
+	"errors"
+)
+
+// Group is a struct that represents a group of search results that have the same value of the GroupBy field.
+//
+// Fields:
+//   - Value (string): The value of the field, formatted like the values of a secondary index.
+//   - Results ([]map[string]any): The results of the group, in the order they were found.
+type Group struct {
+	Value   string
+	Results []map[string]any
+}
+
+// SearchGroups is a method of the Cache struct that searches for a query like Search, and returns the results grouped by the value of
+// the GroupBy field of the search parameters, e.g. the top 3 products of each brand. The Limit is the maximum number of groups, and
+// the GroupLimit the maximum number of results of each group. The groups are in the order of their first result, and the results
+// that don't have the field are left out. The results are grouped as they're retrieved, so the cache is searched once.
+// This method is thread-safe.
+//
+// Parameters:
+//   - sp (SearchParams): A SearchParams struct containing the search parameters.
+//
+// Returns:
+//   - []Group: The groups of results.
+//   - error: An error if the GroupBy field is empty or the search fails.
+func (c *Cache) SearchGroups(sp SearchParams) ([]Group, error) {
+	if len(sp.GroupBy) == 0 {
+		return []Group{}, errors.New("invalid group by field")
+	}
+
+	// Set the default limits
+	if sp.Limit == 0 {
+		sp.Limit = 10
+	}
+	if sp.GroupLimit == 0 {
+		sp.GroupLimit = 3
+	}
+
+	// Search every matching value
+	var limit int = sp.Limit
+	sp.Limit = c.Length()
+	results, err := c.Search(sp)
+	if err != nil {
+		return []Group{}, err
+	}
+
+	// Group the results
+	var (
+		groups []Group        = []Group{}
+		index  map[string]int = map[string]int{}
+	)
+	for _, value := range results {
+		field := pathValue(value, sp.GroupBy)
+		if field == nil {
+			continue
+		}
+		var v string = fieldValue(field)
+		i, ok := index[v]
+		if !ok {
+			if len(groups) >= limit {
+				continue
+			}
+			i = len(groups)
+			index[v] = i
+			groups = append(groups, Group{Value: v, Results: []map[string]any{}})
+		}
+		if len(groups[i].Results) < sp.GroupLimit {
+			groups[i].Results = append(groups[i].Results, value)
+		}
+	}
+	return groups, nil
+}
@@ -0,0 +1,213 @@
+package hermes
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// Highlight is a struct that represents how the matches of a query are highlighted in the snippets of the results of SearchScored.
+// The text of the fields is not escaped, so the values must be escaped by the caller before being shown as HTML.
+//
+// Fields:
+//   - Pre (string): The marker inserted before each matched word. If empty, it's set to "<em>".
+//   - Post (string): The marker inserted after each matched word. If empty, it's set to "</em>".
+//   - Length (int): The maximum length of a snippet, in bytes, without its markers. Longer fields are cut at the words around their
+//     first match, with "..." marking the cut ends. The first match is never cut, even if it's longer. If 0, the snippets hold the whole fields.
+type Highlight struct {
+	Pre    string
+	Post   string
+	Length int
+}
+
+// highlightSpan is a struct that represents a matched word in the value of a field.
+//
+// Fields:
+//   - start (int): The byte offset of the first character of the word.
+//   - end (int): The byte offset after the last character of the word.
+type highlightSpan struct {
+	start int
+	end   int
+}
+
+// snippets is a method of the FullText struct that returns the highlighted snippets of the full-text fields of a value that match a query.
+//
+// Parameters:
+//   - key (string): The cache key of the value.
+//   - value (map[string]any): The expanded value.
+//   - terms ([]string): The terms of the query.
+//   - sp (SearchParams): The search parameters, which decide how the words of the value match the terms and how they're highlighted.
+//
+// Returns:
+//   - map[string]string: The snippet of each full-text field that contains a match.
+func (ft *FullText) snippets(key string, value map[string]any, terms []string, sp SearchParams) map[string]string {
+	var (
+		h      Highlight         = *sp.Highlight
+		result map[string]string = map[string]string{}
+	)
+	if len(h.Pre) == 0 {
+		h.Pre = "<em>"
+	}
+	if len(h.Post) == 0 {
+		h.Post = "</em>"
+	}
+	for _, field := range ft.fields[key] {
+		v, ok := fieldString(pathValue(value, field))
+		if !ok {
+			continue
+		}
+		if spans := ft.matchSpans(field, v, terms, sp); len(spans) > 0 {
+			result[field] = h.snippet(v, spans)
+		}
+	}
+	return result
+}
+
+// matchSpans is a method of the FullText struct that finds the words of the value of a field that match a term of a query.
+// The value is split by spaces, and each part is split into the stored words with the token rule of the field, so a part matches if one of
+// its stored words matches a term. The sentence punctuation around a matched part is not highlighted.
+//
+// Parameters:
+//   - field (string): The name of the field.
+//   - value (string): The value of the field.
+//   - terms ([]string): The terms of the query.
+//   - sp (SearchParams): The search parameters, which decide how the words match the terms.
+//
+// Returns:
+//   - []highlightSpan: The matched words, in the order of the value.
+func (ft *FullText) matchSpans(field string, value string, terms []string, sp SearchParams) []highlightSpan {
+	var (
+		spans []highlightSpan = []highlightSpan{}
+		start int             = -1
+	)
+	for i := 0; i <= len(value); i++ {
+		if i < len(value) && value[i] != ' ' && value[i] != '\t' && value[i] != '\n' && value[i] != '\r' {
+			if start < 0 {
+				start = i
+			}
+			continue
+		} else if start < 0 {
+			continue
+		}
+
+		// Verify whether a stored word of the part matches a term
+		var part string = value[start:i]
+		if ft.partMatches(field, part, terms, sp) {
+			var trimmed string = strings.TrimLeft(part, sentencePunctuation)
+			var s int = start + len(part) - len(trimmed)
+			trimmed = strings.TrimRight(trimmed, sentencePunctuation)
+			if len(trimmed) == 0 {
+				spans = append(spans, highlightSpan{start: start, end: i})
+			} else {
+				spans = append(spans, highlightSpan{start: s, end: s + len(trimmed)})
+			}
+		}
+		start = -1
+	}
+	return spans
+}
+
+// partMatches is a method of the FullText struct that checks whether a space-separated part of the value of a field holds a word that matches a term.
+//
+// Parameters:
+//   - field (string): The name of the field.
+//   - part (string): The part of the value.
+//   - terms ([]string): The terms of the query.
+//   - sp (SearchParams): The search parameters, which decide how the words match the terms.
+//
+// Returns:
+//   - bool: true if one of the stored words of the part matches a term, false otherwise.
+func (ft *FullText) partMatches(field string, part string, terms []string, sp SearchParams) bool {
+	for _, word := range ft.fieldWords(field, part) {
+		for _, term := range terms {
+			if sp.matchesWord(word, term) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// snippet is a method of the Highlight struct that wraps the matched words of a value with the markers, cutting the value to the snippet length.
+//
+// Parameters:
+//   - value (string): The value of the field.
+//   - spans ([]highlightSpan): The matched words, in the order of the value.
+//
+// Returns:
+//   - string: The highlighted snippet.
+func (h Highlight) snippet(value string, spans []highlightSpan) string {
+	var from, to int = 0, len(value)
+	if h.Length > 0 && len(value) > h.Length {
+		from, to = snippetBounds(value, spans[0], h.Length)
+	}
+
+	// Wrap the matches within the snippet with the markers
+	var b strings.Builder
+	if from > 0 {
+		b.WriteString("...")
+	}
+	var offset int = from
+	for _, span := range spans {
+		if span.start < from || span.end > to {
+			continue
+		}
+		b.WriteString(value[offset:span.start])
+		b.WriteString(h.Pre)
+		b.WriteString(value[span.start:span.end])
+		b.WriteString(h.Post)
+		offset = span.end
+	}
+	b.WriteString(value[offset:to])
+	if to < len(value) {
+		b.WriteString("...")
+	}
+	return b.String()
+}
+
+// snippetBounds is a function that returns the bounds of a window of a value around its first match, cut at the spaces between words when possible.
+//
+// Parameters:
+//   - value (string): The value of the field.
+//   - first (highlightSpan): The first matched word.
+//   - length (int): The maximum length of the window, in bytes.
+//
+// Returns:
+//   - int: The byte offset of the start of the window.
+//   - int: The byte offset of the end of the window.
+func snippetBounds(value string, first highlightSpan, length int) (int, int) {
+	// Center the window on the first match
+	var from int = first.start - (length-(first.end-first.start))/2
+	if from < 0 {
+		from = 0
+	} else if from > first.start {
+		from = first.start
+	}
+	var to int = from + length
+	if to > len(value) {
+		to = len(value)
+		from = to - length
+	} else if to < first.end {
+		to = first.end
+	}
+
+	// Cut the window at the spaces around it, without cutting the first match
+	if from > 0 && value[from-1] != ' ' {
+		if i := strings.IndexByte(value[from:], ' '); i >= 0 && from+i < first.start {
+			from += i + 1
+		}
+	}
+	if to < len(value) && value[to] != ' ' {
+		if i := strings.LastIndexByte(value[from:to], ' '); i >= 0 && from+i >= first.end {
+			to = from + i
+		}
+	}
+
+	// Never cut a character
+	for from > 0 && !utf8.RuneStart(value[from]) {
+		from--
+	}
+	for to < len(value) && !utf8.RuneStart(value[to]) {
+		to--
+	}
+	return from, to
+}
@@ -0,0 +1,146 @@
+package hermes
+
+import (
+	"errors"
+	"time"
+)
+
+// Revision is a struct that represents a past value of a key.
+//
+// Fields:
+//   - Version (uint64): The version of the value. It's 0 if the key was removed.
+//   - Time (time.Time): The time at which the value was set or the key was removed.
+//   - Value (map[string]any): A copy of the value. It's nil if the key was removed.
+//   - Deleted (bool): Whether the key was removed.
+type Revision struct {
+	Version uint64         `json:"version"`
+	Time    time.Time      `json:"time"`
+	Value   map[string]any `json:"value"`
+	Deleted bool           `json:"deleted"`
+}
+
+// history is a struct that holds the recent revisions of each key.
+//
+// Fields:
+//   - limit (int): The number of revisions to retain per key.
+//   - revisions (map[string][]Revision): The revisions of each key, oldest first.
+type history struct {
+	limit     int
+	revisions map[string][]Revision
+}
+
+// EnableHistory is a method of the Cache struct that starts retaining the last revisions of each key, which can be read with History.
+// Every set stores a copy of the value, and every removal stores a deleted revision, so the memory usage grows with the limit.
+// The revisions of removed keys are retained until the cache is cleaned. Revisions are not included in snapshots.
+// If history is already enabled, the limit is updated and the existing revisions are trimmed to it.
+// This method is thread-safe.
+//
+// Parameters:
+//   - limit (int): The number of revisions to retain per key.
+//
+// Returns:
+//   - error: An error if the limit is invalid.
+func (c *Cache) EnableHistory(limit int) error {
+	if limit <= 0 {
+		return errors.New("invalid limit")
+	}
+
+	// Lock the mutex
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	// Start retaining revisions, or update the limit
+	if c.history == nil {
+		c.history = &history{revisions: make(map[string][]Revision)}
+	}
+	c.history.limit = limit
+	for key, revisions := range c.history.revisions {
+		if len(revisions) > limit {
+			c.history.revisions[key] = append([]Revision{}, revisions[len(revisions)-limit:]...)
+		}
+	}
+
+	// Return no error
+	return nil
+}
+
+// DisableHistory is a method of the Cache struct that stops retaining revisions and discards the retained ones.
+// This method is thread-safe.
+//
+// Returns:
+//   - None
+func (c *Cache) DisableHistory() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.history = nil
+}
+
+// History is a method of the Cache struct that returns the retained revisions of a key, oldest first.
+// This method is thread-safe.
+//
+// Parameters:
+//   - key (string): The key to get the revisions of.
+//
+// Returns:
+//   - []Revision: A copy of the revisions of the key.
+//   - error: An error if history is not enabled.
+func (c *Cache) History(key string) ([]Revision, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	// Verify that history is enabled
+	if c.history == nil {
+		return []Revision{}, errors.New("history is not enabled")
+	}
+
+	// Copy the revisions
+	var revisions []Revision = make([]Revision, 0, len(c.history.revisions[key]))
+	for _, r := range c.history.revisions[key] {
+		r.Value = copyMap(r.Value)
+		revisions = append(revisions, r)
+	}
+	return revisions, nil
+}
+
+// historyAdd is a method of the Cache struct that records a revision of a key if history is enabled.
+// This method is not thread-safe, and should only be called from an exported function.
+//
+// Parameters:
+//   - key (string): The key that was set or removed.
+//   - value (map[string]any): The new value of the key, or nil if the key was removed.
+//
+// Returns:
+//   - None
+func (c *Cache) historyAdd(key string, value map[string]any) {
+	if c.history == nil {
+		return
+	}
+
+	// Create the revision
+	var r Revision = Revision{
+		Time:    time.Now(),
+		Deleted: value == nil,
+	}
+	if value != nil {
+		r.Version = c.versions[key]
+		r.Value = copyMap(value)
+	}
+
+	// Append the revision, dropping the oldest one if the limit is reached
+	var revisions []Revision = append(c.history.revisions[key], r)
+	if len(revisions) > c.history.limit {
+		revisions = revisions[len(revisions)-c.history.limit:]
+	}
+	c.history.revisions[key] = revisions
+}
+
+// historyClean is a method of the Cache struct that discards the retained revisions of every key.
+// This method is not thread-safe, and should only be called from an exported function.
+//
+// Returns:
+//   - None
+func (c *Cache) historyClean() {
+	if c.history != nil {
+		c.history.revisions = make(map[string][]Revision)
+	}
+}
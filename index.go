@@ -0,0 +1,235 @@
+package hermes
+
+import (
+	"errors"
+	"fmt"
+)
+
+// CreateIndex is a method of the Cache struct that builds a secondary index for the provided field.
+// The index maps each value of the field to the keys that hold it, so that equality lookups with Find
+// don't require scanning all of the cache values. If the field holds an array, such as tags, each of its elements is indexed.
+// This method is thread-safe.
+//
+// Parameters:
+//   - field (string): The field to index.
+//
+// Returns:
+//   - error: An error if the field is invalid or already indexed.
+func (c *Cache) CreateIndex(field string) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	// Verify the field
+	if len(field) == 0 {
+		return errors.New("invalid field")
+	} else if _, ok := c.indexes[field]; ok {
+		return fmt.Errorf("index on field %s already exists", field)
+	}
+
+	// Build the index from the current data
+	c.indexes[field] = indexBuild(field, c.data)
+
+	// Return no error
+	return nil
+}
+
+// DropIndex is a method of the Cache struct that removes the secondary index for the provided field.
+// This method is thread-safe.
+//
+// Parameters:
+//   - field (string): The indexed field.
+//
+// Returns:
+//   - error: An error if the field is not indexed.
+func (c *Cache) DropIndex(field string) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	// Verify that the index exists
+	if _, ok := c.indexes[field]; !ok {
+		return fmt.Errorf("index on field %s does not exist", field)
+	}
+
+	// Delete the index
+	delete(c.indexes, field)
+
+	// Return no error
+	return nil
+}
+
+// Indexes is a method of the Cache struct that returns the fields that have a secondary index.
+// This method is thread-safe.
+//
+// Returns:
+//   - []string: The indexed fields.
+func (c *Cache) Indexes() []string {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	// Copy the indexed fields
+	var fields []string = make([]string, 0, len(c.indexes))
+	for field := range c.indexes {
+		fields = append(fields, field)
+	}
+	return fields
+}
+
+// Find is a method of the Cache struct that returns all the values whose field is equal to, or is an array holding, the provided value.
+// The field must have been indexed with CreateIndex.
+// This method is thread-safe.
+//
+// Parameters:
+//   - field (string): The indexed field.
+//   - value (any): The value to look up.
+//
+// Returns:
+//   - []map[string]any: The values whose field is equal to the provided value.
+//   - error: An error if the field is not indexed.
+func (c *Cache) Find(field string, value any) ([]map[string]any, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	// Verify that the index exists
+	var index, ok = c.indexes[field]
+	if !ok {
+		return []map[string]any{}, fmt.Errorf("index on field %s does not exist", field)
+	}
+
+	// Get the values of the keys
+	var keys map[string]bool = index[fieldValue(value)]
+	var result []map[string]any = make([]map[string]any, 0, len(keys))
+	for key := range keys {
+		result = append(result, c.expand(c.data[key]))
+	}
+	return result, nil
+}
+
+// indexSet is a method of the Cache struct that adds the provided value to every secondary, boolean and numeric index.
+// This method is not thread-safe, and should only be called from an exported function.
+//
+// Parameters:
+//   - key (string): The cache key that the value was set for.
+//   - value (map[string]any): The value that was set.
+//
+// Returns:
+//   - None
+func (c *Cache) indexSet(key string, value map[string]any) {
+	for field, index := range c.indexes {
+		if v, ok := value[field]; ok {
+			for _, fv := range indexValues(v) {
+				indexAdd(index, fv, key)
+			}
+		}
+	}
+	c.booleans.set(key, value)
+	for field, idx := range c.numerics {
+		idx.set(key, value[field])
+	}
+}
+
+// indexDelete is a method of the Cache struct that removes the provided value from every secondary, boolean and numeric index.
+// This method is not thread-safe, and should only be called from an exported function.
+//
+// Parameters:
+//   - key (string): The cache key that the value belonged to.
+//   - value (map[string]any): The value that was removed.
+//
+// Returns:
+//   - None
+func (c *Cache) indexDelete(key string, value map[string]any) {
+	for field, index := range c.indexes {
+		v, ok := value[field]
+		if !ok {
+			continue
+		}
+		for _, fv := range indexValues(v) {
+			if keys, ok := index[fv]; ok {
+				delete(keys, key)
+				if len(keys) == 0 {
+					delete(index, fv)
+				}
+			}
+		}
+	}
+	c.booleans.delete(key)
+	for _, idx := range c.numerics {
+		idx.delete(key)
+	}
+}
+
+// indexRebuild is a method of the Cache struct that rebuilds every secondary, boolean and numeric index from the provided data.
+// This method is not thread-safe, and should only be called from an exported function.
+//
+// Parameters:
+//   - data (map[string]map[string]any): The data to build the indexes from.
+//
+// Returns:
+//   - None
+func (c *Cache) indexRebuild(data map[string]map[string]any) {
+	for field := range c.indexes {
+		c.indexes[field] = indexBuild(field, data)
+	}
+	c.booleans = c.booleans.rebuild(data)
+	for field := range c.numerics {
+		c.numerics[field] = numericBuild(field, data)
+	}
+}
+
+// indexBuild is a function that builds a secondary index for the provided field.
+//
+// Parameters:
+//   - field (string): The field to index.
+//   - data (map[string]map[string]any): The data to build the index from.
+//
+// Returns:
+//   - map[string]map[string]bool: A map of field values to the keys that hold them.
+func indexBuild(field string, data map[string]map[string]any) map[string]map[string]bool {
+	var index map[string]map[string]bool = make(map[string]map[string]bool)
+	for key, value := range data {
+		if v, ok := value[field]; ok {
+			for _, fv := range indexValues(v) {
+				indexAdd(index, fv, key)
+			}
+		}
+	}
+	return index
+}
+
+// indexValues is a function that converts a cache value field to the strings it's indexed under.
+// An array is indexed under each of its elements, and any other value under its own string representation.
+//
+// Parameters:
+//   - value (any): The field value.
+//
+// Returns:
+//   - []string: The indexed strings.
+func indexValues(value any) []string {
+	switch v := value.(type) {
+	case []any:
+		var values []string = make([]string, 0, len(v))
+		for _, e := range v {
+			values = append(values, fieldValue(e))
+		}
+		return values
+	case []string:
+		return v
+	}
+	return []string{fieldValue(value)}
+}
+
+// indexAdd is a function that adds a key to the provided secondary index.
+//
+// Parameters:
+//   - index (map[string]map[string]bool): The secondary index.
+//   - value (string): The field value.
+//   - key (string): The cache key that holds the value.
+//
+// Returns:
+//   - None
+func indexAdd(index map[string]map[string]bool, value string, key string) {
+	if keys, ok := index[value]; ok {
+		keys[key] = true
+	} else {
+		index[value] = map[string]bool{key: true}
+	}
+}
@@ -1,5 +1,7 @@
 package hermes
 
+import "sort"
+
 // When you delete a number of keys from the cache, the index remains
 // the same. Over time, this number will grow to be very large, and will
 // cause the cache to use a lot of memory. This function resets the indices
@@ -23,12 +25,19 @@ func (ft *FullText) sequenceIndices() {
 		tempIndices map[int]string = make(map[int]string)
 		tempindex   int            = 0
 		tempKeys    map[string]int = make(map[string]int)
+		sorted      []int          = make([]int, 0, len(ft.indices))
+		renumbered  map[int]int    = make(map[int]int, len(ft.indices))
 	)
 
-	// Fill the temp indices by iterating over the current
-	// indices and adding them to the tempIndices map
-	for _, value := range ft.indices {
-		tempIndices[tempindex] = value
+	// Fill the temp indices in the order of the current indices,
+	// so the postings of the words stay sorted
+	for index := range ft.indices {
+		sorted = append(sorted, index)
+	}
+	sort.Ints(sorted)
+	for _, index := range sorted {
+		tempIndices[tempindex] = ft.indices[index]
+		renumbered[index] = tempindex
 		tempindex++
 	}
 
@@ -39,27 +48,27 @@ func (ft *FullText) sequenceIndices() {
 
 	// Iterate over the ft storage
 	for word, data := range ft.storage {
-		// Check if the data is []int or int
+		// Check if the data is a bitmap or int
 		if v, ok := data.(int); ok {
 			ft.storage[word] = tempKeys[ft.indices[v]]
 			continue
 		}
 
-		// If the data is []int, loop through the slice
-		if keys, ok := data.([]int); !ok {
-			for i := 0; i < len(keys); i++ {
-				var index int = keys[i]
-
-				// Get the key from the old indices
-				var key string = ft.indices[index]
-
-				// Set the new index
-				ft.storage[word].([]int)[i] = tempKeys[key]
+		// If the data is a bitmap, build the bitmap of the new indices
+		if keys, ok := data.(*roaring); ok {
+			var indices []int = keys.slice()
+			for i, index := range indices {
+				indices[i] = tempKeys[ft.indices[index]]
 			}
+			ft.storage[word] = newRoaring(indices...)
 		}
 	}
 
+	// Renumber the posting lists of the fields
+	ft.fieldStorage.renumber(renumbered)
+
 	// Set the old variables to the new variables
 	ft.indices = tempIndices
+	ft.keys = tempKeys
 	ft.index = tempindex
 }
@@ -3,7 +3,12 @@ package hermes
 import (
 	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
 	"sync"
+	"time"
 
 	utils "github.com/realTristan/hermes/utils"
 )
@@ -14,9 +19,26 @@ import (
 //   - A pointer to a new Cache struct.
 func InitCache() *Cache {
 	return &Cache{
-		data:  make(map[string]map[string]any),
-		mutex: &sync.RWMutex{},
-		ft:    nil,
+		data:       make(map[string]map[string]any),
+		mutex:      &sync.RWMutex{},
+		ft:         nil,
+		uniques:    make(map[string]*unique),
+		indexes:    make(map[string]map[string]map[string]bool),
+		tolerances: make(map[string]Tolerance),
+		booleans:   newBoolIndex(),
+		numerics:   make(map[string]*numericIndex),
+		computed:   make(map[string]computedField),
+		bm25:       newBM25(),
+		subs:       make(map[string]*subscription),
+		keyTrie:    utils.NewTrie(),
+		versions:   make(map[string]uint64),
+		expiries:   make(map[string]time.Time),
+		namespaces: make(map[string]bool),
+		stats:      &stats{},
+		shadow:     &searchShadow{},
+		queries:    &queryCache{},
+		cursors:    &cursors{},
+		dups:       &duplicates{},
 	}
 }
 
@@ -25,8 +47,8 @@ func InitCache() *Cache {
 // If the full-text index is already initialized, an error is returned.
 //
 // Parameters:
-// - maxSize: the maximum number of words to store in the full-text index.
-// - maxBytes: the maximum size, in bytes, of the full-text index.
+// - maxSize: the maximum number of words to store in the full-text index, or Unlimited.
+// - maxBytes: the maximum size, in bytes, of the full-text index, or Unlimited.
 //
 // Returns:
 // - error: If the full-text is already initialized.
@@ -40,27 +62,37 @@ func (c *Cache) FTInit(maxSize int, maxBytes int, minWordLength int) error {
 	}
 
 	// Initialize the FT
-	return c.ftInit(maxSize, maxBytes, minWordLength)
+	return c.ftInit(maxSize, maxBytes, minWordLength, nil)
 }
 
 // Initialize the full-text for the cache.
 // This method is not thread-safe, and should only be called from an exported function.
 //
 // Parameters:
-// - maxSize: the maximum number of words to store in the full-text index.
-// - maxBytes: the maximum size, in bytes, of the full-text index.
+// - maxSize: the maximum number of words to store in the full-text index, or Unlimited.
+// - maxBytes: the maximum size, in bytes, of the full-text index, or Unlimited.
+// - schema: the fields whose string values are always stored in the full-text index. May be nil.
 //
 // Returns:
 // - error: From full-text cache insertion.
-func (c *Cache) ftInit(maxSize int, maxBytes int, minWordLength int) error {
+func (c *Cache) ftInit(maxSize int, maxBytes int, minWordLength int, schema map[string]bool) error {
+	// Verify the limits
+	if err := validLimits(maxSize, maxBytes, minWordLength); err != nil {
+		return err
+	}
+
 	// Initialize the FT struct
 	var ft *FullText = &FullText{
 		storage:       make(map[string]any),
-		indices:       make(map[int]string),
+		indices:       make(map[int]string, c.sizeHint(len(c.data))),
+		keys:          make(map[string]int, c.sizeHint(len(c.data))),
 		index:         0,
 		maxSize:       maxSize,
 		maxBytes:      maxBytes,
 		minWordLength: minWordLength,
+		schema:        schema,
+		fields:        make(map[string][]string, c.sizeHint(len(c.data))),
+		fieldStorage:  make(fieldIndex),
 	}
 
 	// Load the cache data
@@ -70,6 +102,8 @@ func (c *Cache) ftInit(maxSize int, maxBytes int, minWordLength int) error {
 
 	// Update the cache full-text
 	c.ft = ft
+	c.generation++
+	c.limitCheck()
 
 	// Return no error
 	return nil
@@ -81,8 +115,8 @@ func (c *Cache) ftInit(maxSize int, maxBytes int, minWordLength int) error {
 //
 // Parameters:
 // - data: the data to initialize the full-text index with.
-// - maxSize: the maximum number of words to store in the full-text index.
-// - maxBytes: the maximum size, in bytes, of the full-text index.
+// - maxSize: the maximum number of words to store in the full-text index, or Unlimited.
+// - maxBytes: the maximum size, in bytes, of the full-text index, or Unlimited.
 //
 // Returns:
 // - error: If the full-text is already initialized.
@@ -104,20 +138,30 @@ func (c *Cache) FTInitWithMap(data map[string]map[string]any, maxSize int, maxBy
 //
 // Parameters:
 // - data: the data to initialize the full-text index with.
-// - maxSize: the maximum number of words to store in the full-text index.
-// - maxBytes: the maximum size, in bytes, of the full-text index.
+// - maxSize: the maximum number of words to store in the full-text index, or Unlimited.
+// - maxBytes: the maximum size, in bytes, of the full-text index, or Unlimited.
 //
 // Returns:
 // - error
 func (c *Cache) ftInitWithMap(data map[string]map[string]any, maxSize int, maxBytes int, minWordLength int) error {
+	// Verify that the data can be written, and the limits
+	if err := c.writable("FTInit"); err != nil {
+		return err
+	} else if err := validLimits(maxSize, maxBytes, minWordLength); err != nil {
+		return err
+	}
+
 	// Initialize the FT struct
 	var ft *FullText = &FullText{
 		storage:       make(map[string]any),
-		indices:       make(map[int]string),
+		indices:       make(map[int]string, c.sizeHint(len(data)+len(c.data))),
+		keys:          make(map[string]int, c.sizeHint(len(data)+len(c.data))),
 		index:         0,
 		maxSize:       maxSize,
 		maxBytes:      maxBytes,
 		minWordLength: minWordLength,
+		fields:        make(map[string][]string, c.sizeHint(len(data)+len(c.data))),
+		fieldStorage:  make(fieldIndex),
 	}
 
 	// Iterate over the cache keys and add them to the data
@@ -128,6 +172,29 @@ func (c *Cache) ftInitWithMap(data map[string]map[string]any, maxSize int, maxBy
 		data[k] = c.data[k]
 	}
 
+	// Verify that the new values are well-formed, and derive their computed fields
+	for k, v := range data {
+		if _, ok := c.data[k]; ok {
+			continue
+		} else if err := c.checkRecord(k, v); err != nil {
+			if err := c.reject(err); err != errSkipped {
+				return err
+			}
+			delete(data, k)
+		} else if err := c.compute(k, v); err != nil {
+			if err := c.reject(err); err != errSkipped {
+				return err
+			}
+			delete(data, k)
+		}
+	}
+
+	// Verify that the merged data doesn't violate any unique constraints
+	uniques, err := c.uniqueBuild(data)
+	if err != nil {
+		return err
+	}
+
 	// Insert the data into the ft storage
 	if err := ft.insert(&data); err != nil {
 		return err
@@ -136,19 +203,32 @@ func (c *Cache) ftInitWithMap(data map[string]map[string]any, maxSize int, maxBy
 	// Update the cache varoables
 	c.data = data
 	c.ft = ft
+	c.uniques = uniques
+	c.keyTrie = keyTrie(data)
+	c.indexRebuild(data)
+	c.namedRebuild()
+	c.generation++
+	c.versionsReset(data)
+	for k, v := range data {
+		c.expireFromField(k, v)
+		data[k] = c.compress(v)
+	}
+	c.limitCheck()
 
 	// Return no error
 	return nil
 }
 
 // Initialize the full-text for the cache with a JSON file.
+// The path can also be a directory, whose .json files are all read, or a glob pattern such as "data/*.json",
+// so a dataset sharded across several files can be loaded at once. A key can only be in one of the files.
 // This method is thread-safe.
 // If the full-text index is already initialized, an error is returned.
 //
 // Parameters:
-// - file: the path to the JSON file to initialize the full-text index with.
-// - maxSize: the maximum number of words to store in the full-text index.
-// - maxBytes: the maximum size, in bytes, of the full-text index.
+// - file: the path to the JSON file, directory or glob pattern to initialize the full-text index with.
+// - maxSize: the maximum number of words to store in the full-text index, or Unlimited.
+// - maxBytes: the maximum size, in bytes, of the full-text index, or Unlimited.
 //
 // Returns:
 // - error: If the full-text is already initialized.
@@ -169,14 +249,91 @@ func (c *Cache) FTInitWithJson(file string, maxSize int, maxBytes int, minWordLe
 // This method is not thread-safe, and should only be called from an exported function.
 //
 // Parameters:
-// - file: the path to the JSON file to initialize the full-text index with.
-// - maxSize: the maximum number of words to store in the full-text index.
-// - maxBytes: the maximum size, in bytes, of the full-text index.
+// - file: the path to the JSON file, directory or glob pattern to initialize the full-text index with.
+// - maxSize: the maximum number of words to store in the full-text index, or Unlimited.
+// - maxBytes: the maximum size, in bytes, of the full-text index, or Unlimited.
 //
 // Returns:
-// - error: Json file read error, or init with map error.
+// - error: Json file read error, key collision error, or init with map error.
 func (c *Cache) ftInitWithJson(file string, maxSize int, maxBytes int, minWordLength int) error {
-	if data, err := utils.ReadJson[map[string]map[string]any](file); err != nil {
+	if data, err := readJsonFiles(file); err != nil {
+		return err
+	} else {
+		return c.ftInitWithMap(data, maxSize, maxBytes, minWordLength)
+	}
+}
+
+// readJsonFiles is a function that reads the JSON files of a path and merges their values.
+//
+// Parameters:
+// - path: the path to a JSON file, a directory whose .json files are read, or a glob pattern.
+//
+// Returns:
+// - map[string]map[string]any: The merged values of the files.
+// - error: An error if no file matches the pattern, a file could not be read, or a key is in more than one file.
+func readJsonFiles(path string) (map[string]map[string]any, error) {
+	var files []string = []string{path}
+	if info, err := os.Stat(path); err == nil && info.IsDir() {
+		if files, err = filepath.Glob(filepath.Join(path, "*.json")); err != nil {
+			return nil, err
+		}
+	} else if err != nil && strings.ContainsAny(path, "*?[") {
+		if files, err = filepath.Glob(path); err != nil {
+			return nil, err
+		}
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no json files match %s", path)
+	} else if len(files) == 1 {
+		return utils.ReadJson[map[string]map[string]any](files[0])
+	}
+
+	// Merge the values of the files, in the order of their names
+	sort.Strings(files)
+	var (
+		data    map[string]map[string]any = make(map[string]map[string]any)
+		sources map[string]string         = make(map[string]string)
+	)
+	for _, file := range files {
+		values, err := utils.ReadJson[map[string]map[string]any](file)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", file, err)
+		}
+		for key, value := range values {
+			if source, ok := sources[key]; ok {
+				return nil, fmt.Errorf("key %s is in both %s and %s", key, source, file)
+			}
+			data[key] = value
+			sources[key] = file
+		}
+	}
+	return data, nil
+}
+
+// Initialize the full-text for the cache with a YAML file.
+// The file has the same structure as the JSON file of FTInitWithJson: a mapping of keys to values, where the fields to store
+// in the full-text index are mappings with "$hermes.full_text" set to true and the string in "$hermes.value".
+// This method is thread-safe.
+// If the full-text index is already initialized, an error is returned.
+//
+// Parameters:
+// - file: the path to the YAML file to initialize the full-text index with.
+// - maxSize: the maximum number of words to store in the full-text index, or Unlimited.
+// - maxBytes: the maximum size, in bytes, of the full-text index, or Unlimited.
+//
+// Returns:
+// - error: If the full-text is already initialized.
+func (c *Cache) FTInitWithYaml(file string, maxSize int, maxBytes int, minWordLength int) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	// Verify that the ft cache is initialized
+	if c.ft != nil {
+		return errors.New("full-text cache already initialized")
+	}
+
+	// Initialize the FT
+	if data, err := utils.ReadYaml[map[string]map[string]any](file); err != nil {
 		return err
 	} else {
 		return c.ftInitWithMap(data, maxSize, maxBytes, minWordLength)
@@ -193,27 +350,37 @@ func (c *Cache) ftInitWithJson(file string, maxSize int, maxBytes int, minWordLe
 //   - An error if the full-text storage limit or byte-size limit is reached.
 func (ft *FullText) insert(data *map[string]map[string]any) error {
 	// Create a new temp storage
-	var ts *TempStorage = NewTempStorage(ft)
+	var (
+		ts      *TempStorage = NewTempStorage(ft)
+		entries []ftEntry    = []ftEntry{}
+	)
 
 	// Loop through the json data
 	for cacheKey, cacheValue := range *data {
 		for k, v := range cacheValue {
-			if ftv := WFTGetValue(v); len(ftv) == 0 {
+			if ftv := ft.value(k, v); len(ftv) == 0 {
 				continue
 			} else {
 				// Set the key in the provided value to the fulltext value
 				(*data)[cacheKey][k] = ftv
 
-				// Insert the value in the temp storage
-				if err := ts.insert(ft, cacheKey, ftv); err != nil {
-					return err
-				}
+				// Collect the value for the temp storage
+				entries = append(entries, ts.entry(cacheKey, k, ftv))
+				ft.fields[cacheKey] = append(ft.fields[cacheKey], k)
 			}
 		}
+
+		// Collect the nested fields referenced by the schema
+		for k, ftv := range ft.nestedValues(cacheValue) {
+			entries = append(entries, ts.entry(cacheKey, k, ftv))
+			ft.fields[cacheKey] = append(ft.fields[cacheKey], k)
+		}
 	}
 
-	// Merge the keys
-	// ts.mergeKeys()
+	// Insert the values in the temp storage
+	if err := ts.insertAll(ft, entries); err != nil {
+		return err
+	}
 
 	// Iterate over the temp storage and set the values with len 1 to int
 	ts.cleanSingleArrays()
@@ -221,9 +388,6 @@ func (ft *FullText) insert(data *map[string]map[string]any) error {
 	// Set the full-text cache to the temp map
 	ts.updateFullText(ft)
 
-	// Print the size of the full-text cache storage
-	fmt.Println(utils.Size(ft.storage))
-
 	// Return nil for no errors
 	return nil
 }
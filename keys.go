@@ -1,6 +1,8 @@
 package hermes
 
-// Keys is a method of the Cache struct that returns all the keys in the cache.
+import utils "github.com/realTristan/hermes/utils"
+
+// Keys is a method of the Cache struct that returns all the keys in the cache, without the expired keys.
 // This function is thread-safe.
 //
 // Returns:
@@ -15,11 +17,88 @@ func (c *Cache) Keys() []string {
 // This function is not thread-safe, and should only be called from an exported function.
 //
 // Returns:
-//   - A slice of strings containing all the keys in the cache.
+//   - A slice of strings containing all the keys in the cache that haven't expired.
 func (c *Cache) keys() []string {
 	keys := make([]string, 0, len(c.data))
 	for key := range c.data {
-		keys = append(keys, key)
+		if !c.expired(key) {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// KeysWithPrefix is a method of the Cache struct that returns the keys in the cache that start with the provided prefix, in sorted order, without the expired keys.
+// The keys are read from a sorted index, so only the matching keys are visited.
+// This function is thread-safe.
+//
+// Parameters:
+//   - prefix (string): The prefix of the keys. If empty, every key is returned.
+//
+// Returns:
+//   - []string: The matching keys, in sorted order.
+func (c *Cache) KeysWithPrefix(prefix string) []string {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return c.unexpired(c.keyTrie.WithPrefix(prefix, 0))
+}
+
+// KeysMatching is a method of the Cache struct that returns the keys in the cache that match the provided glob pattern, in sorted order, without the expired keys.
+// The pattern syntax follows the Redis KEYS command: * matches any sequence of characters, ? matches a single character,
+// [abc] and [a-z] match a character class, [^abc] matches any character outside of the class, and \ escapes the next character.
+// Only the keys that start with the literal prefix of the pattern are visited.
+// This function is thread-safe.
+//
+// Parameters:
+//   - pattern (string): The glob pattern, for example "order:*:2024".
+//
+// Returns:
+//   - []string: The matching keys, in sorted order.
+//   - error: An error if the pattern is invalid.
+func (c *Cache) KeysMatching(pattern string) ([]string, error) {
+	g, err := utils.CompileGlob(pattern)
+	if err != nil {
+		return []string{}, err
 	}
+
+	// Lock the mutex
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	// Get the matching keys
+	return c.keysMatching(g), nil
+}
+
+// keysMatching is a method of the Cache struct that returns the keys in the cache that match the provided glob pattern, in sorted order.
+// This function is not thread-safe, and should only be called from an exported function.
+//
+// Parameters:
+//   - g (*utils.Glob): The compiled glob pattern.
+//
+// Returns:
+//   - []string: The matching keys that haven't expired, in sorted order.
+func (c *Cache) keysMatching(g *utils.Glob) []string {
+	var keys []string = []string{}
+	c.keyTrie.Walk(g.Prefix(), func(key string) bool {
+		if g.Match(key) && !c.expired(key) {
+			keys = append(keys, key)
+		}
+		return true
+	})
 	return keys
 }
+
+// keyTrie is a function that builds a sorted index of the keys in the provided data.
+//
+// Parameters:
+//   - data (map[string]map[string]any): The data to index the keys of.
+//
+// Returns:
+//   - *utils.Trie: The sorted index of the keys.
+func keyTrie(data map[string]map[string]any) *utils.Trie {
+	var trie *utils.Trie = utils.NewTrie()
+	for key := range data {
+		trie.Insert(key)
+	}
+	return trie
+}
@@ -0,0 +1,139 @@
+package hermes
+
+import (
+	"math/bits"
+	"sync/atomic"
+	"time"
+)
+
+// Latency is a struct that contains the percentiles of the duration of an operation type, see Stats.
+// The durations are recorded in buckets with a relative error of at most 25%, and each percentile is the upper bound of its bucket.
+//
+// Fields:
+//   - Count (uint64): The number of recorded operations.
+//   - P50 (time.Duration): The median duration.
+//   - P95 (time.Duration): The duration that 95% of the operations didn't exceed.
+//   - P99 (time.Duration): The duration that 99% of the operations didn't exceed.
+type Latency struct {
+	Count uint64        `json:"count"`
+	P50   time.Duration `json:"p50"`
+	P95   time.Duration `json:"p95"`
+	P99   time.Duration `json:"p99"`
+}
+
+// The number of buckets of each power of two of a histogram. Four buckets bound the relative error of a percentile to 25%.
+const histogramSubBuckets int = 4
+
+// histogram is a struct that counts durations in logarithmic buckets, so the percentiles of any range of durations can be
+// computed with a fixed amount of memory. The counters are atomic so durations can be recorded while holding the read lock.
+//
+// Fields:
+//   - counts ([64 * histogramSubBuckets]atomic.Uint64): The number of durations in each bucket, see histogramBucket.
+type histogram struct {
+	counts [64 * histogramSubBuckets]atomic.Uint64
+}
+
+// since is a method of the histogram struct that records the duration since the provided time.
+// It's meant to be deferred at the start of an operation.
+//
+// Parameters:
+//   - start (time.Time): The time the operation started.
+//
+// Returns:
+//   - None
+func (h *histogram) since(start time.Time) {
+	h.counts[histogramBucket(time.Since(start))].Add(1)
+}
+
+// reset is a method of the histogram struct that sets all of the buckets to zero.
+//
+// Returns:
+//   - None
+func (h *histogram) reset() {
+	for i := range h.counts {
+		h.counts[i].Store(0)
+	}
+}
+
+// latency is a method of the histogram struct that returns the number of recorded durations and their percentiles.
+//
+// Returns:
+//   - Latency: The latency of the recorded durations.
+func (h *histogram) latency() Latency {
+	var (
+		counts [64 * histogramSubBuckets]uint64
+		result Latency
+	)
+	for i := range h.counts {
+		counts[i] = h.counts[i].Load()
+		result.Count += counts[i]
+	}
+	if result.Count == 0 {
+		return result
+	}
+
+	// Find the bucket of each percentile
+	var (
+		percentiles []*time.Duration = []*time.Duration{&result.P50, &result.P95, &result.P99}
+		ranks       []uint64         = []uint64{rank(result.Count, 50), rank(result.Count, 95), rank(result.Count, 99)}
+		total       uint64           = 0
+		p           int              = 0
+	)
+	for i := 0; i < len(counts) && p < len(percentiles); i++ {
+		total += counts[i]
+		for p < len(percentiles) && total >= ranks[p] {
+			*percentiles[p] = histogramUpperBound(i)
+			p++
+		}
+	}
+	return result
+}
+
+// rank is a function that returns the 1-based rank of a percentile among a number of sorted values.
+//
+// Parameters:
+//   - count (uint64): The number of values.
+//   - percentile (uint64): The percentile, from 1 to 100.
+//
+// Returns:
+//   - uint64: The rank of the value at the percentile.
+func rank(count uint64, percentile uint64) uint64 {
+	if r := (count*percentile + 99) / 100; r > 0 {
+		return r
+	}
+	return 1
+}
+
+// histogramBucket is a function that returns the bucket of a duration. The durations below histogramSubBuckets nanoseconds
+// have a bucket each, and every larger power of two is split into histogramSubBuckets buckets of the same width.
+//
+// Parameters:
+//   - d (time.Duration): The duration.
+//
+// Returns:
+//   - int: The index of the bucket.
+func histogramBucket(d time.Duration) int {
+	if d < time.Duration(histogramSubBuckets) {
+		if d < 0 {
+			return 0
+		}
+		return int(d)
+	}
+	var exp int = bits.Len64(uint64(d)) - 1
+	return exp*histogramSubBuckets + int(uint64(d)>>(exp-2))&(histogramSubBuckets-1)
+}
+
+// histogramUpperBound is a function that returns the largest duration of a bucket.
+//
+// Parameters:
+//   - i (int): The index of the bucket.
+//
+// Returns:
+//   - time.Duration: The largest duration of the bucket.
+func histogramUpperBound(i int) time.Duration {
+	if i < histogramSubBuckets {
+		return time.Duration(i)
+	}
+	var exp, sub int = i / histogramSubBuckets, i % histogramSubBuckets
+	return time.Duration((uint64(histogramSubBuckets+sub+1) << (exp - 2)) - 1)
+}
@@ -0,0 +1,189 @@
+package hermes
+
+import (
+	"errors"
+	"math"
+	"sort"
+
+	utils "github.com/realTristan/hermes/utils"
+)
+
+// Unlimited is the value of the maxSize and maxBytes limits of the full-text index, such as those of FTInit, FTSetMaxSize and FTSetMaxBytes,
+// that disables the limit. A limit of 0 also disables it. Any other negative limit is invalid.
+const Unlimited int = -1
+
+// The names of the limits of the full-text index in the limit warnings
+const (
+	LimitMaxSize  string = "max_size"
+	LimitMaxBytes string = "max_bytes"
+)
+
+// LimitWarning is a struct that represents a soft-limit warning, sent when the usage of a limit of the full-text index crosses
+// one of the thresholds set with SetLimitWarnings, before the limit itself is reached and the writes fail.
+//
+// Fields:
+//   - Limit (string): The limit whose usage crossed the threshold, LimitMaxSize or LimitMaxBytes.
+//   - Usage (int): The number of words, or the size in bytes, of the full-text index.
+//   - Max (int): The value of the limit.
+//   - Threshold (float64): The crossed threshold, as a fraction of the limit.
+type LimitWarning struct {
+	Limit     string
+	Usage     int
+	Max       int
+	Threshold float64
+}
+
+// limitWarnings is a struct that holds the soft-limit thresholds of the full-text index and the listeners of the warnings.
+//
+// Fields:
+//   - thresholds ([]float64): The thresholds, as fractions of the limits, sorted in increasing order.
+//   - listeners ([]func(w LimitWarning)): The functions to call when a threshold is crossed.
+//   - crossed (map[string]float64): The highest threshold that the usage of each limit crossed when it was last checked.
+type limitWarnings struct {
+	thresholds []float64
+	listeners  []func(w LimitWarning)
+	crossed    map[string]float64
+}
+
+// validLimits is a function that verifies the limits of the full-text index.
+//
+// Parameters:
+//   - maxSize (int): The maximum number of words to store in the full-text index.
+//   - maxBytes (int): The maximum size, in bytes, of the full-text index.
+//   - minWordLength (int): The minimum length of a word to store in the full-text index.
+//
+// Returns:
+//   - error: An error if a limit is negative and not Unlimited, or the minimum word length is negative.
+func validLimits(maxSize int, maxBytes int, minWordLength int) error {
+	switch {
+	case maxSize < Unlimited:
+		return errors.New("invalid max size")
+	case maxBytes < Unlimited:
+		return errors.New("invalid max bytes")
+	case minWordLength < 0:
+		return errors.New("invalid min word length")
+	}
+	return nil
+}
+
+// limited is a function that checks whether a limit of the full-text index is enabled.
+//
+// Parameters:
+//   - limit (int): The maxSize or maxBytes limit.
+//
+// Returns:
+//   - bool: true if the limit is positive, false if it's Unlimited or 0.
+func limited(limit int) bool {
+	return limit > 0
+}
+
+// SetLimitWarnings is a method of the Cache struct that sets the thresholds at which soft-limit warnings are sent, as fractions of the
+// maxSize and maxBytes limits of the full-text index. For example, with the thresholds 0.8 and 0.95, a warning is sent when the full-text
+// index holds 80% of its maximum number of words, and another when it holds 95%. The warnings are counted in the LimitWarnings of the
+// stats and sent to the functions registered with OnLimitWarning. A threshold warns again once the usage went back under it.
+// The limits that are Unlimited are never warned about. If no threshold is provided, the warnings are disabled.
+// This method is thread-safe.
+//
+// Parameters:
+//   - thresholds (...float64): The thresholds, each greater than 0 and at most 1.
+//
+// Returns:
+//   - error: An error if a threshold is invalid.
+func (c *Cache) SetLimitWarnings(thresholds ...float64) error {
+	for _, t := range thresholds {
+		if math.IsNaN(t) || t <= 0 || t > 1 {
+			return errors.New("invalid limit warning threshold")
+		}
+	}
+	var sorted []float64 = append([]float64{}, thresholds...)
+	sort.Float64s(sorted)
+
+	// Lock the mutex
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	// Set the thresholds, keeping the listeners
+	if c.limitWarn == nil {
+		c.limitWarn = &limitWarnings{}
+	}
+	c.limitWarn.thresholds = sorted
+	c.limitWarn.crossed = make(map[string]float64)
+
+	// Warn about the limits whose usage already crossed a threshold
+	c.limitCheck()
+	return nil
+}
+
+// OnLimitWarning is a method of the Cache struct that registers a function to be called for every soft-limit warning, see SetLimitWarnings.
+// The function is called in a new goroutine, so it can safely call other methods of the cache.
+// This method is thread-safe.
+//
+// Parameters:
+//   - fn (func(w LimitWarning)): The function to call.
+//
+// Returns:
+//   - None
+func (c *Cache) OnLimitWarning(fn func(w LimitWarning)) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.limitWarn == nil {
+		c.limitWarn = &limitWarnings{crossed: make(map[string]float64)}
+	}
+	c.limitWarn.listeners = append(c.limitWarn.listeners, fn)
+}
+
+// limitCheck is a method of the Cache struct that compares the usage of the limits of the full-text index with the soft-limit thresholds,
+// and sends a warning for each limit whose usage crossed a higher threshold since it was last checked.
+// The size in bytes is only computed if the maxBytes limit is enabled, and it's not checked if the index can't be encoded.
+// This method is not thread-safe, and should only be called from an exported function.
+//
+// Returns:
+//   - None
+func (c *Cache) limitCheck() {
+	var lw *limitWarnings = c.limitWarn
+	if lw == nil || len(lw.thresholds) == 0 || c.ft == nil {
+		return
+	}
+	if limited(c.ft.maxSize) {
+		lw.check(c, LimitMaxSize, len(c.ft.storage), c.ft.maxSize)
+	}
+	if limited(c.ft.maxBytes) {
+		if size, err := utils.Size(c.ft.storage); err == nil {
+			lw.check(c, LimitMaxBytes, size, c.ft.maxBytes)
+		}
+	}
+}
+
+// check is a method of the limitWarnings struct that sends a warning if the usage of a limit crossed a higher threshold since it was last checked.
+//
+// Parameters:
+//   - c (*Cache): The cache whose stats count the warnings.
+//   - limit (string): The name of the limit.
+//   - usage (int): The usage of the limit.
+//   - max (int): The value of the limit.
+//
+// Returns:
+//   - None
+func (lw *limitWarnings) check(c *Cache, limit string, usage int, max int) {
+	// Find the highest crossed threshold
+	var crossed float64
+	for _, t := range lw.thresholds {
+		if float64(usage) >= t*float64(max) {
+			crossed = t
+		}
+	}
+
+	// Remember the threshold, so the lower thresholds warn again once the usage went back under them
+	var previous float64 = lw.crossed[limit]
+	lw.crossed[limit] = crossed
+	if crossed <= previous {
+		return
+	}
+
+	// Count the warning and notify the listeners
+	c.stats.limitWarnings.Add(1)
+	var w LimitWarning = LimitWarning{Limit: limit, Usage: usage, Max: max, Threshold: crossed}
+	for _, fn := range lw.listeners {
+		go fn(w)
+	}
+}
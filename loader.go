@@ -0,0 +1,141 @@
+package hermes
+
+import (
+	"sync"
+	"time"
+)
+
+// loader is a struct that loads the values of missing keys with a user-provided function.
+// Concurrent loads of the same key are deduplicated, so the function is called once and every caller receives its result.
+//
+// Fields:
+//   - fn (func(key string) (map[string]any, error)): The function that loads the value of a key.
+//   - mutex (sync.Mutex): A Mutex that guards access to the in-flight loads.
+//   - calls (map[string]*loadCall): The in-flight load of each key.
+type loader struct {
+	fn    func(key string) (map[string]any, error)
+	mutex sync.Mutex
+	calls map[string]*loadCall
+}
+
+// loadCall is a struct that represents an in-flight load of a key.
+//
+// Fields:
+//   - done (chan struct{}): A channel that is closed when the load completes.
+//   - value (map[string]any): The loaded value, or nil if the key was not found or the load failed.
+//   - err (error): The error returned by the load.
+type loadCall struct {
+	done  chan struct{}
+	value map[string]any
+	err   error
+}
+
+// SetLoader is a method of the Cache struct that sets the function used to load the value of a key on a cache miss.
+// When Get, GetCopy or GetOrLoad is called with a key that doesn't exist, the function is called, and the value it returns
+// is stored and indexed like with Set before it's returned. If the function returns a nil value, the key is treated as not found.
+// Concurrent misses of the same key wait for a single call of the function. The function is called without holding the
+// lock of the cache, so it can safely call other methods of the cache. If fn is nil, missing keys are no longer loaded.
+// This method is thread-safe.
+//
+// Parameters:
+//   - fn (func(key string) (map[string]any, error)): The function that loads the value of a key.
+//
+// Returns:
+//   - None
+func (c *Cache) SetLoader(fn func(key string) (map[string]any, error)) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if fn == nil {
+		c.loader = nil
+		return
+	}
+	c.loader = &loader{fn: fn, calls: make(map[string]*loadCall)}
+}
+
+// GetOrLoad is a method of the Cache struct that retrieves the value associated with the given key, loading it with the
+// function set with SetLoader if the key doesn't exist. Unlike Get, the error returned by the loader is returned.
+// The returned map is the one stored in the cache, so it must not be modified.
+// This method is thread-safe.
+//
+// Parameters:
+//   - key (string): The key to retrieve the value for.
+//
+// Returns:
+//   - map[string]any: The value associated with the key, or nil if it doesn't exist and couldn't be loaded.
+//   - error: The error returned by the loader, or an error if the loaded value could not be stored.
+func (c *Cache) GetOrLoad(key string) (map[string]any, error) {
+	defer c.stats.getTimes.since(time.Now())
+	c.mutex.RLock()
+	var (
+		value map[string]any = c.get(key)
+		l     *loader        = c.loader
+	)
+	c.mutex.RUnlock()
+
+	// Load the value if the key doesn't exist
+	if value != nil || l == nil {
+		return value, nil
+	}
+	return c.loadValue(l, key)
+}
+
+// loadValue is a method of the Cache struct that loads the value of a missing key, or waits for the in-flight load of the key.
+// This method acquires the lock of the cache to store the loaded value, so it must be called without holding it.
+//
+// Parameters:
+//   - l (*loader): The loader to load the value with.
+//   - key (string): The key to load the value of.
+//
+// Returns:
+//   - map[string]any: The stored value, or nil if the key was not found or the load failed.
+//   - error: The error returned by the loader, or an error if the loaded value could not be stored.
+func (c *Cache) loadValue(l *loader, key string) (map[string]any, error) {
+	// Wait for the in-flight load of the key, if there is one
+	l.mutex.Lock()
+	if call, ok := l.calls[key]; ok {
+		l.mutex.Unlock()
+		<-call.done
+		return call.value, call.err
+	}
+	var call *loadCall = &loadCall{done: make(chan struct{})}
+	l.calls[key] = call
+	l.mutex.Unlock()
+
+	// Release the waiters once the load completes, even if the loader panics
+	defer func() {
+		l.mutex.Lock()
+		delete(l.calls, key)
+		l.mutex.Unlock()
+		close(call.done)
+	}()
+
+	// Load the value
+	value, err := l.fn(key)
+	if err != nil || value == nil {
+		call.err = err
+		return nil, err
+	}
+
+	// Store the value, unless the key was set while it was being loaded
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if current, ok := c.data[key]; ok && !c.expired(key) {
+		call.value = c.expand(current)
+		return call.value, nil
+	} else if c.frozen {
+		call.value = value
+		return call.value, nil
+	} else if ok {
+		c.delete(key)
+	}
+	if call.err = c.set(key, value); call.err == errSkipped {
+		call.err = nil
+	} else if call.err == nil {
+		// The value is not sent to the sinks, since it came from the backing store
+		c.stats.sets.Add(1)
+		c.historyAdd(key, value)
+		c.walAdd(key)
+		call.value = c.expand(c.data[key])
+	}
+	return call.value, call.err
+}
@@ -0,0 +1,107 @@
+package hermes
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// The timeout of each attempt to download a snapshot
+const loadURLTimeout time.Duration = 5 * time.Minute
+
+// The delay before the first retry of a failed snapshot download, which doubles with every retry
+const loadURLBackoff time.Duration = time.Second
+
+// LoadURL is a method of the Cache struct that downloads a snapshot written with Save or SaveSnapshot and loads it like Load,
+// so a snapshot built once, e.g. in CI, can be loaded by every server at startup. The download is retried with an exponential
+// backoff if it fails, returns a status other than 200, or doesn't match the checksum.
+// This method is thread-safe.
+//
+// Parameters:
+//   - url (string): The http or https url of the snapshot.
+//   - checksum (string): The hex-encoded SHA-256 checksum of the snapshot, or an empty string to skip the verification.
+//   - retries (int): The number of times to retry the download.
+//
+// Returns:
+//   - error: An error if the url or retries are invalid, every download failed, or the snapshot could not be loaded.
+func (c *Cache) LoadURL(url string, checksum string, retries int) error {
+	switch {
+	case !strings.HasPrefix(url, "http://") && !strings.HasPrefix(url, "https://"):
+		return errors.New("invalid url")
+	case retries < 0:
+		return errors.New("invalid retries")
+	}
+
+	// Download the snapshot, retrying with a backoff
+	var (
+		data    []byte
+		err     error
+		backoff time.Duration = loadURLBackoff
+	)
+	for attempt := 0; attempt <= retries; attempt++ {
+		if attempt > 0 {
+			time.Sleep(backoff)
+			backoff *= 2
+		}
+		if data, err = downloadSnapshot(url, checksum); err == nil {
+			break
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	// Decode the snapshot
+	var s snapshot
+	if err := decodeSnapshot(data, &s); err != nil {
+		return err
+	}
+
+	// Lock the mutex
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	// Load the snapshot into the cache
+	return c.load(&s)
+}
+
+// downloadSnapshot is a function that downloads a snapshot and verifies its checksum.
+//
+// Parameters:
+//   - url (string): The url of the snapshot.
+//   - checksum (string): The hex-encoded SHA-256 checksum of the snapshot, or an empty string to skip the verification.
+//
+// Returns:
+//   - []byte: The snapshot.
+//   - error: An error if the download failed or the snapshot doesn't match the checksum.
+func downloadSnapshot(url string, checksum string) ([]byte, error) {
+	var client *http.Client = &http.Client{Timeout: loadURLTimeout}
+	res, err := client.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	// Read the snapshot
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("snapshot download failed with status %s", res.Status)
+	}
+	data, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	// Verify the checksum
+	if len(checksum) > 0 {
+		var sum [sha256.Size]byte = sha256.Sum256(data)
+		if !strings.EqualFold(hex.EncodeToString(sum[:]), checksum) {
+			return nil, errors.New("snapshot checksum mismatch")
+		}
+	}
+	return data, nil
+}
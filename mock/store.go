@@ -0,0 +1,1593 @@
+package mock
+
+import (
+	"context"
+	"io"
+	"sync"
+	"time"
+
+	hermes "github.com/realTristan/hermes"
+)
+
+// Call is a struct that represents a call made to a Store method.
+// Fields:
+//   - Method (string): The name of the method.
+//   - Args ([]any): The arguments the method was called with.
+type Call struct {
+	Method string
+	Args   []any
+}
+
+// Store is a mock implementation of the hermes.Store interface.
+// Each method calls the function in the field of the same name with the Func suffix, for example Set calls SetFunc.
+// Calling a method whose function is nil panics, so a test only needs to stub the methods that it expects to be called.
+// Every call is recorded and can be inspected with Calls.
+// Store is safe for concurrent use if the stubbed functions are.
+type Store struct {
+	GetFunc                   func(key string) map[string]any
+	GetCopyFunc               func(key string) map[string]any
+	GetWithVersionFunc        func(key string) (map[string]any, uint64, bool)
+	GetOrLoadFunc             func(key string) (map[string]any, error)
+	SetLoaderFunc             func(fn func(key string) (map[string]any, error))
+	VersionFunc               func(key string) (uint64, bool)
+	SetFunc                   func(key string, value map[string]any) error
+	ImportCSVFunc             func(file string, keyColumn string, fullText ...string) (int, error)
+	SetWithTTLFunc            func(key string, value map[string]any, ttl time.Duration) error
+	SetCtxFunc                func(ctx context.Context, key string, value map[string]any) error
+	ReplaceFunc               func(key string, value map[string]any, version uint64) error
+	UpdateFunc                func(key string, fields map[string]any, version uint64) error
+	GetFieldFunc              func(key string, path string) (any, error)
+	SetFieldFunc              func(key string, path string, field any) error
+	DeleteFunc                func(key string)
+	DeleteMatchingFunc        func(pattern string) ([]string, error)
+	ExistsFunc                func(key string) bool
+	ExpireFunc                func(key string, ttl time.Duration) error
+	SetExpiryFieldFunc        func(field string)
+	SetRecordModeFunc         func(mode hermes.RecordMode) error
+	FreezeFunc                func()
+	UnfreezeFunc              func()
+	FrozenFunc                func() bool
+	TouchFunc                 func(key string, ttl time.Duration) error
+	TTLFunc                   func(key string) (time.Duration, bool)
+	EnableHistoryFunc         func(limit int) error
+	DisableHistoryFunc        func()
+	HistoryFunc               func(key string) ([]hermes.Revision, error)
+	OnExpireFunc              func(fn func(key string, value map[string]any))
+	DeleteExpiredFunc         func() int
+	SetWriteRateLimitFunc     func(limit int, per time.Duration) error
+	OnRateLimitFunc           func(fn func(err *hermes.RateLimitError))
+	AddSinkFunc               func(s hermes.Sink, opts hermes.SinkOptions) error
+	KeysFunc                  func() []string
+	KeysWithPrefixFunc        func(prefix string) []string
+	KeysMatchingFunc          func(pattern string) ([]string, error)
+	ValuesFunc                func() []map[string]any
+	ValuesCopyFunc            func() []map[string]any
+	RangeFunc                 func(fn func(key string, value map[string]any) bool)
+	LengthFunc                func() int
+	CleanFunc                 func()
+	ClearFunc                 func(keepFullText bool)
+	CloneFunc                 func() *hermes.Cache
+	GenerationFunc            func() uint64
+	InfoFunc                  func() (map[string]any, error)
+	InfoForTestingFunc        func() (map[string]any, error)
+	StatsFunc                 func() (hermes.Stats, error)
+	SetSearchShadowFunc       func(shadow *hermes.Cache, percent float64, fn func(diff hermes.ShadowDiff)) error
+	SetQueryCacheFunc         func(size int) error
+	SetDuplicateModeFunc      func(mode hermes.DuplicateMode) error
+	DuplicateOfFunc           func(key string) (string, bool)
+	VerifyFunc                func() error
+	SetConsistencyCheckFunc   func(enabled bool)
+	SetExpectedSizeFunc       func(n int) error
+	ResetStatsFunc            func()
+	WithNamespaceFunc         func(ns string) (*hermes.Namespace, error)
+	CreateNamespaceFunc       func(ns string) (*hermes.Namespace, error)
+	NamespacesFunc            func() []string
+	DeleteNamespaceFunc       func(ns string) (int, error)
+	SetComputedFieldFunc      func(field string, fn func(value map[string]any) any, fullText bool) error
+	ComputedFieldsFunc        func() []string
+	CreateUniqueFunc          func(fields ...string) error
+	DropUniqueFunc            func(fields ...string) error
+	UniquesFunc               func() [][]string
+	CreateIndexFunc           func(field string) error
+	DropIndexFunc             func(field string) error
+	IndexesFunc               func() []string
+	FindFunc                  func(field string, value any) ([]map[string]any, error)
+	CreateBoolIndexFunc       func(field string) error
+	DropBoolIndexFunc         func(field string) error
+	BoolIndexesFunc           func() []string
+	CreateNumericIndexFunc    func(field string) error
+	DropNumericIndexFunc      func(field string) error
+	SetToleranceFunc          func(field string, t hermes.Tolerance) error
+	FilterFunc                func(expr string) ([]map[string]any, error)
+	FacetCountsFunc           func(field string, expr string) (map[string]int, error)
+	SaveFunc                  func(path string) error
+	SetSnapshotCodecFunc      func(codec hermes.Codec) error
+	EnableCompressionFunc     func(threshold int) error
+	ExportJsonFunc            func(w io.Writer) error
+	ExportNDJsonFunc          func(w io.Writer) error
+	LoadFunc                  func(path string) error
+	LoadURLFunc               func(url string, checksum string, retries int) error
+	EnableAutoPersistFunc     func(path string, interval time.Duration) error
+	EnableSnapshotHistoryFunc func(dir string, interval time.Duration, keep int) error
+	SaveSnapshotFunc          func(dir string) (string, error)
+	DisableAutoPersistFunc    func()
+	CloseFunc                 func() error
+	FTInitFunc                func(maxSize int, maxBytes int, minWordLength int) error
+	FTInitWithMapFunc         func(data map[string]map[string]any, maxSize int, maxBytes int, minWordLength int) error
+	FTInitWithJsonFunc        func(file string, maxSize int, maxBytes int, minWordLength int) error
+	FTInitWithYamlFunc        func(file string, maxSize int, maxBytes int, minWordLength int) error
+	FTInitWithCSVFunc         func(file string, keyColumn string, maxSize int, maxBytes int, minWordLength int, fullText ...string) error
+	FTInitWithStructFunc      func(v any, maxSize int, maxBytes int, minWordLength int) error
+	FTIsInitializedFunc       func() bool
+	FTCleanFunc               func() error
+	FTSchemaFunc              func() (map[string]bool, error)
+	FTReindexFunc             func(schema map[string]bool) error
+	FTStatsFunc               func() (hermes.FTStats, error)
+	FTCompactFunc             func() error
+	FTCreateIndexFunc         func(name string, schema map[string]bool) error
+	FTDropIndexFunc           func(name string) error
+	FTIndexesFunc             func() map[string][]string
+	FTSetMaxBytesFunc         func(maxBytes int) error
+	FTSetMaxSizeFunc          func(maxSize int) error
+	FTSetMinWordLengthFunc    func(minWordLength int) error
+	FTSetMaxWordLengthFunc    func(maxWordLength int) error
+	SetLimitWarningsFunc      func(thresholds ...float64) error
+	OnLimitWarningFunc        func(fn func(w hermes.LimitWarning))
+	FTSetTokenRuleFunc        func(field string, rule hermes.TokenRule) error
+	FTTokenRulesFunc          func() (map[string]hermes.TokenRule, error)
+	FTSetStemmerFunc          func(stemmer hermes.Stemmer) error
+	FTStemmerFunc             func() (hermes.Stemmer, error)
+	FTSetStopWordsFunc        func(words []string) error
+	FTStopWordsFunc           func() ([]string, error)
+	FTSetSkipNumbersFunc      func(skip bool) error
+	FTSetCaseSensitiveFunc    func(sensitive bool) error
+	FTCaseSensitiveFunc       func() (bool, error)
+	FTSetTokenFilterFunc      func(filter hermes.TokenFilter) error
+	FTSetAnalyzerFunc         func(analyzer hermes.Analyzer) error
+	FTAnalyzerFunc            func() (hermes.Analyzer, error)
+	FTSetFieldAnalyzersFunc   func(analyzers map[string]hermes.Analyzer) error
+	FTFieldAnalyzersFunc      func() (map[string]hermes.Analyzer, error)
+	FTSetFieldWeightsFunc     func(weights map[string]float64) error
+	FTFieldWeightsFunc        func() (map[string]float64, error)
+	FTSaveFunc                func(path string) error
+	FTLoadFunc                func(path string) error
+	FTWriteToFunc             func(w io.Writer) (int64, error)
+	FTReadFromFunc            func(r io.Reader) (int64, error)
+	FTStorageFunc             func() (map[string]any, error)
+	FTStorageSizeFunc         func() (int, error)
+	FTStorageLengthFunc       func() (int, error)
+	FTSequenceIndicesFunc     func()
+	FTKeysForWordFunc         func(word string) (map[string][]string, error)
+	SearchFunc                func(sp hermes.SearchParams) ([]map[string]any, error)
+	SubscribeFunc             func(sp hermes.SearchParams, fn func(id string, key string, value map[string]any)) (string, error)
+	UnsubscribeFunc           func(id string) error
+	SubscriptionsFunc         func() map[string]hermes.SearchParams
+	PercolateFunc             func(doc map[string]any) []string
+	SearchCtxFunc             func(ctx context.Context, sp hermes.SearchParams) ([]map[string]any, error)
+	SearchCursorFunc          func(sp hermes.SearchParams) ([]map[string]any, string, error)
+	SearchCursorCtxFunc       func(ctx context.Context, sp hermes.SearchParams) ([]map[string]any, string, error)
+	SearchOneWordFunc         func(sp hermes.SearchParams) ([]map[string]any, error)
+	SearchRegexFunc           func(pattern string, limit int) ([]map[string]any, error)
+	SearchValuesFunc          func(sp hermes.SearchParams) ([]map[string]any, error)
+	SearchWithKeyFunc         func(sp hermes.SearchParams) ([]map[string]any, error)
+	SearchGroupsFunc          func(sp hermes.SearchParams) ([]hermes.Group, error)
+	SuggestFunc               func(prefix string, limit int) []string
+	SearchScoredFunc          func(sp hermes.SearchParams) ([]hermes.Result, error)
+	SearchPageFunc            func(sp hermes.SearchParams, size int) (hermes.Page, error)
+	NextPageFunc              func(cursor string, size int) (hermes.Page, error)
+	SetBM25Func               func(k1 float64, b float64) error
+
+	mutex sync.Mutex
+	calls []Call
+}
+
+// Verify that the Store implements the hermes.Store interface
+var _ hermes.Store = (*Store)(nil)
+
+// Calls is a method of the Store struct that returns the calls made to the store, in order.
+// Parameters:
+//   - methods (...string): The names of the methods to return the calls for. If empty, every call is returned.
+//
+// Returns:
+//   - []Call: The recorded calls.
+func (m *Store) Calls(methods ...string) []Call {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	// Filter the calls by method
+	var calls []Call = []Call{}
+	for _, call := range m.calls {
+		if len(methods) == 0 {
+			calls = append(calls, call)
+			continue
+		}
+		for _, method := range methods {
+			if call.Method == method {
+				calls = append(calls, call)
+				break
+			}
+		}
+	}
+	return calls
+}
+
+// record is a method of the Store struct that records a call made to the store.
+// Parameters:
+//   - method (string): The name of the method.
+//   - args (...any): The arguments the method was called with.
+//
+// Returns:
+//   - None
+func (m *Store) record(method string, args ...any) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.calls = append(m.calls, Call{Method: method, Args: args})
+}
+
+// Get records the call and calls GetFunc.
+func (m *Store) Get(key string) map[string]any {
+	m.record("Get", key)
+	if m.GetFunc == nil {
+		panic("mock: Store.Get is not implemented")
+	}
+	return m.GetFunc(key)
+}
+
+// GetCopy records the call and calls GetCopyFunc.
+func (m *Store) GetCopy(key string) map[string]any {
+	m.record("GetCopy", key)
+	if m.GetCopyFunc == nil {
+		panic("mock: Store.GetCopy is not implemented")
+	}
+	return m.GetCopyFunc(key)
+}
+
+// GetWithVersion records the call and calls GetWithVersionFunc.
+func (m *Store) GetWithVersion(key string) (map[string]any, uint64, bool) {
+	m.record("GetWithVersion", key)
+	if m.GetWithVersionFunc == nil {
+		panic("mock: Store.GetWithVersion is not implemented")
+	}
+	return m.GetWithVersionFunc(key)
+}
+
+// GetOrLoad records the call and calls GetOrLoadFunc.
+func (m *Store) GetOrLoad(key string) (map[string]any, error) {
+	m.record("GetOrLoad", key)
+	if m.GetOrLoadFunc == nil {
+		panic("mock: Store.GetOrLoad is not implemented")
+	}
+	return m.GetOrLoadFunc(key)
+}
+
+// SetLoader records the call and calls SetLoaderFunc.
+func (m *Store) SetLoader(fn func(key string) (map[string]any, error)) {
+	m.record("SetLoader", fn)
+	if m.SetLoaderFunc == nil {
+		panic("mock: Store.SetLoader is not implemented")
+	}
+	m.SetLoaderFunc(fn)
+}
+
+// Version records the call and calls VersionFunc.
+func (m *Store) Version(key string) (uint64, bool) {
+	m.record("Version", key)
+	if m.VersionFunc == nil {
+		panic("mock: Store.Version is not implemented")
+	}
+	return m.VersionFunc(key)
+}
+
+// Set records the call and calls SetFunc.
+func (m *Store) Set(key string, value map[string]any) error {
+	m.record("Set", key, value)
+	if m.SetFunc == nil {
+		panic("mock: Store.Set is not implemented")
+	}
+	return m.SetFunc(key, value)
+}
+
+// ImportCSV records the call and calls ImportCSVFunc.
+func (m *Store) ImportCSV(file string, keyColumn string, fullText ...string) (int, error) {
+	m.record("ImportCSV", file, keyColumn, fullText)
+	if m.ImportCSVFunc == nil {
+		panic("mock: Store.ImportCSV is not implemented")
+	}
+	return m.ImportCSVFunc(file, keyColumn, fullText...)
+}
+
+// SetWithTTL records the call and calls SetWithTTLFunc.
+func (m *Store) SetWithTTL(key string, value map[string]any, ttl time.Duration) error {
+	m.record("SetWithTTL", key, value, ttl)
+	if m.SetWithTTLFunc == nil {
+		panic("mock: Store.SetWithTTL is not implemented")
+	}
+	return m.SetWithTTLFunc(key, value, ttl)
+}
+
+// SetCtx records the call and calls SetCtxFunc.
+func (m *Store) SetCtx(ctx context.Context, key string, value map[string]any) error {
+	m.record("SetCtx", ctx, key, value)
+	if m.SetCtxFunc == nil {
+		panic("mock: Store.SetCtx is not implemented")
+	}
+	return m.SetCtxFunc(ctx, key, value)
+}
+
+// Replace records the call and calls ReplaceFunc.
+func (m *Store) Replace(key string, value map[string]any, version uint64) error {
+	m.record("Replace", key, value, version)
+	if m.ReplaceFunc == nil {
+		panic("mock: Store.Replace is not implemented")
+	}
+	return m.ReplaceFunc(key, value, version)
+}
+
+// Update records the call and calls UpdateFunc.
+func (m *Store) Update(key string, fields map[string]any, version uint64) error {
+	m.record("Update", key, fields, version)
+	if m.UpdateFunc == nil {
+		panic("mock: Store.Update is not implemented")
+	}
+	return m.UpdateFunc(key, fields, version)
+}
+
+// GetField records the call and calls GetFieldFunc.
+func (m *Store) GetField(key string, path string) (any, error) {
+	m.record("GetField", key, path)
+	if m.GetFieldFunc == nil {
+		panic("mock: Store.GetField is not implemented")
+	}
+	return m.GetFieldFunc(key, path)
+}
+
+// SetField records the call and calls SetFieldFunc.
+func (m *Store) SetField(key string, path string, field any) error {
+	m.record("SetField", key, path, field)
+	if m.SetFieldFunc == nil {
+		panic("mock: Store.SetField is not implemented")
+	}
+	return m.SetFieldFunc(key, path, field)
+}
+
+// Delete records the call and calls DeleteFunc.
+func (m *Store) Delete(key string) {
+	m.record("Delete", key)
+	if m.DeleteFunc == nil {
+		panic("mock: Store.Delete is not implemented")
+	}
+	m.DeleteFunc(key)
+}
+
+// DeleteMatching records the call and calls DeleteMatchingFunc.
+func (m *Store) DeleteMatching(pattern string) ([]string, error) {
+	m.record("DeleteMatching", pattern)
+	if m.DeleteMatchingFunc == nil {
+		panic("mock: Store.DeleteMatching is not implemented")
+	}
+	return m.DeleteMatchingFunc(pattern)
+}
+
+// Exists records the call and calls ExistsFunc.
+func (m *Store) Exists(key string) bool {
+	m.record("Exists", key)
+	if m.ExistsFunc == nil {
+		panic("mock: Store.Exists is not implemented")
+	}
+	return m.ExistsFunc(key)
+}
+
+// Expire records the call and calls ExpireFunc.
+func (m *Store) Expire(key string, ttl time.Duration) error {
+	m.record("Expire", key, ttl)
+	if m.ExpireFunc == nil {
+		panic("mock: Store.Expire is not implemented")
+	}
+	return m.ExpireFunc(key, ttl)
+}
+
+// SetExpiryField records the call and calls SetExpiryFieldFunc.
+func (m *Store) SetExpiryField(field string) {
+	m.record("SetExpiryField", field)
+	if m.SetExpiryFieldFunc == nil {
+		panic("mock: Store.SetExpiryField is not implemented")
+	}
+	m.SetExpiryFieldFunc(field)
+}
+
+// SetRecordMode records the call and calls SetRecordModeFunc.
+func (m *Store) SetRecordMode(mode hermes.RecordMode) error {
+	m.record("SetRecordMode", mode)
+	if m.SetRecordModeFunc == nil {
+		panic("mock: Store.SetRecordMode is not implemented")
+	}
+	return m.SetRecordModeFunc(mode)
+}
+
+// Freeze records the call and calls FreezeFunc.
+func (m *Store) Freeze() {
+	m.record("Freeze")
+	if m.FreezeFunc == nil {
+		panic("mock: Store.Freeze is not implemented")
+	}
+	m.FreezeFunc()
+}
+
+// Unfreeze records the call and calls UnfreezeFunc.
+func (m *Store) Unfreeze() {
+	m.record("Unfreeze")
+	if m.UnfreezeFunc == nil {
+		panic("mock: Store.Unfreeze is not implemented")
+	}
+	m.UnfreezeFunc()
+}
+
+// Frozen records the call and calls FrozenFunc.
+func (m *Store) Frozen() bool {
+	m.record("Frozen")
+	if m.FrozenFunc == nil {
+		panic("mock: Store.Frozen is not implemented")
+	}
+	return m.FrozenFunc()
+}
+
+// Touch records the call and calls TouchFunc.
+func (m *Store) Touch(key string, ttl time.Duration) error {
+	m.record("Touch", key, ttl)
+	if m.TouchFunc == nil {
+		panic("mock: Store.Touch is not implemented")
+	}
+	return m.TouchFunc(key, ttl)
+}
+
+// TTL records the call and calls TTLFunc.
+func (m *Store) TTL(key string) (time.Duration, bool) {
+	m.record("TTL", key)
+	if m.TTLFunc == nil {
+		panic("mock: Store.TTL is not implemented")
+	}
+	return m.TTLFunc(key)
+}
+
+// EnableHistory records the call and calls EnableHistoryFunc.
+func (m *Store) EnableHistory(limit int) error {
+	m.record("EnableHistory", limit)
+	if m.EnableHistoryFunc == nil {
+		panic("mock: Store.EnableHistory is not implemented")
+	}
+	return m.EnableHistoryFunc(limit)
+}
+
+// DisableHistory records the call and calls DisableHistoryFunc.
+func (m *Store) DisableHistory() {
+	m.record("DisableHistory")
+	if m.DisableHistoryFunc == nil {
+		panic("mock: Store.DisableHistory is not implemented")
+	}
+	m.DisableHistoryFunc()
+}
+
+// History records the call and calls HistoryFunc.
+func (m *Store) History(key string) ([]hermes.Revision, error) {
+	m.record("History", key)
+	if m.HistoryFunc == nil {
+		panic("mock: Store.History is not implemented")
+	}
+	return m.HistoryFunc(key)
+}
+
+// OnExpire records the call and calls OnExpireFunc.
+func (m *Store) OnExpire(fn func(key string, value map[string]any)) {
+	m.record("OnExpire", fn)
+	if m.OnExpireFunc == nil {
+		panic("mock: Store.OnExpire is not implemented")
+	}
+	m.OnExpireFunc(fn)
+}
+
+// DeleteExpired records the call and calls DeleteExpiredFunc.
+func (m *Store) DeleteExpired() int {
+	m.record("DeleteExpired")
+	if m.DeleteExpiredFunc == nil {
+		panic("mock: Store.DeleteExpired is not implemented")
+	}
+	return m.DeleteExpiredFunc()
+}
+
+// SetWriteRateLimit records the call and calls SetWriteRateLimitFunc.
+func (m *Store) SetWriteRateLimit(limit int, per time.Duration) error {
+	m.record("SetWriteRateLimit", limit, per)
+	if m.SetWriteRateLimitFunc == nil {
+		panic("mock: Store.SetWriteRateLimit is not implemented")
+	}
+	return m.SetWriteRateLimitFunc(limit, per)
+}
+
+// OnRateLimit records the call and calls OnRateLimitFunc.
+func (m *Store) OnRateLimit(fn func(err *hermes.RateLimitError)) {
+	m.record("OnRateLimit", fn)
+	if m.OnRateLimitFunc == nil {
+		panic("mock: Store.OnRateLimit is not implemented")
+	}
+	m.OnRateLimitFunc(fn)
+}
+
+// AddSink records the call and calls AddSinkFunc.
+func (m *Store) AddSink(s hermes.Sink, opts hermes.SinkOptions) error {
+	m.record("AddSink", s, opts)
+	if m.AddSinkFunc == nil {
+		panic("mock: Store.AddSink is not implemented")
+	}
+	return m.AddSinkFunc(s, opts)
+}
+
+// Keys records the call and calls KeysFunc.
+func (m *Store) Keys() []string {
+	m.record("Keys")
+	if m.KeysFunc == nil {
+		panic("mock: Store.Keys is not implemented")
+	}
+	return m.KeysFunc()
+}
+
+// KeysWithPrefix records the call and calls KeysWithPrefixFunc.
+func (m *Store) KeysWithPrefix(prefix string) []string {
+	m.record("KeysWithPrefix", prefix)
+	if m.KeysWithPrefixFunc == nil {
+		panic("mock: Store.KeysWithPrefix is not implemented")
+	}
+	return m.KeysWithPrefixFunc(prefix)
+}
+
+// KeysMatching records the call and calls KeysMatchingFunc.
+func (m *Store) KeysMatching(pattern string) ([]string, error) {
+	m.record("KeysMatching", pattern)
+	if m.KeysMatchingFunc == nil {
+		panic("mock: Store.KeysMatching is not implemented")
+	}
+	return m.KeysMatchingFunc(pattern)
+}
+
+// Values records the call and calls ValuesFunc.
+func (m *Store) Values() []map[string]any {
+	m.record("Values")
+	if m.ValuesFunc == nil {
+		panic("mock: Store.Values is not implemented")
+	}
+	return m.ValuesFunc()
+}
+
+// ValuesCopy records the call and calls ValuesCopyFunc.
+func (m *Store) ValuesCopy() []map[string]any {
+	m.record("ValuesCopy")
+	if m.ValuesCopyFunc == nil {
+		panic("mock: Store.ValuesCopy is not implemented")
+	}
+	return m.ValuesCopyFunc()
+}
+
+// Range records the call and calls RangeFunc.
+func (m *Store) Range(fn func(key string, value map[string]any) bool) {
+	m.record("Range", fn)
+	if m.RangeFunc == nil {
+		panic("mock: Store.Range is not implemented")
+	}
+	m.RangeFunc(fn)
+}
+
+// Length records the call and calls LengthFunc.
+func (m *Store) Length() int {
+	m.record("Length")
+	if m.LengthFunc == nil {
+		panic("mock: Store.Length is not implemented")
+	}
+	return m.LengthFunc()
+}
+
+// Clean records the call and calls CleanFunc.
+func (m *Store) Clean() {
+	m.record("Clean")
+	if m.CleanFunc == nil {
+		panic("mock: Store.Clean is not implemented")
+	}
+	m.CleanFunc()
+}
+
+// Clear records the call and calls ClearFunc.
+func (m *Store) Clear(keepFullText bool) {
+	m.record("Clear", keepFullText)
+	if m.ClearFunc == nil {
+		panic("mock: Store.Clear is not implemented")
+	}
+	m.ClearFunc(keepFullText)
+}
+
+// Clone records the call and calls CloneFunc.
+func (m *Store) Clone() *hermes.Cache {
+	m.record("Clone")
+	if m.CloneFunc == nil {
+		panic("mock: Store.Clone is not implemented")
+	}
+	return m.CloneFunc()
+}
+
+// Generation records the call and calls GenerationFunc.
+func (m *Store) Generation() uint64 {
+	m.record("Generation")
+	if m.GenerationFunc == nil {
+		panic("mock: Store.Generation is not implemented")
+	}
+	return m.GenerationFunc()
+}
+
+// Info records the call and calls InfoFunc.
+func (m *Store) Info() (map[string]any, error) {
+	m.record("Info")
+	if m.InfoFunc == nil {
+		panic("mock: Store.Info is not implemented")
+	}
+	return m.InfoFunc()
+}
+
+// InfoForTesting records the call and calls InfoForTestingFunc.
+func (m *Store) InfoForTesting() (map[string]any, error) {
+	m.record("InfoForTesting")
+	if m.InfoForTestingFunc == nil {
+		panic("mock: Store.InfoForTesting is not implemented")
+	}
+	return m.InfoForTestingFunc()
+}
+
+// Stats records the call and calls StatsFunc.
+func (m *Store) Stats() (hermes.Stats, error) {
+	m.record("Stats")
+	if m.StatsFunc == nil {
+		panic("mock: Store.Stats is not implemented")
+	}
+	return m.StatsFunc()
+}
+
+// SetSearchShadow records the call and calls SetSearchShadowFunc.
+func (m *Store) SetSearchShadow(shadow *hermes.Cache, percent float64, fn func(diff hermes.ShadowDiff)) error {
+	m.record("SetSearchShadow", shadow, percent, fn)
+	if m.SetSearchShadowFunc == nil {
+		panic("mock: Store.SetSearchShadow is not implemented")
+	}
+	return m.SetSearchShadowFunc(shadow, percent, fn)
+}
+
+// SetQueryCache records the call and calls SetQueryCacheFunc.
+func (m *Store) SetQueryCache(size int) error {
+	m.record("SetQueryCache", size)
+	if m.SetQueryCacheFunc == nil {
+		panic("mock: Store.SetQueryCache is not implemented")
+	}
+	return m.SetQueryCacheFunc(size)
+}
+
+// SetDuplicateMode records the call and calls SetDuplicateModeFunc.
+func (m *Store) SetDuplicateMode(mode hermes.DuplicateMode) error {
+	m.record("SetDuplicateMode", mode)
+	if m.SetDuplicateModeFunc == nil {
+		panic("mock: Store.SetDuplicateMode is not implemented")
+	}
+	return m.SetDuplicateModeFunc(mode)
+}
+
+// DuplicateOf records the call and calls DuplicateOfFunc.
+func (m *Store) DuplicateOf(key string) (string, bool) {
+	m.record("DuplicateOf", key)
+	if m.DuplicateOfFunc == nil {
+		panic("mock: Store.DuplicateOf is not implemented")
+	}
+	return m.DuplicateOfFunc(key)
+}
+
+// Verify records the call and calls VerifyFunc.
+func (m *Store) Verify() error {
+	m.record("Verify")
+	if m.VerifyFunc == nil {
+		panic("mock: Store.Verify is not implemented")
+	}
+	return m.VerifyFunc()
+}
+
+// SetConsistencyCheck records the call and calls SetConsistencyCheckFunc.
+func (m *Store) SetConsistencyCheck(enabled bool) {
+	m.record("SetConsistencyCheck", enabled)
+	if m.SetConsistencyCheckFunc == nil {
+		panic("mock: Store.SetConsistencyCheck is not implemented")
+	}
+	m.SetConsistencyCheckFunc(enabled)
+}
+
+// SetExpectedSize records the call and calls SetExpectedSizeFunc.
+func (m *Store) SetExpectedSize(n int) error {
+	m.record("SetExpectedSize", n)
+	if m.SetExpectedSizeFunc == nil {
+		panic("mock: Store.SetExpectedSize is not implemented")
+	}
+	return m.SetExpectedSizeFunc(n)
+}
+
+// ResetStats records the call and calls ResetStatsFunc.
+func (m *Store) ResetStats() {
+	m.record("ResetStats")
+	if m.ResetStatsFunc == nil {
+		panic("mock: Store.ResetStats is not implemented")
+	}
+	m.ResetStatsFunc()
+}
+
+// WithNamespace records the call and calls WithNamespaceFunc.
+func (m *Store) WithNamespace(ns string) (*hermes.Namespace, error) {
+	m.record("WithNamespace", ns)
+	if m.WithNamespaceFunc == nil {
+		panic("mock: Store.WithNamespace is not implemented")
+	}
+	return m.WithNamespaceFunc(ns)
+}
+
+// CreateNamespace records the call and calls CreateNamespaceFunc.
+func (m *Store) CreateNamespace(ns string) (*hermes.Namespace, error) {
+	m.record("CreateNamespace", ns)
+	if m.CreateNamespaceFunc == nil {
+		panic("mock: Store.CreateNamespace is not implemented")
+	}
+	return m.CreateNamespaceFunc(ns)
+}
+
+// Namespaces records the call and calls NamespacesFunc.
+func (m *Store) Namespaces() []string {
+	m.record("Namespaces")
+	if m.NamespacesFunc == nil {
+		panic("mock: Store.Namespaces is not implemented")
+	}
+	return m.NamespacesFunc()
+}
+
+// DeleteNamespace records the call and calls DeleteNamespaceFunc.
+func (m *Store) DeleteNamespace(ns string) (int, error) {
+	m.record("DeleteNamespace", ns)
+	if m.DeleteNamespaceFunc == nil {
+		panic("mock: Store.DeleteNamespace is not implemented")
+	}
+	return m.DeleteNamespaceFunc(ns)
+}
+
+// SetComputedField records the call and calls SetComputedFieldFunc.
+func (m *Store) SetComputedField(field string, fn func(value map[string]any) any, fullText bool) error {
+	m.record("SetComputedField", field, fn, fullText)
+	if m.SetComputedFieldFunc == nil {
+		panic("mock: Store.SetComputedField is not implemented")
+	}
+	return m.SetComputedFieldFunc(field, fn, fullText)
+}
+
+// ComputedFields records the call and calls ComputedFieldsFunc.
+func (m *Store) ComputedFields() []string {
+	m.record("ComputedFields")
+	if m.ComputedFieldsFunc == nil {
+		panic("mock: Store.ComputedFields is not implemented")
+	}
+	return m.ComputedFieldsFunc()
+}
+
+// CreateUnique records the call and calls CreateUniqueFunc.
+func (m *Store) CreateUnique(fields ...string) error {
+	m.record("CreateUnique", fields)
+	if m.CreateUniqueFunc == nil {
+		panic("mock: Store.CreateUnique is not implemented")
+	}
+	return m.CreateUniqueFunc(fields...)
+}
+
+// DropUnique records the call and calls DropUniqueFunc.
+func (m *Store) DropUnique(fields ...string) error {
+	m.record("DropUnique", fields)
+	if m.DropUniqueFunc == nil {
+		panic("mock: Store.DropUnique is not implemented")
+	}
+	return m.DropUniqueFunc(fields...)
+}
+
+// Uniques records the call and calls UniquesFunc.
+func (m *Store) Uniques() [][]string {
+	m.record("Uniques")
+	if m.UniquesFunc == nil {
+		panic("mock: Store.Uniques is not implemented")
+	}
+	return m.UniquesFunc()
+}
+
+// CreateIndex records the call and calls CreateIndexFunc.
+func (m *Store) CreateIndex(field string) error {
+	m.record("CreateIndex", field)
+	if m.CreateIndexFunc == nil {
+		panic("mock: Store.CreateIndex is not implemented")
+	}
+	return m.CreateIndexFunc(field)
+}
+
+// DropIndex records the call and calls DropIndexFunc.
+func (m *Store) DropIndex(field string) error {
+	m.record("DropIndex", field)
+	if m.DropIndexFunc == nil {
+		panic("mock: Store.DropIndex is not implemented")
+	}
+	return m.DropIndexFunc(field)
+}
+
+// Indexes records the call and calls IndexesFunc.
+func (m *Store) Indexes() []string {
+	m.record("Indexes")
+	if m.IndexesFunc == nil {
+		panic("mock: Store.Indexes is not implemented")
+	}
+	return m.IndexesFunc()
+}
+
+// Find records the call and calls FindFunc.
+func (m *Store) Find(field string, value any) ([]map[string]any, error) {
+	m.record("Find", field, value)
+	if m.FindFunc == nil {
+		panic("mock: Store.Find is not implemented")
+	}
+	return m.FindFunc(field, value)
+}
+
+// CreateBoolIndex records the call and calls CreateBoolIndexFunc.
+func (m *Store) CreateBoolIndex(field string) error {
+	m.record("CreateBoolIndex", field)
+	if m.CreateBoolIndexFunc == nil {
+		panic("mock: Store.CreateBoolIndex is not implemented")
+	}
+	return m.CreateBoolIndexFunc(field)
+}
+
+// DropBoolIndex records the call and calls DropBoolIndexFunc.
+func (m *Store) DropBoolIndex(field string) error {
+	m.record("DropBoolIndex", field)
+	if m.DropBoolIndexFunc == nil {
+		panic("mock: Store.DropBoolIndex is not implemented")
+	}
+	return m.DropBoolIndexFunc(field)
+}
+
+// BoolIndexes records the call and calls BoolIndexesFunc.
+func (m *Store) BoolIndexes() []string {
+	m.record("BoolIndexes")
+	if m.BoolIndexesFunc == nil {
+		panic("mock: Store.BoolIndexes is not implemented")
+	}
+	return m.BoolIndexesFunc()
+}
+
+// CreateNumericIndex records the call and calls CreateNumericIndexFunc.
+func (m *Store) CreateNumericIndex(field string) error {
+	m.record("CreateNumericIndex", field)
+	if m.CreateNumericIndexFunc == nil {
+		panic("mock: Store.CreateNumericIndex is not implemented")
+	}
+	return m.CreateNumericIndexFunc(field)
+}
+
+// DropNumericIndex records the call and calls DropNumericIndexFunc.
+func (m *Store) DropNumericIndex(field string) error {
+	m.record("DropNumericIndex", field)
+	if m.DropNumericIndexFunc == nil {
+		panic("mock: Store.DropNumericIndex is not implemented")
+	}
+	return m.DropNumericIndexFunc(field)
+}
+
+// SetTolerance records the call and calls SetToleranceFunc.
+func (m *Store) SetTolerance(field string, t hermes.Tolerance) error {
+	m.record("SetTolerance", field, t)
+	if m.SetToleranceFunc == nil {
+		panic("mock: Store.SetTolerance is not implemented")
+	}
+	return m.SetToleranceFunc(field, t)
+}
+
+// Filter records the call and calls FilterFunc.
+func (m *Store) Filter(expr string) ([]map[string]any, error) {
+	m.record("Filter", expr)
+	if m.FilterFunc == nil {
+		panic("mock: Store.Filter is not implemented")
+	}
+	return m.FilterFunc(expr)
+}
+
+// FacetCounts records the call and calls FacetCountsFunc.
+func (m *Store) FacetCounts(field string, expr string) (map[string]int, error) {
+	m.record("FacetCounts", field, expr)
+	if m.FacetCountsFunc == nil {
+		panic("mock: Store.FacetCounts is not implemented")
+	}
+	return m.FacetCountsFunc(field, expr)
+}
+
+// Save records the call and calls SaveFunc.
+func (m *Store) Save(path string) error {
+	m.record("Save", path)
+	if m.SaveFunc == nil {
+		panic("mock: Store.Save is not implemented")
+	}
+	return m.SaveFunc(path)
+}
+
+// SetSnapshotCodec records the call and calls SetSnapshotCodecFunc.
+func (m *Store) SetSnapshotCodec(codec hermes.Codec) error {
+	m.record("SetSnapshotCodec", codec)
+	if m.SetSnapshotCodecFunc == nil {
+		panic("mock: Store.SetSnapshotCodec is not implemented")
+	}
+	return m.SetSnapshotCodecFunc(codec)
+}
+
+// EnableCompression records the call and calls EnableCompressionFunc.
+func (m *Store) EnableCompression(threshold int) error {
+	m.record("EnableCompression", threshold)
+	if m.EnableCompressionFunc == nil {
+		panic("mock: Store.EnableCompression is not implemented")
+	}
+	return m.EnableCompressionFunc(threshold)
+}
+
+// ExportJson records the call and calls ExportJsonFunc.
+func (m *Store) ExportJson(w io.Writer) error {
+	m.record("ExportJson", w)
+	if m.ExportJsonFunc == nil {
+		panic("mock: Store.ExportJson is not implemented")
+	}
+	return m.ExportJsonFunc(w)
+}
+
+// ExportNDJson records the call and calls ExportNDJsonFunc.
+func (m *Store) ExportNDJson(w io.Writer) error {
+	m.record("ExportNDJson", w)
+	if m.ExportNDJsonFunc == nil {
+		panic("mock: Store.ExportNDJson is not implemented")
+	}
+	return m.ExportNDJsonFunc(w)
+}
+
+// Load records the call and calls LoadFunc.
+func (m *Store) Load(path string) error {
+	m.record("Load", path)
+	if m.LoadFunc == nil {
+		panic("mock: Store.Load is not implemented")
+	}
+	return m.LoadFunc(path)
+}
+
+// LoadURL records the call and calls LoadURLFunc.
+func (m *Store) LoadURL(url string, checksum string, retries int) error {
+	m.record("LoadURL", url, checksum, retries)
+	if m.LoadURLFunc == nil {
+		panic("mock: Store.LoadURL is not implemented")
+	}
+	return m.LoadURLFunc(url, checksum, retries)
+}
+
+// EnableAutoPersist records the call and calls EnableAutoPersistFunc.
+func (m *Store) EnableAutoPersist(path string, interval time.Duration) error {
+	m.record("EnableAutoPersist", path, interval)
+	if m.EnableAutoPersistFunc == nil {
+		panic("mock: Store.EnableAutoPersist is not implemented")
+	}
+	return m.EnableAutoPersistFunc(path, interval)
+}
+
+// EnableSnapshotHistory records the call and calls EnableSnapshotHistoryFunc.
+func (m *Store) EnableSnapshotHistory(dir string, interval time.Duration, keep int) error {
+	m.record("EnableSnapshotHistory", dir, interval, keep)
+	if m.EnableSnapshotHistoryFunc == nil {
+		panic("mock: Store.EnableSnapshotHistory is not implemented")
+	}
+	return m.EnableSnapshotHistoryFunc(dir, interval, keep)
+}
+
+// SaveSnapshot records the call and calls SaveSnapshotFunc.
+func (m *Store) SaveSnapshot(dir string) (string, error) {
+	m.record("SaveSnapshot", dir)
+	if m.SaveSnapshotFunc == nil {
+		panic("mock: Store.SaveSnapshot is not implemented")
+	}
+	return m.SaveSnapshotFunc(dir)
+}
+
+// DisableAutoPersist records the call and calls DisableAutoPersistFunc.
+func (m *Store) DisableAutoPersist() {
+	m.record("DisableAutoPersist")
+	if m.DisableAutoPersistFunc == nil {
+		panic("mock: Store.DisableAutoPersist is not implemented")
+	}
+	m.DisableAutoPersistFunc()
+}
+
+// Close records the call and calls CloseFunc.
+func (m *Store) Close() error {
+	m.record("Close")
+	if m.CloseFunc == nil {
+		panic("mock: Store.Close is not implemented")
+	}
+	return m.CloseFunc()
+}
+
+// FTInit records the call and calls FTInitFunc.
+func (m *Store) FTInit(maxSize int, maxBytes int, minWordLength int) error {
+	m.record("FTInit", maxSize, maxBytes, minWordLength)
+	if m.FTInitFunc == nil {
+		panic("mock: Store.FTInit is not implemented")
+	}
+	return m.FTInitFunc(maxSize, maxBytes, minWordLength)
+}
+
+// FTInitWithMap records the call and calls FTInitWithMapFunc.
+func (m *Store) FTInitWithMap(data map[string]map[string]any, maxSize int, maxBytes int, minWordLength int) error {
+	m.record("FTInitWithMap", data, maxSize, maxBytes, minWordLength)
+	if m.FTInitWithMapFunc == nil {
+		panic("mock: Store.FTInitWithMap is not implemented")
+	}
+	return m.FTInitWithMapFunc(data, maxSize, maxBytes, minWordLength)
+}
+
+// FTInitWithJson records the call and calls FTInitWithJsonFunc.
+func (m *Store) FTInitWithJson(file string, maxSize int, maxBytes int, minWordLength int) error {
+	m.record("FTInitWithJson", file, maxSize, maxBytes, minWordLength)
+	if m.FTInitWithJsonFunc == nil {
+		panic("mock: Store.FTInitWithJson is not implemented")
+	}
+	return m.FTInitWithJsonFunc(file, maxSize, maxBytes, minWordLength)
+}
+
+// FTInitWithYaml records the call and calls FTInitWithYamlFunc.
+func (m *Store) FTInitWithYaml(file string, maxSize int, maxBytes int, minWordLength int) error {
+	m.record("FTInitWithYaml", file, maxSize, maxBytes, minWordLength)
+	if m.FTInitWithYamlFunc == nil {
+		panic("mock: Store.FTInitWithYaml is not implemented")
+	}
+	return m.FTInitWithYamlFunc(file, maxSize, maxBytes, minWordLength)
+}
+
+// FTInitWithCSV records the call and calls FTInitWithCSVFunc.
+func (m *Store) FTInitWithCSV(file string, keyColumn string, maxSize int, maxBytes int, minWordLength int, fullText ...string) error {
+	m.record("FTInitWithCSV", file, keyColumn, maxSize, maxBytes, minWordLength, fullText)
+	if m.FTInitWithCSVFunc == nil {
+		panic("mock: Store.FTInitWithCSV is not implemented")
+	}
+	return m.FTInitWithCSVFunc(file, keyColumn, maxSize, maxBytes, minWordLength, fullText...)
+}
+
+// FTInitWithStruct records the call and calls FTInitWithStructFunc.
+func (m *Store) FTInitWithStruct(v any, maxSize int, maxBytes int, minWordLength int) error {
+	m.record("FTInitWithStruct", v, maxSize, maxBytes, minWordLength)
+	if m.FTInitWithStructFunc == nil {
+		panic("mock: Store.FTInitWithStruct is not implemented")
+	}
+	return m.FTInitWithStructFunc(v, maxSize, maxBytes, minWordLength)
+}
+
+// FTIsInitialized records the call and calls FTIsInitializedFunc.
+func (m *Store) FTIsInitialized() bool {
+	m.record("FTIsInitialized")
+	if m.FTIsInitializedFunc == nil {
+		panic("mock: Store.FTIsInitialized is not implemented")
+	}
+	return m.FTIsInitializedFunc()
+}
+
+// FTClean records the call and calls FTCleanFunc.
+func (m *Store) FTClean() error {
+	m.record("FTClean")
+	if m.FTCleanFunc == nil {
+		panic("mock: Store.FTClean is not implemented")
+	}
+	return m.FTCleanFunc()
+}
+
+// FTSchema records the call and calls FTSchemaFunc.
+func (m *Store) FTSchema() (map[string]bool, error) {
+	m.record("FTSchema")
+	if m.FTSchemaFunc == nil {
+		panic("mock: Store.FTSchema is not implemented")
+	}
+	return m.FTSchemaFunc()
+}
+
+// FTReindex records the call and calls FTReindexFunc.
+func (m *Store) FTReindex(schema map[string]bool) error {
+	m.record("FTReindex", schema)
+	if m.FTReindexFunc == nil {
+		panic("mock: Store.FTReindex is not implemented")
+	}
+	return m.FTReindexFunc(schema)
+}
+
+// FTStats records the call and calls FTStatsFunc.
+func (m *Store) FTStats() (hermes.FTStats, error) {
+	m.record("FTStats")
+	if m.FTStatsFunc == nil {
+		panic("mock: Store.FTStats is not implemented")
+	}
+	return m.FTStatsFunc()
+}
+
+// FTCompact records the call and calls FTCompactFunc.
+func (m *Store) FTCompact() error {
+	m.record("FTCompact")
+	if m.FTCompactFunc == nil {
+		panic("mock: Store.FTCompact is not implemented")
+	}
+	return m.FTCompactFunc()
+}
+
+// FTCreateIndex records the call and calls FTCreateIndexFunc.
+func (m *Store) FTCreateIndex(name string, schema map[string]bool) error {
+	m.record("FTCreateIndex", name, schema)
+	if m.FTCreateIndexFunc == nil {
+		panic("mock: Store.FTCreateIndex is not implemented")
+	}
+	return m.FTCreateIndexFunc(name, schema)
+}
+
+// FTDropIndex records the call and calls FTDropIndexFunc.
+func (m *Store) FTDropIndex(name string) error {
+	m.record("FTDropIndex", name)
+	if m.FTDropIndexFunc == nil {
+		panic("mock: Store.FTDropIndex is not implemented")
+	}
+	return m.FTDropIndexFunc(name)
+}
+
+// FTIndexes records the call and calls FTIndexesFunc.
+func (m *Store) FTIndexes() map[string][]string {
+	m.record("FTIndexes")
+	if m.FTIndexesFunc == nil {
+		panic("mock: Store.FTIndexes is not implemented")
+	}
+	return m.FTIndexesFunc()
+}
+
+// FTSetMaxBytes records the call and calls FTSetMaxBytesFunc.
+func (m *Store) FTSetMaxBytes(maxBytes int) error {
+	m.record("FTSetMaxBytes", maxBytes)
+	if m.FTSetMaxBytesFunc == nil {
+		panic("mock: Store.FTSetMaxBytes is not implemented")
+	}
+	return m.FTSetMaxBytesFunc(maxBytes)
+}
+
+// FTSetMaxSize records the call and calls FTSetMaxSizeFunc.
+func (m *Store) FTSetMaxSize(maxSize int) error {
+	m.record("FTSetMaxSize", maxSize)
+	if m.FTSetMaxSizeFunc == nil {
+		panic("mock: Store.FTSetMaxSize is not implemented")
+	}
+	return m.FTSetMaxSizeFunc(maxSize)
+}
+
+// FTSetMinWordLength records the call and calls FTSetMinWordLengthFunc.
+func (m *Store) FTSetMinWordLength(minWordLength int) error {
+	m.record("FTSetMinWordLength", minWordLength)
+	if m.FTSetMinWordLengthFunc == nil {
+		panic("mock: Store.FTSetMinWordLength is not implemented")
+	}
+	return m.FTSetMinWordLengthFunc(minWordLength)
+}
+
+// FTSetMaxWordLength records the call and calls FTSetMaxWordLengthFunc.
+func (m *Store) FTSetMaxWordLength(maxWordLength int) error {
+	m.record("FTSetMaxWordLength", maxWordLength)
+	if m.FTSetMaxWordLengthFunc == nil {
+		panic("mock: Store.FTSetMaxWordLength is not implemented")
+	}
+	return m.FTSetMaxWordLengthFunc(maxWordLength)
+}
+
+// SetLimitWarnings records the call and calls SetLimitWarningsFunc.
+func (m *Store) SetLimitWarnings(thresholds ...float64) error {
+	m.record("SetLimitWarnings", thresholds)
+	if m.SetLimitWarningsFunc == nil {
+		panic("mock: Store.SetLimitWarnings is not implemented")
+	}
+	return m.SetLimitWarningsFunc(thresholds...)
+}
+
+// OnLimitWarning records the call and calls OnLimitWarningFunc.
+func (m *Store) OnLimitWarning(fn func(w hermes.LimitWarning)) {
+	m.record("OnLimitWarning", fn)
+	if m.OnLimitWarningFunc == nil {
+		panic("mock: Store.OnLimitWarning is not implemented")
+	}
+	m.OnLimitWarningFunc(fn)
+}
+
+// FTSetTokenRule records the call and calls FTSetTokenRuleFunc.
+func (m *Store) FTSetTokenRule(field string, rule hermes.TokenRule) error {
+	m.record("FTSetTokenRule", field, rule)
+	if m.FTSetTokenRuleFunc == nil {
+		panic("mock: Store.FTSetTokenRule is not implemented")
+	}
+	return m.FTSetTokenRuleFunc(field, rule)
+}
+
+// FTTokenRules records the call and calls FTTokenRulesFunc.
+func (m *Store) FTTokenRules() (map[string]hermes.TokenRule, error) {
+	m.record("FTTokenRules")
+	if m.FTTokenRulesFunc == nil {
+		panic("mock: Store.FTTokenRules is not implemented")
+	}
+	return m.FTTokenRulesFunc()
+}
+
+// FTSetStemmer records the call and calls FTSetStemmerFunc.
+func (m *Store) FTSetStemmer(stemmer hermes.Stemmer) error {
+	m.record("FTSetStemmer", stemmer)
+	if m.FTSetStemmerFunc == nil {
+		panic("mock: Store.FTSetStemmer is not implemented")
+	}
+	return m.FTSetStemmerFunc(stemmer)
+}
+
+// FTStemmer records the call and calls FTStemmerFunc.
+func (m *Store) FTStemmer() (hermes.Stemmer, error) {
+	m.record("FTStemmer")
+	if m.FTStemmerFunc == nil {
+		panic("mock: Store.FTStemmer is not implemented")
+	}
+	return m.FTStemmerFunc()
+}
+
+// FTSetStopWords records the call and calls FTSetStopWordsFunc.
+func (m *Store) FTSetStopWords(words []string) error {
+	m.record("FTSetStopWords", words)
+	if m.FTSetStopWordsFunc == nil {
+		panic("mock: Store.FTSetStopWords is not implemented")
+	}
+	return m.FTSetStopWordsFunc(words)
+}
+
+// FTStopWords records the call and calls FTStopWordsFunc.
+func (m *Store) FTStopWords() ([]string, error) {
+	m.record("FTStopWords")
+	if m.FTStopWordsFunc == nil {
+		panic("mock: Store.FTStopWords is not implemented")
+	}
+	return m.FTStopWordsFunc()
+}
+
+// FTSetSkipNumbers records the call and calls FTSetSkipNumbersFunc.
+func (m *Store) FTSetSkipNumbers(skip bool) error {
+	m.record("FTSetSkipNumbers", skip)
+	if m.FTSetSkipNumbersFunc == nil {
+		panic("mock: Store.FTSetSkipNumbers is not implemented")
+	}
+	return m.FTSetSkipNumbersFunc(skip)
+}
+
+// FTSetCaseSensitive records the call and calls FTSetCaseSensitiveFunc.
+func (m *Store) FTSetCaseSensitive(sensitive bool) error {
+	m.record("FTSetCaseSensitive", sensitive)
+	if m.FTSetCaseSensitiveFunc == nil {
+		panic("mock: Store.FTSetCaseSensitive is not implemented")
+	}
+	return m.FTSetCaseSensitiveFunc(sensitive)
+}
+
+// FTCaseSensitive records the call and calls FTCaseSensitiveFunc.
+func (m *Store) FTCaseSensitive() (bool, error) {
+	m.record("FTCaseSensitive")
+	if m.FTCaseSensitiveFunc == nil {
+		panic("mock: Store.FTCaseSensitive is not implemented")
+	}
+	return m.FTCaseSensitiveFunc()
+}
+
+// FTSetTokenFilter records the call and calls FTSetTokenFilterFunc.
+func (m *Store) FTSetTokenFilter(filter hermes.TokenFilter) error {
+	m.record("FTSetTokenFilter", filter)
+	if m.FTSetTokenFilterFunc == nil {
+		panic("mock: Store.FTSetTokenFilter is not implemented")
+	}
+	return m.FTSetTokenFilterFunc(filter)
+}
+
+// FTSetAnalyzer records the call and calls FTSetAnalyzerFunc.
+func (m *Store) FTSetAnalyzer(analyzer hermes.Analyzer) error {
+	m.record("FTSetAnalyzer", analyzer)
+	if m.FTSetAnalyzerFunc == nil {
+		panic("mock: Store.FTSetAnalyzer is not implemented")
+	}
+	return m.FTSetAnalyzerFunc(analyzer)
+}
+
+// FTAnalyzer records the call and calls FTAnalyzerFunc.
+func (m *Store) FTAnalyzer() (hermes.Analyzer, error) {
+	m.record("FTAnalyzer")
+	if m.FTAnalyzerFunc == nil {
+		panic("mock: Store.FTAnalyzer is not implemented")
+	}
+	return m.FTAnalyzerFunc()
+}
+
+// FTSetFieldAnalyzers records the call and calls FTSetFieldAnalyzersFunc.
+func (m *Store) FTSetFieldAnalyzers(analyzers map[string]hermes.Analyzer) error {
+	m.record("FTSetFieldAnalyzers", analyzers)
+	if m.FTSetFieldAnalyzersFunc == nil {
+		panic("mock: Store.FTSetFieldAnalyzers is not implemented")
+	}
+	return m.FTSetFieldAnalyzersFunc(analyzers)
+}
+
+// FTFieldAnalyzers records the call and calls FTFieldAnalyzersFunc.
+func (m *Store) FTFieldAnalyzers() (map[string]hermes.Analyzer, error) {
+	m.record("FTFieldAnalyzers")
+	if m.FTFieldAnalyzersFunc == nil {
+		panic("mock: Store.FTFieldAnalyzers is not implemented")
+	}
+	return m.FTFieldAnalyzersFunc()
+}
+
+// FTSetFieldWeights records the call and calls FTSetFieldWeightsFunc.
+func (m *Store) FTSetFieldWeights(weights map[string]float64) error {
+	m.record("FTSetFieldWeights", weights)
+	if m.FTSetFieldWeightsFunc == nil {
+		panic("mock: Store.FTSetFieldWeights is not implemented")
+	}
+	return m.FTSetFieldWeightsFunc(weights)
+}
+
+// FTFieldWeights records the call and calls FTFieldWeightsFunc.
+func (m *Store) FTFieldWeights() (map[string]float64, error) {
+	m.record("FTFieldWeights")
+	if m.FTFieldWeightsFunc == nil {
+		panic("mock: Store.FTFieldWeights is not implemented")
+	}
+	return m.FTFieldWeightsFunc()
+}
+
+// FTSave records the call and calls FTSaveFunc.
+func (m *Store) FTSave(path string) error {
+	m.record("FTSave", path)
+	if m.FTSaveFunc == nil {
+		panic("mock: Store.FTSave is not implemented")
+	}
+	return m.FTSaveFunc(path)
+}
+
+// FTLoad records the call and calls FTLoadFunc.
+func (m *Store) FTLoad(path string) error {
+	m.record("FTLoad", path)
+	if m.FTLoadFunc == nil {
+		panic("mock: Store.FTLoad is not implemented")
+	}
+	return m.FTLoadFunc(path)
+}
+
+// FTWriteTo records the call and calls FTWriteToFunc.
+func (m *Store) FTWriteTo(w io.Writer) (int64, error) {
+	m.record("FTWriteTo", w)
+	if m.FTWriteToFunc == nil {
+		panic("mock: Store.FTWriteTo is not implemented")
+	}
+	return m.FTWriteToFunc(w)
+}
+
+// FTReadFrom records the call and calls FTReadFromFunc.
+func (m *Store) FTReadFrom(r io.Reader) (int64, error) {
+	m.record("FTReadFrom", r)
+	if m.FTReadFromFunc == nil {
+		panic("mock: Store.FTReadFrom is not implemented")
+	}
+	return m.FTReadFromFunc(r)
+}
+
+// FTStorage records the call and calls FTStorageFunc.
+func (m *Store) FTStorage() (map[string]any, error) {
+	m.record("FTStorage")
+	if m.FTStorageFunc == nil {
+		panic("mock: Store.FTStorage is not implemented")
+	}
+	return m.FTStorageFunc()
+}
+
+// FTStorageSize records the call and calls FTStorageSizeFunc.
+func (m *Store) FTStorageSize() (int, error) {
+	m.record("FTStorageSize")
+	if m.FTStorageSizeFunc == nil {
+		panic("mock: Store.FTStorageSize is not implemented")
+	}
+	return m.FTStorageSizeFunc()
+}
+
+// FTStorageLength records the call and calls FTStorageLengthFunc.
+func (m *Store) FTStorageLength() (int, error) {
+	m.record("FTStorageLength")
+	if m.FTStorageLengthFunc == nil {
+		panic("mock: Store.FTStorageLength is not implemented")
+	}
+	return m.FTStorageLengthFunc()
+}
+
+// FTSequenceIndices records the call and calls FTSequenceIndicesFunc.
+func (m *Store) FTSequenceIndices() {
+	m.record("FTSequenceIndices")
+	if m.FTSequenceIndicesFunc == nil {
+		panic("mock: Store.FTSequenceIndices is not implemented")
+	}
+	m.FTSequenceIndicesFunc()
+}
+
+// FTKeysForWord records the call and calls FTKeysForWordFunc.
+func (m *Store) FTKeysForWord(word string) (map[string][]string, error) {
+	m.record("FTKeysForWord", word)
+	if m.FTKeysForWordFunc == nil {
+		panic("mock: Store.FTKeysForWord is not implemented")
+	}
+	return m.FTKeysForWordFunc(word)
+}
+
+// Search records the call and calls SearchFunc.
+func (m *Store) Search(sp hermes.SearchParams) ([]map[string]any, error) {
+	m.record("Search", sp)
+	if m.SearchFunc == nil {
+		panic("mock: Store.Search is not implemented")
+	}
+	return m.SearchFunc(sp)
+}
+
+// Subscribe records the call and calls SubscribeFunc.
+func (m *Store) Subscribe(sp hermes.SearchParams, fn func(id string, key string, value map[string]any)) (string, error) {
+	m.record("Subscribe", sp, fn)
+	if m.SubscribeFunc == nil {
+		panic("mock: Store.Subscribe is not implemented")
+	}
+	return m.SubscribeFunc(sp, fn)
+}
+
+// Unsubscribe records the call and calls UnsubscribeFunc.
+func (m *Store) Unsubscribe(id string) error {
+	m.record("Unsubscribe", id)
+	if m.UnsubscribeFunc == nil {
+		panic("mock: Store.Unsubscribe is not implemented")
+	}
+	return m.UnsubscribeFunc(id)
+}
+
+// Subscriptions records the call and calls SubscriptionsFunc.
+func (m *Store) Subscriptions() map[string]hermes.SearchParams {
+	m.record("Subscriptions")
+	if m.SubscriptionsFunc == nil {
+		panic("mock: Store.Subscriptions is not implemented")
+	}
+	return m.SubscriptionsFunc()
+}
+
+// Percolate records the call and calls PercolateFunc.
+func (m *Store) Percolate(doc map[string]any) []string {
+	m.record("Percolate", doc)
+	if m.PercolateFunc == nil {
+		panic("mock: Store.Percolate is not implemented")
+	}
+	return m.PercolateFunc(doc)
+}
+
+// SearchCtx records the call and calls SearchCtxFunc.
+func (m *Store) SearchCtx(ctx context.Context, sp hermes.SearchParams) ([]map[string]any, error) {
+	m.record("SearchCtx", ctx, sp)
+	if m.SearchCtxFunc == nil {
+		panic("mock: Store.SearchCtx is not implemented")
+	}
+	return m.SearchCtxFunc(ctx, sp)
+}
+
+// SearchCursor records the call and calls SearchCursorFunc.
+func (m *Store) SearchCursor(sp hermes.SearchParams) ([]map[string]any, string, error) {
+	m.record("SearchCursor", sp)
+	if m.SearchCursorFunc == nil {
+		panic("mock: Store.SearchCursor is not implemented")
+	}
+	return m.SearchCursorFunc(sp)
+}
+
+// SearchCursorCtx records the call and calls SearchCursorCtxFunc.
+func (m *Store) SearchCursorCtx(ctx context.Context, sp hermes.SearchParams) ([]map[string]any, string, error) {
+	m.record("SearchCursorCtx", ctx, sp)
+	if m.SearchCursorCtxFunc == nil {
+		panic("mock: Store.SearchCursorCtx is not implemented")
+	}
+	return m.SearchCursorCtxFunc(ctx, sp)
+}
+
+// SearchOneWord records the call and calls SearchOneWordFunc.
+func (m *Store) SearchOneWord(sp hermes.SearchParams) ([]map[string]any, error) {
+	m.record("SearchOneWord", sp)
+	if m.SearchOneWordFunc == nil {
+		panic("mock: Store.SearchOneWord is not implemented")
+	}
+	return m.SearchOneWordFunc(sp)
+}
+
+// SearchRegex records the call and calls SearchRegexFunc.
+func (m *Store) SearchRegex(pattern string, limit int) ([]map[string]any, error) {
+	m.record("SearchRegex", pattern, limit)
+	if m.SearchRegexFunc == nil {
+		panic("mock: Store.SearchRegex is not implemented")
+	}
+	return m.SearchRegexFunc(pattern, limit)
+}
+
+// SearchValues records the call and calls SearchValuesFunc.
+func (m *Store) SearchValues(sp hermes.SearchParams) ([]map[string]any, error) {
+	m.record("SearchValues", sp)
+	if m.SearchValuesFunc == nil {
+		panic("mock: Store.SearchValues is not implemented")
+	}
+	return m.SearchValuesFunc(sp)
+}
+
+// SearchWithKey records the call and calls SearchWithKeyFunc.
+func (m *Store) SearchWithKey(sp hermes.SearchParams) ([]map[string]any, error) {
+	m.record("SearchWithKey", sp)
+	if m.SearchWithKeyFunc == nil {
+		panic("mock: Store.SearchWithKey is not implemented")
+	}
+	return m.SearchWithKeyFunc(sp)
+}
+
+// SearchGroups records the call and calls SearchGroupsFunc.
+func (m *Store) SearchGroups(sp hermes.SearchParams) ([]hermes.Group, error) {
+	m.record("SearchGroups", sp)
+	if m.SearchGroupsFunc == nil {
+		panic("mock: Store.SearchGroups is not implemented")
+	}
+	return m.SearchGroupsFunc(sp)
+}
+
+// Suggest records the call and calls SuggestFunc.
+func (m *Store) Suggest(prefix string, limit int) []string {
+	m.record("Suggest", prefix, limit)
+	if m.SuggestFunc == nil {
+		panic("mock: Store.Suggest is not implemented")
+	}
+	return m.SuggestFunc(prefix, limit)
+}
+
+// SearchScored records the call and calls SearchScoredFunc.
+func (m *Store) SearchScored(sp hermes.SearchParams) ([]hermes.Result, error) {
+	m.record("SearchScored", sp)
+	if m.SearchScoredFunc == nil {
+		panic("mock: Store.SearchScored is not implemented")
+	}
+	return m.SearchScoredFunc(sp)
+}
+
+// SearchPage records the call and calls SearchPageFunc.
+func (m *Store) SearchPage(sp hermes.SearchParams, size int) (hermes.Page, error) {
+	m.record("SearchPage", sp, size)
+	if m.SearchPageFunc == nil {
+		panic("mock: Store.SearchPage is not implemented")
+	}
+	return m.SearchPageFunc(sp, size)
+}
+
+// NextPage records the call and calls NextPageFunc.
+func (m *Store) NextPage(cursor string, size int) (hermes.Page, error) {
+	m.record("NextPage", cursor, size)
+	if m.NextPageFunc == nil {
+		panic("mock: Store.NextPage is not implemented")
+	}
+	return m.NextPageFunc(cursor, size)
+}
+
+// SetBM25 records the call and calls SetBM25Func.
+func (m *Store) SetBM25(k1 float64, b float64) error {
+	m.record("SetBM25", k1, b)
+	if m.SetBM25Func == nil {
+		panic("mock: Store.SetBM25 is not implemented")
+	}
+	return m.SetBM25Func(k1, b)
+}
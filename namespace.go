@@ -0,0 +1,355 @@
+package hermes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// The separator between a namespace and the keys in it
+const namespaceSeparator string = ":"
+
+// Namespace is a struct that represents a handle to the keys of a cache that belong to a namespace.
+// The keys passed to the handle are prefixed with the namespace, and searches only return values from the namespace,
+// so multiple tenants of an embedded application can share a cache without seeing each other's data.
+//
+// Fields:
+//   - cache (*Cache): The underlying cache.
+//   - name (string): The name of the namespace.
+//   - prefix (string): The prefix of the keys in the namespace.
+type Namespace struct {
+	cache  *Cache
+	name   string
+	prefix string
+}
+
+// WithNamespace is a method of the Cache struct that returns a handle scoped to the provided namespace.
+// The keys of the namespace are stored in the cache as "<namespace>:<key>".
+// The namespace doesn't have to be created with CreateNamespace, but only created namespaces are listed by Namespaces.
+//
+// Parameters:
+//   - ns (string): The name of the namespace.
+//
+// Returns:
+//   - *Namespace: The scoped handle.
+//   - error: An error if the name is empty or contains the namespace separator.
+func (c *Cache) WithNamespace(ns string) (*Namespace, error) {
+	if err := namespaceVerify(ns); err != nil {
+		return nil, err
+	}
+	return &Namespace{
+		cache:  c,
+		name:   ns,
+		prefix: ns + namespaceSeparator,
+	}, nil
+}
+
+// CreateNamespace is a method of the Cache struct that registers a namespace and returns a handle scoped to it.
+// This method is thread-safe.
+//
+// Parameters:
+//   - ns (string): The name of the namespace.
+//
+// Returns:
+//   - *Namespace: The scoped handle.
+//   - error: An error if the name is invalid or the namespace already exists.
+func (c *Cache) CreateNamespace(ns string) (*Namespace, error) {
+	n, err := c.WithNamespace(ns)
+	if err != nil {
+		return nil, err
+	}
+
+	// Lock the mutex
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	// Verify that the namespace doesn't exist
+	if c.namespaces[ns] {
+		return nil, fmt.Errorf("namespace %s already exists", ns)
+	}
+
+	// Register the namespace
+	c.namespaces[ns] = true
+	return n, nil
+}
+
+// Namespaces is a method of the Cache struct that returns the names of the created namespaces, in sorted order.
+// This method is thread-safe.
+//
+// Returns:
+//   - []string: The names of the namespaces.
+func (c *Cache) Namespaces() []string {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	// Copy the namespace names
+	var names []string = make([]string, 0, len(c.namespaces))
+	for ns := range c.namespaces {
+		names = append(names, ns)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// DeleteNamespace is a method of the Cache struct that removes a namespace along with all of its keys.
+// The keys are removed from the full-text index in a single pass over the index, so this is much faster than deleting the keys one by one.
+// This method is thread-safe.
+//
+// Parameters:
+//   - ns (string): The name of the namespace.
+//
+// Returns:
+//   - int: The number of removed keys.
+//   - error: An error if the namespace doesn't exist.
+func (c *Cache) DeleteNamespace(ns string) (int, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	// Verify that the cache isn't frozen and the namespace exists
+	if err := c.writable("DeleteNamespace"); err != nil {
+		return 0, err
+	} else if !c.namespaces[ns] {
+		return 0, fmt.Errorf("namespace %s does not exist", ns)
+	}
+
+	// Delete the keys and unregister the namespace
+	var keys []string = c.keyTrie.WithPrefix(ns+namespaceSeparator, 0)
+	c.deleteKeys(keys)
+	c.deleteDone(keys...)
+	delete(c.namespaces, ns)
+	return len(keys), nil
+}
+
+// Name is a method of the Namespace struct that returns the name of the namespace.
+//
+// Returns:
+//   - string: The name of the namespace.
+func (n *Namespace) Name() string {
+	return n.name
+}
+
+// Key is a method of the Namespace struct that returns the cache key of a key in the namespace.
+//
+// Parameters:
+//   - key (string): The key in the namespace.
+//
+// Returns:
+//   - string: The key as it is stored in the cache.
+func (n *Namespace) Key(key string) string {
+	return n.prefix + key
+}
+
+// Set is a method of the Namespace struct that sets a value in the namespace for the specified key.
+// This method is thread-safe.
+//
+// Parameters:
+//   - key (string): The key to set the value for.
+//   - value (map[string]any): The value to set.
+//
+// Returns:
+//   - error: An error if the set fails.
+func (n *Namespace) Set(key string, value map[string]any) error {
+	return n.cache.Set(n.Key(key), value)
+}
+
+// SetWithTTL is a method of the Namespace struct that sets a value in the namespace for the specified key, which expires after the provided duration.
+// This method is thread-safe.
+//
+// Parameters:
+//   - key (string): The key to set the value for.
+//   - value (map[string]any): The value to set.
+//   - ttl (time.Duration): The time after which the key expires.
+//
+// Returns:
+//   - error: An error if the ttl is invalid or the set fails.
+func (n *Namespace) SetWithTTL(key string, value map[string]any, ttl time.Duration) error {
+	return n.cache.SetWithTTL(n.Key(key), value, ttl)
+}
+
+// Touch is a method of the Namespace struct that resets the time to live of a key in the namespace, without rewriting its value.
+// This method is thread-safe.
+//
+// Parameters:
+//   - key (string): The key to refresh.
+//   - ttl (time.Duration): The new time after which the key expires.
+//
+// Returns:
+//   - error: An error if the ttl is invalid or the key doesn't exist.
+func (n *Namespace) Touch(key string, ttl time.Duration) error {
+	return n.cache.Touch(n.Key(key), ttl)
+}
+
+// Get is a method of the Namespace struct that retrieves the value associated with the given key in the namespace.
+// The returned map is the one stored in the cache, so it must not be modified.
+// This method is thread-safe.
+//
+// Parameters:
+//   - key (string): The key to retrieve the value for.
+//
+// Returns:
+//   - map[string]any: The value associated with the key, or nil if it doesn't exist.
+func (n *Namespace) Get(key string) map[string]any {
+	return n.cache.Get(n.Key(key))
+}
+
+// GetCopy is a method of the Namespace struct that retrieves a copy of the value associated with the given key in the namespace.
+// This method is thread-safe.
+//
+// Parameters:
+//   - key (string): The key to retrieve the value for.
+//
+// Returns:
+//   - map[string]any: A copy of the value associated with the key, or nil if it doesn't exist.
+func (n *Namespace) GetCopy(key string) map[string]any {
+	return n.cache.GetCopy(n.Key(key))
+}
+
+// Delete is a method of the Namespace struct that removes a key from the namespace.
+// This method is thread-safe.
+//
+// Parameters:
+//   - key (string): The key to remove.
+//
+// Returns:
+//   - None
+func (n *Namespace) Delete(key string) {
+	n.cache.Delete(n.Key(key))
+}
+
+// Exists is a method of the Namespace struct that checks if a key exists in the namespace.
+// This method is thread-safe.
+//
+// Parameters:
+//   - key (string): The key to check.
+//
+// Returns:
+//   - bool: Whether the key exists.
+func (n *Namespace) Exists(key string) bool {
+	return n.cache.Exists(n.Key(key))
+}
+
+// Keys is a method of the Namespace struct that returns the keys in the namespace, without the namespace prefix, in sorted order.
+// This method is thread-safe.
+//
+// Returns:
+//   - []string: The keys in the namespace.
+func (n *Namespace) Keys() []string {
+	var keys []string = n.cache.KeysWithPrefix(n.prefix)
+	for i := range keys {
+		keys[i] = strings.TrimPrefix(keys[i], n.prefix)
+	}
+	return keys
+}
+
+// Values is a method of the Namespace struct that returns the values in the namespace, in the sorted order of their keys.
+// The returned maps are the ones stored in the cache, so they must not be modified.
+// This method is thread-safe.
+//
+// Returns:
+//   - []map[string]any: The values in the namespace.
+func (n *Namespace) Values() []map[string]any {
+	n.cache.mutex.RLock()
+	defer n.cache.mutex.RUnlock()
+
+	// Get the values of the keys with the namespace prefix
+	var values []map[string]any = []map[string]any{}
+	n.cache.keyTrie.Walk(n.prefix, func(key string) bool {
+		values = append(values, n.cache.data[key])
+		return true
+	})
+	return values
+}
+
+// Length is a method of the Namespace struct that returns the number of keys in the namespace.
+// This method is thread-safe.
+//
+// Returns:
+//   - int: The number of keys in the namespace.
+func (n *Namespace) Length() int {
+	n.cache.mutex.RLock()
+	defer n.cache.mutex.RUnlock()
+
+	// Count the keys with the namespace prefix
+	var length int = 0
+	n.cache.keyTrie.Walk(n.prefix, func(string) bool {
+		length++
+		return true
+	})
+	return length
+}
+
+// Search is a method of the Namespace struct that searches the full-text index like Cache.Search, only returning values from the namespace.
+// This method is thread-safe.
+//
+// Parameters:
+//   - sp (SearchParams): A SearchParams struct containing the search parameters. The KeyPrefix is overwritten.
+//
+// Returns:
+//   - []map[string]any: The matching values.
+//   - error: An error if the query is invalid or the full-text index is not initialized.
+func (n *Namespace) Search(sp SearchParams) ([]map[string]any, error) {
+	return n.SearchCtx(context.Background(), sp)
+}
+
+// SearchCtx is a method of the Namespace struct that searches the full-text index like Cache.SearchCtx, only returning values from the namespace.
+// This method is thread-safe.
+//
+// Parameters:
+//   - ctx (context.Context): The context of the request.
+//   - sp (SearchParams): A SearchParams struct containing the search parameters. The KeyPrefix is overwritten.
+//
+// Returns:
+//   - []map[string]any: The matching values.
+//   - error: An error if the query is invalid, or the context error if the context is done before the search completes.
+func (n *Namespace) SearchCtx(ctx context.Context, sp SearchParams) ([]map[string]any, error) {
+	sp.KeyPrefix = n.prefix
+	return n.cache.SearchCtx(ctx, sp)
+}
+
+// SearchOneWord is a method of the Namespace struct that searches the full-text index like Cache.SearchOneWord, only returning values from the namespace.
+// This method is thread-safe.
+//
+// Parameters:
+//   - sp (SearchParams): A SearchParams struct containing the search parameters. The KeyPrefix is overwritten.
+//
+// Returns:
+//   - []map[string]any: The matching values.
+//   - error: An error if the query is invalid or the full-text index is not initialized.
+func (n *Namespace) SearchOneWord(sp SearchParams) ([]map[string]any, error) {
+	sp.KeyPrefix = n.prefix
+	return n.cache.SearchOneWord(sp)
+}
+
+// SearchValues is a method of the Namespace struct that searches the values like Cache.SearchValues, only returning values from the namespace.
+// This method is thread-safe.
+//
+// Parameters:
+//   - sp (SearchParams): A SearchParams struct containing the search parameters. The KeyPrefix is overwritten.
+//
+// Returns:
+//   - []map[string]any: The matching values.
+//   - error: An error if the query is invalid.
+func (n *Namespace) SearchValues(sp SearchParams) ([]map[string]any, error) {
+	sp.KeyPrefix = n.prefix
+	return n.cache.SearchValues(sp)
+}
+
+// namespaceVerify is a function that verifies the name of a namespace.
+//
+// Parameters:
+//   - ns (string): The name of the namespace.
+//
+// Returns:
+//   - error: An error if the name is empty or contains the namespace separator.
+func namespaceVerify(ns string) error {
+	switch {
+	case len(ns) == 0:
+		return errors.New("invalid namespace")
+	case strings.Contains(ns, namespaceSeparator):
+		return errors.New("invalid namespace. the name can't contain " + namespaceSeparator)
+	}
+	return nil
+}
@@ -0,0 +1,178 @@
+package hermes
+
+import (
+	"errors"
+)
+
+// ngramIndex is a struct that maps the character n-grams of the words of a full-text index to the words that contain them,
+// so the words that contain a substring can be looked up instead of scanning every word of the index.
+// Words that are removed from the full-text index may be left in the n-gram index, so the looked up words must be verified.
+//
+// Fields:
+//   - n (int): The length of the n-grams, in bytes.
+//   - grams (map[string]map[string]bool): The set of words that contain each n-gram.
+type ngramIndex struct {
+	n     int
+	grams map[string]map[string]bool
+}
+
+// FTSetNgramIndex is a method of the Cache struct that indexes the character n-grams of the words of the full-text index, such as
+// the trigrams "run", "unn", "nni", "nin" and "ing" of "running", so the non-strict searches of a word with at least n characters
+// look up the words that contain it instead of scanning every word of the index. The fuzzy searches that aren't strict also need it,
+// along with the vocabulary of the index, to look up their words.
+// The n-gram index uses more memory than the full-text index itself for large vocabularies, and isn't stored in the snapshots,
+// only its length is, so it's rebuilt when a snapshot is loaded.
+// This method is thread-safe.
+//
+// Parameters:
+//   - n (int): The length of the n-grams, from 2 to 5, such as 3 for trigrams, or 0 to remove the n-gram index.
+//
+// Returns:
+//   - error: An error if the full-text index is not initialized or the length is invalid.
+func (c *Cache) FTSetNgramIndex(n int) error {
+	if n != 0 && (n < 2 || n > 5) {
+		return errors.New("invalid n-gram length")
+	}
+
+	// Lock the mutex
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	// Check if the ft is initialized
+	if c.ft == nil {
+		return errors.New("full text not initialized")
+	}
+
+	// Build the n-gram index
+	c.ft.ngrams = newNgramIndex(n)
+	c.ft.ngrams.build(c.ft.storage)
+	return nil
+}
+
+// newNgramIndex is a function that returns an empty n-gram index.
+//
+// Parameters:
+//   - n (int): The length of the n-grams, or 0 for no n-gram index.
+//
+// Returns:
+//   - *ngramIndex: The n-gram index, or nil if n is 0.
+func newNgramIndex(n int) *ngramIndex {
+	if n == 0 {
+		return nil
+	}
+	return &ngramIndex{n: n, grams: make(map[string]map[string]bool)}
+}
+
+// size is a method of the ngramIndex struct that returns the length of the n-grams.
+//
+// Returns:
+//   - int: The length of the n-grams, or 0 if the n-gram index is nil.
+func (idx *ngramIndex) size() int {
+	if idx == nil {
+		return 0
+	}
+	return idx.n
+}
+
+// build is a method of the ngramIndex struct that indexes the n-grams of every word of a full-text storage.
+//
+// Parameters:
+//   - storage (map[string]any): The full-text storage.
+//
+// Returns:
+//   - None
+func (idx *ngramIndex) build(storage map[string]any) {
+	if idx == nil {
+		return
+	}
+	for word := range storage {
+		idx.add(word)
+	}
+}
+
+// add is a method of the ngramIndex struct that indexes the n-grams of a word.
+//
+// Parameters:
+//   - word (string): The word.
+//
+// Returns:
+//   - None
+func (idx *ngramIndex) add(word string) {
+	if idx == nil {
+		return
+	}
+	for i := 0; i+idx.n <= len(word); i++ {
+		var gram string = word[i : i+idx.n]
+		if idx.grams[gram] == nil {
+			idx.grams[gram] = make(map[string]bool)
+		}
+		idx.grams[gram][word] = true
+	}
+}
+
+// remove is a method of the ngramIndex struct that removes a word from the sets of its n-grams.
+//
+// Parameters:
+//   - word (string): The word.
+//
+// Returns:
+//   - None
+func (idx *ngramIndex) remove(word string) {
+	if idx == nil {
+		return
+	}
+	for i := 0; i+idx.n <= len(word); i++ {
+		var gram string = word[i : i+idx.n]
+		if delete(idx.grams[gram], word); len(idx.grams[gram]) == 0 {
+			delete(idx.grams, gram)
+		}
+	}
+}
+
+// substringWords is a method of the FullText struct that looks up the stored words that may contain a term with the suffix index,
+// or with the n-gram index if the full-text index has no suffix index.
+//
+// Parameters:
+//   - term (string): The lowercase term.
+//
+// Returns:
+//   - map[string]any: The stored words that contain the term, or the least common n-gram of the term, with their storage values.
+//   - bool: false if the index has neither a suffix index nor an n-gram index, or the term is empty or shorter than the n-grams,
+//     so every word has to be scanned.
+func (ft *FullText) substringWords(term string) (map[string]any, bool) {
+	if ft.suffixes != nil && len(term) > 0 {
+		var result map[string]any = map[string]any{}
+		for _, word := range ft.suffixes.lookup(term) {
+			if v, ok := ft.storage[word]; ok {
+				result[word] = v
+			}
+		}
+		return result, true
+	}
+
+	// Look up the words with the n-gram index
+	var idx *ngramIndex = ft.ngrams
+	if idx == nil || len(term) < idx.n {
+		return nil, false
+	}
+
+	// Find the n-gram of the term with the fewest words
+	var smallest map[string]bool
+	for i := 0; i+idx.n <= len(term); i++ {
+		var words map[string]bool = idx.grams[term[i:i+idx.n]]
+		if len(words) == 0 {
+			return map[string]any{}, true
+		} else if smallest == nil || len(words) < len(smallest) {
+			smallest = words
+		}
+	}
+
+	// Keep the words that are still stored and long enough to contain the term
+	var result map[string]any = make(map[string]any, len(smallest))
+	for word := range smallest {
+		if v, ok := ft.storage[word]; ok && len(word) >= len(term) {
+			result[word] = v
+		}
+	}
+	return result, true
+}
@@ -0,0 +1,104 @@
+package nocache
+
+import (
+	"errors"
+	"reflect"
+	"sort"
+	"strings"
+
+	utils "github.com/realTristan/hermes/utils"
+)
+
+// Hit is a struct that represents a search result of SearchHits.
+//
+// Fields:
+//   - Index (int): The position of the value in the data the full-text index was initialized with.
+//   - Score (float64): The number of occurrences of the words of the query in the full-text fields of the value.
+//   - Terms ([]string): The words of the query that the value contains, in the order of the query.
+//   - Value (map[string]any): The value. It's the one stored in the index, so it must not be modified.
+type Hit struct {
+	Index int
+	Score float64
+	Terms []string
+	Value map[string]any
+}
+
+// SearchHits searches for all occurrences of the given query string in the FullText object's data, by splitting the query
+// into separate words and looking for each of them in the data. It returns each result with its position, score and matched words,
+// from the highest to the lowest score. Results with the same score keep the order they were found in.
+// Parameters:
+//   - sp (SearchParams): A SearchParams struct containing the search parameters.
+//
+// Returns:
+//   - []Hit: The results, from the highest to the lowest score.
+//   - error: An error if the query or limit is invalid.
+func (ft *FullText) SearchHits(sp SearchParams) ([]Hit, error) {
+	switch {
+	case len(sp.Query) == 0:
+		return []Hit{}, errors.New("invalid query")
+	case sp.Limit < 1:
+		return []Hit{}, errors.New("invalid limit")
+	}
+
+	// Convert the query to lowercase
+	sp.Query = strings.ToLower(sp.Query)
+
+	// Lock the mutex
+	ft.mutex.RLock()
+	defer ft.mutex.RUnlock()
+
+	// Find the position of each value
+	var positions map[uintptr]int = make(map[uintptr]int, len(ft.data))
+	for i, value := range ft.data {
+		positions[reflect.ValueOf(value).Pointer()] = i
+	}
+
+	// Score the results
+	var (
+		terms []string = strings.Fields(sp.Query)
+		hits  []Hit    = []Hit{}
+	)
+	for _, value := range ft.search(sp) {
+		var hit Hit = Hit{Index: positions[reflect.ValueOf(value).Pointer()], Terms: []string{}, Value: value}
+		for _, term := range terms {
+			if n := occurrences(value, term, sp.Strict); n > 0 {
+				hit.Score += float64(n)
+				hit.Terms = append(hit.Terms, term)
+			}
+		}
+		hits = append(hits, hit)
+	}
+	sort.SliceStable(hits, func(i, j int) bool {
+		return hits[i].Score > hits[j].Score
+	})
+	if len(hits) > sp.Limit {
+		hits = hits[:sp.Limit]
+	}
+	return hits, nil
+}
+
+// occurrences is a function that counts the words of the string fields of a value that match a term.
+// Parameters:
+//   - value (map[string]any): The value.
+//   - term (string): The lowercase term.
+//   - strict (bool): Whether a word must be equal to the term, instead of containing it.
+//
+// Returns:
+//   - int: The number of matching words.
+func occurrences(value map[string]any, term string, strict bool) int {
+	var n int = 0
+	for _, v := range value {
+		s, ok := v.(string)
+		if !ok {
+			continue
+		}
+		for _, word := range strings.Fields(strings.ToLower(s)) {
+			for _, w := range utils.SplitByAlphaNum(utils.TrimNonAlphaNum(word)) {
+				if w == term || (!strict && strings.Contains(w, term)) {
+					n++
+				}
+			}
+		}
+	}
+	return n
+}
@@ -1,13 +1,11 @@
 package nocache
 
 import (
-	"errors"
 	"strings"
 )
 
 // Search searches for all occurrences of the given query string in the FullText object's data.
-// The search is done by splitting the query into separate words and looking for each of them in the data.
-// The search result is limited to the specified number of entries.
+// It returns the values of the hits of SearchHits, from the highest to the lowest score.
 // Parameters:
 //   - sp (SearchParams): A SearchParams struct containing the search parameters.
 //
@@ -15,23 +13,15 @@ import (
 //   - []map[string]any: A slice of maps where each map represents a data record that matches the given query.
 //     The keys of the map correspond to the column names of the data that were searched and returned in the result.
 //   - error: An error if the query or limit is invalid.
+//
+// Deprecated: Use SearchHits, which also returns the position, score and matched words of each result.
 func (ft *FullText) Search(sp SearchParams) ([]map[string]any, error) {
-	switch {
-	case len(sp.Query) == 0:
-		return []map[string]any{}, errors.New("invalid query")
-	case sp.Limit < 1:
-		return []map[string]any{}, errors.New("invalid limit")
+	hits, err := ft.SearchHits(sp)
+	var result []map[string]any = make([]map[string]any, 0, len(hits))
+	for _, hit := range hits {
+		result = append(result, hit.Value)
 	}
-
-	// Convert the query to lowercase
-	sp.Query = strings.ToLower(sp.Query)
-
-	// Lock the mutex
-	ft.mutex.RLock()
-	defer ft.mutex.RUnlock()
-
-	// Perform the search
-	return ft.search(sp), nil
+	return result, err
 }
 
 // search searches for all occurrences of the given query string in the FullText object's data.
@@ -6,7 +6,8 @@ type SearchParams struct {
 	Query string
 	// The limit of search results to return
 	Limit int
-	// A boolean to indicate whether the search should be strict or not
+	// A boolean to indicate whether a word of the query must be equal to a stored word, instead of being contained in it.
+	// The values and the query are lowercased, so strict searches are case-insensitive
 	Strict bool
 	// A map containing the schema to search for
 	Schema map[string]bool
@@ -0,0 +1,383 @@
+package hermes
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// The operators of the conditions of a range filter, with the two-character operators first so they're matched before their prefixes
+var rangeOperators []string = []string{">=", "<=", "!=", ">", "<", "="}
+
+// The separator of the conditions of a range filter
+var rangeSeparator *regexp.Regexp = regexp.MustCompile(`(?i)\s+and\s+`)
+
+// numericIndex is a struct that represents the numeric index of a field, which holds the keys sorted by the value of the field,
+// so the keys whose value is within a range are found with a binary search.
+//
+// Fields:
+//   - entries ([]numericEntry): The indexed values and their keys, sorted by value, then by key.
+//   - values (map[string]float64): The indexed value of each key.
+type numericIndex struct {
+	entries []numericEntry
+	values  map[string]float64
+}
+
+// numericEntry is a struct that represents a value of a numeric index.
+//
+// Fields:
+//   - value (float64): The value of the field.
+//   - key (string): The cache key that holds the value.
+type numericEntry struct {
+	value float64
+	key   string
+}
+
+// rangeCondition is a struct that represents a condition of a range filter, such as "price >= 10".
+//
+// Fields:
+//   - field (string): The numerically indexed field.
+//   - op (string): The comparison operator, one of >=, <=, !=, >, < and =.
+//   - value (float64): The value the field is compared with.
+type rangeCondition struct {
+	field string
+	op    string
+	value float64
+}
+
+// CreateNumericIndex is a method of the Cache struct that builds a numeric index for the provided field.
+// The index holds the keys sorted by the value of the field, so the conditions of the RangeFilter of the search parameters,
+// such as "price >= 10 AND price < 100", find the matching keys without comparing every value. The numbers and the strings
+// that hold a number, such as the cells of a CSV file, are indexed. The values that hold anything else aren't.
+// This method is thread-safe.
+//
+// Parameters:
+//   - field (string): The numeric field to index.
+//
+// Returns:
+//   - error: An error if the field is invalid or already indexed.
+func (c *Cache) CreateNumericIndex(field string) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	// Verify the field
+	if len(field) == 0 {
+		return errors.New("invalid field")
+	} else if _, ok := c.numerics[field]; ok {
+		return fmt.Errorf("numeric index on field %s already exists", field)
+	}
+
+	// Build the index from the current data
+	c.numerics[field] = numericBuild(field, c.data)
+	c.generation++
+
+	// Return no error
+	return nil
+}
+
+// DropNumericIndex is a method of the Cache struct that removes the numeric index for the provided field.
+// This method is thread-safe.
+//
+// Parameters:
+//   - field (string): The numerically indexed field.
+//
+// Returns:
+//   - error: An error if the field is not numerically indexed.
+func (c *Cache) DropNumericIndex(field string) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	// Verify that the index exists
+	if _, ok := c.numerics[field]; !ok {
+		return fmt.Errorf("numeric index on field %s does not exist", field)
+	}
+
+	// Delete the index
+	delete(c.numerics, field)
+	c.generation++
+
+	// Return no error
+	return nil
+}
+
+// numericBuild is a function that builds a numeric index for the provided field.
+//
+// Parameters:
+//   - field (string): The field to index.
+//   - data (map[string]map[string]any): The data to build the index from.
+//
+// Returns:
+//   - *numericIndex: The numeric index.
+func numericBuild(field string, data map[string]map[string]any) *numericIndex {
+	var idx *numericIndex = &numericIndex{entries: []numericEntry{}, values: make(map[string]float64)}
+	for key, value := range data {
+		if v, ok := numericValue(value[field]); ok {
+			idx.entries = append(idx.entries, numericEntry{value: v, key: key})
+			idx.values[key] = v
+		}
+	}
+	sort.Slice(idx.entries, func(i, j int) bool {
+		return idx.entries[i].less(idx.entries[j])
+	})
+	return idx
+}
+
+// numericValue is a function that converts a field of a cache value to the number it's indexed under.
+//
+// Parameters:
+//   - value (any): The field value.
+//
+// Returns:
+//   - float64: The number.
+//   - bool: true if the value is a number or a string that holds a number, false otherwise.
+func numericValue(value any) (float64, bool) {
+	var f float64
+	switch v := value.(type) {
+	case float64:
+		f = v
+	case float32:
+		f = float64(v)
+	case int:
+		f = float64(v)
+	case int8:
+		f = float64(v)
+	case int16:
+		f = float64(v)
+	case int32:
+		f = float64(v)
+	case int64:
+		f = float64(v)
+	case uint:
+		f = float64(v)
+	case uint8:
+		f = float64(v)
+	case uint16:
+		f = float64(v)
+	case uint32:
+		f = float64(v)
+	case uint64:
+		f = float64(v)
+	case string:
+		n, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		if err != nil {
+			return 0, false
+		}
+		f = n
+	default:
+		return 0, false
+	}
+	return f, !math.IsNaN(f)
+}
+
+// less is a method of the numericEntry struct that compares two entries by value, then by key.
+//
+// Parameters:
+//   - other (numericEntry): The other entry.
+//
+// Returns:
+//   - bool: true if the entry is sorted before the other entry.
+func (e numericEntry) less(other numericEntry) bool {
+	if e.value != other.value {
+		return e.value < other.value
+	}
+	return e.key < other.key
+}
+
+// set is a method of the numericIndex struct that indexes the value of a key, replacing its previous value.
+//
+// Parameters:
+//   - key (string): The cache key.
+//   - value (any): The field value. If it's not a number, the key is only removed.
+//
+// Returns:
+//   - None
+func (idx *numericIndex) set(key string, value any) {
+	idx.delete(key)
+	v, ok := numericValue(value)
+	if !ok {
+		return
+	}
+	var e numericEntry = numericEntry{value: v, key: key}
+	var i int = sort.Search(len(idx.entries), func(i int) bool {
+		return !idx.entries[i].less(e)
+	})
+	idx.entries = append(idx.entries, numericEntry{})
+	copy(idx.entries[i+1:], idx.entries[i:])
+	idx.entries[i] = e
+	idx.values[key] = v
+}
+
+// delete is a method of the numericIndex struct that removes a key from the index.
+//
+// Parameters:
+//   - key (string): The cache key.
+//
+// Returns:
+//   - None
+func (idx *numericIndex) delete(key string) {
+	v, ok := idx.values[key]
+	if !ok {
+		return
+	}
+	var e numericEntry = numericEntry{value: v, key: key}
+	var i int = sort.Search(len(idx.entries), func(i int) bool {
+		return !idx.entries[i].less(e)
+	})
+	if i < len(idx.entries) && idx.entries[i] == e {
+		idx.entries = append(idx.entries[:i], idx.entries[i+1:]...)
+	}
+	delete(idx.values, key)
+}
+
+// clone is a method of the numericIndex struct that returns a deep copy of the index.
+//
+// Returns:
+//   - *numericIndex: The copy of the index.
+func (idx *numericIndex) clone() *numericIndex {
+	var clone *numericIndex = &numericIndex{
+		entries: append([]numericEntry{}, idx.entries...),
+		values:  make(map[string]float64, len(idx.values)),
+	}
+	for key, v := range idx.values {
+		clone.values[key] = v
+	}
+	return clone
+}
+
+// keys is a method of the numericIndex struct that returns the keys whose value matches a condition.
+//
+// Parameters:
+//   - cond (rangeCondition): The condition.
+//
+// Returns:
+//   - map[string]bool: A new set of the matching keys.
+func (idx *numericIndex) keys(cond rangeCondition) map[string]bool {
+	// Find the first entry that is greater than or equal to, and the first that is greater than the value
+	var (
+		lower int = sort.Search(len(idx.entries), func(i int) bool { return idx.entries[i].value >= cond.value })
+		upper int = sort.Search(len(idx.entries), func(i int) bool { return idx.entries[i].value > cond.value })
+	)
+
+	// Select the entries of the condition
+	var selected [][]numericEntry
+	switch cond.op {
+	case ">=":
+		selected = [][]numericEntry{idx.entries[lower:]}
+	case ">":
+		selected = [][]numericEntry{idx.entries[upper:]}
+	case "<=":
+		selected = [][]numericEntry{idx.entries[:upper]}
+	case "<":
+		selected = [][]numericEntry{idx.entries[:lower]}
+	case "=":
+		selected = [][]numericEntry{idx.entries[lower:upper]}
+	default:
+		selected = [][]numericEntry{idx.entries[:lower], idx.entries[upper:]}
+	}
+	var result map[string]bool = map[string]bool{}
+	for _, entries := range selected {
+		for _, e := range entries {
+			result[e.key] = true
+		}
+	}
+	return result
+}
+
+// parseRangeFilter is a function that parses a range filter into its conditions.
+//
+// Parameters:
+//   - expr (string): The range filter, e.g. "price >= 10 AND price < 100".
+//
+// Returns:
+//   - []rangeCondition: The conditions of the filter.
+//   - error: An error if a condition is invalid.
+func parseRangeFilter(expr string) ([]rangeCondition, error) {
+	var conditions []rangeCondition = []rangeCondition{}
+	for _, s := range rangeSeparator.Split(strings.TrimSpace(expr), -1) {
+		var cond rangeCondition
+		for _, op := range rangeOperators {
+			if field, value, ok := strings.Cut(s, op); ok {
+				cond.field, cond.op = strings.TrimSpace(field), op
+				v, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+				if err != nil || math.IsNaN(v) {
+					return conditions, fmt.Errorf("invalid number in range condition %s", s)
+				}
+				cond.value = v
+				break
+			}
+		}
+		if len(cond.op) == 0 || len(cond.field) == 0 {
+			return conditions, fmt.Errorf("invalid range condition %s", s)
+		}
+		conditions = append(conditions, cond)
+	}
+	return conditions, nil
+}
+
+// rangeKeys is a method of the Cache struct that returns the keys that match every condition of a range filter.
+// This method is not thread-safe, and should only be called from an exported function.
+//
+// Parameters:
+//   - expr (string): The range filter.
+//
+// Returns:
+//   - map[string]bool: The matching keys.
+//   - error: An error if the filter is invalid or a field has no numeric index.
+func (c *Cache) rangeKeys(expr string) (map[string]bool, error) {
+	conditions, err := parseRangeFilter(expr)
+	if err != nil {
+		return nil, err
+	}
+	var result map[string]bool
+	for _, cond := range conditions {
+		idx, ok := c.numerics[cond.field]
+		if !ok {
+			return nil, fmt.Errorf("numeric index on field %s does not exist", cond.field)
+		}
+		var keys map[string]bool = idx.keys(cond)
+		if result == nil {
+			result = keys
+			continue
+		}
+		for key := range result {
+			if !keys[key] {
+				delete(result, key)
+			}
+		}
+	}
+	return result, nil
+}
+
+// rangeFilter is a method of the Cache struct that keeps the search results whose key matches a range filter, up to a limit.
+// This method is not thread-safe, and should only be called from an exported function.
+//
+// Parameters:
+//   - values ([]map[string]any): The search results, which are the values stored in the cache.
+//   - expr (string): The range filter.
+//   - limit (int): The maximum number of results to keep.
+//
+// Returns:
+//   - []map[string]any: The matching results, in their order.
+//   - error: An error if the filter is invalid or a field has no numeric index.
+func (c *Cache) rangeFilter(values []map[string]any, expr string, limit int) ([]map[string]any, error) {
+	allowed, err := c.rangeKeys(expr)
+	if err != nil {
+		return []map[string]any{}, err
+	}
+	var (
+		keys   []string         = c.resultKeys(values)
+		result []map[string]any = make([]map[string]any, 0, len(values))
+	)
+	for i, value := range values {
+		if len(result) == limit {
+			break
+		} else if allowed[keys[i]] {
+			result = append(result, value)
+		}
+	}
+	return result, nil
+}
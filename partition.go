@@ -0,0 +1,185 @@
+package hermes
+
+import (
+	"context"
+	"errors"
+	"hash/fnv"
+	"sync"
+)
+
+// wordPartitions is a struct that splits the words of a full-text index into partitions, so the searches that have to match
+// every word of the index, such as the non-strict searches without an n-gram or suffix index, match each partition on its own goroutine.
+//
+// Fields:
+//   - parts ([]map[string]bool): The words of each partition.
+type wordPartitions struct {
+	parts []map[string]bool
+}
+
+// FTSetSearchPartitions is a method of the Cache struct that splits the words of the full-text index into partitions, so a search
+// that matches every word of the index, such as a non-strict, fuzzy or ranked search, matches the words of each partition concurrently
+// and uses up to n cores. The n-gram and suffix indexes take precedence over the partitions, since they look up the matching words
+// instead of scanning them. The partitions use about 16 bytes per word, and only their number is stored in the snapshots, so they're
+// rebuilt when a snapshot is loaded.
+// This method is thread-safe.
+//
+// Parameters:
+//   - n (int): The number of partitions, such as runtime.NumCPU(), or 0 or 1 to match the words on the goroutine of the search.
+//
+// Returns:
+//   - error: An error if the full-text index is not initialized or the number of partitions is negative.
+func (c *Cache) FTSetSearchPartitions(n int) error {
+	if n < 0 {
+		return errors.New("invalid number of partitions")
+	}
+
+	// Lock the mutex
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	// Check if the ft is initialized
+	if c.ft == nil {
+		return errors.New("full text not initialized")
+	}
+
+	// Partition the words
+	c.ft.partitions = newWordPartitions(n)
+	c.ft.partitions.build(c.ft.storage)
+	return nil
+}
+
+// newWordPartitions is a function that returns empty word partitions.
+//
+// Parameters:
+//   - n (int): The number of partitions.
+//
+// Returns:
+//   - *wordPartitions: The word partitions, or nil if there are fewer than 2.
+func newWordPartitions(n int) *wordPartitions {
+	if n < 2 {
+		return nil
+	}
+	var p *wordPartitions = &wordPartitions{parts: make([]map[string]bool, n)}
+	for i := range p.parts {
+		p.parts[i] = make(map[string]bool)
+	}
+	return p
+}
+
+// count is a method of the wordPartitions struct that returns the number of partitions.
+//
+// Returns:
+//   - int: The number of partitions, or 0 if the word partitions are nil.
+func (p *wordPartitions) count() int {
+	if p == nil {
+		return 0
+	}
+	return len(p.parts)
+}
+
+// build is a method of the wordPartitions struct that partitions every word of a full-text storage.
+//
+// Parameters:
+//   - storage (map[string]any): The full-text storage.
+//
+// Returns:
+//   - None
+func (p *wordPartitions) build(storage map[string]any) {
+	if p == nil {
+		return
+	}
+	for word := range storage {
+		p.add(word)
+	}
+}
+
+// part is a method of the wordPartitions struct that returns the partition of a word, chosen by its hash.
+//
+// Parameters:
+//   - word (string): The word.
+//
+// Returns:
+//   - map[string]bool: The words of the partition.
+func (p *wordPartitions) part(word string) map[string]bool {
+	var h = fnv.New32a()
+	h.Write([]byte(word))
+	return p.parts[h.Sum32()%uint32(len(p.parts))]
+}
+
+// add is a method of the wordPartitions struct that adds a word to its partition.
+//
+// Parameters:
+//   - word (string): The word.
+//
+// Returns:
+//   - None
+func (p *wordPartitions) add(word string) {
+	if p != nil {
+		p.part(word)[word] = true
+	}
+}
+
+// remove is a method of the wordPartitions struct that removes a word from its partition.
+//
+// Parameters:
+//   - word (string): The word.
+//
+// Returns:
+//   - None
+func (p *wordPartitions) remove(word string) {
+	if p != nil {
+		delete(p.part(word), word)
+	}
+}
+
+// matchingWords is a method of the wordPartitions struct that matches the words of every partition concurrently.
+// The storage is only read, so it must not be modified until the method returns.
+//
+// Parameters:
+//   - ctx (context.Context): The context of the request.
+//   - storage (map[string]any): The full-text storage of the partitioned words.
+//   - match (func(word string) bool): The function that checks whether a word matches.
+//
+// Returns:
+//   - map[string]any: The matching words, of the words that were matched before the context was done, with their storage values.
+//   - error: The context error if the context is done before every word is matched.
+func (p *wordPartitions) matchingWords(ctx context.Context, storage map[string]any, match func(word string) bool) (map[string]any, error) {
+	var (
+		matches []map[string]any = make([]map[string]any, len(p.parts))
+		errs    []error          = make([]error, len(p.parts))
+		wg      sync.WaitGroup
+	)
+	for i, part := range p.parts {
+		wg.Add(1)
+		go func(i int, part map[string]bool) {
+			defer wg.Done()
+			matches[i] = map[string]any{}
+			var j int = 0
+			for word := range part {
+				if errs[i] = ctxDone(ctx, j); errs[i] != nil {
+					return
+				}
+				j++
+				if match(word) {
+					matches[i][word] = storage[word]
+				}
+			}
+		}(i, part)
+	}
+	wg.Wait()
+
+	// Merge the matching words of the partitions
+	var (
+		matching map[string]any = map[string]any{}
+		err      error
+	)
+	for i := range matches {
+		for word, v := range matches[i] {
+			matching[word] = v
+		}
+		if err == nil {
+			err = errs[i]
+		}
+	}
+	return matching, err
+}
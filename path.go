@@ -0,0 +1,179 @@
+package hermes
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// GetField is a method of the Cache struct that returns a field of the value of a key, where nested fields are accessed with a dot path,
+// e.g. "profile.address.city" for the city field of the address map of the profile map.
+// The returned field is the one stored in the cache, so it must not be modified if it's a map or a slice.
+// This method is thread-safe.
+//
+// Parameters:
+//   - key (string): The key of the value.
+//   - path (string): The dot path of the field.
+//
+// Returns:
+//   - any: The field.
+//   - error: An error if the key or the field doesn't exist.
+func (c *Cache) GetField(key string, path string) (any, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	// Get the value of the key
+	var value map[string]any = c.get(key)
+	if value == nil {
+		return nil, fmt.Errorf("key %s does not exist", key)
+	}
+
+	// Get the field of the value
+	if v, ok := lookupPath(value, path); ok {
+		return v, nil
+	}
+	return nil, fmt.Errorf("field %s does not exist", path)
+}
+
+// SetField is a method of the Cache struct that sets a field of the value of an existing key, where nested fields are accessed with a dot path.
+// The maps of the path that don't exist are created, and a nil field removes the field from the value. The value is replaced like with Update,
+// so the full-text, unique and secondary indexes are updated, including the nested fields that the full-text schema references with a dot path.
+// This method is thread-safe.
+//
+// Parameters:
+//   - key (string): The key of the value.
+//   - path (string): The dot path of the field.
+//   - field (any): The new field.
+//
+// Returns:
+//   - error: An error if the key doesn't exist, the path is invalid or goes through a field that isn't a map, or the set fails,
+//     and ErrReplaceSkipped if the new value is skipped.
+func (c *Cache) SetField(key string, path string, field any) error {
+	if len(path) == 0 {
+		return errors.New("invalid path")
+	}
+
+	// Lock the mutex
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	// Verify that the key exists and the write rate limit
+	if err := c.writable("SetField"); err != nil {
+		return err
+	} else if err := c.versionCheck(key, 0); err != nil {
+		return err
+	} else if err := c.writeAllow(key); err != nil {
+		return err
+	}
+
+	// Set the field on a copy of the current value
+	var value map[string]any = c.ftValue(key)
+	if err := setPath(value, path, field); err != nil {
+		return err
+	}
+
+	// Replace the value
+	return c.setDone(key, c.replace(key, value))
+}
+
+// nestedValues is a method of the FullText struct that returns the nested string fields of a value that the schema references with a dot path.
+//
+// Parameters:
+//   - value (map[string]any): The value.
+//
+// Returns:
+//   - map[string]string: The strings to store in the full-text index, keyed by the dot path of their field.
+func (ft *FullText) nestedValues(value map[string]any) map[string]string {
+	var result map[string]string = map[string]string{}
+	for field := range ft.schema {
+		if !strings.Contains(field, ".") {
+			continue
+		} else if _, ok := value[field]; ok {
+			continue
+		}
+		if v, ok := lookupPath(value, field); ok {
+			if s, ok := fieldString(v); ok && len(s) > 0 {
+				result[field] = s
+			}
+		}
+	}
+	return result
+}
+
+// pathValue is a function that returns a field of a value that is stored in the full-text index, which is either a top-level field
+// or a nested field referenced by its dot path.
+//
+// Parameters:
+//   - value (map[string]any): The value.
+//   - field (string): The name or the dot path of the field.
+//
+// Returns:
+//   - any: The field, or nil if it doesn't exist.
+func pathValue(value map[string]any, field string) any {
+	if v, ok := value[field]; ok {
+		return v
+	}
+	v, _ := lookupPath(value, field)
+	return v
+}
+
+// lookupPath is a function that returns the nested field of a value at a dot path.
+//
+// Parameters:
+//   - value (map[string]any): The value.
+//   - path (string): The dot path of the field.
+//
+// Returns:
+//   - any: The field.
+//   - bool: false if the field doesn't exist.
+func lookupPath(value map[string]any, path string) (any, bool) {
+	var parts []string = strings.Split(path, ".")
+	for _, part := range parts[:len(parts)-1] {
+		next, ok := value[part].(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		value = next
+	}
+	v, ok := value[parts[len(parts)-1]]
+	return v, ok
+}
+
+// setPath is a function that sets the nested field of a value at a dot path, creating the maps of the path that don't exist.
+// The maps of the path are modified in place, so the value must be a deep copy.
+//
+// Parameters:
+//   - value (map[string]any): The value.
+//   - path (string): The dot path of the field.
+//   - field (any): The field to set, or nil to remove the field.
+//
+// Returns:
+//   - error: An error if the path has an empty part or goes through a field that isn't a map.
+func setPath(value map[string]any, path string, field any) error {
+	var parts []string = strings.Split(path, ".")
+	for _, part := range parts {
+		if len(part) == 0 {
+			return fmt.Errorf("invalid path %s", path)
+		}
+	}
+	for _, part := range parts[:len(parts)-1] {
+		switch next := value[part].(type) {
+		case map[string]any:
+			value = next
+		case nil:
+			if field == nil {
+				return nil
+			}
+			value[part] = map[string]any{}
+			value = value[part].(map[string]any)
+		default:
+			return fmt.Errorf("field %s of path %s is not a map", part, path)
+		}
+	}
+	if field == nil {
+		delete(value, parts[len(parts)-1])
+	} else {
+		value[parts[len(parts)-1]] = field
+	}
+	return nil
+}
@@ -0,0 +1,228 @@
+package hermes
+
+import (
+	"errors"
+	"log"
+	"os"
+	"time"
+)
+
+// persister is a struct that periodically saves a snapshot of the cache to disk.
+//
+// Fields:
+//   - path (string): The path of the snapshot file, or the directory of the snapshots if keep is not 0.
+//   - keep (int): The number of timestamped snapshots to retain. If 0, a single snapshot file is overwritten.
+//   - stop (chan struct{}): A channel that is closed to stop the persister.
+//   - done (chan struct{}): A channel that is closed once the persister goroutine has exited.
+//   - wal (*wal): The write-ahead log of the timestamped snapshots. If nil, the writes are not logged.
+type persister struct {
+	path string
+	keep int
+	stop chan struct{}
+	done chan struct{}
+	wal  *wal
+}
+
+// EnableAutoPersist is a method of the Cache struct that starts saving a snapshot of the cache to the provided path on every interval.
+// A final snapshot is saved when Close is called, so call Close on graceful shutdown.
+// This method is thread-safe.
+//
+// Parameters:
+//   - path (string): The path of the snapshot file.
+//   - interval (time.Duration): The interval between snapshots.
+//
+// Returns:
+//   - error: An error if the interval is invalid or auto-persist is already enabled.
+func (c *Cache) EnableAutoPersist(path string, interval time.Duration) error {
+	switch {
+	case len(path) == 0:
+		return errors.New("invalid path")
+	case interval <= 0:
+		return errors.New("invalid interval")
+	}
+	return c.enableAutoPersist(path, 0, interval)
+}
+
+// EnableSnapshotHistory is a method of the Cache struct that starts saving a timestamped snapshot of the cache to the provided directory
+// on every interval, keeping the most recent snapshots. The snapshots can be opened with OpenAt to read the cache as of a point in time.
+// A first snapshot is saved immediately, and the writes made after each snapshot are logged as JSON in a write-ahead log next to it,
+// so OpenAt can replay them. Changes to the configuration of the cache, Load and FTReindex are not logged.
+// A final snapshot is saved when Close is called, so call Close on graceful shutdown.
+// This method is thread-safe.
+//
+// Parameters:
+//   - dir (string): The directory of the snapshots. It's created if it doesn't exist.
+//   - interval (time.Duration): The interval between snapshots.
+//   - keep (int): The number of snapshots to retain. Older snapshots are removed.
+//
+// Returns:
+//   - error: An error if the arguments are invalid, the directory or the first snapshot could not be created, or auto-persist is already enabled.
+func (c *Cache) EnableSnapshotHistory(dir string, interval time.Duration, keep int) error {
+	switch {
+	case len(dir) == 0:
+		return errors.New("invalid directory")
+	case interval <= 0:
+		return errors.New("invalid interval")
+	case keep <= 0:
+		return errors.New("invalid keep")
+	}
+
+	// Create the snapshot directory
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	} else if err := c.enableAutoPersist(dir, keep, interval); err != nil {
+		return err
+	}
+
+	// Save the first snapshot, which starts the write-ahead log
+	if _, err := c.SaveSnapshot(dir); err != nil {
+		c.DisableAutoPersist()
+		return err
+	}
+	return nil
+}
+
+// enableAutoPersist is a method of the Cache struct that starts the persister.
+// This method is thread-safe.
+//
+// Parameters:
+//   - path (string): The path of the snapshot file, or the directory of the snapshots if keep is not 0.
+//   - keep (int): The number of timestamped snapshots to retain. If 0, a single snapshot file is overwritten.
+//   - interval (time.Duration): The interval between snapshots.
+//
+// Returns:
+//   - error: An error if auto-persist is already enabled.
+func (c *Cache) enableAutoPersist(path string, keep int, interval time.Duration) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	// Verify that auto-persist isn't already enabled
+	if c.persist != nil {
+		return errors.New("auto-persist already enabled")
+	}
+
+	// Start the persister
+	c.persist = &persister{
+		path: path,
+		keep: keep,
+		stop: make(chan struct{}),
+		done: make(chan struct{}),
+	}
+	if keep > 0 {
+		c.persist.wal = &wal{dir: path}
+	}
+	go c.persist.run(c, interval)
+
+	// Return no error
+	return nil
+}
+
+// DisableAutoPersist is a method of the Cache struct that stops saving snapshots of the cache.
+// No final snapshot is saved.
+// This method is thread-safe.
+//
+// Returns:
+//   - None
+func (c *Cache) DisableAutoPersist() {
+	c.mutex.Lock()
+	var p *persister = c.persist
+	c.persist = nil
+	c.mutex.Unlock()
+
+	// Stop the persister outside of the lock, it might be saving
+	if p != nil {
+		p.close()
+		p.walClose()
+	}
+}
+
+// Close is a method of the Cache struct that stops all background work of the cache.
+// If auto-persist is enabled, a final snapshot is saved. The queued writes of asynchronous sinks are sent before it returns. Expired keys are no longer removed automatically after Close.
+// This method is thread-safe.
+//
+// Returns:
+//   - error: An error if the final snapshot could not be saved.
+func (c *Cache) Close() error {
+	c.mutex.Lock()
+	var p *persister = c.persist
+	var e *expirer = c.expirer
+	c.persist = nil
+	c.expirer = nil
+	c.mutex.Unlock()
+
+	// Stop the expirer outside of the lock, it might be removing keys
+	if e != nil {
+		e.close()
+	}
+
+	// Send the queued writes to the sinks
+	c.sinkClose()
+
+	// Stop the persister and save the final snapshot
+	if p != nil {
+		p.close()
+		defer p.walClose()
+		return p.save(c)
+	}
+	return nil
+}
+
+// run is a method of the persister struct that saves a snapshot of the cache on every interval until the persister is stopped.
+//
+// Parameters:
+//   - c (*Cache): The cache to save.
+//   - interval (time.Duration): The interval between snapshots.
+//
+// Returns:
+//   - None
+func (p *persister) run(c *Cache, interval time.Duration) {
+	var ticker *time.Ticker = time.NewTicker(interval)
+	defer ticker.Stop()
+	defer close(p.done)
+	for {
+		select {
+		case <-p.stop:
+			return
+		case <-ticker.C:
+			if err := p.save(c); err != nil {
+				log.Println("hermes: auto-persist:", err)
+			}
+		}
+	}
+}
+
+// save is a method of the persister struct that saves a snapshot of the cache.
+// If the persister retains timestamped snapshots, the oldest snapshots beyond the retained number are removed.
+//
+// Parameters:
+//   - c (*Cache): The cache to save.
+//
+// Returns:
+//   - error: An error if the snapshot could not be saved or the old snapshots could not be removed.
+func (p *persister) save(c *Cache) error {
+	if p.keep == 0 {
+		return c.Save(p.path)
+	} else if _, err := c.SaveSnapshot(p.path); err != nil {
+		return err
+	}
+	return pruneSnapshots(p.path, p.keep)
+}
+
+// walClose is a method of the persister struct that closes its write-ahead log.
+//
+// Returns:
+//   - None
+func (p *persister) walClose() {
+	if p.wal != nil {
+		p.wal.close()
+	}
+}
+
+// close is a method of the persister struct that stops the persister and waits for it to exit.
+//
+// Returns:
+//   - None
+func (p *persister) close() {
+	close(p.stop)
+	<-p.done
+}
@@ -0,0 +1,223 @@
+package hermes
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The prefix of timestamped snapshot files
+const snapshotFilePrefix string = "hermes-"
+
+// snapshotFile is a struct that represents a timestamped snapshot file.
+//
+// Fields:
+//   - time (time.Time): The time of the snapshot.
+//   - name (string): The name of the snapshot file.
+type snapshotFile struct {
+	time time.Time
+	name string
+}
+
+// SaveSnapshot is a method of the Cache struct that writes a snapshot of the cache to a timestamped file in the provided directory.
+// The file is named with the extension of the snapshot codec. The snapshots in a directory can be opened with OpenAt to read the cache
+// as of a point in time. If the cache keeps its snapshot history in the directory, the writes are logged in a new segment from then on.
+// This method is thread-safe.
+//
+// Parameters:
+//   - dir (string): The directory of the snapshots.
+//
+// Returns:
+//   - string: The path of the snapshot file.
+//   - error: An error if the snapshot could not be encoded or written.
+func (c *Cache) SaveSnapshot(dir string) (string, error) {
+	c.mutex.RLock()
+	var now time.Time = time.Now()
+	var name string = snapshotFileName(now, c.codec)
+	data, err := c.codec.encode(c.snapshot())
+	if err == nil && c.persist != nil && c.persist.wal != nil && filepath.Clean(c.persist.wal.dir) == filepath.Clean(dir) {
+		// Start the segment of the snapshot before any other write is made
+		if err := c.persist.wal.rotate(now); err != nil {
+			log.Println("hermes: wal:", err)
+		}
+	}
+	c.mutex.RUnlock()
+	if err != nil {
+		return "", err
+	}
+
+	// Write the snapshot file
+	var path string = filepath.Join(dir, name)
+	return path, writeFileAtomic(path, data)
+}
+
+// Snapshots is a function that returns the times of the timestamped snapshots in the provided directory, oldest first.
+//
+// Parameters:
+//   - dir (string): The directory of the snapshots.
+//
+// Returns:
+//   - []time.Time: The times of the snapshots.
+//   - error: An error if the directory could not be read.
+func Snapshots(dir string) ([]time.Time, error) {
+	files, err := snapshotFiles(dir)
+	var times []time.Time = make([]time.Time, 0, len(files))
+	for _, f := range files {
+		times = append(times, f.time)
+	}
+	return times, err
+}
+
+// snapshotFiles is a function that returns the timestamped snapshot files in the provided directory, oldest first.
+//
+// Parameters:
+//   - dir (string): The directory of the snapshots.
+//
+// Returns:
+//   - []snapshotFile: The snapshot files.
+//   - error: An error if the directory could not be read.
+func snapshotFiles(dir string) ([]snapshotFile, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return []snapshotFile{}, err
+	}
+
+	// Parse the times from the snapshot file names
+	var files []snapshotFile = []snapshotFile{}
+	for _, entry := range entries {
+		if t, ok := snapshotFileTime(entry.Name()); ok && !entry.IsDir() {
+			files = append(files, snapshotFile{time: t, name: entry.Name()})
+		}
+	}
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].time.Before(files[j].time)
+	})
+	return files, nil
+}
+
+// OpenAt is a function that materializes a read-only cache as of the provided time from the timestamped snapshots in a directory.
+// The most recent snapshot taken at or before the time is loaded. If the cache that wrote it kept its snapshot history with
+// EnableSnapshotHistory, the writes logged after the snapshot are replayed up to the time, so the result is exact. Otherwise,
+// the result is as precise as the snapshot interval. Keys that had expired at the time that the cache is read at are not included.
+//
+// Parameters:
+//   - dir (string): The directory of the snapshots.
+//   - t (time.Time): The point in time to read the cache at.
+//
+// Returns:
+//   - *ReadOnlyCache: The cache as of the time, or as of the snapshot if the writes after it were not logged.
+//   - error: An error if there is no snapshot at or before the time, or the snapshot or the logged writes could not be loaded.
+func OpenAt(dir string, t time.Time) (*ReadOnlyCache, error) {
+	files, err := snapshotFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	// Find the most recent snapshot at or before the time
+	var i int = sort.Search(len(files), func(i int) bool {
+		return files[i].time.After(t)
+	})
+	if i == 0 {
+		return nil, fmt.Errorf("no snapshot at or before %s", t.Format(time.RFC3339))
+	}
+	var at time.Time = files[i-1].time
+
+	// Read the snapshot
+	var s snapshot
+	if data, err := os.ReadFile(filepath.Join(dir, files[i-1].name)); err != nil {
+		return nil, err
+	} else if err := decodeSnapshot(data, &s); err != nil {
+		return nil, err
+	}
+
+	// Load the snapshot into a new cache, without the expiries, since its keys must not expire while it's read
+	var expiries map[string]time.Time = s.Expiries
+	if expiries == nil {
+		expiries = map[string]time.Time{}
+	}
+	s.Expiries = nil
+	var c *Cache = InitCache()
+	if err := c.load(&s); err != nil {
+		return nil, err
+	}
+
+	// Replay the writes logged after the snapshot
+	var readAt time.Time = at
+	if replayed, err := c.walReplay(dir, at, t, expiries); err != nil {
+		return nil, err
+	} else if replayed {
+		readAt = t
+	}
+
+	// Remove the keys that had expired at the time the cache is read at
+	for key, expiry := range expiries {
+		if _, ok := c.data[key]; ok && !readAt.Before(expiry) {
+			c.delete(key)
+		}
+	}
+	return &ReadOnlyCache{cache: c, time: readAt}, nil
+}
+
+// pruneSnapshots is a function that removes the oldest timestamped snapshots in a directory, keeping the provided number of snapshots.
+// The write-ahead log segments of the removed snapshots are removed with them.
+//
+// Parameters:
+//   - dir (string): The directory of the snapshots.
+//   - keep (int): The number of snapshots to keep.
+//
+// Returns:
+//   - error: An error if the directory could not be read or a snapshot could not be removed.
+func pruneSnapshots(dir string, keep int) error {
+	files, err := snapshotFiles(dir)
+	if err != nil {
+		return err
+	}
+	for i := 0; i < len(files)-keep; i++ {
+		for _, name := range []string{files[i].name, walFileName(files[i].time)} {
+			if err := os.Remove(filepath.Join(dir, name)); err != nil && !errors.Is(err, os.ErrNotExist) {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// snapshotFileName is a function that returns the name of the snapshot file for the provided time and codec.
+// The names sort in the same order as the times.
+//
+// Parameters:
+//   - t (time.Time): The time of the snapshot.
+//   - codec (Codec): The codec of the snapshot.
+//
+// Returns:
+//   - string: The name of the snapshot file.
+func snapshotFileName(t time.Time, codec Codec) string {
+	return fmt.Sprintf("%s%020d%s", snapshotFilePrefix, t.UnixNano(), codec.ext())
+}
+
+// snapshotFileTime is a function that parses the time of a snapshot from its file name.
+//
+// Parameters:
+//   - name (string): The name of the snapshot file.
+//
+// Returns:
+//   - time.Time: The time of the snapshot.
+//   - bool: false if the name is not a snapshot file name.
+func snapshotFileTime(name string) (time.Time, bool) {
+	var ext string = filepath.Ext(name)
+	if !strings.HasPrefix(name, snapshotFilePrefix) || (ext != CodecJSON.ext() && ext != CodecGob.ext()) {
+		return time.Time{}, false
+	}
+	var nanos string = strings.TrimSuffix(strings.TrimPrefix(name, snapshotFilePrefix), ext)
+	if n, err := strconv.ParseInt(nanos, 10, 64); err != nil {
+		return time.Time{}, false
+	} else {
+		return time.Unix(0, n), true
+	}
+}
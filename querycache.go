@@ -0,0 +1,255 @@
+package hermes
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sort"
+	"sync"
+)
+
+// queryCache is a struct that holds the results of the most popular searches, so a repeated search doesn't scan the full-text index again.
+// The results are only valid for the generation of the cache at which they were computed, while the popularity of the searches is kept
+// across writes and snapshots, so the popular searches can be run again after a restart.
+//
+// Fields:
+//   - mutex (sync.Mutex): A Mutex that guards access to the fields. It's separate from the cache mutex, since searches only hold the read lock.
+//   - size (int): The maximum number of searches to keep. If 0, the query cache is disabled.
+//   - generation (uint64): The generation of the cache at which the results were computed.
+//   - params (map[string]SearchParams): The search parameters of each kept search, keyed by their JSON encoding.
+//   - hits (map[string]uint64): The number of times each kept search was run.
+//   - results (map[string][]map[string]any): The results of the kept searches that were run at the current generation.
+type queryCache struct {
+	mutex      sync.Mutex
+	size       int
+	generation uint64
+	params     map[string]SearchParams
+	hits       map[string]uint64
+	results    map[string][]map[string]any
+}
+
+// SetQueryCache is a method of the Cache struct that keeps the results of the size most popular searches of Search and SearchCtx,
+// so a repeated search returns without scanning the full-text index until the cache is written to. The searches, without their results,
+// are stored in the snapshots, and when a snapshot is loaded they're run again in the background, so the popular searches are fast
+// right after a restart. The query cache must be enabled before the snapshot is loaded. If size is 0, the query cache is disabled and cleared.
+// This method is thread-safe.
+//
+// Parameters:
+//   - size (int): The maximum number of searches to keep.
+//
+// Returns:
+//   - error: An error if the size is negative.
+func (c *Cache) SetQueryCache(size int) error {
+	if size < 0 {
+		return errors.New("invalid query cache size")
+	}
+
+	// Resize the query cache
+	c.queries.mutex.Lock()
+	defer c.queries.mutex.Unlock()
+	c.queries.size = size
+	if size == 0 {
+		c.queries.params, c.queries.hits, c.queries.results = nil, nil, nil
+		return nil
+	}
+	if c.queries.params == nil {
+		c.queries.params = make(map[string]SearchParams)
+		c.queries.hits = make(map[string]uint64)
+		c.queries.results = make(map[string][]map[string]any)
+	}
+	for len(c.queries.params) > size {
+		c.queries.evict()
+	}
+	return nil
+}
+
+// searchCached is a method of the Cache struct that returns the results of a search from the query cache, or runs the search
+// and keeps its results in the query cache.
+// This method is not thread-safe, and should only be called from an exported function.
+//
+// Parameters:
+//   - ctx (context.Context): The context of the request.
+//   - sp (SearchParams): The search parameters, with a lowercase query and a limit.
+//
+// Returns:
+//   - []map[string]any: The results of the search.
+//   - error: An error if the search failed, in which case its results are not kept.
+func (c *Cache) searchCached(ctx context.Context, sp SearchParams) ([]map[string]any, error) {
+	if sp.Explain != nil {
+		return c.searchAll(ctx, sp)
+	}
+	var key string = c.queries.key(sp)
+	if result, ok := c.queries.get(key, c.generation); ok {
+		return result, nil
+	}
+	result, err := c.searchAll(ctx, sp)
+	if err == nil {
+		c.queries.put(key, sp, c.generation, result)
+	}
+	return result, err
+}
+
+// warmQueries is a method of the Cache struct that runs searches to keep their results in the query cache.
+// Each search acquires the read lock, so it waits for the load that scheduled it to release the lock.
+// It's meant to be run in its own goroutine.
+//
+// Parameters:
+//   - queries ([]SearchParams): The searches, from the most to the least popular.
+//
+// Returns:
+//   - None
+func (c *Cache) warmQueries(queries []SearchParams) {
+	for _, sp := range queries {
+		c.mutex.RLock()
+		if c.ft != nil {
+			_, _ = c.searchCached(context.Background(), sp)
+		}
+		c.mutex.RUnlock()
+	}
+}
+
+// key is a method of the queryCache struct that returns the key of a search in the query cache.
+//
+// Parameters:
+//   - sp (SearchParams): The search parameters.
+//
+// Returns:
+//   - string: The encoding of the search parameters, or an empty string if the query cache is disabled.
+func (q *queryCache) key(sp SearchParams) string {
+	q.mutex.Lock()
+	var enabled bool = q.size > 0
+	q.mutex.Unlock()
+	if !enabled {
+		return ""
+	}
+	return string(sp.encode())
+}
+
+// get is a method of the queryCache struct that counts a search and returns its results if they were computed at the current generation.
+//
+// Parameters:
+//   - key (string): The key of the search. If empty, the search is not cached.
+//   - generation (uint64): The current generation of the cache.
+//
+// Returns:
+//   - []map[string]any: A copy of the slice of results.
+//   - bool: true if the results were found, false otherwise.
+func (q *queryCache) get(key string, generation uint64) ([]map[string]any, bool) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	if len(key) == 0 || q.size == 0 {
+		return nil, false
+	} else if q.generation != generation {
+		q.generation = generation
+		q.results = make(map[string][]map[string]any)
+	}
+	if _, ok := q.params[key]; ok {
+		q.hits[key]++
+	}
+	result, ok := q.results[key]
+	if !ok {
+		return nil, false
+	}
+	return append([]map[string]any{}, result...), true
+}
+
+// put is a method of the queryCache struct that keeps the results of a search, evicting the least popular search if the cache is full.
+//
+// Parameters:
+//   - key (string): The key of the search. If empty, the search is not cached.
+//   - sp (SearchParams): The search parameters.
+//   - generation (uint64): The generation of the cache at which the results were computed.
+//   - result ([]map[string]any): The results of the search.
+//
+// Returns:
+//   - None
+func (q *queryCache) put(key string, sp SearchParams, generation uint64, result []map[string]any) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	if len(key) == 0 || q.size == 0 || q.generation != generation {
+		return
+	}
+	if _, ok := q.params[key]; !ok {
+		if len(q.params) >= q.size {
+			q.evict()
+		}
+		q.params[key] = sp
+		q.hits[key] = 1
+	}
+	q.results[key] = append([]map[string]any{}, result...)
+}
+
+// evict is a method of the queryCache struct that removes the least popular search.
+// This method is not thread-safe, and should only be called while holding the mutex of the query cache.
+//
+// Returns:
+//   - None
+func (q *queryCache) evict() {
+	var (
+		least string
+		found bool = false
+	)
+	for key := range q.params {
+		if !found || q.hits[key] < q.hits[least] || (q.hits[key] == q.hits[least] && key < least) {
+			least, found = key, true
+		}
+	}
+	delete(q.params, least)
+	delete(q.hits, least)
+	delete(q.results, least)
+}
+
+// popular is a method of the queryCache struct that returns the kept searches.
+//
+// Returns:
+//   - []SearchParams: The search parameters, from the most to the least popular, or nil if the query cache is disabled.
+func (q *queryCache) popular() []SearchParams {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	if len(q.params) == 0 {
+		return nil
+	}
+	var keys []string = make([]string, 0, len(q.params))
+	for key := range q.params {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if q.hits[keys[i]] != q.hits[keys[j]] {
+			return q.hits[keys[i]] > q.hits[keys[j]]
+		}
+		return keys[i] < keys[j]
+	})
+	var result []SearchParams = make([]SearchParams, len(keys))
+	for i, key := range keys {
+		result[i] = q.params[key]
+	}
+	return result
+}
+
+// restore is a method of the queryCache struct that keeps the searches of a snapshot without their results, up to the size of the cache,
+// so they're counted as popular until they're run again.
+//
+// Parameters:
+//   - queries ([]SearchParams): The searches of the snapshot, from the most to the least popular.
+//
+// Returns:
+//   - []SearchParams: The searches that are kept, or nil if the query cache is disabled.
+func (q *queryCache) restore(queries []SearchParams) []SearchParams {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	if q.size == 0 || len(queries) == 0 {
+		return nil
+	}
+	if len(queries) > q.size {
+		queries = queries[:q.size]
+	}
+	q.params = make(map[string]SearchParams, len(queries))
+	q.hits = make(map[string]uint64, len(queries))
+	q.results = make(map[string][]map[string]any)
+	for i, sp := range queries {
+		data, _ := json.Marshal(sp)
+		q.params[string(data)] = sp
+		q.hits[string(data)] = uint64(len(queries) - i)
+	}
+	return queries
+}
@@ -0,0 +1,33 @@
+package hermes
+
+// Range is a method of the Cache struct that calls fn for each key and value in the cache, without copying the keys or values
+// into a slice. Iteration stops when fn returns false.
+// The read lock is held for the whole iteration, so fn must not modify the value or call methods that write to the cache.
+// This method is thread-safe.
+//
+// Parameters:
+//   - fn (func(key string, value map[string]any) bool): The function to call for each key and value.
+//
+// Returns:
+//   - None
+func (c *Cache) Range(fn func(key string, value map[string]any) bool) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	c.rangeData(fn)
+}
+
+// rangeData is a method of the Cache struct that calls fn for each key and value in the cache until fn returns false.
+// This method is not thread-safe, and should only be called from an exported function.
+//
+// Parameters:
+//   - fn (func(key string, value map[string]any) bool): The function to call for each key and value.
+//
+// Returns:
+//   - None
+func (c *Cache) rangeData(fn func(key string, value map[string]any) bool) {
+	for key, value := range c.data {
+		if !fn(key, c.expand(value)) {
+			return
+		}
+	}
+}
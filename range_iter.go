@@ -0,0 +1,15 @@
+//go:build go1.23
+
+package hermes
+
+import "iter"
+
+// All is a method of the Cache struct that returns an iterator over the keys and values in the cache, for use with range-over-func.
+// The read lock is held while the iterator runs, so the loop body must not modify the value or call methods that write to the cache.
+// This method is thread-safe.
+//
+// Returns:
+//   - iter.Seq2[string, map[string]any]: The iterator over the keys and values.
+func (c *Cache) All() iter.Seq2[string, map[string]any] {
+	return c.Range
+}
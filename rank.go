@@ -0,0 +1,564 @@
+package hermes
+
+import (
+	"context"
+	"errors"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	utils "github.com/realTristan/hermes/utils"
+)
+
+// Ranker is a type that represents how the results of a search are ordered.
+type Ranker int
+
+const (
+	// RankNone returns the results in the order they're found in the full-text index, which is the fastest.
+	RankNone Ranker = iota
+	// RankTFIDF orders the results by their TF-IDF score, which is the sum, for each word of the query, of the number of times
+	// the word occurs in the full-text fields of the value, multiplied by how rare the word is among the values of the cache.
+	RankTFIDF
+	// RankBM25 orders the results by their BM25 score, which is like the TF-IDF score, but each additional occurrence of a word
+	// adds less to the score, and values that are longer than the average value score lower. See SetBM25 for its parameters.
+	RankBM25
+)
+
+// Result is a struct that represents a search result along with its relevance score.
+//
+// Fields:
+//   - Key (string): The cache key of the value.
+//   - Score (float64): The relevance score of the value. Higher scores are more relevant.
+//   - Value (map[string]any): The value.
+//   - Snippets (map[string]string): The highlighted snippet of each full-text field that matches the query, if the search parameters
+//     have a Highlight. Otherwise, nil.
+type Result struct {
+	Key      string
+	Score    float64
+	Value    map[string]any
+	Snippets map[string]string
+}
+
+// SearchScored is a method of the Cache struct that searches for a query like Search, and returns the results ordered by relevance
+// along with their key and score. The results are scored with the Ranker of the search parameters, or with RankTFIDF if it's RankNone.
+// Every matching value is scored once, with the frequencies of all the words of the query in it, before the results are limited,
+// so it's slower than a search that isn't ranked.
+// If the search parameters have a Highlight, the matches of the full-text fields of each result are highlighted in its snippets.
+// The RangeFilter and SortBy of the search parameters apply like in Search.
+// This method is thread-safe.
+//
+// Parameters:
+//   - sp (SearchParams): A SearchParams struct containing the search parameters.
+//
+// Returns:
+//   - []Result: The results, from the most to the least relevant.
+//   - error: An error if the query is invalid or the full-text index is not initialized.
+func (c *Cache) SearchScored(sp SearchParams) ([]Result, error) {
+	if len(sp.Query) == 0 {
+		return []Result{}, errors.New("invalid query")
+	}
+
+	// Set the default limit and ranker
+	if sp.Limit == 0 {
+		sp.Limit = 10
+	}
+	if sp.Ranker == RankNone {
+		sp.Ranker = RankTFIDF
+	}
+	return c.searchScored(context.Background(), sp, nil)
+}
+
+// searchScored is a method of the Cache struct that runs a SearchScored search, scoring the results with the provided statistics
+// instead of the statistics of the cache if they're not nil, so the scores of the shards of a ShardedCache can be compared.
+// This method is thread-safe.
+//
+// Parameters:
+//   - ctx (context.Context): The context of the request.
+//   - sp (SearchParams): The search parameters, with a query, limit and ranker.
+//   - stats (*rankStats): The statistics to score the results with, or nil for the statistics of the cache.
+//
+// Returns:
+//   - []Result: The results, from the most to the least relevant.
+//   - error: An error if the full-text index is not initialized, or the context error if the context is done before the search completes.
+func (c *Cache) searchScored(ctx context.Context, sp SearchParams, stats *rankStats) ([]Result, error) {
+	// Lock the mutex, and stop the search once its timeout elapses
+	sp.Explain.reset(strings.ToLower(sp.Query))
+	var (
+		start  time.Time       = time.Now()
+		parent context.Context = ctx
+	)
+	ctx, cancel := searchTimeout(ctx, sp)
+	defer cancel()
+	if err := c.rlockCtx(ctx); err != nil {
+		return []Result{}, timeoutErr(parent, sp, err)
+	}
+	defer c.mutex.RUnlock()
+	sp.Explain.phase("lock", start)
+
+	// Check if the FT index is initialized, and search the named index
+	if c.ft == nil {
+		return []Result{}, errors.New("full-text not initialized")
+	}
+	view, err := c.ftView(sp.Index)
+	if err != nil {
+		return []Result{}, err
+	}
+
+	// Search and rank the results
+	sp = sp.lower()
+	results, err := view.searchRanked(ctx, sp, stats)
+	for i := range results {
+		results[i].Value = c.expand(results[i].Value)
+	}
+
+	// Highlight the matches
+	if sp.Highlight != nil {
+		var terms []string = view.ft.queryTerms(sp.Query)
+		for i := range results {
+			results[i].Snippets = view.ft.snippets(results[i].Key, results[i].Value, terms, sp)
+		}
+	}
+	view.explain(ctx, sp, len(results))
+	return results, timeoutErr(parent, sp, err)
+}
+
+// searchRanked is a method of the Cache struct that searches for a query and returns the results ordered by their score, up to the limit.
+// This method is not thread-safe, and should only be called from an exported function.
+//
+// Parameters:
+//   - ctx (context.Context): The context of the request.
+//   - sp (SearchParams): The search parameters, with a lowercase query.
+//   - stats (*rankStats): The statistics to score the results with, or nil for the statistics of the cache.
+//
+// Returns:
+//   - []Result: The scored results, of the values that were found before the context was done.
+//   - error: An error if the range filter is invalid, or the context error if the context is done before the search completes.
+func (c *Cache) searchRanked(ctx context.Context, sp SearchParams, stats *rankStats) ([]Result, error) {
+	var limit int = sp.Limit
+
+	// Find every matching value. If the context is done, the values that were found are still scored
+	var start time.Time = time.Now()
+	sp.Limit = len(c.data)
+	values, err := searchVariants(sp, func(sp SearchParams) ([]map[string]any, error) {
+		return c.search(ctx, sp)
+	})
+	sp.Explain.phase("search", start)
+	if err != nil && ctx.Err() == nil {
+		return []Result{}, err
+	}
+	c.explainKeyPrefix(ctx, sp, len(values))
+	if len(sp.RangeFilter) > 0 {
+		var before int = len(values)
+		start = time.Now()
+		filtered, filterErr := c.rangeFilter(values, sp.RangeFilter, len(values))
+		if filterErr != nil {
+			return []Result{}, filterErr
+		}
+		values = filtered
+		sp.Explain.filter("range filter", before, len(values))
+		sp.Explain.phase("range filter", start)
+	}
+
+	// Score the values
+	start = time.Now()
+	var terms []string = c.ft.queryTerms(sp.Query)
+	if stats == nil {
+		local, statsErr := c.rankStats(ctx, terms, sp)
+		if err == nil {
+			err = statsErr
+		}
+		stats = &local
+	}
+	var (
+		score  func(tf []float64, length int) float64 = c.scorer(sp.Ranker, *stats)
+		keys   []string                               = c.resultKeys(values)
+		result []Result                               = make([]Result, 0, len(values))
+		added  map[string]bool                        = map[string]bool{}
+	)
+	for i, value := range values {
+		if added[keys[i]] {
+			continue
+		}
+		added[keys[i]] = true
+		result = append(result, Result{
+			Key:   keys[i],
+			Score: score(c.ft.termFrequencies(keys[i], value, terms, sp)),
+			Value: value,
+		})
+	}
+
+	// Leave out the results below the minimum score
+	if sp.MinScore > 0 {
+		var kept []Result = result[:0]
+		for _, r := range result {
+			if r.Score >= sp.MinScore {
+				kept = append(kept, r)
+			}
+		}
+		sp.Explain.filter("min score", len(result), len(kept))
+		result = kept
+	}
+	sp.Explain.phase("score", start)
+	sp.Explain.matched(len(result))
+
+	// Sort the results
+	start = time.Now()
+	defer sp.Explain.phase("sort", start)
+	return sortResults(result, sp, limit), err
+}
+
+// sortResults is a function that sorts scored results by the SortBy field of the search parameters, then by score, then by key,
+// keeping the first results.
+//
+// Parameters:
+//   - result ([]Result): The scored results.
+//   - sp (SearchParams): The search parameters.
+//   - limit (int): The maximum number of results to keep.
+//
+// Returns:
+//   - []Result: The first sorted results.
+func sortResults(result []Result, sp SearchParams, limit int) []Result {
+	// Sort the results by the sort field, then by score, then by key
+	if len(sp.SortBy) > 0 {
+		var sortKeys []sortKey = make([]sortKey, len(result))
+		for i, r := range result {
+			sortKeys[i] = newSortKey(r.Value, sp.SortBy)
+		}
+		var sorted []Result = make([]Result, 0, limit)
+		for _, i := range partialSort(len(result), limit, func(i, j int) bool {
+			if cmp := sortKeys[i].compare(sortKeys[j], sp.SortOrder); cmp != 0 {
+				return cmp < 0
+			} else if result[i].Score != result[j].Score {
+				return result[i].Score > result[j].Score
+			}
+			return result[i].Key < result[j].Key
+		}) {
+			sorted = append(sorted, result[i])
+		}
+		return sorted
+	}
+
+	// Sort the results by score, then by key
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Score != result[j].Score {
+			return result[i].Score > result[j].Score
+		}
+		return result[i].Key < result[j].Key
+	})
+	if len(result) > limit {
+		result = result[:limit]
+	}
+	return result
+}
+
+// resultKeys is a method of the Cache struct that returns the cache keys of search results, which are the values stored in the cache.
+// This method is not thread-safe, and should only be called from an exported function.
+//
+// Parameters:
+//   - values ([]map[string]any): The search results.
+//
+// Returns:
+//   - []string: The key of each result.
+func (c *Cache) resultKeys(values []map[string]any) []string {
+	var pointers map[uintptr]int = make(map[uintptr]int, len(values))
+	for i, value := range values {
+		pointers[reflect.ValueOf(value).Pointer()] = i
+	}
+	var keys []string = make([]string, len(values))
+	for key, value := range c.data {
+		if i, ok := pointers[reflect.ValueOf(value).Pointer()]; ok {
+			keys[i] = key
+		}
+	}
+	return keys
+}
+
+// queryTerms is a method of the FullText struct that returns the distinct words of a query that are scored, analyzed with each analyzer of the index.
+//
+// Parameters:
+//   - query (string): The lowercase query.
+//
+// Returns:
+//   - []string: The words of the query, or its space-separated parts if none of its words is long enough to be stored in the full-text index.
+func (ft *FullText) queryTerms(query string) []string {
+	var terms []string = []string{}
+	for _, analyzer := range ft.analyzerSet() {
+		for _, w := range ft.analyzerTerms(analyzer, query) {
+			if !utils.SliceContains(terms, w) {
+				terms = append(terms, w)
+			}
+		}
+	}
+	return terms
+}
+
+// analyzerTerms is a method of the FullText struct that returns the distinct words of a query analyzed with an analyzer.
+//
+// Parameters:
+//   - analyzer (Analyzer): The analyzer.
+//   - query (string): The lowercase query.
+//
+// Returns:
+//   - []string: The analyzed words of the query, or its space-separated parts if none of its words is long enough to be stored in the full-text index.
+func (ft *FullText) analyzerTerms(analyzer Analyzer, query string) []string {
+	var words []string = ft.ruleWords(TokenDefault, analyzer, query)
+	if len(words) == 0 {
+		words = strings.Fields(query)
+	}
+	var terms []string = make([]string, 0, len(words))
+	for _, w := range words {
+		if !utils.SliceContains(terms, w) {
+			terms = append(terms, w)
+		}
+	}
+	return terms
+}
+
+// rankStats is a struct that holds the statistics of the values that the scores of a ranked search are computed from,
+// which are the statistics of a cache, or their sum over the shards of a ShardedCache.
+//
+// Fields:
+//   - values (int): The number of values in the full-text index.
+//   - df ([]int): The number of values that contain each term of the query.
+//   - average (float64): The average number of words of the values. Only computed for RankBM25.
+type rankStats struct {
+	values  int
+	df      []int
+	average float64
+}
+
+// rankStats is a method of the Cache struct that returns the statistics that the scores of a ranked search are computed from.
+// This method is not thread-safe, and should only be called from an exported function.
+//
+// Parameters:
+//   - ctx (context.Context): The context of the request.
+//   - terms ([]string): The terms of the query.
+//   - sp (SearchParams): The search parameters, which decide how the stored words match the terms and which ranker scores the values.
+//
+// Returns:
+//   - rankStats: The statistics of the cache, with the number of values that contain each term counted in the words that were scanned
+//     before the context was done.
+//   - error: The context error if the context is done before every word is scanned.
+func (c *Cache) rankStats(ctx context.Context, terms []string, sp SearchParams) (rankStats, error) {
+	df, err := c.ft.documentFrequencies(ctx, terms, sp)
+	var stats rankStats = rankStats{values: len(c.ft.fields), df: df}
+	if sp.Ranker == RankBM25 {
+		stats.average = c.bm25.averageLength(c)
+	}
+	return stats, err
+}
+
+// searchStats is a method of the Cache struct that returns the statistics that the scores of a ranked search with the search parameters
+// are computed from, so they can be added up over the shards of a ShardedCache.
+// This method is thread-safe.
+//
+// Parameters:
+//   - ctx (context.Context): The context of the request.
+//   - sp (SearchParams): The search parameters, with a query and ranker.
+//
+// Returns:
+//   - rankStats: The statistics of the cache.
+//   - error: An error if the full-text index is not initialized or the named index doesn't exist,
+//     or the context error if the context is done before the statistics are computed.
+func (c *Cache) searchStats(ctx context.Context, sp SearchParams) (rankStats, error) {
+	if err := c.rlockCtx(ctx); err != nil {
+		return rankStats{}, err
+	}
+	defer c.mutex.RUnlock()
+
+	// Check if the FT index is initialized, and search the named index
+	if c.ft == nil {
+		return rankStats{}, errors.New("full-text not initialized")
+	}
+	view, err := c.ftView(sp.Index)
+	if err != nil {
+		return rankStats{}, err
+	}
+	sp = sp.lower()
+	return view.rankStats(ctx, view.ft.queryTerms(sp.Query), sp)
+}
+
+// add is a method of the rankStats struct that returns the statistics of two sets of values together.
+//
+// Parameters:
+//   - o (rankStats): The statistics of the other values, for the same terms.
+//
+// Returns:
+//   - rankStats: The statistics of both sets of values.
+func (s rankStats) add(o rankStats) rankStats {
+	var sum rankStats = rankStats{values: s.values + o.values, df: append([]int{}, s.df...)}
+	for i := 0; i < len(sum.df) && i < len(o.df); i++ {
+		sum.df[i] += o.df[i]
+	}
+	if sum.values > 0 {
+		sum.average = (s.average*float64(s.values) + o.average*float64(o.values)) / float64(sum.values)
+	}
+	return sum
+}
+
+// scorer is a method of the Cache struct that returns the function that scores a value with a ranker.
+// This method is not thread-safe, and should only be called from an exported function.
+//
+// Parameters:
+//   - ranker (Ranker): The ranker, either RankTFIDF or RankBM25.
+//   - stats (rankStats): The statistics of the values, with the number of values that contain each term of the query.
+//
+// Returns:
+//   - func(tf []float64, length int) float64: The function that returns the score of a value from the weighted number of occurrences
+//     of each term in the value and the number of words of the value.
+func (c *Cache) scorer(ranker Ranker, stats rankStats) func(tf []float64, length int) float64 {
+	var (
+		n   float64   = float64(stats.values)
+		df  []int     = stats.df
+		idf []float64 = make([]float64, len(df))
+	)
+
+	// Score the values with TF-IDF
+	if ranker != RankBM25 {
+		for i, f := range df {
+			idf[i] = math.Log(1 + n/math.Max(float64(f), 1))
+		}
+		return func(tf []float64, length int) float64 {
+			var score float64 = 0
+			for i, f := range tf {
+				score += f * idf[i]
+			}
+			return score
+		}
+	}
+
+	// Score the values with BM25
+	for i, f := range df {
+		idf[i] = math.Log(1 + (n-float64(f)+0.5)/(float64(f)+0.5))
+	}
+	var (
+		k1, b   float64 = c.bm25.k1, c.bm25.b
+		average float64 = stats.average
+	)
+	return func(tf []float64, length int) float64 {
+		var score float64 = 0
+		for i, f := range tf {
+			if f > 0 {
+				var norm float64 = 1 - b + b*float64(length)/math.Max(average, 1)
+				score += idf[i] * f * (k1 + 1) / (f + k1*norm)
+			}
+		}
+		return score
+	}
+}
+
+// documentFrequencies is a method of the FullText struct that returns the number of values that contain each term.
+//
+// Parameters:
+//   - ctx (context.Context): The context of the request.
+//   - terms ([]string): The terms.
+//   - sp (SearchParams): The search parameters, which decide how the stored words match the terms.
+//
+// Returns:
+//   - []int: The number of values that contain each term, counted in the words that were scanned before the context was done.
+//   - error: The context error if the context is done before every word is scanned.
+func (ft *FullText) documentFrequencies(ctx context.Context, terms []string, sp SearchParams) ([]int, error) {
+	var df []int = make([]int, len(terms))
+	for i, term := range terms {
+		indices, err := ft.matchingIndices(ctx, term, sp)
+		if df[i] = len(indices); err != nil {
+			return df, err
+		}
+	}
+	return df, nil
+}
+
+// matchingIndices is a method of the FullText struct that returns the indices of the values that contain a stored word that matches a term.
+//
+// Parameters:
+//   - ctx (context.Context): The context of the request.
+//   - term (string): The term.
+//   - sp (SearchParams): The search parameters, which decide how the stored words match the term.
+//
+// Returns:
+//   - map[int]bool: The set of the indices, of the words that were scanned before the context was done.
+//   - error: The context error if the context is done before every word is scanned.
+func (ft *FullText) matchingIndices(ctx context.Context, term string, sp SearchParams) (map[int]bool, error) {
+	var indices map[int]bool = map[int]bool{}
+	words, err := ft.matchingWords(ctx, term, sp)
+	for _, v := range words {
+		eachPosting(v, func(index int) bool {
+			indices[index] = true
+			return true
+		})
+	}
+	return indices, err
+}
+
+// matchingWords is a method of the FullText struct that returns the stored words that match a term.
+// The words that aren't looked up are matched in their partitions concurrently, if the index has word partitions.
+//
+// Parameters:
+//   - ctx (context.Context): The context of the request.
+//   - term (string): The term.
+//   - sp (SearchParams): The search parameters, which decide how the stored words match the term.
+//
+// Returns:
+//   - map[string]any: The matching words, of the words that were scanned before the context was done, with their storage values.
+//   - error: The context error if the context is done before every word is scanned.
+func (ft *FullText) matchingWords(ctx context.Context, term string, sp SearchParams) (map[string]any, error) {
+	var (
+		matching map[string]any = map[string]any{}
+		words    map[string]any = ft.storage
+		i        int            = 0
+	)
+	if w, ok := ft.candidateWords(term, sp); ok {
+		words = w
+	} else if ft.partitions != nil {
+		return ft.partitions.matchingWords(ctx, ft.storage, func(word string) bool {
+			return sp.matchesWord(word, term)
+		})
+	}
+	for word, v := range words {
+		if err := ctxDone(ctx, i); err != nil {
+			return matching, err
+		}
+		i++
+		if sp.matchesWord(word, term) {
+			matching[word] = v
+		}
+	}
+	return matching, nil
+}
+
+// termFrequencies is a method of the FullText struct that returns the number of occurrences of each term in the full-text fields of a value.
+// Each occurrence counts as the weight of its field, see FTSetFieldWeights.
+//
+// Parameters:
+//   - key (string): The cache key of the value.
+//   - value (map[string]any): The value.
+//   - terms ([]string): The terms of the query.
+//   - sp (SearchParams): The search parameters, which decide how the words of the value match the terms.
+//
+// Returns:
+//   - []float64: The weighted number of occurrences of each term.
+//   - int: The number of words in the full-text fields of the value.
+func (ft *FullText) termFrequencies(key string, value map[string]any, terms []string, sp SearchParams) ([]float64, int) {
+	var (
+		tf     []float64 = make([]float64, len(terms))
+		length int       = 0
+	)
+	for _, field := range ft.fields[key] {
+		v, ok := fieldString(pathValue(value, field))
+		if !ok {
+			continue
+		}
+		var weight float64 = ft.weight(field)
+		for _, word := range ft.fieldWords(field, v) {
+			length++
+			for i, term := range terms {
+				if sp.matchesWord(word, term) {
+					tf[i] += weight
+				}
+			}
+		}
+	}
+	return tf, length
+}
@@ -0,0 +1,190 @@
+package hermes
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+// The minimum number of token buckets before the refilled buckets are pruned
+const writeBucketsPruneMin int = 1024
+
+// RateLimitError is the error returned by writes to a key that has exceeded the write rate limit set with SetWriteRateLimit.
+//
+// Fields:
+//   - Key (string): The key that was written.
+//   - Limit (int): The number of writes allowed per interval.
+//   - Per (time.Duration): The interval of the limit.
+//   - RetryAfter (time.Duration): The time after which the next write to the key is allowed.
+type RateLimitError struct {
+	Key        string
+	Limit      int
+	Per        time.Duration
+	RetryAfter time.Duration
+}
+
+// Error is a method of the RateLimitError struct that returns the error message.
+//
+// Returns:
+//   - string: The error message.
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("write rate limit of %d per %s exceeded for key %s. retry after %s", e.Limit, e.Per, e.Key, e.RetryAfter)
+}
+
+// writeLimiter is a struct that limits the rate of writes to each key with a token bucket per key.
+//
+// Fields:
+//   - limit (int): The number of writes allowed per interval, which is also the size of the buckets.
+//   - per (time.Duration): The interval of the limit.
+//   - buckets (map[string]*writeBucket): The token bucket of each recently written key.
+//   - pruneAt (int): The number of buckets at which the refilled buckets are pruned.
+//   - listeners ([]func(err *RateLimitError)): The functions to call when a write is rejected.
+type writeLimiter struct {
+	limit     int
+	per       time.Duration
+	buckets   map[string]*writeBucket
+	pruneAt   int
+	listeners []func(err *RateLimitError)
+}
+
+// writeBucket is a struct that represents the token bucket of a key.
+//
+// Fields:
+//   - tokens (float64): The number of writes that are currently allowed.
+//   - last (time.Time): The time at which the tokens were last updated.
+type writeBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// SetWriteRateLimit is a method of the Cache struct that limits the number of writes to each key, so that a misbehaving upstream
+// can't churn the full-text index continuously. Writes with Set, SetCtx, SetWithTTL, Replace and Update beyond the limit fail with a *RateLimitError.
+// Short bursts of up to limit writes are allowed. If limit is 0, writes are no longer limited.
+// This method is thread-safe.
+//
+// Parameters:
+//   - limit (int): The number of writes allowed per interval for each key.
+//   - per (time.Duration): The interval of the limit.
+//
+// Returns:
+//   - error: An error if the limit or interval is invalid.
+func (c *Cache) SetWriteRateLimit(limit int, per time.Duration) error {
+	switch {
+	case limit < 0:
+		return errors.New("invalid limit")
+	case limit > 0 && per <= 0:
+		return errors.New("invalid interval")
+	}
+
+	// Lock the mutex
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	// Remove the limit
+	if limit == 0 {
+		if c.writeLimit != nil {
+			c.writeLimit.buckets = make(map[string]*writeBucket)
+			c.writeLimit.limit = 0
+		}
+		return nil
+	}
+
+	// Set the limit, keeping the listeners
+	if c.writeLimit == nil {
+		c.writeLimit = &writeLimiter{}
+	}
+	c.writeLimit.limit = limit
+	c.writeLimit.per = per
+	c.writeLimit.buckets = make(map[string]*writeBucket)
+	c.writeLimit.pruneAt = writeBucketsPruneMin
+
+	// Return no error
+	return nil
+}
+
+// OnRateLimit is a method of the Cache struct that registers a function to be called for every write that is rejected by the write rate limit.
+// The function is called in a new goroutine, so it can safely call other methods of the cache.
+// This method is thread-safe.
+//
+// Parameters:
+//   - fn (func(err *RateLimitError)): The function to call.
+//
+// Returns:
+//   - None
+func (c *Cache) OnRateLimit(fn func(err *RateLimitError)) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.writeLimit == nil {
+		c.writeLimit = &writeLimiter{buckets: make(map[string]*writeBucket)}
+	}
+	c.writeLimit.listeners = append(c.writeLimit.listeners, fn)
+}
+
+// writeAllow is a method of the Cache struct that takes a token from the bucket of a key before it's written.
+// This method is not thread-safe, and should only be called from an exported function.
+//
+// Parameters:
+//   - key (string): The key that is written.
+//
+// Returns:
+//   - error: A *RateLimitError if the key has exceeded the write rate limit.
+func (c *Cache) writeAllow(key string) error {
+	var wl *writeLimiter = c.writeLimit
+	if wl == nil || wl.limit == 0 {
+		return nil
+	}
+
+	// Refill the bucket of the key
+	var (
+		now  time.Time = time.Now()
+		rate float64   = float64(wl.limit) / float64(wl.per)
+	)
+	b, ok := wl.buckets[key]
+	if !ok {
+		if len(wl.buckets) >= wl.pruneAt {
+			wl.prune(now)
+			if wl.pruneAt = 2 * len(wl.buckets); wl.pruneAt < writeBucketsPruneMin {
+				wl.pruneAt = writeBucketsPruneMin
+			}
+		}
+		b = &writeBucket{tokens: float64(wl.limit), last: now}
+		wl.buckets[key] = b
+	}
+	b.tokens = math.Min(float64(wl.limit), b.tokens+float64(now.Sub(b.last))*rate)
+	b.last = now
+
+	// Take a token
+	if b.tokens >= 1 {
+		b.tokens--
+		return nil
+	}
+
+	// Reject the write and notify the listeners
+	var err *RateLimitError = &RateLimitError{
+		Key:        key,
+		Limit:      wl.limit,
+		Per:        wl.per,
+		RetryAfter: time.Duration((1 - b.tokens) / rate),
+	}
+	for _, fn := range wl.listeners {
+		go fn(err)
+	}
+	return err
+}
+
+// prune is a method of the writeLimiter struct that removes the buckets that have refilled completely, since they don't limit any write.
+//
+// Parameters:
+//   - now (time.Time): The current time.
+//
+// Returns:
+//   - None
+func (wl *writeLimiter) prune(now time.Time) {
+	var rate float64 = float64(wl.limit) / float64(wl.per)
+	for key, b := range wl.buckets {
+		if b.tokens+float64(now.Sub(b.last))*rate >= float64(wl.limit) {
+			delete(wl.buckets, key)
+		}
+	}
+}
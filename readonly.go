@@ -0,0 +1,194 @@
+package hermes
+
+import (
+	"context"
+	"time"
+)
+
+// ReadOnlyCache is a struct that wraps a Cache to only expose its read operations.
+// It's returned by OpenAt to read the cache as of a point in time.
+//
+// Fields:
+//   - cache (*Cache): The underlying cache.
+//   - time (time.Time): The time that the cache is read at.
+type ReadOnlyCache struct {
+	cache *Cache
+	time  time.Time
+}
+
+// Time is a method of the ReadOnlyCache struct that returns the time that the cache is read at.
+// It's the time passed to OpenAt if the writes made after the snapshot were replayed, and the time of the snapshot otherwise.
+//
+// Returns:
+//   - time.Time: The time that the cache is read at.
+func (r *ReadOnlyCache) Time() time.Time {
+	return r.time
+}
+
+// Get is a method of the ReadOnlyCache struct that retrieves the value associated with the given key.
+// The returned map is the one stored in the cache, so it must not be modified.
+// This method is thread-safe.
+//
+// Parameters:
+//   - key (string): The key to retrieve the value for.
+//
+// Returns:
+//   - map[string]any: The value associated with the key, or nil if it doesn't exist.
+func (r *ReadOnlyCache) Get(key string) map[string]any {
+	return r.cache.Get(key)
+}
+
+// GetCopy is a method of the ReadOnlyCache struct that retrieves a copy of the value associated with the given key.
+// This method is thread-safe.
+//
+// Parameters:
+//   - key (string): The key to retrieve the value for.
+//
+// Returns:
+//   - map[string]any: A copy of the value associated with the key, or nil if it doesn't exist.
+func (r *ReadOnlyCache) GetCopy(key string) map[string]any {
+	return r.cache.GetCopy(key)
+}
+
+// Exists is a method of the ReadOnlyCache struct that checks if a key exists in the cache.
+// This method is thread-safe.
+//
+// Parameters:
+//   - key (string): The key to check.
+//
+// Returns:
+//   - bool: Whether the key exists.
+func (r *ReadOnlyCache) Exists(key string) bool {
+	return r.cache.Exists(key)
+}
+
+// Keys is a method of the ReadOnlyCache struct that returns all the keys in the cache.
+// This method is thread-safe.
+//
+// Returns:
+//   - []string: The keys in the cache.
+func (r *ReadOnlyCache) Keys() []string {
+	return r.cache.Keys()
+}
+
+// KeysWithPrefix is a method of the ReadOnlyCache struct that returns the keys that start with the provided prefix, in sorted order.
+// This method is thread-safe.
+//
+// Parameters:
+//   - prefix (string): The prefix of the keys.
+//
+// Returns:
+//   - []string: The matching keys.
+func (r *ReadOnlyCache) KeysWithPrefix(prefix string) []string {
+	return r.cache.KeysWithPrefix(prefix)
+}
+
+// KeysMatching is a method of the ReadOnlyCache struct that returns the keys that match the provided glob pattern, in sorted order.
+// This method is thread-safe.
+//
+// Parameters:
+//   - pattern (string): The glob pattern.
+//
+// Returns:
+//   - []string: The matching keys.
+//   - error: An error if the pattern is invalid.
+func (r *ReadOnlyCache) KeysMatching(pattern string) ([]string, error) {
+	return r.cache.KeysMatching(pattern)
+}
+
+// Values is a method of the ReadOnlyCache struct that returns all the values in the cache.
+// The returned maps are the ones stored in the cache, so they must not be modified.
+// This method is thread-safe.
+//
+// Returns:
+//   - []map[string]any: The values in the cache.
+func (r *ReadOnlyCache) Values() []map[string]any {
+	return r.cache.Values()
+}
+
+// Length is a method of the ReadOnlyCache struct that returns the number of keys in the cache.
+// This method is thread-safe.
+//
+// Returns:
+//   - int: The number of keys.
+func (r *ReadOnlyCache) Length() int {
+	return r.cache.Length()
+}
+
+// Range is a method of the ReadOnlyCache struct that calls fn for each key and value in the cache until fn returns false.
+// This method is thread-safe.
+//
+// Parameters:
+//   - fn (func(key string, value map[string]any) bool): The function to call for each key and value.
+//
+// Returns:
+//   - None
+func (r *ReadOnlyCache) Range(fn func(key string, value map[string]any) bool) {
+	r.cache.Range(fn)
+}
+
+// Search is a method of the ReadOnlyCache struct that searches the full-text index like Cache.Search.
+// This method is thread-safe.
+//
+// Parameters:
+//   - sp (SearchParams): A SearchParams struct containing the search parameters.
+//
+// Returns:
+//   - []map[string]any: The matching values.
+//   - error: An error if the query is invalid or the full-text index was not initialized in the snapshot.
+func (r *ReadOnlyCache) Search(sp SearchParams) ([]map[string]any, error) {
+	return r.cache.Search(sp)
+}
+
+// SearchCtx is a method of the ReadOnlyCache struct that searches the full-text index like Cache.SearchCtx.
+// This method is thread-safe.
+//
+// Parameters:
+//   - ctx (context.Context): The context of the request.
+//   - sp (SearchParams): A SearchParams struct containing the search parameters.
+//
+// Returns:
+//   - []map[string]any: The matching values.
+//   - error: An error if the query is invalid, or the context error if the context is done before the search completes.
+func (r *ReadOnlyCache) SearchCtx(ctx context.Context, sp SearchParams) ([]map[string]any, error) {
+	return r.cache.SearchCtx(ctx, sp)
+}
+
+// SearchOneWord is a method of the ReadOnlyCache struct that searches the full-text index like Cache.SearchOneWord.
+// This method is thread-safe.
+//
+// Parameters:
+//   - sp (SearchParams): A SearchParams struct containing the search parameters.
+//
+// Returns:
+//   - []map[string]any: The matching values.
+//   - error: An error if the query is invalid or the full-text index was not initialized in the snapshot.
+func (r *ReadOnlyCache) SearchOneWord(sp SearchParams) ([]map[string]any, error) {
+	return r.cache.SearchOneWord(sp)
+}
+
+// SearchValues is a method of the ReadOnlyCache struct that searches the values like Cache.SearchValues.
+// This method is thread-safe.
+//
+// Parameters:
+//   - sp (SearchParams): A SearchParams struct containing the search parameters.
+//
+// Returns:
+//   - []map[string]any: The matching values.
+//   - error: An error if the query is invalid.
+func (r *ReadOnlyCache) SearchValues(sp SearchParams) ([]map[string]any, error) {
+	return r.cache.SearchValues(sp)
+}
+
+// SearchWithKey is a method of the ReadOnlyCache struct that searches the values like Cache.SearchWithKey.
+// This method is thread-safe.
+//
+// Parameters:
+//   - sp (SearchParams): A SearchParams struct containing the search parameters.
+//
+// Returns:
+//   - []map[string]any: The matching values.
+//   - error: An error if the key or query is invalid.
+func (r *ReadOnlyCache) SearchWithKey(sp SearchParams) ([]map[string]any, error) {
+	return r.cache.SearchWithKey(sp)
+}
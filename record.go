@@ -0,0 +1,114 @@
+package hermes
+
+import (
+	"errors"
+	"fmt"
+)
+
+// RecordMode is a type that represents how the cache handles malformed values, which are nil values, nil or malformed full-text values,
+// values whose full-text schema fields are not strings, and values whose computed fields panic.
+type RecordMode int
+
+const (
+	// RecordStrict rejects malformed values with a *RecordError. This is the default mode.
+	RecordStrict RecordMode = iota
+	// RecordLenient skips malformed values and counts them in Stats.Skipped. The writes of a malformed value return no error
+	// without setting it, and the full-text initializations leave the malformed values out of the cache.
+	RecordLenient
+)
+
+// errSkipped is the error returned by set when a malformed value is skipped in lenient mode.
+// The exported methods return no error for it.
+var errSkipped = errors.New("malformed value skipped")
+
+// RecordError is the error returned for a malformed value in strict mode.
+//
+// Fields:
+//   - Key (string): The key of the value.
+//   - Field (string): The malformed field, or an empty string if the whole value is malformed.
+//   - Reason (string): Why the value is malformed.
+type RecordError struct {
+	Key    string
+	Field  string
+	Reason string
+}
+
+// Error is a method of the RecordError struct that returns the error message.
+//
+// Returns:
+//   - string: The error message.
+func (e *RecordError) Error() string {
+	if len(e.Field) == 0 {
+		return fmt.Sprintf("malformed value of key %s: %s", e.Key, e.Reason)
+	}
+	return fmt.Sprintf("malformed field %s of key %s: %s", e.Field, e.Key, e.Reason)
+}
+
+// SetRecordMode is a method of the Cache struct that sets how malformed values are handled by the writes and the full-text initializations.
+// This method is thread-safe.
+//
+// Parameters:
+//   - mode (RecordMode): Either RecordStrict or RecordLenient.
+//
+// Returns:
+//   - error: An error if the mode is invalid.
+func (c *Cache) SetRecordMode(mode RecordMode) error {
+	if mode != RecordStrict && mode != RecordLenient {
+		return errors.New("invalid record mode")
+	}
+
+	// Set the mode
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.records = mode
+	return nil
+}
+
+// checkRecord is a method of the Cache struct that verifies that a value can be stored without being silently mangled.
+// This method is not thread-safe, and should only be called from an exported function.
+//
+// Parameters:
+//   - key (string): The key of the value.
+//   - value (map[string]any): The value.
+//
+// Returns:
+//   - *RecordError: The reason the value is malformed, or nil if it's valid.
+func (c *Cache) checkRecord(key string, value map[string]any) *RecordError {
+	if value == nil {
+		return &RecordError{Key: key, Reason: "the value is nil"}
+	}
+	for field, v := range value {
+		switch v := v.(type) {
+		case *WFT:
+			if v == nil {
+				return &RecordError{Key: key, Field: field, Reason: "the full-text value is nil"}
+			}
+		case map[string]any:
+			if _, ok := v["$hermes.full_text"]; ok && len(WFTGetValueFromMap(v)) == 0 {
+				return &RecordError{Key: key, Field: field, Reason: "the full-text value is malformed"}
+			}
+		case string, nil:
+		default:
+			if c.ft != nil && c.ft.schema[field] {
+				return &RecordError{Key: key, Field: field, Reason: fmt.Sprintf("the full-text field holds a %T instead of a string", v)}
+			}
+		}
+	}
+	return nil
+}
+
+// reject is a method of the Cache struct that handles a malformed value according to the record mode.
+// This method is not thread-safe, and should only be called from an exported function.
+//
+// Parameters:
+//   - err (*RecordError): The reason the value is malformed.
+//
+// Returns:
+//   - error: The provided error in strict mode, or errSkipped in lenient mode.
+func (c *Cache) reject(err *RecordError) error {
+	if c.records == RecordLenient {
+		c.stats.skipped.Add(1)
+		return errSkipped
+	}
+	return err
+}
@@ -0,0 +1,186 @@
+package hermes
+
+import (
+	"errors"
+
+	utils "github.com/realTristan/hermes/utils"
+)
+
+// FTReindex is a method of the Cache struct that rebuilds the full-text index from the current cache data with a new schema,
+// and swaps it in once it's built, so the schema can be changed without clearing the cache and losing its values.
+// The index is built without holding the lock of the cache, so the cache can be read, searched and written while it's built,
+// and the values that are set or deleted in the meantime are applied to the new index before it's swapped in.
+// The fields that are indexed because they're in the current schema are only kept if they're in the new schema, and the values
+// that were set with WithFT stay indexed. The other settings of the index, such as its limits and analyzers, are kept.
+// This method blocks until the new index is swapped in, so it can be called in a goroutine to reindex in the background.
+// This method is thread-safe.
+//
+// Parameters:
+//   - schema (map[string]bool): The fields whose string values are stored in the full-text index, or nil to only store the values set with WithFT.
+//
+// Returns:
+//   - error: A *FrozenError if the cache is frozen before the new index is swapped in, or an error if the full-text index is not
+//     initialized, the values exceed the limits of the index, or the index was rebuilt or reinitialized by another call while it
+//     was built. The current index is kept if an error is returned.
+func (c *Cache) FTReindex(schema map[string]bool) error {
+	var fields map[string]bool = nil
+	for field, ok := range schema {
+		if !ok {
+			continue
+		} else if fields == nil {
+			fields = make(map[string]bool, len(schema))
+		}
+		fields[field] = true
+	}
+
+	// Collect the values to index, and the generation they were read at
+	c.mutex.RLock()
+	if err := c.writable("FTReindex"); err != nil {
+		c.mutex.RUnlock()
+		return err
+	} else if c.ft == nil {
+		c.mutex.RUnlock()
+		return errors.New("full text not initialized")
+	}
+	var (
+		current    *FullText            = c.ft
+		generation uint64               = c.generation
+		ft         *FullText            = c.ft.empty()
+		values     map[string][]ftEntry = make(map[string][]ftEntry, len(c.data))
+	)
+	ft.schema = fields
+	for key, value := range c.data {
+		values[key] = ft.reindexEntries(current, key, c.expand(value))
+	}
+	c.mutex.RUnlock()
+
+	// Build the new index without holding the lock
+	var (
+		ts      *TempStorage = NewTempStorage(ft)
+		entries []ftEntry    = []ftEntry{}
+	)
+	for key, e := range values {
+		for _, entry := range e {
+			entries = append(entries, ts.entry(key, entry.field, entry.value))
+			ft.fields[key] = append(ft.fields[key], entry.field)
+		}
+	}
+	if err := ts.insertAll(ft, entries); err != nil {
+		return err
+	}
+	ts.cleanSingleArrays()
+	ts.updateFullText(ft)
+
+	// Lock the mutex
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	// Verify that the cache wasn't frozen and the index wasn't replaced while the new one was built
+	if err := c.writable("FTReindex"); err != nil {
+		return err
+	} else if c.ft != current {
+		return errors.New("the full-text index was changed while it was reindexed")
+	}
+
+	// Apply the values that were set or deleted while the new index was built
+	if c.generation != generation {
+		if err := c.reindexCatchUp(ft, current, generation, values); err != nil {
+			return err
+		}
+	}
+
+	// Swap the new index in
+	c.ft = ft
+	c.generation++
+	c.consistencyCheck()
+	c.limitCheck()
+	return nil
+}
+
+// reindexCatchUp is a method of the Cache struct that applies the values that were set or deleted since a generation to a new full-text index.
+// A key whose value was read at that generation is removed from the new index if it was deleted or set again since, and every key
+// that was set since is stored in the new index with its current value.
+// This method is not thread-safe, and should only be called from an exported function.
+//
+// Parameters:
+//   - ft (*FullText): The new full-text index.
+//   - current (*FullText): The current full-text index, whose fields decide which fields of the values are stored.
+//   - generation (uint64): The generation the values were read at.
+//   - values (map[string][]ftEntry): The field values that were read at that generation, by key.
+//
+// Returns:
+//   - error: An error if the values exceed the limits of the new index.
+func (c *Cache) reindexCatchUp(ft *FullText, current *FullText, generation uint64, values map[string][]ftEntry) error {
+	// Remove the keys that were deleted or set again
+	for key, entries := range values {
+		if _, ok := c.data[key]; ok && c.versions[key] <= generation {
+			continue
+		}
+		var words []string = []string{}
+		for _, entry := range entries {
+			words = append(words, ft.fieldWords(entry.field, entry.value)...)
+		}
+		ft.removeKey(key, words)
+	}
+
+	// Store the keys that were set
+	var ts *TempStorage = NewTempStorage(ft)
+	for key, value := range c.data {
+		if _, ok := values[key]; ok && c.versions[key] <= generation {
+			continue
+		}
+		for _, entry := range ft.reindexEntries(current, key, c.expand(value)) {
+			if err := ts.insert(ft, key, entry.field, entry.value); err != nil {
+				return err
+			}
+			ft.fields[key] = append(ft.fields[key], entry.field)
+		}
+	}
+	ts.cleanInserted()
+	ts.updateFullText(ft)
+	return nil
+}
+
+// reindexEntries is a method of the FullText struct that returns the field values of a cache value to store in a rebuilt full-text index.
+// These are the fields that are stored in the current index, unless they're only stored because of the schema of the current index
+// and aren't in the schema of the rebuilt index, and the fields of the schema of the rebuilt index.
+//
+// Parameters:
+//   - current (*FullText): The current full-text index.
+//   - key (string): The key of the value.
+//   - value (map[string]any): The expanded value.
+//
+// Returns:
+//   - []ftEntry: The field values, whose indices are not set.
+func (ft *FullText) reindexEntries(current *FullText, key string, value map[string]any) []ftEntry {
+	var (
+		entries []ftEntry = []ftEntry{}
+		indexed []string  = []string{}
+	)
+
+	// Keep the fields of the current index that are still stored
+	for _, field := range current.fields[key] {
+		if current.schema[field] && !ft.schema[field] {
+			continue
+		}
+		if v, ok := fieldString(pathValue(value, field)); ok && !utils.SliceContains(indexed, field) {
+			entries = append(entries, ftEntry{field: field, value: v})
+			indexed = append(indexed, field)
+		}
+	}
+
+	// Add the fields of the new schema
+	for field, v := range value {
+		if ftv := ft.value(field, v); len(ftv) > 0 && !utils.SliceContains(indexed, field) {
+			entries = append(entries, ftEntry{field: field, value: ftv})
+			indexed = append(indexed, field)
+		}
+	}
+	for field, ftv := range ft.nestedValues(value) {
+		if !utils.SliceContains(indexed, field) {
+			entries = append(entries, ftEntry{field: field, value: ftv})
+			indexed = append(indexed, field)
+		}
+	}
+	return entries
+}
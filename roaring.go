@@ -0,0 +1,533 @@
+package hermes
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"math/bits"
+	"sort"
+)
+
+// The maximum number of values of an array container of a roaring bitmap, above which the container is stored as a bitmap
+const roaringArrayMax int = 4096
+
+// roaring is a compressed bitmap of the indices of the keys of a posting list of the full-text index, with the roaring layout.
+// The indices are split by their upper 16 bits into containers, and each container stores the lower 16 bits of its indices either as
+// a sorted array, if it holds at most 4096 of them, or as a bitmap of 65536 bits otherwise. A sparse posting list takes 2 bytes per key,
+// a dense one 1 bit per key, and two posting lists are intersected a container, and for the dense ones a word, at a time.
+// The indices must be between 0 and 2^32 - 1, and are iterated in ascending order.
+//
+// Fields:
+//   - containers ([]roaringContainer): The containers, sorted by their upper 16 bits.
+type roaring struct {
+	containers []roaringContainer
+}
+
+// roaringContainer is a struct that represents the indices of a roaring bitmap that share their upper 16 bits.
+//
+// Fields:
+//   - high (uint16): The upper 16 bits of the indices.
+//   - card (int): The number of indices.
+//   - array ([]uint16): The sorted lower 16 bits of the indices, if the container is an array.
+//   - bitmap ([]uint64): The bitmap of the lower 16 bits of the indices, if the container is a bitmap. If nil, the container is an array.
+type roaringContainer struct {
+	high   uint16
+	card   int
+	array  []uint16
+	bitmap []uint64
+}
+
+// newRoaring is a function that returns a roaring bitmap of indices, which can be in any order and contain duplicates.
+//
+// Parameters:
+//   - indices (...int): The indices.
+//
+// Returns:
+//   - *roaring: The roaring bitmap.
+func newRoaring(indices ...int) *roaring {
+	var r *roaring = &roaring{}
+	if sort.IntsAreSorted(indices) {
+		// Append the sorted indices to the last container, which is how a bulk insert builds its posting lists
+		for _, index := range indices {
+			r.push(index)
+		}
+		return r
+	}
+	for _, index := range indices {
+		r.add(index)
+	}
+	return r
+}
+
+// push is a method of the roaring struct that adds an index that is greater than or equal to every index of the bitmap.
+//
+// Parameters:
+//   - index (int): The index.
+//
+// Returns:
+//   - None
+func (r *roaring) push(index int) {
+	var high, low uint16 = uint16(index >> 16), uint16(index)
+	if n := len(r.containers); n == 0 || r.containers[n-1].high != high {
+		r.containers = append(r.containers, roaringContainer{high: high})
+	}
+	var c *roaringContainer = &r.containers[len(r.containers)-1]
+	if c.bitmap == nil && c.card > 0 && c.array[c.card-1] == low {
+		return
+	}
+	c.add(low)
+}
+
+// container is a method of the roaring struct that returns the position of the container of the upper 16 bits of an index.
+//
+// Parameters:
+//   - high (uint16): The upper 16 bits of the index.
+//
+// Returns:
+//   - int: The position of the container, or the position it would be inserted at.
+//   - bool: Whether the container exists.
+func (r *roaring) container(high uint16) (int, bool) {
+	var i int = sort.Search(len(r.containers), func(i int) bool {
+		return r.containers[i].high >= high
+	})
+	return i, i < len(r.containers) && r.containers[i].high == high
+}
+
+// add is a method of the roaring struct that adds an index to the bitmap.
+//
+// Parameters:
+//   - index (int): The index.
+//
+// Returns:
+//   - bool: true if the index was added, false if it was already in the bitmap.
+func (r *roaring) add(index int) bool {
+	var high, low uint16 = uint16(index >> 16), uint16(index)
+	i, ok := r.container(high)
+	if !ok {
+		r.containers = append(r.containers, roaringContainer{})
+		copy(r.containers[i+1:], r.containers[i:])
+		r.containers[i] = roaringContainer{high: high}
+	}
+	return r.containers[i].add(low)
+}
+
+// remove is a method of the roaring struct that removes an index from the bitmap.
+//
+// Parameters:
+//   - index (int): The index.
+//
+// Returns:
+//   - bool: true if the index was removed, false if it wasn't in the bitmap.
+func (r *roaring) remove(index int) bool {
+	i, ok := r.container(uint16(index >> 16))
+	if !ok || !r.containers[i].remove(uint16(index)) {
+		return false
+	} else if r.containers[i].card == 0 {
+		r.containers = append(r.containers[:i], r.containers[i+1:]...)
+	}
+	return true
+}
+
+// contains is a method of the roaring struct that checks whether an index is in the bitmap.
+//
+// Parameters:
+//   - index (int): The index.
+//
+// Returns:
+//   - bool: true if the index is in the bitmap, false otherwise.
+func (r *roaring) contains(index int) bool {
+	i, ok := r.container(uint16(index >> 16))
+	return ok && r.containers[i].contains(uint16(index))
+}
+
+// len is a method of the roaring struct that returns the number of indices of the bitmap.
+//
+// Returns:
+//   - int: The number of indices.
+func (r *roaring) len() int {
+	var n int = 0
+	for i := range r.containers {
+		n += r.containers[i].card
+	}
+	return n
+}
+
+// each is a method of the roaring struct that calls a function with every index of the bitmap, in ascending order,
+// until the function returns false.
+//
+// Parameters:
+//   - fn (func(index int) bool): The function, which returns whether to continue.
+//
+// Returns:
+//   - bool: false if the function stopped the iteration, true otherwise.
+func (r *roaring) each(fn func(index int) bool) bool {
+	for i := range r.containers {
+		var (
+			c    *roaringContainer = &r.containers[i]
+			base int               = int(c.high) << 16
+		)
+		if c.bitmap == nil {
+			for _, low := range c.array {
+				if !fn(base | int(low)) {
+					return false
+				}
+			}
+			continue
+		}
+		for w, word := range c.bitmap {
+			for word != 0 {
+				var bit int = bits.TrailingZeros64(word)
+				if !fn(base | w<<6 | bit) {
+					return false
+				}
+				word &= word - 1
+			}
+		}
+	}
+	return true
+}
+
+// slice is a method of the roaring struct that returns the indices of the bitmap.
+//
+// Returns:
+//   - []int: The indices, in ascending order.
+func (r *roaring) slice() []int {
+	var indices []int = make([]int, 0, r.len())
+	r.each(func(index int) bool {
+		indices = append(indices, index)
+		return true
+	})
+	return indices
+}
+
+// and is a method of the roaring struct that returns the intersection of two bitmaps, intersecting the containers that
+// share their upper 16 bits.
+//
+// Parameters:
+//   - o (*roaring): The other bitmap.
+//
+// Returns:
+//   - *roaring: The indices that are in both bitmaps.
+func (r *roaring) and(o *roaring) *roaring {
+	var result *roaring = &roaring{}
+	for i, j := 0, 0; i < len(r.containers) && j < len(o.containers); {
+		var a, b *roaringContainer = &r.containers[i], &o.containers[j]
+		switch {
+		case a.high < b.high:
+			i++
+		case a.high > b.high:
+			j++
+		default:
+			if c := a.and(b); c.card > 0 {
+				result.containers = append(result.containers, c)
+			}
+			i, j = i+1, j+1
+		}
+	}
+	return result
+}
+
+// clone is a method of the roaring struct that returns a deep copy of the bitmap, with containers of the exact size.
+//
+// Returns:
+//   - *roaring: The copy of the bitmap.
+func (r *roaring) clone() *roaring {
+	var clone *roaring = &roaring{containers: make([]roaringContainer, len(r.containers))}
+	for i, c := range r.containers {
+		clone.containers[i] = roaringContainer{high: c.high, card: c.card}
+		if c.bitmap != nil {
+			clone.containers[i].bitmap = append([]uint64{}, c.bitmap...)
+		} else {
+			clone.containers[i].array = append([]uint16{}, c.array...)
+		}
+	}
+	return clone
+}
+
+// MarshalJSON is a method of the roaring struct that encodes the bitmap as the JSON array of its indices,
+// so the snapshots store the posting lists like the integer arrays they were stored as before.
+//
+// Returns:
+//   - []byte: The JSON array.
+//   - error: Always nil.
+func (r *roaring) MarshalJSON() ([]byte, error) {
+	return json.Marshal(r.slice())
+}
+
+// UnmarshalJSON is a method of the roaring struct that decodes the bitmap from the JSON array of its indices.
+//
+// Parameters:
+//   - data ([]byte): The JSON array.
+//
+// Returns:
+//   - error: An error if the data is not an array of indices.
+func (r *roaring) UnmarshalJSON(data []byte) error {
+	var indices []int
+	if err := json.Unmarshal(data, &indices); err != nil {
+		return err
+	}
+	*r = *newRoaring(indices...)
+	return nil
+}
+
+// GobEncode is a method of the roaring struct that encodes the bitmap for encoding/gob, as the varint gaps between its indices.
+//
+// Returns:
+//   - []byte: The encoded bitmap.
+//   - error: Always nil.
+func (r *roaring) GobEncode() ([]byte, error) {
+	var (
+		data     []byte = make([]byte, 0, r.len()+binary.MaxVarintLen64)
+		previous int    = 0
+	)
+	data = binary.AppendUvarint(data, uint64(r.len()))
+	r.each(func(index int) bool {
+		data = binary.AppendUvarint(data, uint64(index-previous))
+		previous = index
+		return true
+	})
+	return data, nil
+}
+
+// GobDecode is a method of the roaring struct that decodes the bitmap encoded by GobEncode.
+//
+// Parameters:
+//   - data ([]byte): The encoded bitmap.
+//
+// Returns:
+//   - error: An error if the data is invalid.
+func (r *roaring) GobDecode(data []byte) error {
+	var d *ftDecoder = &ftDecoder{data: data}
+	var indices []int = d.postings(d.count())
+	if d.err == nil && len(d.data) > 0 {
+		d.err = errors.New("trailing data")
+	}
+	if d.err != nil {
+		return errors.New("invalid roaring bitmap")
+	}
+	*r = *newRoaring(indices...)
+	return nil
+}
+
+// add is a method of the roaringContainer struct that adds the lower 16 bits of an index to the container.
+// An array container is converted to a bitmap once it holds more than roaringArrayMax values.
+//
+// Parameters:
+//   - low (uint16): The lower 16 bits of the index.
+//
+// Returns:
+//   - bool: true if the value was added, false if it was already in the container.
+func (c *roaringContainer) add(low uint16) bool {
+	if c.bitmap != nil {
+		var mask uint64 = 1 << (low & 63)
+		if c.bitmap[low>>6]&mask != 0 {
+			return false
+		}
+		c.bitmap[low>>6] |= mask
+		c.card++
+		return true
+	}
+	var i int = sort.Search(len(c.array), func(i int) bool {
+		return c.array[i] >= low
+	})
+	if i < len(c.array) && c.array[i] == low {
+		return false
+	}
+	c.array = append(c.array, 0)
+	copy(c.array[i+1:], c.array[i:])
+	c.array[i] = low
+	if c.card++; c.card > roaringArrayMax {
+		c.toBitmap()
+	}
+	return true
+}
+
+// remove is a method of the roaringContainer struct that removes the lower 16 bits of an index from the container.
+// A bitmap container is converted to an array once it holds roaringArrayMax values or fewer.
+//
+// Parameters:
+//   - low (uint16): The lower 16 bits of the index.
+//
+// Returns:
+//   - bool: true if the value was removed, false if it wasn't in the container.
+func (c *roaringContainer) remove(low uint16) bool {
+	if c.bitmap != nil {
+		var mask uint64 = 1 << (low & 63)
+		if c.bitmap[low>>6]&mask == 0 {
+			return false
+		}
+		c.bitmap[low>>6] &^= mask
+		if c.card--; c.card <= roaringArrayMax {
+			c.toArray()
+		}
+		return true
+	}
+	var i int = sort.Search(len(c.array), func(i int) bool {
+		return c.array[i] >= low
+	})
+	if i == len(c.array) || c.array[i] != low {
+		return false
+	}
+	c.array = append(c.array[:i], c.array[i+1:]...)
+	c.card--
+	return true
+}
+
+// contains is a method of the roaringContainer struct that checks whether the lower 16 bits of an index are in the container.
+//
+// Parameters:
+//   - low (uint16): The lower 16 bits of the index.
+//
+// Returns:
+//   - bool: true if the value is in the container, false otherwise.
+func (c *roaringContainer) contains(low uint16) bool {
+	if c.bitmap != nil {
+		return c.bitmap[low>>6]&(1<<(low&63)) != 0
+	}
+	var i int = sort.Search(len(c.array), func(i int) bool {
+		return c.array[i] >= low
+	})
+	return i < len(c.array) && c.array[i] == low
+}
+
+// and is a method of the roaringContainer struct that returns the intersection of two containers with the same upper 16 bits.
+//
+// Parameters:
+//   - o (*roaringContainer): The other container.
+//
+// Returns:
+//   - roaringContainer: The values that are in both containers.
+func (c *roaringContainer) and(o *roaringContainer) roaringContainer {
+	var result roaringContainer = roaringContainer{high: c.high}
+	switch {
+	case c.bitmap != nil && o.bitmap != nil:
+		result.bitmap = make([]uint64, len(c.bitmap))
+		for w := range c.bitmap {
+			result.bitmap[w] = c.bitmap[w] & o.bitmap[w]
+			result.card += bits.OnesCount64(result.bitmap[w])
+		}
+		if result.card <= roaringArrayMax {
+			result.toArray()
+		}
+	case c.bitmap != nil || o.bitmap != nil:
+		var array, bitmap *roaringContainer = c, o
+		if c.bitmap != nil {
+			array, bitmap = o, c
+		}
+		for _, low := range array.array {
+			if bitmap.contains(low) {
+				result.array = append(result.array, low)
+			}
+		}
+		result.card = len(result.array)
+	default:
+		for i, j := 0, 0; i < len(c.array) && j < len(o.array); {
+			switch {
+			case c.array[i] < o.array[j]:
+				i++
+			case c.array[i] > o.array[j]:
+				j++
+			default:
+				result.array = append(result.array, c.array[i])
+				i, j = i+1, j+1
+			}
+		}
+		result.card = len(result.array)
+	}
+	return result
+}
+
+// toBitmap is a method of the roaringContainer struct that converts an array container to a bitmap container.
+//
+// Returns:
+//   - None
+func (c *roaringContainer) toBitmap() {
+	c.bitmap = make([]uint64, 1<<10)
+	for _, low := range c.array {
+		c.bitmap[low>>6] |= 1 << (low & 63)
+	}
+	c.array = nil
+}
+
+// toArray is a method of the roaringContainer struct that converts a bitmap container to an array container.
+//
+// Returns:
+//   - None
+func (c *roaringContainer) toArray() {
+	c.array = make([]uint16, 0, c.card)
+	for w, word := range c.bitmap {
+		for word != 0 {
+			c.array = append(c.array, uint16(w<<6|bits.TrailingZeros64(word)))
+			word &= word - 1
+		}
+	}
+	c.bitmap = nil
+}
+
+// eachPosting is a function that calls a function with every index of a posting list of the full-text storage, which is either a single
+// index or a roaring bitmap, in ascending order, until the function returns false.
+//
+// Parameters:
+//   - v (any): The posting list.
+//   - fn (func(index int) bool): The function, which returns whether to continue.
+//
+// Returns:
+//   - bool: false if the function stopped the iteration, true otherwise.
+func eachPosting(v any, fn func(index int) bool) bool {
+	switch v := v.(type) {
+	case int:
+		return fn(v)
+	case *roaring:
+		return v.each(fn)
+	}
+	return true
+}
+
+// postingsOf is a function that returns the indices of a posting list of the full-text storage, which is either a single index or a roaring bitmap.
+//
+// Parameters:
+//   - v (any): The posting list.
+//
+// Returns:
+//   - []int: The indices, in ascending order.
+func postingsOf(v any) []int {
+	switch v := v.(type) {
+	case int:
+		return []int{v}
+	case *roaring:
+		return v.slice()
+	}
+	return []int{}
+}
+
+// postingsLen is a function that returns the number of indices of a posting list of the full-text storage.
+//
+// Parameters:
+//   - v (any): The posting list.
+//
+// Returns:
+//   - int: The number of indices.
+func postingsLen(v any) int {
+	switch v := v.(type) {
+	case int:
+		return 1
+	case *roaring:
+		return v.len()
+	}
+	return 0
+}
+
+// postingsValue is a function that returns the posting list of the full-text storage for the indices of a word,
+// which is the index itself if there's only one, or a roaring bitmap of the indices.
+//
+// Parameters:
+//   - indices ([]int): The indices.
+//
+// Returns:
+//   - any: The posting list.
+func postingsValue(indices []int) any {
+	if len(indices) == 1 {
+		return indices[0]
+	}
+	return newRoaring(indices...)
+}
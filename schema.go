@@ -0,0 +1,79 @@
+package hermes
+
+import (
+	"errors"
+	"reflect"
+)
+
+// FTInitWithStruct is a method of the Cache struct that initializes the full-text index with a schema derived from a struct type.
+// The string fields of the struct that are tagged with `hermes:"index"` are stored in the full-text index under their JSON
+// field name, without having to wrap their values with WithFT.
+// This method is thread-safe.
+// If the full-text index is already initialized, an error is returned.
+//
+// Parameters:
+//   - v (any): A value of the struct type, or a pointer to it.
+//   - maxSize (int): The maximum number of words to store in the full-text index, or Unlimited.
+//   - maxBytes (int): The maximum size, in bytes, of the full-text index, or Unlimited.
+//   - minWordLength (int): The minimum length of a word to store in the full-text index.
+//
+// Returns:
+//   - error: If the value is not a struct, or the full-text is already initialized.
+func (c *Cache) FTInitWithStruct(v any, maxSize int, maxBytes int, minWordLength int) error {
+	schema, err := structSchema(reflect.TypeOf(v))
+	if err != nil {
+		return err
+	}
+
+	// Lock the mutex
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	// Verify that the ft has already been initialized
+	if c.ft != nil {
+		return errors.New("full-text already initialized")
+	}
+
+	// Initialize the FT
+	return c.ftInit(maxSize, maxBytes, minWordLength, schema)
+}
+
+// FTSchema is a method of the Cache struct that returns a copy of the full-text schema.
+// This method is thread-safe.
+//
+// Returns:
+//   - map[string]bool: The fields whose string values are always stored in the full-text index.
+//   - error: An error if the full-text index is not initialized.
+func (c *Cache) FTSchema() (map[string]bool, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	// Check if the ft is initialized
+	if c.ft == nil {
+		return nil, errors.New("full-text is not initialized")
+	}
+
+	// Copy the schema
+	var schema map[string]bool = make(map[string]bool, len(c.ft.schema))
+	for k, v := range c.ft.schema {
+		schema[k] = v
+	}
+	return schema, nil
+}
+
+// value is a method of the FullText struct that returns the string to store in the full-text index for a field of a cache value.
+//
+// Parameters:
+//   - field (string): The name of the field.
+//   - value (any): The value of the field.
+//
+// Returns:
+//   - string: The full-text value, or an empty string if the field shouldn't be stored in the full-text index.
+func (ft *FullText) value(field string, value any) string {
+	if ftv := WFTGetValue(value); len(ftv) > 0 {
+		return ftv
+	} else if v, ok := fieldString(value); ok && ft.schema[field] {
+		return v
+	}
+	return ""
+}
@@ -1,11 +1,25 @@
 package hermes
 
 import (
-	"errors"
+	"context"
+	"sort"
 	"strings"
+	"time"
+	"unicode"
 )
 
 // Search is a method of the Cache struct that searches for a query by splitting the query into separate words and returning the search results.
+// Each value is returned once, however many of its words or fields match the query.
+// The Offset of the search parameters skips that many results before the first returned result, to page through the results by offset.
+// It can't be negative, and can't be combined with a Cursor.
+// The RangeFilter holds numeric conditions joined by AND, e.g. "price >= 10 AND price < 100", with the operators >=, <=, !=, >, < and =.
+// Every field of the filter must have a numeric index, see CreateNumericIndex.
+// The SortBy field, which can be a dot path to a nested field such as "price" or "created_at", sorts the results instead of the order
+// they're found in or their score. Numbers and numeric strings are compared as numbers, times by their instant, and other values by their
+// text. The values without the field are last.
+// If the Timeout elapses while the search waits for the read lock or searches, the search stops and returns the results that were found
+// so far with an error wrapping ErrSearchTimeout, so the results are partial. The same applies to SearchCtx, SearchCursor, SearchPage,
+// SearchScored and SearchOneWord.
 // Parameters:
 //   - c (c *Cache): A pointer to the Cache struct
 //   - sp (SearchParams): A SearchParams struct containing the search parameters.
@@ -14,131 +28,343 @@ import (
 //   - []map[string]any: A slice of maps containing the search results.
 //   - error: An error if the query is invalid or if the smallest words array is not found in the cache.
 func (c *Cache) Search(sp SearchParams) ([]map[string]any, error) {
-	// If the query is empty, return an error
-	if len(sp.Query) == 0 {
-		return []map[string]any{}, errors.New("invalid query")
-	}
-
-	// If no limit is provided, set it to 10
-	if sp.Limit == 0 {
-		sp.Limit = 10
-	}
+	return c.SearchCtx(context.Background(), sp)
+}
 
-	// Lock the mutex
-	c.mutex.RLock()
-	defer c.mutex.RUnlock()
+// SearchCtx is a method of the Cache struct that searches for a query like Search, honoring the cancellation and deadline
+// of the provided context while waiting for the cache mutex and while scanning the full-text index.
+// Parameters:
+//   - ctx (context.Context): The context of the request.
+//   - sp (SearchParams): A SearchParams struct containing the search parameters.
+//
+// Returns:
+//   - []map[string]any: A slice of maps containing the search results.
+//   - error: An error if the query is invalid, or the context error if the context is done before the search completes.
+func (c *Cache) SearchCtx(ctx context.Context, sp SearchParams) ([]map[string]any, error) {
+	result, _, err := c.searchCursor(ctx, sp)
+	return result, err
+}
 
-	// Check if the FT index is initialized
-	if c.ft == nil {
-		return []map[string]any{}, errors.New("full-text not initialized")
+// searchAll is a method of the Cache struct that searches for a query with every variant of the search parameters,
+// ordering the results by score if a ranker is set and keeping the ones that match the range filter.
+// This method is not thread-safe, and should only be called from an exported function.
+//
+// Parameters:
+//   - ctx (context.Context): The context of the request.
+//   - sp (SearchParams): The search parameters, with a lowercase query and a limit.
+//
+// Returns:
+//   - []map[string]any: The results of the search, of the values that were found before the context was done.
+//   - error: An error if the query or the range filter is invalid, or the context error if the context is done before the search completes.
+func (c *Cache) searchAll(ctx context.Context, sp SearchParams) (result []map[string]any, err error) {
+	if sp.Ranker != RankNone {
+		ranked, err := c.searchRanked(ctx, sp, nil)
+		result = make([]map[string]any, len(ranked))
+		for i, r := range ranked {
+			result[i] = r.Value
+		}
+		return c.expandAll(result), err
 	}
 
-	// Set the query to lowercase
-	sp.Query = strings.ToLower(sp.Query)
-
-	// Search for the query
-	return c.search(sp), nil
+	// Search every matching value if the results are filtered, sorted or explained, so the limit applies to the filtered and sorted results
+	var (
+		limit int       = sp.Limit
+		start time.Time = time.Now()
+	)
+	if len(sp.RangeFilter) > 0 || len(sp.SortBy) > 0 || sp.Explain != nil {
+		sp.Limit = len(c.data)
+	}
+	// If the context is done, the values that were found are still filtered and sorted
+	result, err = searchVariants(sp, func(sp SearchParams) ([]map[string]any, error) {
+		return c.search(ctx, sp)
+	})
+	sp.Explain.phase("search", start)
+	if err != nil && ctx.Err() == nil {
+		return []map[string]any{}, err
+	}
+	c.explainKeyPrefix(ctx, sp, len(result))
+	if len(sp.RangeFilter) > 0 {
+		var before int = len(result)
+		start = time.Now()
+		filtered, filterErr := c.rangeFilter(result, sp.RangeFilter, sp.Limit)
+		if filterErr != nil {
+			return []map[string]any{}, filterErr
+		}
+		result = filtered
+		sp.Explain.filter("range filter", before, len(result))
+		sp.Explain.phase("range filter", start)
+	}
+	sp.Explain.matched(len(result))
+	if len(sp.SortBy) > 0 {
+		start = time.Now()
+		result = sortValues(result, sp, limit)
+		sp.Explain.phase("sort", start)
+	} else if len(result) > limit {
+		result = result[:limit]
+	}
+	return c.expandAll(result), err
 }
 
 // search is a method of the Cache struct that searches for a query by splitting the query into separate words and returning the search results.
 // Parameters:
-//   - c (c *Cache): A pointer to the Cache struct
+//   - ctx (context.Context): The context of the request.
 //   - sp (SearchParams): A SearchParams struct containing the search parameters.
 //
 // Returns:
 //   - []map[string]any: A slice of maps containing the search results.
-func (c *Cache) search(sp SearchParams) []map[string]any {
+//   - error: The context error if the context is done before the search completes.
+func (c *Cache) search(ctx context.Context, sp SearchParams) ([]map[string]any, error) {
 	// Split the query into separate words
 	var words []string = strings.Split(strings.TrimSpace(sp.Query), " ")
 	switch {
 	// If the words array is empty
 	case len(words) == 0:
-		return []map[string]any{}
+		return []map[string]any{}, nil
+	// Match the words with wildcards against the stored words
+	case sp.Wildcards && hasWildcards(sp.Query):
+		sp.Explain.strategy("wildcards")
+		return c.searchWildcards(ctx, sp)
 	// Get the search result of the first word
 	case len(words) == 1:
+		sp.Explain.strategy("word")
 		sp.Query = words[0]
-		return c.searchOneWord(sp)
+		return c.searchOneWord(ctx, sp)
 	}
 
-	// Define variables
-	var result []map[string]any = []map[string]any{}
+	// Find the values that contain every word approximately
+	if sp.Fuzziness > 0 {
+		sp.Explain.strategy("fuzzy")
+		return c.searchFuzzyWords(ctx, sp)
+	}
+	sp.Explain.strategy("phrase")
 
-	// Variables for storing the smallest words array
-	// var smallestData []int = []int{}
+	// Find the values that may contain the phrase with the token rule and analyzer of each field
 	var (
-		smallestIndex int = 0
-		smallest      int = 0
+		keys  []string     = []string{}
+		added map[int]bool = map[int]bool{}
 	)
-
-	// Check if the query is in the cache
-	if indices, ok := c.ft.storage[words[0]]; !ok {
-		return []map[string]any{}
-	} else {
-		/*for {
-			if v, ok := indices.(string); ok {
-				indices = c.ft.storage[v]
-			} else {
-				break
-			}
-		}*/
-		if temp, ok := indices.(int); ok {
-			return []map[string]any{
-				c.data[c.ft.indices[temp]],
+	for _, rule := range c.ft.ruleSet() {
+		for _, analyzer := range c.ft.analyzerSet() {
+			indices, scan := c.ft.phraseIndices(c.ft.ruleWords(rule, analyzer, sp.Query))
+			if scan {
+				return c.searchPhrase(ctx, sp, c.keys())
 			}
-		}
-		// smallestData = indices.([]int)
-		smallest = len(indices.([]int))
-	}
-
-	// Find the smallest words array
-	// Don't include the first or last words from the query
-	for i := 1; i < len(words)-1; i++ {
-		if indices, ok := c.ft.storage[words[i]]; ok {
-			/*for {
-				if v, ok := indices.(string); ok {
-					indices = c.ft.storage[v]
-				} else {
-					break
-				}
-			}*/
-			if index, ok := indices.(int); ok {
-				return []map[string]any{
-					c.data[c.ft.indices[index]],
+			for _, index := range indices {
+				if !added[index] {
+					added[index] = true
+					keys = append(keys, c.ft.indices[index])
 				}
 			}
-			/*if l := len(indices.([]int)); l < len(smallestData) {
-				smallestData = indices.([]int)
-			}*/
-			if l := len(indices.([]int)); l < smallest {
-				smallest = l
-				smallestIndex = i
-			}
 		}
 	}
 
-	// Loop through the indices
-	/*for i := 0; i < len(smallestData); i++ {
-		for _, value := range c.data[c.ft.indices[smallestData[i]]] {
-			// Check if the value contains the query
-			if v, ok := value.(string); ok {
-				if strings.Contains(strings.ToLower(v), sp.Query) {
-					result = append(result, c.data[c.ft.indices[smallestData[i]]])
+	// Verify that the values contain the phrase
+	return c.searchPhrase(ctx, sp, keys)
+}
+
+// searchFuzzyWords is a method of the Cache struct that returns the values that contain a word matching each word of the query,
+// within the fuzziness of the search parameters. The words can be in any order, and are analyzed with each analyzer of the index in turn.
+// This method is not thread-safe, and should only be called from an exported function.
+//
+// Parameters:
+//   - ctx (context.Context): The context of the request.
+//   - sp (SearchParams): The search parameters, with a lowercase query.
+//
+// Returns:
+//   - []map[string]any: The matching values, in the order they were stored in the full-text index.
+//   - error: The context error if the context is done before the search completes.
+func (c *Cache) searchFuzzyWords(ctx context.Context, sp SearchParams) ([]map[string]any, error) {
+	var (
+		result  []map[string]any = []map[string]any{}
+		indices map[int]bool     = map[int]bool{}
+	)
+
+	// Intersect the values that match each word analyzed in each language
+	for _, analyzer := range c.ft.analyzerSet() {
+		var intersection map[int]bool
+		for i, term := range c.ft.analyzerTerms(analyzer, sp.Query) {
+			if err := ctxDone(ctx, i); err != nil {
+				return result, err
+			}
+			matching, err := c.ft.matchingIndices(ctx, term, sp)
+			if err != nil {
+				return result, err
+			}
+			if intersection == nil {
+				intersection = matching
+				continue
+			}
+			for index := range intersection {
+				if !matching[index] {
+					delete(intersection, index)
 				}
 			}
 		}
-	}*/
-	var keys []int = c.ft.storage[words[smallestIndex]].([]int)
-	for i := 0; i < len(keys); i++ {
-		for _, value := range c.data[c.ft.indices[keys[i]]] {
-			// Check if the value contains the query
-			if v, ok := value.(string); ok {
-				if strings.Contains(strings.ToLower(v), sp.Query) {
-					result = append(result, c.data[c.ft.indices[keys[i]]])
-				}
+		for index := range intersection {
+			indices[index] = true
+		}
+	}
+	return c.indexValues(indices, sp), nil
+}
+
+// indexValues is a method of the Cache struct that returns the values of a set of full-text indices, in the order they were stored
+// in the full-text index, up to the limit of the search parameters.
+// This method is not thread-safe, and should only be called from an exported function.
+//
+// Parameters:
+//   - indices (map[int]bool): The set of the indices.
+//   - sp (SearchParams): The search parameters, with the limit and key prefix of the results.
+//
+// Returns:
+//   - []map[string]any: The values of the indices whose keys match the search parameters.
+func (c *Cache) indexValues(indices map[int]bool, sp SearchParams) []map[string]any {
+	var (
+		result []map[string]any = []map[string]any{}
+		sorted []int            = make([]int, 0, len(indices))
+	)
+	for index := range indices {
+		sorted = append(sorted, index)
+	}
+	sort.Ints(sorted)
+	for _, index := range sorted {
+		if len(result) >= sp.Limit {
+			break
+		} else if key := c.ft.indices[index]; c.searchable(sp, key) {
+			if value, ok := c.data[key]; ok {
+				result = append(result, value)
 			}
 		}
 	}
+	return result
+}
+
+// phraseIndices is a method of the FullText struct that returns the intersection of the posting lists of the words of a phrase,
+// which contains every value that may contain the phrase.
+//
+// Parameters:
+//   - terms ([]string): The words of the phrase that are stored in the full-text index.
+//
+// Returns:
+//   - []int: The indices of the values that may contain the phrase.
+//   - bool: Whether none of the words could be looked up, so every value has to be checked.
+func (ft *FullText) phraseIndices(terms []string) ([]int, bool) {
+	// Only the words that are long enough to be stored in the full-text index can be looked up. The other words,
+	// such as "to" and "be" in "to be or not to be", are gaps that are matched by the phrase check.
+	// If a word is not in the index, no value contains the phrase, except for the last word, which may be the start of a longer word.
+	var intersection *roaring
+	for i, term := range terms {
+		indices, ok := ft.storage[term]
+		switch {
+		case !ok && i < len(terms)-1:
+			return []int{}, false
+		case !ok:
+			continue
+		}
+		var termIndices *roaring
+		if index, ok := indices.(int); ok {
+			termIndices = newRoaring(index)
+		} else {
+			termIndices = indices.(*roaring)
+		}
+		if intersection == nil {
+			intersection = termIndices
+		} else if intersection = intersection.and(termIndices); intersection.len() == 0 {
+			return []int{}, false
+		}
+	}
+	if intersection == nil {
+		return nil, true
+	}
+	return intersection.slice(), false
+}
+
+// searchPhrase is a method of the Cache struct that returns the values of the provided keys that contain the query as a phrase.
+// The query and the values are compared by their words, so punctuation and repeated spaces between the words are ignored,
+// and the query is analyzed with the analyzer of each field it's compared with.
+// This method is not thread-safe, and should only be called from an exported function.
+//
+// Parameters:
+//   - ctx (context.Context): The context of the request.
+//   - sp (SearchParams): A SearchParams struct containing the lowercase query.
+//   - keys ([]string): The keys of the values to check.
+//
+// Returns:
+//   - []map[string]any: The values that contain the query.
+//   - error: The context error if the context is done before the search completes.
+func (c *Cache) searchPhrase(ctx context.Context, sp SearchParams, keys []string) ([]map[string]any, error) {
+	var (
+		result  []map[string]any    = []map[string]any{}
+		queries map[Analyzer]string = map[Analyzer]string{}
+	)
+	for _, analyzer := range c.ft.analyzerSet() {
+		if q := c.ft.phrase(analyzer, sp.Query); len(q) > 0 {
+			queries[analyzer] = q
+		}
+	}
+	if len(queries) == 0 {
+		return result, nil
+	}
+
+	// Check if a field contains the query analyzed with the analyzer of the field
+	var contains func(field string, v string) bool = func(field string, v string) bool {
+		var analyzer Analyzer = c.ft.fieldAnalyzer(field)
+		q, ok := queries[analyzer]
+		return ok && strings.Contains(c.ft.phrase(analyzer, v), q)
+	}
+	for i, key := range keys {
+		if err := ctxDone(ctx, i); err != nil {
+			return result, err
+		} else if !c.searchable(sp, key) {
+			continue
+		}
+		if c.containsPhrase(key, contains) {
+			result = append(result, c.data[key])
+		}
+	}
 
 	// Return the result
-	return result
+	return result, nil
+}
+
+// containsPhrase is a method of the Cache struct that checks whether a field of a value contains a phrase, so the value is added
+// to the results once however many of its fields contain the phrase.
+// This method is not thread-safe, and should only be called from an exported function.
+//
+// Parameters:
+//   - key (string): The key of the value.
+//   - contains (func(field string, v string) bool): The function that checks whether the string of a field contains the phrase.
+//
+// Returns:
+//   - bool: true if a field, or a nested field stored in the full-text index, contains the phrase.
+func (c *Cache) containsPhrase(key string, contains func(field string, v string) bool) bool {
+	for field, value := range c.data[key] {
+		// Check if the value contains the query
+		if v, ok := fieldString(value); ok && contains(field, v) {
+			return true
+		}
+	}
+
+	// Check the nested fields stored in the full-text index
+	for _, field := range c.ft.fields[key] {
+		if _, ok := c.data[key][field]; ok || !strings.Contains(field, ".") {
+			continue
+		} else if v, ok := fieldString(pathValue(c.data[key], field)); ok && contains(field, v) {
+			return true
+		}
+	}
+	return false
+}
+
+// phrase is a function that returns the lowercase words of a string separated by single spaces, so phrases can be
+// compared regardless of the punctuation between their words.
+//
+// Parameters:
+//   - s (string): The string.
+//
+// Returns:
+//   - string: The words of the string.
+func phrase(s string) string {
+	return strings.Join(strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}), " ")
 }
@@ -1,10 +1,10 @@
 package hermes
 
 import (
+	"context"
 	"errors"
 	"strings"
-
-	utils "github.com/realTristan/hermes/utils"
+	"time"
 )
 
 // SearchOneWord searches for a single word in the FullText struct's data and returns a list of maps containing the search results.
@@ -16,7 +16,14 @@ import (
 //   - []map[string]any: A slice of maps where each map represents a data record that matches the given query.
 //     The keys of the map correspond to the column names of the data that were searched and returned in the result.
 //   - error: An error if the query or limit is invalid or if the full-text is not initialized.
-func (c Cache) SearchOneWord(sp SearchParams) ([]map[string]any, error) {
+func (c Cache) SearchOneWord(sp SearchParams) (result []map[string]any, err error) {
+	defer c.stats.search(time.Now(), sp.Strict)
+	defer func(sp SearchParams) {
+		c.shadow.mirror("SearchOneWord", sp, result, err, func(shadow *Cache) ([]map[string]any, error) {
+			return shadow.SearchOneWord(sp)
+		})
+	}(sp)
+
 	// If the query is empty, return an error
 	if len(sp.Query) == 0 {
 		return []map[string]any{}, errors.New("invalid query")
@@ -27,55 +34,110 @@ func (c Cache) SearchOneWord(sp SearchParams) ([]map[string]any, error) {
 		sp.Limit = 10
 	}
 
-	// Lock the mutex
-	c.mutex.RLock()
+	// Lock the mutex, and stop the search once its timeout elapses
+	ctx, cancel := searchTimeout(context.Background(), sp)
+	defer cancel()
+	defer func(sp SearchParams) {
+		err = timeoutErr(context.Background(), sp, err)
+	}(sp)
+	if err := c.rlockCtx(ctx); err != nil {
+		return []map[string]any{}, err
+	}
 	defer c.mutex.RUnlock()
 
-	// Check if the full-text is initialized
+	// Check if the full-text is initialized, and search the named index
 	if c.ft == nil {
 		return []map[string]any{}, errors.New("full-text is not initialized")
 	}
+	view, err := c.ftView(sp.Index)
+	if err != nil {
+		return []map[string]any{}, err
+	}
 
 	// Search the data
-	return c.searchOneWord(sp), nil
+	sp = sp.lower()
+	result, err = searchVariants(sp, func(sp SearchParams) ([]map[string]any, error) {
+		return view.searchOneWord(ctx, sp)
+	})
+	return c.expandAll(result), err
 }
 
 // searchOneWord searches for a single word in the FullText struct's data and returns a list of maps containing the search results.
 // Parameters:
-//   - c (c *Cache): A pointer to the Cache struct
+//   - ctx (context.Context): The context of the request.
 //   - sp (SearchParams): A SearchParams struct containing the search parameters.
 //
 // Returns:
 //   - []map[string]any: A slice of maps where each map represents a data record that matches the given query.
 //     The keys of the map correspond to the column names of the data that were searched and returned in the result.
-func (c *Cache) searchOneWord(sp SearchParams) []map[string]any {
-	// Set the query to lowercase
-	sp.Query = strings.ToLower(sp.Query)
+//   - error: The context error if the context is done before the search completes.
+func (c *Cache) searchOneWord(ctx context.Context, sp SearchParams) ([]map[string]any, error) {
+	if sp.Wildcards && hasWildcards(sp.Query) {
+		return c.searchWildcards(ctx, sp)
+	}
 
+	// Look up the form of the lowercase word that is stored by each analyzer of the index
+	return searchQueries(sp, c.ft.wordForms(strings.ToLower(sp.Query)), func(sp SearchParams) ([]map[string]any, error) {
+		return c.searchWordForm(ctx, sp)
+	})
+}
+
+// searchWordForm is a method of the Cache struct that searches for a word that is already in the form that is stored in the full-text index.
+// This method is not thread-safe, and should only be called from an exported function.
+//
+// Parameters:
+//   - ctx (context.Context): The context of the request.
+//   - sp (SearchParams): A SearchParams struct containing the search parameters, with the stored form of the word as the query.
+//
+// Returns:
+//   - []map[string]any: The values that contain the word.
+//   - error: The context error if the context is done before the search completes.
+func (c *Cache) searchWordForm(ctx context.Context, sp SearchParams) ([]map[string]any, error) {
 	// Define variables
 	var result []map[string]any = []map[string]any{}
 
 	// If the user wants a strict search, just return the result
 	// straight from the cache
-	if sp.Strict {
-		return c.searchOneWordStrict(result, sp)
+	if sp.Strict && sp.Fuzziness == 0 {
+		return c.searchOneWordStrict(result, sp), nil
 	}
 
 	// Define a map to store the indices that have already been added
 	var alreadyAdded map[int]int = map[int]int{}
 
+	// Look up the words that may match the query, or scan every word
+	var (
+		words   map[string]any = c.ft.storage
+		matched bool           = false
+		err     error
+	)
+	if w, ok := c.ft.candidateWords(sp.Query, sp); ok {
+		words = w
+	} else if c.ft.partitions != nil {
+		// Match the words of every partition concurrently. The values of the words that matched before the context was done are still added
+		words, err = c.ft.partitions.matchingWords(ctx, c.ft.storage, func(word string) bool {
+			return sp.matchesWord(word, sp.Query)
+		})
+		matched = true
+	}
+
 	// Loop through the cache keys
-	for k, v := range c.ft.storage {
+	var i int = 0
+	for k, v := range words {
+		if err := ctxDone(ctx, i); err != nil && !matched {
+			return result, err
+		}
+		i++
 		switch {
 		case len(result) >= sp.Limit:
-			return result
-		case !utils.Contains(k, sp.Query):
+			return result, err
+		case !sp.matchesWord(k, sp.Query):
 			continue
 		}
 
 		// Loop through the cache indices
 		if index, ok := v.(int); ok {
-			if _, ok := alreadyAdded[index]; ok {
+			if _, ok := alreadyAdded[index]; ok || !c.searchable(sp, c.ft.indices[index]) {
 				continue
 			}
 			result = append(result, c.data[c.ft.indices[index]])
@@ -83,20 +145,22 @@ func (c *Cache) searchOneWord(sp SearchParams) []map[string]any {
 			continue
 		}
 
-		var indices []int = v.([]int)
-		for j := 0; j < len(indices); j++ {
-			if _, ok := alreadyAdded[indices[j]]; ok {
-				continue
+		v.(*roaring).each(func(index int) bool {
+			if len(result) >= sp.Limit {
+				return false
+			} else if _, ok := alreadyAdded[index]; ok || !c.searchable(sp, c.ft.indices[index]) {
+				return true
 			}
 
 			// Else, append the index to the result
-			result = append(result, c.data[c.ft.indices[indices[j]]])
-			alreadyAdded[indices[j]] = 0
-		}
+			result = append(result, c.data[c.ft.indices[index]])
+			alreadyAdded[index] = 0
+			return true
+		})
 	}
 
 	// Return the result
-	return result
+	return result, err
 }
 
 // searchOneWordStrict is a method of the Cache struct that searches for a single word in the cache and returns the results.
@@ -116,20 +180,22 @@ func (c *Cache) searchOneWordStrict(result []map[string]any, sp SearchParams) []
 
 	// If there's only one result
 	if v, ok := c.ft.storage[sp.Query].(int); ok {
-		return []map[string]any{c.data[c.ft.indices[v]]}
+		if key := c.ft.indices[v]; c.searchable(sp, key) {
+			return []map[string]any{c.data[key]}
+		}
+		return result
 	}
 
 	// Loop through the indices
-	for i := 0; i < len(c.ft.storage[sp.Query].([]int)); i++ {
+	c.ft.storage[sp.Query].(*roaring).each(func(index int) bool {
 		if len(result) >= sp.Limit {
-			return result
+			return false
 		}
-		var (
-			index int    = c.ft.storage[sp.Query].([]int)[i]
-			key   string = c.ft.indices[index]
-		)
-		result = append(result, c.data[key])
-	}
+		if key := c.ft.indices[index]; c.searchable(sp, key) {
+			result = append(result, c.data[key])
+		}
+		return true
+	})
 
 	// Return the result
 	return result
@@ -1,15 +1,192 @@
 package hermes
 
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"time"
+
+	utils "github.com/realTristan/hermes/utils"
+)
+
 // SearchParams is a struct that contains the search parameters for the Cache search methods.
 type SearchParams struct {
 	// The search query
 	Query string
 	// The limit of search results to return
 	Limit int
-	// A boolean to indicate whether the search should be strict or not
+	// The number of results to skip before the first returned result
+	Offset int
+	// The cursor of the next page returned by SearchCursor. If empty, the first page is returned
+	Cursor string
+	// A boolean to indicate whether a word of the query must be equal to a stored word, instead of being contained in it.
+	// Strict searches are case-insensitive, unless the index is case-sensitive, see FTSetCaseSensitive
 	Strict bool
 	// A map containing the schema to search for
 	Schema map[string]bool
 	// Key to search in
 	Key string
+	// The name of the full-text index that Search, SearchOneWord, SearchWithKey, SearchScored, SearchGroups and SearchPage search,
+	// created with FTCreateIndex. If empty, the default full-text index is searched
+	Index string
+	// The prefix that the keys of the results must start with. If empty, all keys are searched
+	KeyPrefix string
+	// A boolean to indicate whether the query should also be searched transliterated between the Cyrillic and Latin scripts,
+	// and as if it was typed with the wrong keyboard layout (QWERTY and Russian ЙЦУКЕН). The default token rule only stores
+	// Latin letters in the full-text index, so Cyrillic fields need another token rule, see FTSetTokenRule
+	Transliterate bool
+	// The maximum number of typos, counted as single-character insertions, deletions, substitutions and transpositions, with which
+	// a word of the query still matches a stored word in Search and SearchOneWord, e.g. 1 to match "tristan" with "tirstan".
+	// With fuzziness, the words of a query with several words must each match a word of a value, in any order
+	Fuzziness int
+	// A boolean to indicate whether the * and ? characters of the query are wildcards in Search and SearchOneWord, so "te?t" matches
+	// "test" and "text", and "hermes*" matches the words that start with "hermes". A word with a wildcard must match a whole stored word,
+	// and [abc] matches a character class like in KeysMatching. The words of the query must each match a word of a value, in any order
+	Wildcards bool
+	// How the results are ordered. If RankNone, the results are returned in the order they're found
+	Ranker Ranker
+	// The minimum score of the results of a ranked search, so the weak fuzzy or partial matches are left out instead of being returned.
+	// It only applies to the searches whose results are scored, which are SearchScored and the searches with a Ranker. If 0, no result is left out
+	MinScore float64
+	// The field to group the results of SearchGroups by, which can be a dot path to a nested field
+	GroupBy string
+	// The maximum number of results of each group of SearchGroups. If 0, it's set to 3
+	GroupLimit int
+	// The numeric conditions that the results must match, e.g. "price >= 10 AND price < 100". If empty, the results aren't filtered
+	RangeFilter string
+	// How the matches are highlighted in the snippets of the results of SearchScored. If nil, the results have no snippets
+	Highlight *Highlight
+	// The field that the results are sorted by. If empty, the results aren't sorted by a field
+	SortBy string
+	// The direction in which the results are sorted by the SortBy field
+	SortOrder SortOrder
+	// The maximum time that the search spends. If 0, the search only stops when its context is done
+	Timeout time.Duration `json:"-"`
+	// The explanation of how the results were found, filled in by the search. If nil, the search isn't explained
+	Explain *Explanation `json:"-"`
+	// The query as it was typed, before it's lowercased, for the indexes whose strict searches are case-sensitive
+	typed string
+}
+
+// matchesKey is a method of the SearchParams struct that checks whether a cache key can be included in the search results.
+//
+// Parameters:
+//   - key (string): The cache key.
+//
+// Returns:
+//   - bool: true if the key starts with the KeyPrefix, false otherwise.
+func (sp SearchParams) matchesKey(key string) bool {
+	return strings.HasPrefix(key, sp.KeyPrefix)
+}
+
+// lower is a method of the SearchParams struct that lowercases the query, and keeps the query as it was typed.
+//
+// Returns:
+//   - SearchParams: The search parameters with the lowercase query.
+func (sp SearchParams) lower() SearchParams {
+	if len(sp.typed) == 0 {
+		sp.typed = sp.Query
+	}
+	sp.Query = strings.ToLower(sp.Query)
+	return sp
+}
+
+// encode is a method of the SearchParams struct that encodes the search parameters to identify a search.
+// The query of a strict search is also encoded as it was typed, since its case matters for the case-sensitive indexes.
+//
+// Returns:
+//   - []byte: The JSON encoding of the search parameters, followed by the typed query of a strict search.
+func (sp SearchParams) encode() []byte {
+	data, _ := json.Marshal(sp)
+	if sp.Strict {
+		data = append(data, sp.typed...)
+	}
+	return data
+}
+
+// matchesWord is a method of the SearchParams struct that checks whether a word stored in the full-text index matches a word of the query.
+//
+// Parameters:
+//   - word (string): The stored word.
+//   - term (string): The lowercase word of the query.
+//
+// Returns:
+//   - bool: true if the word is equal to the term, contains it if the search isn't strict, or is within the fuzziness of it.
+func (sp SearchParams) matchesWord(word string, term string) bool {
+	if word == term || (!sp.Strict && utils.Contains(word, term)) {
+		return true
+	}
+	return sp.Fuzziness > 0 && utils.EditDistance(word, term, sp.Fuzziness) <= sp.Fuzziness
+}
+
+// queries is a method of the SearchParams struct that returns the lowercase queries to search for.
+//
+// Returns:
+//   - []string: The query, followed by its distinct transliterated and keyboard layout variants if Transliterate is true.
+func (sp SearchParams) queries() []string {
+	var queries []string = []string{sp.Query}
+	if !sp.Transliterate {
+		return queries
+	}
+	for _, q := range []string{utils.Transliterate(sp.Query), utils.SwitchLayout(sp.Query)} {
+		if !utils.SliceContains(queries, q) {
+			queries = append(queries, q)
+		}
+	}
+	return queries
+}
+
+// searchVariants is a function that runs a search for each query returned by sp.queries and merges the results,
+// without duplicates, up to the limit of the search parameters.
+//
+// Parameters:
+//   - sp (SearchParams): The search parameters with the lowercase query.
+//   - search (func(sp SearchParams) ([]map[string]any, error)): The function that runs the search.
+//
+// Returns:
+//   - []map[string]any: The merged results.
+//   - error: The first error returned by the search.
+func searchVariants(sp SearchParams, search func(sp SearchParams) ([]map[string]any, error)) ([]map[string]any, error) {
+	return searchQueries(sp, sp.queries(), search)
+}
+
+// searchQueries is a function that runs a search for each of the provided queries and merges the results,
+// without duplicates, up to the limit of the search parameters.
+//
+// Parameters:
+//   - sp (SearchParams): The search parameters.
+//   - queries ([]string): The queries to search for.
+//   - search (func(sp SearchParams) ([]map[string]any, error)): The function that runs the search.
+//
+// Returns:
+//   - []map[string]any: The merged results.
+//   - error: The first error returned by the search.
+func searchQueries(sp SearchParams, queries []string, search func(sp SearchParams) ([]map[string]any, error)) ([]map[string]any, error) {
+	if len(queries) == 1 {
+		sp.Query = queries[0]
+		return search(sp)
+	}
+
+	// Merge the results of each query, comparing the values by their map pointer
+	var (
+		result []map[string]any = []map[string]any{}
+		added  map[uintptr]bool = map[uintptr]bool{}
+	)
+	for _, q := range queries {
+		sp.Query = q
+		values, err := search(sp)
+		if err != nil {
+			return result, err
+		}
+		for _, value := range values {
+			if p := reflect.ValueOf(value).Pointer(); !added[p] {
+				added[p] = true
+				result = append(result, value)
+			}
+		}
+	}
+	if sp.Limit > 0 && len(result) > sp.Limit {
+		result = result[:sp.Limit]
+	}
+	return result, nil
 }
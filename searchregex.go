@@ -0,0 +1,81 @@
+package hermes
+
+import (
+	"errors"
+	"regexp"
+	"time"
+)
+
+// SearchRegex is a method of the Cache struct that returns the values that contain a word of the full-text index matching a regular expression,
+// such as "^connect(ion|ed)?$" or "timeout", for exploring the words of the index. The expression is matched against each stored word,
+// which is lowercase and reduced to its stem if the index has a stemmer, and the values of the matching words are merged.
+// The words are looked up with the literal prefix of the expression in the suffix or n-gram index, if the index has one,
+// see FTSetSuffixIndex and FTSetNgramIndex.
+// This method is thread-safe.
+//
+// Parameters:
+//   - pattern (string): The regular expression, with the syntax of the regexp package. It matches a word if it matches any part of it, unless it's anchored.
+//   - limit (int): The maximum number of values to return, or 0 for 10.
+//
+// Returns:
+//   - []map[string]any: The matching values, in the order they were stored in the full-text index.
+//   - error: An error if the pattern or limit is invalid, or the full-text index is not initialized.
+func (c *Cache) SearchRegex(pattern string, limit int) ([]map[string]any, error) {
+	defer c.stats.search(time.Now(), false)
+
+	// Compile the pattern
+	re, err := regexp.Compile(pattern)
+	switch {
+	case len(pattern) == 0:
+		return []map[string]any{}, errors.New("invalid pattern")
+	case err != nil:
+		return []map[string]any{}, err
+	case limit < 0:
+		return []map[string]any{}, errors.New("invalid limit")
+	case limit == 0:
+		limit = 10
+	}
+
+	// Lock the mutex
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	// Check if the full-text is initialized
+	if c.ft == nil {
+		return []map[string]any{}, errors.New("full-text is not initialized")
+	}
+	return c.expandAll(c.indexValues(c.ft.regexIndices(re), SearchParams{Limit: limit})), nil
+}
+
+// regexIndices is a method of the FullText struct that returns the indices of the values that contain a stored word matching a regular expression.
+//
+// Parameters:
+//   - re (*regexp.Regexp): The compiled regular expression.
+//
+// Returns:
+//   - map[int]bool: The set of the indices.
+func (ft *FullText) regexIndices(re *regexp.Regexp) map[int]bool {
+	var (
+		indices map[int]bool   = map[int]bool{}
+		words   map[string]any = ft.storage
+	)
+
+	// Every match contains the literal prefix of the expression
+	if prefix, _ := re.LiteralPrefix(); len(prefix) > 0 {
+		if w, ok := ft.substringWords(prefix); ok {
+			words = w
+		}
+	}
+
+	// Merge the indices of the matching words
+	for word, v := range words {
+		if !re.MatchString(word) {
+			continue
+		}
+		eachPosting(v, func(index int) bool {
+			indices[index] = true
+			return true
+		})
+	}
+	return indices
+}
@@ -3,9 +3,11 @@ package hermes
 import (
 	"errors"
 	"strings"
+	"time"
 )
 
 // SearchValues searches for all records containing the given query in the specified schema with a limit of results to return.
+// Each record is returned once, however many of its fields contain the query.
 // Parameters:
 //   - c (c *Cache): A pointer to the Cache struct
 //   - sp (SearchParams): A SearchParams struct containing the search parameters.
@@ -14,7 +16,14 @@ import (
 //   - []map[string]any: A slice of maps where each map represents a data record that matches the given query.
 //     The keys of the map correspond to the column names of the data that were searched and returned in the result.
 //   - error: An error if the query or limit is invalid
-func (c *Cache) SearchValues(sp SearchParams) ([]map[string]any, error) {
+func (c *Cache) SearchValues(sp SearchParams) (result []map[string]any, err error) {
+	defer c.stats.search(time.Now(), sp.Strict)
+	defer func(sp SearchParams) {
+		c.shadow.mirror("SearchValues", sp, result, err, func(shadow *Cache) ([]map[string]any, error) {
+			return shadow.SearchValues(sp)
+		})
+	}(sp)
+
 	// If the query is empty, return an error
 	if len(sp.Query) == 0 {
 		return []map[string]any{}, errors.New("invalid query")
@@ -31,14 +40,17 @@ func (c *Cache) SearchValues(sp SearchParams) ([]map[string]any, error) {
 	}
 
 	// Set the query to lowercase
-	sp.Query = strings.ToLower(sp.Query)
+	sp = sp.lower()
 
 	// Lock the mutex
 	c.mutex.RLock()
 	defer c.mutex.RUnlock()
 
 	// Search the data
-	return c.searchValues(sp), nil
+	result, err = searchVariants(sp, func(sp SearchParams) ([]map[string]any, error) {
+		return c.searchValues(sp), nil
+	})
+	return c.expandAll(result), err
 }
 
 // searchValues searches for all records containing the given query in the specified schema with a limit of results to return.
@@ -54,21 +66,25 @@ func (c *Cache) searchValues(sp SearchParams) []map[string]any {
 	var result []map[string]any = []map[string]any{}
 
 	// Iterate over the query result
-	for _, item := range c.data {
-		// Iterate over the keys and values for the data for that index
+	for cacheKey, item := range c.data {
+		if !c.searchable(sp, cacheKey) {
+			continue
+		}
+
+		// Iterate over the keys and values for the data for that index,
+		// and add the item once even if several of its fields contain the query
+		if len(result) >= sp.Limit {
+			return result
+		}
 		for key, value := range item {
-			switch {
-			case len(result) >= sp.Limit:
-				return result
-			case !sp.Schema[key]:
+			if !sp.Schema[key] {
 				continue
 			}
 
 			// Check if the value contains the query
-			if v, ok := value.(string); ok {
-				if strings.Contains(strings.ToLower(v), sp.Query) {
-					result = append(result, item)
-				}
+			if v, ok := fieldString(value); ok && strings.Contains(strings.ToLower(v), sp.Query) {
+				result = append(result, item)
+				break
 			}
 		}
 	}
@@ -3,9 +3,12 @@ package hermes
 import (
 	"errors"
 	"strings"
+	"time"
 )
 
 // SearchWithKey searches for all records containing the given query in the specified key column with a limit of results to return.
+// If the field is stored in the full-text index, the values are looked up in the posting lists of the field, and they must contain
+// a word that matches each word of the query, like with Search. Otherwise, the values whose field contains the query are returned.
 // Parameters:
 //   - c (c *Cache): A pointer to the Cache struct
 //   - sp (SearchParams): A SearchParams struct containing the search parameters.
@@ -13,7 +16,14 @@ import (
 // Returns:
 //   - []map[string]any: A slice of maps containing the search results
 //   - error: An error if the key, query or limit is invalid
-func (c *Cache) SearchWithKey(sp SearchParams) ([]map[string]any, error) {
+func (c *Cache) SearchWithKey(sp SearchParams) (result []map[string]any, err error) {
+	defer c.stats.search(time.Now(), sp.Strict)
+	defer func(sp SearchParams) {
+		c.shadow.mirror("SearchWithKey", sp, result, err, func(shadow *Cache) ([]map[string]any, error) {
+			return shadow.SearchWithKey(sp)
+		})
+	}(sp)
+
 	switch {
 	case len(sp.Key) == 0:
 		return []map[string]any{}, errors.New("invalid key")
@@ -22,17 +32,27 @@ func (c *Cache) SearchWithKey(sp SearchParams) ([]map[string]any, error) {
 	}
 
 	// Set the query to lowercase
-	sp.Query = strings.ToLower(sp.Query)
+	sp = sp.lower()
 
 	// Lock the mutex
 	c.mutex.RLock()
 	defer c.mutex.RUnlock()
 
+	// Search the named index
+	view, err := c.ftView(sp.Index)
+	if err != nil {
+		return []map[string]any{}, err
+	}
+
 	// Search the data
-	return c.searchWithKey(sp), nil
+	result, err = searchVariants(sp, func(sp SearchParams) ([]map[string]any, error) {
+		return view.searchWithKey(sp), nil
+	})
+	return c.expandAll(result), err
 }
 
 // searchWithKey searches for all records containing the given query in the specified key column with a limit of results to return.
+// The posting lists of the field are used if it's stored in the full-text index and the query has words that can be stored in it.
 // Parameters:
 //   - c (c *Cache): A pointer to the Cache struct
 //   - sp (SearchParams): A SearchParams struct containing the search parameters.
@@ -40,21 +60,28 @@ func (c *Cache) SearchWithKey(sp SearchParams) ([]map[string]any, error) {
 // Returns:
 //   - []map[string]any: A slice of maps containing the search results
 func (c *Cache) searchWithKey(sp SearchParams) []map[string]any {
+	// Look up the words of the query in the posting lists of the field
+	if c.ft != nil && c.ft.fieldStorage[sp.Key] != nil {
+		if terms := c.ft.fieldWords(sp.Key, sp.Query); len(terms) > 0 {
+			return c.indexValues(c.ft.fieldIndices(sp.Key, terms, sp), sp)
+		}
+	}
+
 	// Define variables
 	var result []map[string]any = []map[string]any{}
 
 	// Iterate over the query result
-	for _, item := range c.data {
-		for _, v := range item {
-			if len(result) >= sp.Limit {
-				return result
-			}
+	for key, item := range c.data {
+		if len(result) >= sp.Limit {
+			return result
+		} else if !c.searchable(sp, key) {
+			continue
+		}
 
-			// Check if the value contains the query
-			if v, ok := v.(string); ok {
-				if strings.Contains(strings.ToLower(v), sp.Query) {
-					result = append(result, item)
-				}
+		// Check if the value of the field contains the query
+		if v, ok := fieldString(pathValue(c.expand(item), sp.Key)); ok {
+			if strings.Contains(strings.ToLower(v), sp.Query) {
+				result = append(result, item)
 			}
 		}
 	}
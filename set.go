@@ -1,6 +1,9 @@
 package hermes
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+)
 
 // Set is a method of the Cache struct that sets a value in the cache for the specified key.
 // This function is thread-safe.
@@ -12,9 +15,42 @@ import "fmt"
 // Returns:
 //   - Error
 func (c *Cache) Set(key string, value map[string]any) error {
+	defer c.stats.setTimes.since(time.Now())
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
-	return c.set(key, value)
+
+	// Verify that the cache isn't frozen and the key hasn't exceeded the write rate limit
+	if err := c.writable("Set"); err != nil {
+		return err
+	} else if err := c.writeAllow(key); err != nil {
+		return err
+	}
+	return c.setDone(key, c.set(key, value))
+}
+
+// setDone is a method of the Cache struct that is called by the exported methods once they have set the value of a key.
+// It counts the set, records the new value in the history of the key, notifies the matching subscriptions and sends it to the sinks.
+// This function is not thread-safe, and should only be called from an exported function.
+//
+// Parameters:
+//   - key: The key whose value was set.
+//   - err: The error returned when setting the value. If not nil, nothing is recorded, and if the value was skipped, no error is returned.
+//
+// Returns:
+//   - The provided error, or the error of a synchronous sink.
+func (c *Cache) setDone(key string, err error) error {
+	if err == errSkipped {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	var value map[string]any = c.expand(c.data[key])
+	c.stats.sets.Add(1)
+	c.limitCheck()
+	c.historyAdd(key, value)
+	c.walAdd(key)
+	c.notify(key)
+	return c.sinkSend(key, value)
 }
 
 // set is a method of the Cache struct that sets a value in the cache for the specified key.
@@ -28,25 +64,70 @@ func (c *Cache) Set(key string, value map[string]any) error {
 // Returns:
 //   - An error if the full-text cache key already exists. Otherwise, nil.
 func (c *Cache) set(key string, value map[string]any) error {
+	defer c.consistencyCheck()
 	if _, ok := c.data[key]; ok {
 		return fmt.Errorf("full-text cache key already exists (%s). delete it before setting it another value", key)
 	}
 
-	// Update the value in the FT cache
-	if c.ft != nil {
+	// Verify that the value is well-formed, and derive its computed fields
+	if err := c.checkRecord(key, value); err != nil {
+		return c.reject(err)
+	} else if err := c.compute(key, value); err != nil {
+		return c.reject(err)
+	}
+
+	// Verify that the value doesn't violate any unique constraints
+	if err := c.uniqueCheck(key, value); err != nil {
+		return err
+	}
+
+	// Handle the value if it duplicates the full-text content of another value
+	var hash, original string = c.duplicateCheck(key, value)
+	if len(original) > 0 {
+		c.stats.duplicates.Add(1)
+		if c.dups.mode == DuplicateSkip {
+			return errSkipped
+		}
+	}
+
+	// Update the value in the FT cache, storing the full-text fields of a linked duplicate as plain strings
+	if c.ft != nil && len(original) > 0 && c.dups.mode == DuplicateLink {
+		for k, v := range value {
+			if ftv := c.ft.value(k, v); len(ftv) > 0 {
+				value[k] = ftv
+			}
+		}
+	} else if c.ft != nil {
 		if err := c.ftSet(key, value); err != nil {
 			return err
 		}
 	}
+	c.namedSet(key, value)
 
 	// Update the value in the cache
 	c.data[key] = value
+	c.keyTrie.Insert(key)
+
+	// Update the unique constraint and secondary indexes
+	c.uniqueSet(key, value)
+	c.indexSet(key, value)
+	c.duplicateSet(key, hash, original)
+	c.generation++
+	c.versions[key] = c.generation
+
+	// Set the expiry of the value from its expiry field
+	c.expireFromField(key, value)
+
+	// Compress the long fields of the value once it's indexed
+	c.data[key] = c.compress(value)
 
 	// Return nil for no error
 	return nil
 }
 
 // ftSet is a method of the Cache struct that sets a value in the full-text cache for the specified key.
+// Only the postings of the words of the value are updated. If the value can't be stored, the words that were already
+// inserted are removed, so a rejected value doesn't leave any entry in the index.
 // This function is not thread-safe, and should only be called from an exported function.
 //
 // Parameters:
@@ -58,21 +139,32 @@ func (c *Cache) set(key string, value map[string]any) error {
 func (c *Cache) ftSet(key string, value map[string]any) error {
 	var ts *TempStorage = NewTempStorage(c.ft)
 	for k, v := range value {
-		if ftv := WFTGetValue(v); len(ftv) == 0 {
+		if ftv := c.ft.value(k, v); len(ftv) == 0 {
 			continue
 		} else {
 			// Update the value
 			value[k] = ftv
 
 			// Insert the value in the temp storage
-			if err := ts.insert(c.ft, key, ftv); err != nil {
+			if err := ts.insert(c.ft, key, k, ftv); err != nil {
+				c.ft.removeKey(key, ts.inserted)
 				return err
 			}
+			c.ft.fields[key] = append(c.ft.fields[key], k)
+		}
+	}
+
+	// Insert the nested fields referenced by the schema
+	for k, ftv := range c.ft.nestedValues(value) {
+		if err := ts.insert(c.ft, key, k, ftv); err != nil {
+			c.ft.removeKey(key, ts.inserted)
+			return err
 		}
+		c.ft.fields[key] = append(c.ft.fields[key], k)
 	}
 
-	// Iterate over the temp storage and set the values with len 1 to int
-	ts.cleanSingleArrays()
+	// Set the inserted words with a single index to int
+	ts.cleanInserted()
 
 	// Set the full-text cache to the temp map
 	ts.updateFullText(c.ft)
@@ -0,0 +1,188 @@
+package hermes
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"math/rand"
+	"sync"
+)
+
+// ShadowDiff is a struct that describes a search whose results differed between a cache and its shadow cache.
+//
+// Fields:
+//   - Method (string): The name of the search method, e.g. SearchOneWord.
+//   - Params (SearchParams): The search parameters as provided by the caller.
+//   - Primary ([]map[string]any): The results of the cache. They're the values stored in the cache, so they must not be modified.
+//   - Shadow ([]map[string]any): The results of the shadow cache. They're the values stored in the shadow cache, so they must not be modified.
+//   - PrimaryError (error): The error returned by the cache.
+//   - ShadowError (error): The error returned by the shadow cache.
+//   - Missing ([]map[string]any): The results of the cache that the shadow cache didn't return.
+//   - Extra ([]map[string]any): The results of the shadow cache that the cache didn't return.
+type ShadowDiff struct {
+	Method       string
+	Params       SearchParams
+	Primary      []map[string]any
+	Shadow       []map[string]any
+	PrimaryError error
+	ShadowError  error
+	Missing      []map[string]any
+	Extra        []map[string]any
+}
+
+// String is a method of the ShadowDiff struct that returns a one-line summary of the diff.
+//
+// Returns:
+//   - string: The summary of the diff.
+func (d ShadowDiff) String() string {
+	return fmt.Sprintf("%s %q: %d results (err: %v), shadow %d results (err: %v), %d missing, %d extra",
+		d.Method, d.Params.Query, len(d.Primary), d.PrimaryError, len(d.Shadow), d.ShadowError, len(d.Missing), len(d.Extra))
+}
+
+// searchShadow is a struct that holds the shadow cache that a percentage of searches is mirrored to.
+//
+// Fields:
+//   - mutex (sync.RWMutex): A RWMutex that guards access to the fields. It's separate from the cache mutex, since the shadow is read after a search releases it.
+//   - cache (*Cache): The shadow cache. If nil, searches are not mirrored.
+//   - percent (float64): The percentage of searches to mirror.
+//   - fn (func(diff ShadowDiff)): The function to call for every mirrored search whose results differ. If nil, the diffs are logged.
+type searchShadow struct {
+	mutex   sync.RWMutex
+	cache   *Cache
+	percent float64
+	fn      func(diff ShadowDiff)
+}
+
+// SetSearchShadow is a method of the Cache struct that mirrors a percentage of the searches to a shadow cache, which can hold the same
+// data with a different full-text configuration. The mirrored searches run in the background after the search returns, so they don't
+// slow down the caller. For every mirrored search whose results or errors differ, fn is called with the diff, or the diff is logged if fn is nil.
+// Results are compared as sets, so a different order of the same results is not a diff. If shadow is nil, searches are no longer mirrored.
+// This method is thread-safe.
+//
+// Parameters:
+//   - shadow (*Cache): The shadow cache.
+//   - percent (float64): The percentage of searches to mirror, from 0 to 100.
+//   - fn (func(diff ShadowDiff)): The function to call with the diff of every mirrored search whose results differ.
+//
+// Returns:
+//   - error: An error if the shadow cache is the cache itself or the percentage is invalid.
+func (c *Cache) SetSearchShadow(shadow *Cache, percent float64, fn func(diff ShadowDiff)) error {
+	switch {
+	case shadow == c:
+		return errors.New("a cache can't shadow itself")
+	case percent < 0 || percent > 100:
+		return errors.New("invalid percent")
+	}
+
+	// Set the shadow cache
+	c.shadow.mutex.Lock()
+	defer c.shadow.mutex.Unlock()
+	c.shadow.cache = shadow
+	c.shadow.percent = percent
+	c.shadow.fn = fn
+	return nil
+}
+
+// mirror is a method of the searchShadow struct that runs a search on the shadow cache in the background, if the search is sampled,
+// and reports the diff of the results.
+//
+// Parameters:
+//   - method (string): The name of the search method.
+//   - sp (SearchParams): The search parameters as provided by the caller.
+//   - result ([]map[string]any): The results of the cache.
+//   - err (error): The error returned by the cache.
+//   - search (func(shadow *Cache) ([]map[string]any, error)): The function that runs the same search on the shadow cache.
+//
+// Returns:
+//   - None
+func (s *searchShadow) mirror(method string, sp SearchParams, result []map[string]any, err error, search func(shadow *Cache) ([]map[string]any, error)) {
+	s.mutex.RLock()
+	var (
+		shadow  *Cache                = s.cache
+		percent float64               = s.percent
+		fn      func(diff ShadowDiff) = s.fn
+	)
+	s.mutex.RUnlock()
+
+	// Verify that the search is sampled
+	if shadow == nil || rand.Float64()*100 >= percent {
+		return
+	}
+
+	// Run the search on the shadow cache and report the diff, without explaining it
+	sp.Explain = nil
+	go func() {
+		var diff ShadowDiff = ShadowDiff{
+			Method:       method,
+			Params:       sp,
+			Primary:      result,
+			PrimaryError: err,
+		}
+		diff.Shadow, diff.ShadowError = search(shadow)
+		if !diff.compare() {
+			return
+		}
+		if fn != nil {
+			fn(diff)
+		} else {
+			log.Println("hermes: search shadow:", diff)
+		}
+	}()
+}
+
+// compare is a method of the ShadowDiff struct that computes the missing and extra results.
+//
+// Returns:
+//   - bool: Whether the results or errors differ.
+func (d *ShadowDiff) compare() bool {
+	// Count the results of the cache
+	var counts map[string]int = make(map[string]int, len(d.Primary))
+	for _, value := range d.Primary {
+		counts[shadowKey(value)]++
+	}
+
+	// Find the results of the shadow cache that the cache didn't return
+	d.Missing, d.Extra = []map[string]any{}, []map[string]any{}
+	for _, value := range d.Shadow {
+		var key string = shadowKey(value)
+		if counts[key] > 0 {
+			counts[key]--
+		} else {
+			d.Extra = append(d.Extra, value)
+		}
+	}
+
+	// Find the results of the cache that the shadow cache didn't return
+	for _, value := range d.Primary {
+		var key string = shadowKey(value)
+		if counts[key] > 0 {
+			counts[key]--
+			d.Missing = append(d.Missing, value)
+		}
+	}
+
+	// Compare the errors by their message, since they're never the same value
+	var primaryError, shadowError string
+	if d.PrimaryError != nil {
+		primaryError = d.PrimaryError.Error()
+	}
+	if d.ShadowError != nil {
+		shadowError = d.ShadowError.Error()
+	}
+	return len(d.Missing) > 0 || len(d.Extra) > 0 || primaryError != shadowError
+}
+
+// shadowKey is a function that returns a string that identifies a search result, so results of different caches can be compared.
+//
+// Parameters:
+//   - value (map[string]any): The search result.
+//
+// Returns:
+//   - string: The JSON encoding of the result, which has sorted keys.
+func shadowKey(value map[string]any) string {
+	if data, err := json.Marshal(value); err == nil {
+		return string(data)
+	}
+	return fmt.Sprint(value)
+}
@@ -0,0 +1,529 @@
+package hermes
+
+import (
+	"context"
+	"errors"
+	"hash/fnv"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ErrShardedIndex is returned when a unique constraint or a secondary index is created on a ShardedCache
+var ErrShardedIndex = errors.New("unique constraints and secondary indexes are not supported by a sharded cache")
+
+// ShardedCache is a struct that spreads keys over multiple caches by the hash of the key.
+// Each shard has its own mutex and full-text index, so writes to keys in different shards don't wait for each other.
+// A Cache itself still guards its data with a single mutex, so only the writes through a ShardedCache are spread over several locks.
+// Searches are run on every shard concurrently, so a single search uses as many cores as there are shards, and the results are merged.
+// The ranked results of the shards are scored with the statistics of the whole cache, so they're merged by score.
+// Unique constraints and secondary indexes can't span the shards, so CreateUnique and CreateIndex return ErrShardedIndex.
+//
+// Fields:
+//   - shards ([]*Cache): The caches that hold the keys.
+type ShardedCache struct {
+	shards []*Cache
+}
+
+// InitShardedCache is a function that initializes a new ShardedCache with the provided number of shards.
+//
+// Parameters:
+//   - n (int): The number of shards.
+//
+// Returns:
+//   - *ShardedCache: A pointer to the new ShardedCache.
+//   - error: An error if the number of shards is invalid.
+func InitShardedCache(n int) (*ShardedCache, error) {
+	if n <= 0 {
+		return nil, errors.New("invalid number of shards")
+	}
+
+	// Initialize the shards
+	var sc *ShardedCache = &ShardedCache{shards: make([]*Cache, n)}
+	for i := range sc.shards {
+		sc.shards[i] = InitCache()
+	}
+	return sc, nil
+}
+
+// Shard is a method of the ShardedCache struct that returns the shard that holds the provided key.
+//
+// Parameters:
+//   - key (string): The key.
+//
+// Returns:
+//   - *Cache: The shard that holds the key.
+func (sc *ShardedCache) Shard(key string) *Cache {
+	var h = fnv.New32a()
+	_, _ = h.Write([]byte(key))
+	return sc.shards[h.Sum32()%uint32(len(sc.shards))]
+}
+
+// Shards is a method of the ShardedCache struct that returns all of the shards.
+// A unique constraint or an index created on a shard only covers the keys of that shard.
+//
+// Returns:
+//   - []*Cache: The shards.
+func (sc *ShardedCache) Shards() []*Cache {
+	return append([]*Cache{}, sc.shards...)
+}
+
+// FTInit is a method of the ShardedCache struct that initializes the full-text index of every shard.
+// The limits apply to each shard separately.
+// This method is thread-safe.
+//
+// Parameters:
+//   - maxSize (int): The maximum number of words to store in the full-text index of each shard.
+//   - maxBytes (int): The maximum size, in bytes, of the full-text index of each shard.
+//   - minWordLength (int): The minimum length of a word to store in the full-text index.
+//
+// Returns:
+//   - error: An error if the full-text index of a shard is already initialized.
+func (sc *ShardedCache) FTInit(maxSize int, maxBytes int, minWordLength int) error {
+	for _, shard := range sc.shards {
+		if err := shard.FTInit(maxSize, maxBytes, minWordLength); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Set is a method of the ShardedCache struct that sets a value in the shard of the specified key.
+// This method is thread-safe.
+//
+// Parameters:
+//   - key (string): The key to set the value for.
+//   - value (map[string]any): The value to set.
+//
+// Returns:
+//   - error: An error if the set fails.
+func (sc *ShardedCache) Set(key string, value map[string]any) error {
+	return sc.Shard(key).Set(key, value)
+}
+
+// SetWithTTL is a method of the ShardedCache struct that sets a value in the shard of the specified key, which expires after the provided duration.
+// This method is thread-safe.
+//
+// Parameters:
+//   - key (string): The key to set the value for.
+//   - value (map[string]any): The value to set.
+//   - ttl (time.Duration): The time after which the key expires.
+//
+// Returns:
+//   - error: An error if the ttl is invalid or the set fails.
+func (sc *ShardedCache) SetWithTTL(key string, value map[string]any, ttl time.Duration) error {
+	return sc.Shard(key).SetWithTTL(key, value, ttl)
+}
+
+// Touch is a method of the ShardedCache struct that resets the time to live of a key in its shard, without rewriting its value.
+// This method is thread-safe.
+//
+// Parameters:
+//   - key (string): The key to refresh.
+//   - ttl (time.Duration): The new time after which the key expires.
+//
+// Returns:
+//   - error: An error if the ttl is invalid or the key doesn't exist.
+func (sc *ShardedCache) Touch(key string, ttl time.Duration) error {
+	return sc.Shard(key).Touch(key, ttl)
+}
+
+// Get is a method of the ShardedCache struct that retrieves the value associated with the given key.
+// The returned map is the one stored in the cache, so it must not be modified.
+// This method is thread-safe.
+//
+// Parameters:
+//   - key (string): The key to retrieve the value for.
+//
+// Returns:
+//   - map[string]any: The value associated with the key, or nil if it doesn't exist.
+func (sc *ShardedCache) Get(key string) map[string]any {
+	return sc.Shard(key).Get(key)
+}
+
+// GetCopy is a method of the ShardedCache struct that retrieves a copy of the value associated with the given key.
+// This method is thread-safe.
+//
+// Parameters:
+//   - key (string): The key to retrieve the value for.
+//
+// Returns:
+//   - map[string]any: A copy of the value associated with the key, or nil if it doesn't exist.
+func (sc *ShardedCache) GetCopy(key string) map[string]any {
+	return sc.Shard(key).GetCopy(key)
+}
+
+// Delete is a method of the ShardedCache struct that removes a key from its shard.
+// This method is thread-safe.
+//
+// Parameters:
+//   - key (string): The key to remove.
+//
+// Returns:
+//   - None
+func (sc *ShardedCache) Delete(key string) {
+	sc.Shard(key).Delete(key)
+}
+
+// CreateUnique is a method of the ShardedCache struct that rejects a unique constraint, since the values of a field can't be
+// checked across the shards without a lock over every shard.
+//
+// Parameters:
+//   - fields (...string): The fields of the constraint.
+//
+// Returns:
+//   - error: ErrShardedIndex.
+func (sc *ShardedCache) CreateUnique(fields ...string) error {
+	return ErrShardedIndex
+}
+
+// CreateIndex is a method of the ShardedCache struct that rejects a secondary index, since the index of each shard would only
+// cover the keys of that shard.
+//
+// Parameters:
+//   - field (string): The field to index.
+//
+// Returns:
+//   - error: ErrShardedIndex.
+func (sc *ShardedCache) CreateIndex(field string) error {
+	return ErrShardedIndex
+}
+
+// Exists is a method of the ShardedCache struct that checks if a key exists.
+// This method is thread-safe.
+//
+// Parameters:
+//   - key (string): The key to check.
+//
+// Returns:
+//   - bool: Whether the key exists.
+func (sc *ShardedCache) Exists(key string) bool {
+	return sc.Shard(key).Exists(key)
+}
+
+// Keys is a method of the ShardedCache struct that returns the keys of every shard.
+// Each shard is read separately, so the result is not a consistent snapshot if keys are written concurrently.
+// This method is thread-safe.
+//
+// Returns:
+//   - []string: The keys.
+func (sc *ShardedCache) Keys() []string {
+	var keys []string = []string{}
+	for _, shard := range sc.shards {
+		keys = append(keys, shard.Keys()...)
+	}
+	return keys
+}
+
+// KeysWithPrefix is a method of the ShardedCache struct that returns the keys of every shard that start with the provided prefix, in sorted order.
+// This method is thread-safe.
+//
+// Parameters:
+//   - prefix (string): The prefix of the keys.
+//
+// Returns:
+//   - []string: The matching keys.
+func (sc *ShardedCache) KeysWithPrefix(prefix string) []string {
+	var keys []string = []string{}
+	for _, shard := range sc.shards {
+		keys = append(keys, shard.KeysWithPrefix(prefix)...)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Values is a method of the ShardedCache struct that returns the values of every shard.
+// The returned maps are the ones stored in the cache, so they must not be modified.
+// This method is thread-safe.
+//
+// Returns:
+//   - []map[string]any: The values.
+func (sc *ShardedCache) Values() []map[string]any {
+	var values []map[string]any = []map[string]any{}
+	for _, shard := range sc.shards {
+		values = append(values, shard.Values()...)
+	}
+	return values
+}
+
+// Length is a method of the ShardedCache struct that returns the number of keys in every shard.
+// This method is thread-safe.
+//
+// Returns:
+//   - int: The number of keys.
+func (sc *ShardedCache) Length() int {
+	var length int = 0
+	for _, shard := range sc.shards {
+		length += shard.Length()
+	}
+	return length
+}
+
+// Clean is a method of the ShardedCache struct that clears every shard.
+// This method is thread-safe.
+//
+// Returns:
+//   - None
+func (sc *ShardedCache) Clean() {
+	for _, shard := range sc.shards {
+		shard.Clean()
+	}
+}
+
+// Search is a method of the ShardedCache struct that searches the full-text index of every shard concurrently like Cache.Search.
+// This method is thread-safe.
+//
+// Parameters:
+//   - sp (SearchParams): A SearchParams struct containing the search parameters.
+//
+// Returns:
+//   - []map[string]any: The matching values, at most sp.Limit of them.
+//   - error: An error if the search of a shard fails.
+func (sc *ShardedCache) Search(sp SearchParams) ([]map[string]any, error) {
+	return sc.SearchCtx(context.Background(), sp)
+}
+
+// SearchCtx is a method of the ShardedCache struct that searches the full-text index of every shard concurrently like Cache.SearchCtx.
+// Each shard returns its results up to the end of the page, and the page is taken from the merged results. A cursor holds the position
+// of a search of a single cache, so the search parameters can't have a Cursor.
+// This method is thread-safe.
+//
+// Parameters:
+//   - ctx (context.Context): The context of the request.
+//   - sp (SearchParams): A SearchParams struct containing the search parameters.
+//
+// Returns:
+//   - []map[string]any: The matching values, at most sp.Limit of them.
+//   - error: An error if the search parameters are invalid or the search of a shard fails, or the context error if the context is done before the search completes.
+func (sc *ShardedCache) SearchCtx(ctx context.Context, sp SearchParams) ([]map[string]any, error) {
+	// The shards are searched concurrently, so they can't fill in the same explanation
+	sp.Explain = nil
+	if len(sp.Cursor) > 0 {
+		return []map[string]any{}, errors.New("a sharded cache can't search from a cursor")
+	} else if sp.Limit == 0 {
+		sp.Limit = 10
+	} else if sp.Limit < 0 {
+		return []map[string]any{}, errors.New("invalid limit")
+	}
+	if sp.Offset < 0 {
+		return []map[string]any{}, errors.New("invalid offset")
+	}
+
+	// Search every shard up to the end of the page
+	var offset, limit int = sp.Offset, sp.Limit
+	sp.Offset, sp.Limit = 0, offset+limit
+	if sp.Ranker == RankNone {
+		result, err := sc.search(sp, func(shard *Cache) ([]map[string]any, error) {
+			return shard.SearchCtx(ctx, sp)
+		})
+		if offset >= len(result) {
+			return []map[string]any{}, err
+		}
+		return result[offset:], err
+	}
+
+	// Rank the results of every shard
+	ranked, err := sc.searchScored(ctx, sp)
+	if (err != nil && !errors.Is(err, ErrSearchTimeout)) || offset >= len(ranked) {
+		return []map[string]any{}, err
+	}
+	var result []map[string]any = make([]map[string]any, 0, len(ranked)-offset)
+	for _, r := range ranked[offset:] {
+		result = append(result, r.Value)
+	}
+	return result, err
+}
+
+// SearchScored is a method of the ShardedCache struct that searches the full-text index of every shard concurrently like Cache.SearchScored.
+// The results of the shards are scored with the statistics of the whole cache, such as the number of values that contain each word of the query,
+// so their scores are the same as if the values were in a single cache, and the results are merged by score.
+// This method is thread-safe.
+//
+// Parameters:
+//   - sp (SearchParams): A SearchParams struct containing the search parameters.
+//
+// Returns:
+//   - []Result: The results, from the most to the least relevant, at most sp.Limit of them.
+//   - error: An error if the query is invalid, or the search of a shard fails.
+func (sc *ShardedCache) SearchScored(sp SearchParams) ([]Result, error) {
+	return sc.searchScored(context.Background(), sp)
+}
+
+// SearchOneWord is a method of the ShardedCache struct that searches the full-text index of every shard concurrently like Cache.SearchOneWord.
+// This method is thread-safe.
+//
+// Parameters:
+//   - sp (SearchParams): A SearchParams struct containing the search parameters.
+//
+// Returns:
+//   - []map[string]any: The matching values, at most sp.Limit of them.
+//   - error: An error if the search of a shard fails.
+func (sc *ShardedCache) SearchOneWord(sp SearchParams) ([]map[string]any, error) {
+	return sc.search(sp, func(shard *Cache) ([]map[string]any, error) {
+		return shard.SearchOneWord(sp)
+	})
+}
+
+// SearchValues is a method of the ShardedCache struct that searches the values of every shard concurrently like Cache.SearchValues.
+// This method is thread-safe.
+//
+// Parameters:
+//   - sp (SearchParams): A SearchParams struct containing the search parameters.
+//
+// Returns:
+//   - []map[string]any: The matching values, at most sp.Limit of them.
+//   - error: An error if the search of a shard fails.
+func (sc *ShardedCache) SearchValues(sp SearchParams) ([]map[string]any, error) {
+	return sc.search(sp, func(shard *Cache) ([]map[string]any, error) {
+		return shard.SearchValues(sp)
+	})
+}
+
+// Close is a method of the ShardedCache struct that stops the background work of every shard.
+//
+// Returns:
+//   - error: The first error returned by a shard.
+func (sc *ShardedCache) Close() error {
+	var err error
+	for _, shard := range sc.shards {
+		if e := shard.Close(); e != nil && err == nil {
+			err = e
+		}
+	}
+	return err
+}
+
+// searchScored is a method of the ShardedCache struct that runs a ranked search on every shard concurrently, with the statistics
+// of every shard added up, and merges the results by score.
+//
+// Parameters:
+//   - ctx (context.Context): The context of the request.
+//   - sp (SearchParams): The search parameters.
+//
+// Returns:
+//   - []Result: The merged results, at most sp.Limit of them.
+//   - error: An error if the query is invalid, the first error returned by a shard, or an error wrapping ErrSearchTimeout
+//     with the results that the shards found in time.
+func (sc *ShardedCache) searchScored(ctx context.Context, sp SearchParams) ([]Result, error) {
+	if len(sp.Query) == 0 {
+		return []Result{}, errors.New("invalid query")
+	}
+	sp.Explain = nil
+
+	// Stop both phases of the search once its timeout elapses
+	var parent context.Context = ctx
+	ctx, cancel := searchTimeout(ctx, sp)
+	defer cancel()
+	var timeout SearchParams = sp
+	sp.Timeout = 0
+
+	// Set the default limit and ranker
+	if sp.Limit == 0 {
+		sp.Limit = 10
+	}
+	if sp.Ranker == RankNone {
+		sp.Ranker = RankTFIDF
+	}
+
+	// Add up the statistics of the shards
+	var (
+		stats []rankStats = make([]rankStats, len(sc.shards))
+		errs  []error     = make([]error, len(sc.shards))
+		total rankStats
+	)
+	sc.each(func(i int, shard *Cache) {
+		stats[i], errs[i] = shard.searchStats(ctx, sp)
+	})
+	for i := range stats {
+		if errs[i] != nil {
+			return []Result{}, timeoutErr(parent, timeout, errs[i])
+		} else if i == 0 {
+			total = stats[i]
+		} else if len(stats[i].df) != len(total.df) {
+			return []Result{}, errors.New("the shards analyze the query differently")
+		} else {
+			total = total.add(stats[i])
+		}
+	}
+
+	// Score the results of every shard with the statistics of the whole cache
+	var results [][]Result = make([][]Result, len(sc.shards))
+	sc.each(func(i int, shard *Cache) {
+		results[i], errs[i] = shard.searchScored(ctx, sp, &total)
+	})
+	var (
+		result []Result = []Result{}
+		err    error
+	)
+	for i := range results {
+		if errs[i] != nil && ctx.Err() == nil {
+			return []Result{}, errs[i]
+		} else if errs[i] != nil {
+			err = timeoutErr(parent, timeout, errs[i])
+		}
+		result = append(result, results[i]...)
+	}
+	return sortResults(result, sp, sp.Limit), err
+}
+
+// each is a method of the ShardedCache struct that calls a function with every shard concurrently, and waits for the calls to return.
+//
+// Parameters:
+//   - fn (func(i int, shard *Cache)): The function, called with the position and the shard.
+//
+// Returns:
+//   - None
+func (sc *ShardedCache) each(fn func(i int, shard *Cache)) {
+	var wg sync.WaitGroup
+	for i, shard := range sc.shards {
+		wg.Add(1)
+		go func(i int, shard *Cache) {
+			defer wg.Done()
+			fn(i, shard)
+		}(i, shard)
+	}
+	wg.Wait()
+}
+
+// search is a method of the ShardedCache struct that runs a search on every shard concurrently and merges the results.
+//
+// Parameters:
+//   - sp (SearchParams): The search parameters. Only the limit and sort fields are used to merge the results, so the shards
+//     must be searched from the first result.
+//   - fn (func(shard *Cache) ([]map[string]any, error)): The function that searches a shard.
+//
+// Returns:
+//   - []map[string]any: The merged results, at most sp.Limit of them.
+//   - error: The first error returned by a shard, or the first error wrapping ErrSearchTimeout, with the results that the shards found in time.
+func (sc *ShardedCache) search(sp SearchParams, fn func(shard *Cache) ([]map[string]any, error)) ([]map[string]any, error) {
+	var (
+		results [][]map[string]any = make([][]map[string]any, len(sc.shards))
+		errs    []error            = make([]error, len(sc.shards))
+	)
+	sc.each(func(i int, shard *Cache) {
+		results[i], errs[i] = fn(shard)
+	})
+
+	// Merge the results
+	if sp.Limit == 0 {
+		sp.Limit = 10
+	}
+	var (
+		result  []map[string]any = []map[string]any{}
+		timeout error
+	)
+	for i := range results {
+		if errs[i] != nil && !errors.Is(errs[i], ErrSearchTimeout) {
+			return []map[string]any{}, errs[i]
+		} else if errs[i] != nil && timeout == nil {
+			timeout = errs[i]
+		}
+		result = append(result, results[i]...)
+	}
+	if len(sp.SortBy) > 0 {
+		return sortValues(result, sp, sp.Limit), timeout
+	} else if len(result) > sp.Limit {
+		result = result[:sp.Limit]
+	}
+	return result, timeout
+}
@@ -0,0 +1,196 @@
+package hermes
+
+import (
+	"errors"
+	"log"
+	"time"
+)
+
+// Sink is an interface of a backing store that receives the writes of a cache, such as a database.
+// Set is called with the new value of a key every time it's set, and Delete every time a key is removed,
+// including when it expires. Clean doesn't remove keys from the sinks.
+type Sink interface {
+	Set(key string, value map[string]any) error
+	Delete(key string) error
+}
+
+// SinkOptions is a struct that contains the options of a sink added with AddSink.
+//
+// Fields:
+//   - Async (bool): Whether the writes are queued and sent in the background (write-behind), instead of while the write holds the lock (write-through).
+//   - BufferSize (int): The number of writes that can be queued before writes wait for the queue. Defaults to 1024. Only used if Async is true.
+//   - MaxRetries (int): The number of times a failed write is retried. Only used if Async is true.
+//   - RetryDelay (time.Duration): The delay before the first retry, which is doubled for every following retry. Defaults to 100ms.
+//   - OnError (func(key string, err error)): The function to call for every write that failed. If nil, the errors are logged.
+type SinkOptions struct {
+	Async      bool
+	BufferSize int
+	MaxRetries int
+	RetryDelay time.Duration
+	OnError    func(key string, err error)
+}
+
+// sink is a struct that sends the writes of a cache to a Sink.
+//
+// Fields:
+//   - sink (Sink): The backing store.
+//   - opts (SinkOptions): The options of the sink.
+//   - queue (chan sinkWrite): The queued writes. If nil, the writes are sent synchronously.
+//   - done (chan struct{}): A channel that is closed once the queue is drained after it's closed.
+type sink struct {
+	sink  Sink
+	opts  SinkOptions
+	queue chan sinkWrite
+	done  chan struct{}
+}
+
+// sinkWrite is a struct that represents a write sent to a sink.
+//
+// Fields:
+//   - key (string): The key that was written.
+//   - value (map[string]any): The new value of the key, or nil if the key was removed.
+type sinkWrite struct {
+	key   string
+	value map[string]any
+}
+
+// AddSink is a method of the Cache struct that mirrors every set and removal of a key to a backing store.
+// Synchronous sinks are called while the write holds the lock of the cache, so they must not call methods of the cache, and an error
+// of a synchronous sink is returned by the set, after the value was stored in the cache. Asynchronous sinks receive the writes in order
+// from a buffered queue, and retry the failed writes. Close waits until the queues are drained, and writes after Close are not sent.
+// Values loaded with the loader set with SetLoader are not sent to the sinks.
+// This method is thread-safe.
+//
+// Parameters:
+//   - s (Sink): The backing store.
+//   - opts (SinkOptions): The options of the sink.
+//
+// Returns:
+//   - error: An error if the sink or the options are invalid.
+func (c *Cache) AddSink(s Sink, opts SinkOptions) error {
+	switch {
+	case s == nil:
+		return errors.New("invalid sink")
+	case opts.BufferSize < 0:
+		return errors.New("invalid buffer size")
+	case opts.MaxRetries < 0:
+		return errors.New("invalid max retries")
+	case opts.RetryDelay < 0:
+		return errors.New("invalid retry delay")
+	}
+
+	// Set the default options
+	if opts.BufferSize == 0 {
+		opts.BufferSize = 1024
+	}
+	if opts.RetryDelay == 0 {
+		opts.RetryDelay = 100 * time.Millisecond
+	}
+
+	// Start the background writer of an asynchronous sink
+	var sk *sink = &sink{sink: s, opts: opts}
+	if opts.Async {
+		sk.queue = make(chan sinkWrite, opts.BufferSize)
+		sk.done = make(chan struct{})
+		go sk.run()
+	}
+
+	// Add the sink
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.sinks = append(c.sinks, sk)
+	return nil
+}
+
+// sinkSend is a method of the Cache struct that sends a write to every sink.
+// This method is not thread-safe, and should only be called from an exported function.
+//
+// Parameters:
+//   - key (string): The key that was written.
+//   - value (map[string]any): The new value of the key, or nil if the key was removed.
+//
+// Returns:
+//   - error: The first error returned by a synchronous sink.
+func (c *Cache) sinkSend(key string, value map[string]any) error {
+	var err error
+	for _, sk := range c.sinks {
+		var w sinkWrite = sinkWrite{key: key, value: value}
+		if sk.queue != nil {
+			sk.queue <- w
+		} else if e := sk.write(w); e != nil {
+			sk.report(key, e)
+			if err == nil {
+				err = e
+			}
+		}
+	}
+	return err
+}
+
+// sinkClose is a method of the Cache struct that stops sending writes to the sinks, and waits until the queued writes are sent.
+// This method acquires the lock of the cache, so it must be called without holding it.
+//
+// Returns:
+//   - None
+func (c *Cache) sinkClose() {
+	c.mutex.Lock()
+	var sinks []*sink = c.sinks
+	c.sinks = nil
+	c.mutex.Unlock()
+
+	// Drain the queues outside of the lock
+	for _, sk := range sinks {
+		if sk.queue != nil {
+			close(sk.queue)
+			<-sk.done
+		}
+	}
+}
+
+// run is a method of the sink struct that sends the queued writes, retrying the failed ones, until the queue is closed.
+//
+// Returns:
+//   - None
+func (sk *sink) run() {
+	defer close(sk.done)
+	for w := range sk.queue {
+		var err error = sk.write(w)
+		for retry := 0; err != nil && retry < sk.opts.MaxRetries; retry++ {
+			time.Sleep(sk.opts.RetryDelay << retry)
+			err = sk.write(w)
+		}
+		if err != nil {
+			sk.report(w.key, err)
+		}
+	}
+}
+
+// write is a method of the sink struct that sends a write to the backing store.
+//
+// Parameters:
+//   - w (sinkWrite): The write.
+//
+// Returns:
+//   - error: The error returned by the backing store.
+func (sk *sink) write(w sinkWrite) error {
+	if w.value == nil {
+		return sk.sink.Delete(w.key)
+	}
+	return sk.sink.Set(w.key, w.value)
+}
+
+// report is a method of the sink struct that reports a failed write.
+//
+// Parameters:
+//   - key (string): The key of the failed write.
+//   - err (error): The error of the write.
+//
+// Returns:
+//   - None
+func (sk *sink) report(key string, err error) {
+	if sk.opts.OnError != nil {
+		sk.opts.OnError(key, err)
+	} else {
+		log.Println("hermes: sink:", key, err)
+	}
+}
@@ -0,0 +1,85 @@
+package hermes
+
+import (
+	"errors"
+)
+
+// SetExpectedSize is a method of the Cache struct that sets the number of values the cache is expected to hold, so its maps are
+// allocated for that many values up front instead of growing while they're loaded. Growing a map copies it and leaves the previous
+// copy to the garbage collector, so pre-sizing the maps cuts the pauses of loading millions of values with FTInitWithMap,
+// FTInitWithJson or ImportCSV. The maps are sized for the values of the cache, its versions and the keys of the full-text index,
+// and are resized at once if the cache already holds values. When the hint is set, ImportCSV also sizes the maps for the rows of
+// the file before setting them. The memory of the maps is allocated even if fewer values are set.
+// This method is thread-safe.
+//
+// Parameters:
+//   - n (int): The expected number of values, or 0 to stop pre-sizing the maps.
+//
+// Returns:
+//   - error: An error if the number is negative.
+func (c *Cache) SetExpectedSize(n int) error {
+	if n < 0 {
+		return errors.New("invalid expected size")
+	}
+
+	// Lock the mutex
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	// Size the maps for the expected values
+	c.expected = n
+	c.reserve(n)
+	return nil
+}
+
+// reserve is a method of the Cache struct that resizes the maps of the values, their versions and the keys of the full-text index
+// so they can hold n values without growing. The maps that already hold n values or more are kept.
+// This method is not thread-safe, and should only be called from an exported function.
+//
+// Parameters:
+//   - n (int): The number of values.
+//
+// Returns:
+//   - None
+func (c *Cache) reserve(n int) {
+	if n <= len(c.data) {
+		return
+	}
+	c.data = resized(c.data, n)
+	c.versions = resized(c.versions, n)
+	if c.ft != nil {
+		c.ft.indices = resized(c.ft.indices, n)
+		c.ft.keys = resized(c.ft.keys, n)
+		c.ft.fields = resized(c.ft.fields, n)
+	}
+}
+
+// sizeHint is a method of the Cache struct that returns the number of values to allocate a map for.
+//
+// Parameters:
+//   - n (int): The number of values the map is known to hold.
+//
+// Returns:
+//   - int: The larger of n and the expected size of the cache.
+func (c *Cache) sizeHint(n int) int {
+	if c.expected > n {
+		return c.expected
+	}
+	return n
+}
+
+// resized is a function that copies a map into a new map allocated for n entries.
+//
+// Parameters:
+//   - m (map[K]V): The map.
+//   - n (int): The number of entries to allocate the new map for.
+//
+// Returns:
+//   - map[K]V: The new map.
+func resized[K comparable, V any](m map[K]V, n int) map[K]V {
+	var result map[K]V = make(map[K]V, n)
+	for k, v := range m {
+		result[k] = v
+	}
+	return result
+}
@@ -0,0 +1,228 @@
+package hermes
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// The format version of the snapshots written by this version of the cache.
+// Snapshots without a format version were written before it was recorded, and have the same format as version 1.
+const snapshotFormat int = 1
+
+// ErrIncompatibleSnapshot is the error returned when a snapshot can't be loaded because it's corrupted, was written by a newer version
+// of the cache, or its full-text index was built with a schema or analyzer configuration that differs from the running one.
+// The returned errors wrap it with a description of the difference, so they can be matched with errors.Is.
+var ErrIncompatibleSnapshot = errors.New("incompatible snapshot")
+
+// verify is a method of the snapshot struct that verifies that the snapshot can be loaded into a cache whose full-text index
+// has the running configuration.
+//
+// Parameters:
+//   - running (*FullText): The running full-text index, or nil if it's not initialized.
+//
+// Returns:
+//   - error: An error wrapping ErrIncompatibleSnapshot if the snapshot can't be loaded.
+func (s *snapshot) verify(running *FullText) error {
+	if s.Format > snapshotFormat {
+		return fmt.Errorf("%w: snapshot format %d is newer than the supported format %d", ErrIncompatibleSnapshot, s.Format, snapshotFormat)
+	} else if s.FullText == nil {
+		return nil
+	} else if err := s.FullText.verify(); err != nil {
+		return err
+	}
+
+	// Verify that the full-text index only references keys of the data
+	for _, key := range s.FullText.Indices {
+		if _, ok := s.Data[key]; !ok {
+			return fmt.Errorf("%w: the full-text index references key %s that is not in the snapshot data", ErrIncompatibleSnapshot, key)
+		}
+	}
+
+	// Verify that the full-text configuration matches the running one
+	if running == nil {
+		return nil
+	}
+	return running.snapshot().compatible(s.FullText)
+}
+
+// verify is a method of the ftSnapshot struct that verifies that the schema and analyzer configuration of the snapshot
+// match the hashes that were recorded when it was written.
+//
+// Returns:
+//   - error: An error wrapping ErrIncompatibleSnapshot if a hash doesn't match.
+func (s *ftSnapshot) verify() error {
+	if len(s.SchemaHash) > 0 && s.SchemaHash != schemaHash(s.Schema) {
+		return fmt.Errorf("%w: the schema of the full-text index doesn't match its recorded hash", ErrIncompatibleSnapshot)
+	} else if len(s.AnalyzerHash) > 0 && s.AnalyzerHash != s.analyzerHash() {
+		return fmt.Errorf("%w: the analyzer configuration of the full-text index doesn't match its recorded hash", ErrIncompatibleSnapshot)
+	} else if _, ok := stemmers[s.Stemmer]; !ok && s.Stemmer != StemNone {
+		return fmt.Errorf("%w: unknown stemmer %d", ErrIncompatibleSnapshot, s.Stemmer)
+	} else if !validAnalyzer(s.Analyzer) {
+		return fmt.Errorf("%w: unknown analyzer %d", ErrIncompatibleSnapshot, s.Analyzer)
+	}
+	for field, analyzer := range s.FieldAnalyzers {
+		if !validAnalyzer(analyzer) {
+			return fmt.Errorf("%w: unknown analyzer %d of field %s", ErrIncompatibleSnapshot, analyzer, field)
+		}
+	}
+	return nil
+}
+
+// compatible is a method of the ftSnapshot struct that verifies that a snapshot of a full-text index being loaded has the same schema
+// and analyzer configuration as the snapshot of the running index, so that it stores the same words for the same values.
+//
+// Parameters:
+//   - loaded (*ftSnapshot): The snapshot being loaded.
+//
+// Returns:
+//   - error: An error wrapping ErrIncompatibleSnapshot that describes the first difference, or nil if there is none.
+func (s *ftSnapshot) compatible(loaded *ftSnapshot) error {
+	// Compare the schemas
+	for _, field := range sortedFields(s.Schema, loaded.Schema) {
+		if s.Schema[field] != loaded.Schema[field] {
+			var in, notIn string = "snapshot", "running cache"
+			if s.Schema[field] {
+				in, notIn = notIn, in
+			}
+			return fmt.Errorf("%w: field %s is full-text indexed by the schema of the %s but not of the %s", ErrIncompatibleSnapshot, field, in, notIn)
+		}
+	}
+
+	// Compare the analyzer configurations
+	if s.MinWordLength != loaded.MinWordLength {
+		return fmt.Errorf("%w: the minimum word length of the snapshot is %d, but the running one is %d", ErrIncompatibleSnapshot,
+			loaded.MinWordLength, s.MinWordLength)
+	} else if s.MaxWordLength != loaded.MaxWordLength {
+		return fmt.Errorf("%w: the maximum word length of the snapshot is %d, but the running one is %d", ErrIncompatibleSnapshot,
+			loaded.MaxWordLength, s.MaxWordLength)
+	} else if s.SkipNumbers != loaded.SkipNumbers {
+		return fmt.Errorf("%w: the snapshot skips numeric words: %t, but the running cache: %t", ErrIncompatibleSnapshot,
+			loaded.SkipNumbers, s.SkipNumbers)
+	}
+	var fields []string = []string{}
+	for field := range s.TokenRules {
+		fields = append(fields, field)
+	}
+	for field := range loaded.TokenRules {
+		if _, ok := s.TokenRules[field]; !ok {
+			fields = append(fields, field)
+		}
+	}
+	sort.Strings(fields)
+	for _, field := range fields {
+		if s.TokenRules[field] != loaded.TokenRules[field] {
+			return fmt.Errorf("%w: the token rule of field %s is %d in the snapshot, but %d in the running cache", ErrIncompatibleSnapshot,
+				field, loaded.TokenRules[field], s.TokenRules[field])
+		}
+	}
+	if s.Stemmer != loaded.Stemmer {
+		return fmt.Errorf("%w: the stemmer of the snapshot is %d, but the running one is %d", ErrIncompatibleSnapshot, loaded.Stemmer, s.Stemmer)
+	} else if strings.Join(s.StopWords, " ") != strings.Join(loaded.StopWords, " ") {
+		return fmt.Errorf("%w: the stop words of the snapshot differ from the running ones", ErrIncompatibleSnapshot)
+	} else if s.Analyzer != loaded.Analyzer {
+		return fmt.Errorf("%w: the analyzer of the snapshot is %d, but the running one is %d", ErrIncompatibleSnapshot, loaded.Analyzer, s.Analyzer)
+	}
+	fields = fields[:0]
+	for field := range s.FieldAnalyzers {
+		fields = append(fields, field)
+	}
+	for field := range loaded.FieldAnalyzers {
+		if _, ok := s.FieldAnalyzers[field]; !ok {
+			fields = append(fields, field)
+		}
+	}
+	sort.Strings(fields)
+	for _, field := range fields {
+		if s.FieldAnalyzers[field] != loaded.FieldAnalyzers[field] {
+			return fmt.Errorf("%w: the analyzer of field %s is %d in the snapshot, but %d in the running cache", ErrIncompatibleSnapshot,
+				field, loaded.FieldAnalyzers[field], s.FieldAnalyzers[field])
+		}
+	}
+	return nil
+}
+
+// analyzerHash is a method of the ftSnapshot struct that returns a hash of the configuration that decides which words are stored for a value.
+//
+// Returns:
+//   - string: The hex-encoded SHA-256 hash of the configuration.
+func (s *ftSnapshot) analyzerHash() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "min_word_length=%d;", s.MinWordLength)
+	if s.MaxWordLength > 0 {
+		fmt.Fprintf(&b, "max_word_length=%d;", s.MaxWordLength)
+	}
+	if s.SkipNumbers {
+		b.WriteString("skip_numbers;")
+	}
+	var fields []string = make([]string, 0, len(s.TokenRules))
+	for field := range s.TokenRules {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	for _, field := range fields {
+		fmt.Fprintf(&b, "token_rule:%q=%d;", field, s.TokenRules[field])
+	}
+	if s.Stemmer != StemNone {
+		fmt.Fprintf(&b, "stemmer=%d;", s.Stemmer)
+	}
+	if len(s.StopWords) > 0 {
+		fmt.Fprintf(&b, "stop_words=%q;", s.StopWords)
+	}
+	if s.Analyzer != AnalyzerNone {
+		fmt.Fprintf(&b, "analyzer=%d;", s.Analyzer)
+	}
+	fields = fields[:0]
+	for field := range s.FieldAnalyzers {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	for _, field := range fields {
+		fmt.Fprintf(&b, "analyzer:%q=%d;", field, s.FieldAnalyzers[field])
+	}
+	var sum [sha256.Size]byte = sha256.Sum256([]byte(b.String()))
+	return hex.EncodeToString(sum[:])
+}
+
+// schemaHash is a function that returns a hash of the fields that a full-text schema indexes.
+//
+// Parameters:
+//   - schema (map[string]bool): The schema.
+//
+// Returns:
+//   - string: The hex-encoded SHA-256 hash of the schema.
+func schemaHash(schema map[string]bool) string {
+	var b strings.Builder
+	for _, field := range sortedFields(schema, nil) {
+		if schema[field] {
+			fmt.Fprintf(&b, "%q;", field)
+		}
+	}
+	var sum [sha256.Size]byte = sha256.Sum256([]byte(b.String()))
+	return hex.EncodeToString(sum[:])
+}
+
+// sortedFields is a function that returns the sorted fields of two schemas, without duplicates.
+//
+// Parameters:
+//   - a (map[string]bool): The first schema.
+//   - b (map[string]bool): The second schema.
+//
+// Returns:
+//   - []string: The sorted fields.
+func sortedFields(a map[string]bool, b map[string]bool) []string {
+	var fields []string = make([]string, 0, len(a)+len(b))
+	for field := range a {
+		fields = append(fields, field)
+	}
+	for field := range b {
+		if _, ok := a[field]; !ok {
+			fields = append(fields, field)
+		}
+	}
+	sort.Strings(fields)
+	return fields
+}
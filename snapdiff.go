@@ -0,0 +1,184 @@
+package hermes
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// SnapshotDiff is a struct that contains the differences between two snapshot files, such as a backup and the snapshot it was taken from,
+// or the snapshots of two releases of a dataset.
+//
+// Fields:
+//   - Added ([]string): The keys of the values that are only in the second snapshot, sorted.
+//   - Removed ([]string): The keys of the values that are only in the first snapshot, sorted.
+//   - Changed (map[string][]string): The sorted fields that were added, removed or modified, for each key that is in both snapshots
+//     with a different value. Values are compared by their JSON encoding, so snapshots written with different codecs can be compared.
+//   - WordsAdded ([]string): The words that are only stored in the full-text index of the second snapshot, sorted.
+//   - WordsRemoved ([]string): The words that are only stored in the full-text index of the first snapshot, sorted.
+//   - VocabularyDrift (float64): The number of words stored in only one of the full-text indexes divided by the number of words stored
+//     in either, from 0 for the same vocabulary to 1 for disjoint vocabularies. It's 0 if neither snapshot has a full-text index.
+//   - Config (error): An error wrapping ErrIncompatibleSnapshot that describes the first difference between the full-text schemas and
+//     analyzer configurations, worded as if the second snapshot was loaded into a cache with the configuration of the first, or nil if
+//     they're the same or one of the snapshots has no full-text index.
+type SnapshotDiff struct {
+	Added           []string
+	Removed         []string
+	Changed         map[string][]string
+	WordsAdded      []string
+	WordsRemoved    []string
+	VocabularyDrift float64
+	Config          error
+}
+
+// String is a method of the SnapshotDiff struct that returns a one-line summary of the differences.
+//
+// Returns:
+//   - string: The summary of the differences.
+func (d SnapshotDiff) String() string {
+	var config string = "same full-text configuration"
+	if d.Config != nil {
+		config = d.Config.Error()
+	}
+	return fmt.Sprintf("%d added, %d removed, %d changed, %d words added, %d words removed, vocabulary drift %.3f, %s",
+		len(d.Added), len(d.Removed), len(d.Changed), len(d.WordsAdded), len(d.WordsRemoved), d.VocabularyDrift, config)
+}
+
+// Empty is a method of the SnapshotDiff struct that returns whether the snapshots hold the same values and full-text index.
+//
+// Returns:
+//   - bool: true if no value or word was added, removed or changed and the full-text configurations are the same, false otherwise.
+func (d SnapshotDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0 &&
+		len(d.WordsAdded) == 0 && len(d.WordsRemoved) == 0 && d.Config == nil
+}
+
+// DiffSnapshots is a function that compares two snapshot files written by Save or SaveSnapshot, with any codec,
+// and reports the values that were added, removed and changed, and how the vocabulary of the full-text index drifted.
+// Both snapshots are read into memory, so comparing large snapshots needs as much memory as loading them twice.
+//
+// Parameters:
+//   - a (string): The path of the first snapshot file, e.g. the older one.
+//   - b (string): The path of the second snapshot file, e.g. the newer one.
+//
+// Returns:
+//   - SnapshotDiff: The differences between the snapshots.
+//   - error: An error if a snapshot could not be read or decoded, or an error wrapping ErrIncompatibleSnapshot if a snapshot
+//     was written by a newer version or is corrupted.
+func DiffSnapshots(a string, b string) (SnapshotDiff, error) {
+	sa, err := readSnapshot(a)
+	if err != nil {
+		return SnapshotDiff{}, err
+	}
+	sb, err := readSnapshot(b)
+	if err != nil {
+		return SnapshotDiff{}, err
+	}
+
+	// Compare the values
+	var d SnapshotDiff = SnapshotDiff{Added: []string{}, Removed: []string{}, Changed: map[string][]string{}}
+	for key, va := range sa.Data {
+		if vb, ok := sb.Data[key]; !ok {
+			d.Removed = append(d.Removed, key)
+		} else if fields := changedFields(va, vb); len(fields) > 0 {
+			d.Changed[key] = fields
+		}
+	}
+	for key := range sb.Data {
+		if _, ok := sa.Data[key]; !ok {
+			d.Added = append(d.Added, key)
+		}
+	}
+	sort.Strings(d.Added)
+	sort.Strings(d.Removed)
+
+	// Compare the vocabularies and configurations of the full-text indexes
+	var wordsA, wordsB map[string]any
+	if sa.FullText != nil {
+		wordsA = sa.FullText.Storage
+	}
+	if sb.FullText != nil {
+		wordsB = sb.FullText.Storage
+	}
+	d.WordsAdded, d.WordsRemoved = []string{}, []string{}
+	for word := range wordsB {
+		if _, ok := wordsA[word]; !ok {
+			d.WordsAdded = append(d.WordsAdded, word)
+		}
+	}
+	for word := range wordsA {
+		if _, ok := wordsB[word]; !ok {
+			d.WordsRemoved = append(d.WordsRemoved, word)
+		}
+	}
+	sort.Strings(d.WordsAdded)
+	sort.Strings(d.WordsRemoved)
+	if union := len(wordsA) + len(d.WordsAdded); union > 0 {
+		d.VocabularyDrift = float64(len(d.WordsAdded)+len(d.WordsRemoved)) / float64(union)
+	}
+	if sa.FullText != nil && sb.FullText != nil {
+		d.Config = sa.FullText.compatible(sb.FullText)
+	}
+	return d, nil
+}
+
+// readSnapshot is a function that reads and decodes a snapshot file, and verifies that it can be loaded.
+//
+// Parameters:
+//   - path (string): The path of the snapshot file.
+//
+// Returns:
+//   - *snapshot: The snapshot.
+//   - error: An error if the snapshot could not be read or decoded, or an error wrapping ErrIncompatibleSnapshot if it can't be loaded.
+func readSnapshot(path string) (*snapshot, error) {
+	var s snapshot
+	if data, err := os.ReadFile(filepath.Clean(path)); err != nil {
+		return nil, err
+	} else if err := decodeSnapshot(data, &s); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	} else if err := s.verify(nil); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return &s, nil
+}
+
+// changedFields is a function that returns the fields that differ between two values, comparing their JSON encoding.
+//
+// Parameters:
+//   - a (map[string]any): The first value.
+//   - b (map[string]any): The second value.
+//
+// Returns:
+//   - []string: The sorted fields that are in only one of the values or hold different values.
+func changedFields(a map[string]any, b map[string]any) []string {
+	var fields []string = []string{}
+	for field, va := range a {
+		if vb, ok := b[field]; !ok || !sameJson(va, vb) {
+			fields = append(fields, field)
+		}
+	}
+	for field := range b {
+		if _, ok := a[field]; !ok {
+			fields = append(fields, field)
+		}
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// sameJson is a function that checks whether two values have the same JSON encoding.
+// The keys of the maps are sorted by the encoder, so maps with the same entries have the same encoding.
+//
+// Parameters:
+//   - a (any): The first value.
+//   - b (any): The second value.
+//
+// Returns:
+//   - bool: true if the encodings are equal, or false if they differ or a value can't be encoded.
+func sameJson(a any, b any) bool {
+	ja, errA := json.Marshal(a)
+	jb, errB := json.Marshal(b)
+	return errA == nil && errB == nil && string(ja) == string(jb)
+}
@@ -0,0 +1,332 @@
+package hermes
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// snapshot is a struct that represents the on-disk state of a cache.
+//
+// Fields:
+//   - Data (map[string]map[string]any): The cache data.
+//   - FullText (*ftSnapshot): The full-text index. If nil, the full-text index was not initialized.
+//   - Expiries (map[string]time.Time): The time at which each key with a time to live expires.
+//   - Namespaces ([]string): The names of the created namespaces.
+//   - Format (int): The format version of the snapshot. If 0, the snapshot was written before the format version was recorded.
+//   - Queries ([]SearchParams): The searches kept by the query cache, from the most to the least popular, without their results.
+type snapshot struct {
+	Format     int                       `json:"format,omitempty"`
+	Data       map[string]map[string]any `json:"data"`
+	FullText   *ftSnapshot               `json:"full_text,omitempty"`
+	Expiries   map[string]time.Time      `json:"expiries,omitempty"`
+	Namespaces []string                  `json:"namespaces,omitempty"`
+	Queries    []SearchParams            `json:"queries,omitempty"`
+}
+
+// ftSnapshot is a struct that represents the on-disk state of a full-text index.
+// See the FullText struct for a description of each field. The schema and analyzer hashes are recorded when
+// the snapshot is written, to detect a corrupted configuration when it's loaded.
+type ftSnapshot struct {
+	Storage        map[string]any       `json:"storage"`
+	Indices        map[int]string       `json:"indices"`
+	Index          int                  `json:"index"`
+	MaxSize        int                  `json:"max_size"`
+	MaxBytes       int                  `json:"max_bytes"`
+	MinWordLength  int                  `json:"min_word_length"`
+	MaxWordLength  int                  `json:"max_word_length,omitempty"`
+	SkipNumbers    bool                 `json:"skip_numbers,omitempty"`
+	CaseSensitive  bool                 `json:"case_sensitive,omitempty"`
+	Schema         map[string]bool      `json:"schema,omitempty"`
+	Fields         map[string][]string  `json:"fields,omitempty"`
+	FieldStorage   fieldIndex           `json:"field_storage,omitempty"`
+	TokenRules     map[string]TokenRule `json:"token_rules,omitempty"`
+	Stemmer        Stemmer              `json:"stemmer,omitempty"`
+	StopWords      []string             `json:"stop_words,omitempty"`
+	Analyzer       Analyzer             `json:"analyzer,omitempty"`
+	FieldAnalyzers map[string]Analyzer  `json:"field_analyzers,omitempty"`
+	NgramSize      int                  `json:"ngram_size,omitempty"`
+	SuffixIndex    bool                 `json:"suffix_index,omitempty"`
+	Partitions     int                  `json:"partitions,omitempty"`
+	FieldWeights   map[string]float64   `json:"field_weights,omitempty"`
+	SchemaHash     string               `json:"schema_hash,omitempty"`
+	AnalyzerHash   string               `json:"analyzer_hash,omitempty"`
+}
+
+// Save is a method of the Cache struct that writes a snapshot of the cache data and full-text index to the provided file.
+// The snapshot is written to a temporary file first and then renamed, so an existing snapshot is never left half-written.
+// This method is thread-safe.
+//
+// Parameters:
+//   - path (string): The path of the snapshot file.
+//
+// Returns:
+//   - error: An error if the snapshot could not be encoded or written.
+func (c *Cache) Save(path string) error {
+	c.mutex.RLock()
+	data, err := c.codec.encode(c.snapshot())
+	c.mutex.RUnlock()
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(path, data)
+}
+
+// snapshot is a method of the Cache struct that returns the current state of the cache as a snapshot.
+// The returned snapshot shares its maps with the cache, so it must be encoded before the lock is released.
+// This method is not thread-safe, and should only be called from an exported function.
+//
+// Returns:
+//   - *snapshot: The snapshot of the cache.
+func (c *Cache) snapshot() *snapshot {
+	var s *snapshot = &snapshot{
+		Format:   snapshotFormat,
+		Data:     c.data,
+		Expiries: c.expiries,
+		Queries:  c.queries.popular(),
+	}
+	if c.compressAt > 0 {
+		s.Data = make(map[string]map[string]any, len(c.data))
+		for key, value := range c.data {
+			s.Data[key] = c.expand(value)
+		}
+	}
+	for ns := range c.namespaces {
+		s.Namespaces = append(s.Namespaces, ns)
+	}
+	if c.ft != nil {
+		s.FullText = c.ft.snapshot()
+	}
+	return s
+}
+
+// snapshot is a method of the FullText struct that returns the current state of the full-text index as a snapshot.
+// The returned snapshot shares its maps with the index, so it must be encoded before the lock is released.
+//
+// Returns:
+//   - *ftSnapshot: The snapshot of the full-text index.
+func (ft *FullText) snapshot() *ftSnapshot {
+	var s *ftSnapshot = &ftSnapshot{
+		Storage:        ft.storage,
+		Indices:        ft.indices,
+		Index:          ft.index,
+		MaxSize:        ft.maxSize,
+		MaxBytes:       ft.maxBytes,
+		MinWordLength:  ft.minWordLength,
+		MaxWordLength:  ft.maxWordLength,
+		SkipNumbers:    ft.skipNumbers,
+		CaseSensitive:  ft.caseSensitive,
+		Schema:         ft.schema,
+		Fields:         ft.fields,
+		FieldStorage:   ft.fieldStorage,
+		TokenRules:     ft.tokenRules,
+		Stemmer:        ft.stemmer,
+		StopWords:      sortedStopWords(ft.stopWords),
+		Analyzer:       ft.analyzer,
+		FieldAnalyzers: ft.analyzers,
+		NgramSize:      ft.ngrams.size(),
+		SuffixIndex:    ft.suffixes.enabled(),
+		Partitions:     ft.partitions.count(),
+		FieldWeights:   ft.weights,
+		SchemaHash:     schemaHash(ft.schema),
+	}
+	s.AnalyzerHash = s.analyzerHash()
+	return s
+}
+
+// Load is a method of the Cache struct that replaces the cache data and full-text index with the contents of a snapshot file.
+// This method is thread-safe.
+//
+// Parameters:
+//   - path (string): The path of the snapshot file.
+//
+// Returns:
+//   - error: An error if the snapshot could not be read, decoded, or violates a unique constraint, or an error wrapping
+//     ErrIncompatibleSnapshot if it was written by a newer version, is corrupted, or its full-text configuration differs from the running one.
+func (c *Cache) Load(path string) error {
+	var s snapshot
+	if data, err := os.ReadFile(filepath.Clean(path)); err != nil {
+		return err
+	} else if err := decodeSnapshot(data, &s); err != nil {
+		return err
+	}
+
+	// Lock the mutex
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	// Load the snapshot into the cache
+	return c.load(&s)
+}
+
+// load is a method of the Cache struct that replaces the cache data and full-text index with the provided snapshot.
+// This method is not thread-safe, and should only be called from an exported function.
+//
+// Parameters:
+//   - s (*snapshot): The snapshot to load.
+//
+// Returns:
+//   - error: An error if the snapshot is invalid, incompatible with the cache, or violates a unique constraint.
+func (c *Cache) load(s *snapshot) error {
+	if s.Data == nil {
+		s.Data = make(map[string]map[string]any)
+	}
+
+	// Verify that the cache isn't frozen and the snapshot is compatible with it
+	if err := c.writable("Load"); err != nil {
+		return err
+	} else if err := s.verify(c.ft); err != nil {
+		return err
+	}
+
+	// Rebuild the unique constraint indexes
+	uniques, err := c.uniqueBuild(s.Data)
+	if err != nil {
+		return err
+	}
+
+	// Restore the full-text index
+	var ft *FullText = nil
+	if s.FullText != nil {
+		if ft, err = s.FullText.fullText(); err != nil {
+			return err
+		}
+	}
+
+	// Update the cache variables
+	c.data = s.Data
+	c.ft = ft
+	if ft != nil {
+		c.buildFieldIndex(ft)
+	}
+	c.uniques = uniques
+	c.keyTrie = keyTrie(s.Data)
+	c.indexRebuild(s.Data)
+	c.namedRebuild()
+	c.generation++
+	c.versionsReset(s.Data)
+	for key, value := range s.Data {
+		s.Data[key] = c.compress(value)
+	}
+
+	// Restore the expiries of the keys
+	c.expiries = make(map[string]time.Time, len(s.Expiries))
+	for key, expiry := range s.Expiries {
+		if _, ok := s.Data[key]; ok {
+			c.expire(key, expiry)
+		}
+	}
+
+	// Restore the namespaces
+	c.namespaces = make(map[string]bool, len(s.Namespaces))
+	for _, ns := range s.Namespaces {
+		c.namespaces[ns] = true
+	}
+
+	// Run the popular searches again once the lock is released
+	if queries := c.queries.restore(s.Queries); len(queries) > 0 {
+		go c.warmQueries(queries)
+	}
+
+	// Return no error
+	return nil
+}
+
+// fullText is a method of the ftSnapshot struct that converts the snapshot into a FullText index.
+// JSON decodes the storage values as float64 and []any, so they are converted back to int and []int.
+// If the snapshot was written before the field index was recorded, the field index is nil and is built with buildFieldIndex
+// once the cache data is loaded.
+//
+// Returns:
+//   - *FullText: The full-text index.
+//   - error: An error if a storage value has an invalid type.
+func (s *ftSnapshot) fullText() (*FullText, error) {
+	var ft *FullText = &FullText{
+		storage:       make(map[string]any, len(s.Storage)),
+		indices:       s.Indices,
+		index:         s.Index,
+		maxSize:       s.MaxSize,
+		maxBytes:      s.MaxBytes,
+		minWordLength: s.MinWordLength,
+		maxWordLength: s.MaxWordLength,
+		skipNumbers:   s.SkipNumbers,
+		caseSensitive: s.CaseSensitive,
+		schema:        s.Schema,
+		fields:        s.Fields,
+		fieldStorage:  s.FieldStorage,
+		tokenRules:    s.TokenRules,
+		stemmer:       s.Stemmer,
+		analyzer:      s.Analyzer,
+		analyzers:     s.FieldAnalyzers,
+		weights:       s.FieldWeights,
+	}
+	if len(s.StopWords) > 0 {
+		ft.stopWords = make(map[string]bool, len(s.StopWords))
+		for _, w := range s.StopWords {
+			ft.stopWords[w] = true
+		}
+	}
+	if ft.indices == nil {
+		ft.indices = make(map[int]string)
+	}
+	ft.keys = make(map[string]int, len(ft.indices))
+	for index, key := range ft.indices {
+		ft.keys[key] = index
+	}
+	if ft.fields == nil {
+		ft.fields = make(map[string][]string)
+	}
+
+	// Convert the storage values
+	for word, value := range s.Storage {
+		switch v := value.(type) {
+		case int:
+			ft.storage[word] = v
+		case []int:
+			ft.storage[word] = postingsValue(v)
+		case *roaring:
+			ft.storage[word] = postingsValue(v.slice())
+		case float64:
+			ft.storage[word] = int(v)
+		case []any:
+			var indices []int = make([]int, 0, len(v))
+			for _, index := range v {
+				if index, ok := index.(float64); !ok {
+					return nil, errors.New("invalid full-text snapshot storage")
+				} else {
+					indices = append(indices, int(index))
+				}
+			}
+			ft.storage[word] = postingsValue(indices)
+		default:
+			return nil, errors.New("invalid full-text snapshot storage")
+		}
+	}
+
+	// Rebuild the n-gram and suffix indexes, the word partitions and the vocabulary
+	ft.ngrams = newNgramIndex(s.NgramSize)
+	ft.ngrams.build(ft.storage)
+	ft.suffixes = newSuffixIndex(s.SuffixIndex)
+	ft.suffixes.build(ft.storage)
+	ft.partitions = newWordPartitions(s.Partitions)
+	ft.partitions.build(ft.storage)
+	ft.vocab = buildTrie(ft.storage)
+	return ft, nil
+}
+
+// writeFileAtomic is a function that writes data to a temporary file and renames it to the provided path.
+//
+// Parameters:
+//   - path (string): The path of the file.
+//   - data ([]byte): The data to write.
+//
+// Returns:
+//   - error: An error if the file could not be written or renamed.
+func writeFileAtomic(path string, data []byte) error {
+	var temp string = path + ".tmp"
+	if err := os.WriteFile(temp, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(temp, path)
+}
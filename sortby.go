@@ -0,0 +1,213 @@
+package hermes
+
+import (
+	"container/heap"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// SortOrder is a type that represents the direction in which the results are sorted by the SortBy field of the search parameters.
+type SortOrder int
+
+const (
+	// SortAscending orders the results from the smallest to the largest value of the field.
+	SortAscending SortOrder = iota
+	// SortDescending orders the results from the largest to the smallest value of the field.
+	SortDescending
+)
+
+// sortKey is a struct that represents the value of the sort field of a result, in a form that is compared with the other results.
+//
+// Fields:
+//   - kind (int): 0 for a number, 1 for a string and 2 for a missing field, which is the order of the kinds whatever the sort order.
+//   - number (float64): The number, for a number, a numeric string or a time.
+//   - text (string): The text, for a string or any other value.
+type sortKey struct {
+	kind   int
+	number float64
+	text   string
+}
+
+// newSortKey is a function that returns the sort key of a field of a value.
+// Numbers and the strings that hold a number, such as the cells of a CSV file, are compared as numbers, times by their instant,
+// and every other value by its text.
+//
+// Parameters:
+//   - value (map[string]any): The value, which may have compressed fields.
+//   - field (string): The sort field, which can be a dot path to a nested field.
+//
+// Returns:
+//   - sortKey: The sort key.
+func newSortKey(value map[string]any, field string) sortKey {
+	var v any = pathValue(value, field)
+	if n, ok := numericValue(v); ok {
+		return sortKey{kind: 0, number: n}
+	} else if t, ok := v.(time.Time); ok {
+		return sortKey{kind: 0, number: float64(t.UnixNano())}
+	} else if s, ok := fieldString(v); ok {
+		return sortKey{kind: 1, text: s}
+	} else if v == nil {
+		return sortKey{kind: 2}
+	}
+	return sortKey{kind: 1, text: fmt.Sprint(v)}
+}
+
+// compare is a method of the sortKey struct that compares two sort keys in a sort order. The missing fields are always last.
+//
+// Parameters:
+//   - other (sortKey): The other sort key.
+//   - order (SortOrder): The sort order.
+//
+// Returns:
+//   - int: A negative number if the key is sorted before the other key, a positive number if it's sorted after, and 0 if they're equal.
+func (k sortKey) compare(other sortKey, order SortOrder) int {
+	var result int
+	switch {
+	case k.kind != other.kind:
+		return k.kind - other.kind
+	case k.kind == 2:
+		return 0
+	case k.kind == 0 && k.number < other.number:
+		result = -1
+	case k.kind == 0 && k.number > other.number:
+		result = 1
+	case k.kind == 1:
+		result = strings.Compare(k.text, other.text)
+	}
+	if order == SortDescending {
+		return -result
+	}
+	return result
+}
+
+// sortValues is a function that sorts the results of a search by the SortBy field of the search parameters, keeping the first results.
+// The results with equal fields keep the order in which they were found.
+//
+// Parameters:
+//   - values ([]map[string]any): The results of the search.
+//   - sp (SearchParams): The search parameters.
+//   - limit (int): The maximum number of results to keep.
+//
+// Returns:
+//   - []map[string]any: The first sorted results.
+func sortValues(values []map[string]any, sp SearchParams, limit int) []map[string]any {
+	var keys []sortKey = make([]sortKey, len(values))
+	for i, value := range values {
+		keys[i] = newSortKey(value, sp.SortBy)
+	}
+	var positions []int = partialSort(len(values), limit, func(i, j int) bool {
+		return keys[i].compare(keys[j], sp.SortOrder) < 0
+	})
+	var result []map[string]any = make([]map[string]any, len(positions))
+	for i, p := range positions {
+		result[i] = values[p]
+	}
+	return result
+}
+
+// partialSort is a function that returns the positions of the first items of a sequence in sorted order, without sorting the other items.
+// The first items are kept in a heap, so sorting n items up to a limit of k takes O(n log k) comparisons. The items that are equal keep their order.
+//
+// Parameters:
+//   - n (int): The number of items.
+//   - limit (int): The number of items to keep. If it's not positive or greater than n, every item is kept.
+//   - less (func(i, j int) bool): The function that reports whether the item at position i is sorted before the item at position j.
+//
+// Returns:
+//   - []int: The positions of the first items, in sorted order.
+func partialSort(n int, limit int, less func(i, j int) bool) []int {
+	if limit <= 0 || limit > n {
+		limit = n
+	}
+
+	// Order the equal items by position, so the order is total
+	var before func(i, j int) bool = func(i, j int) bool {
+		if less(i, j) {
+			return true
+		} else if less(j, i) {
+			return false
+		}
+		return i < j
+	}
+
+	// Keep the first items in a heap whose root is the last of them
+	var h *positionHeap = &positionHeap{positions: make([]int, 0, limit), before: before}
+	for i := 0; i < n; i++ {
+		if len(h.positions) < limit {
+			heap.Push(h, i)
+		} else if limit > 0 && before(i, h.positions[0]) {
+			h.positions[0] = i
+			heap.Fix(h, 0)
+		}
+	}
+	sort.Slice(h.positions, func(a, b int) bool {
+		return before(h.positions[a], h.positions[b])
+	})
+	return h.positions
+}
+
+// positionHeap is a struct that implements heap.Interface for the positions of the items kept by partialSort,
+// with the last of the kept items at its root.
+//
+// Fields:
+//   - positions ([]int): The positions of the kept items.
+//   - before (func(i, j int) bool): The function that reports whether the item at position i is sorted before the item at position j.
+type positionHeap struct {
+	positions []int
+	before    func(i, j int) bool
+}
+
+// Len is a method of the positionHeap struct that returns the number of kept items.
+//
+// Returns:
+//   - int: The number of kept items.
+func (h *positionHeap) Len() int {
+	return len(h.positions)
+}
+
+// Less is a method of the positionHeap struct that orders the kept items from the last to the first.
+//
+// Parameters:
+//   - a (int): The index of an item in the heap.
+//   - b (int): The index of another item in the heap.
+//
+// Returns:
+//   - bool: true if the item at index a is sorted after the item at index b.
+func (h *positionHeap) Less(a int, b int) bool {
+	return h.before(h.positions[b], h.positions[a])
+}
+
+// Swap is a method of the positionHeap struct that swaps two kept items.
+//
+// Parameters:
+//   - a (int): The index of an item in the heap.
+//   - b (int): The index of another item in the heap.
+//
+// Returns:
+//   - None
+func (h *positionHeap) Swap(a int, b int) {
+	h.positions[a], h.positions[b] = h.positions[b], h.positions[a]
+}
+
+// Push is a method of the positionHeap struct that adds the position of an item.
+//
+// Parameters:
+//   - x (any): The position of the item.
+//
+// Returns:
+//   - None
+func (h *positionHeap) Push(x any) {
+	h.positions = append(h.positions, x.(int))
+}
+
+// Pop is a method of the positionHeap struct that removes the position of the last item of the heap.
+//
+// Returns:
+//   - any: The removed position.
+func (h *positionHeap) Pop() any {
+	var last int = h.positions[len(h.positions)-1]
+	h.positions = h.positions[:len(h.positions)-1]
+	return last
+}
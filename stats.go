@@ -0,0 +1,190 @@
+package hermes
+
+import (
+	"sync/atomic"
+	"time"
+
+	utils "github.com/realTristan/hermes/utils"
+)
+
+// Stats is a struct that contains the operation counters and index sizes of a cache.
+// The counters are counted from when the cache was initialized or the stats were last reset.
+//
+// Fields:
+//   - Gets (uint64): The number of Get, GetCopy and GetWithVersion calls.
+//   - Hits (uint64): The number of gets that found the key.
+//   - Misses (uint64): The number of gets that didn't find the key.
+//   - Sets (uint64): The number of successful Set, SetCtx, SetWithTTL, Replace and Update calls.
+//   - Deletes (uint64): The number of existing keys removed with Delete, DeleteMatching and DeleteNamespace.
+//   - Expired (uint64): The number of keys removed because they expired.
+//   - Skipped (uint64): The number of malformed values skipped in lenient mode, see SetRecordMode.
+//   - Duplicates (uint64): The number of duplicate values detected when they were set, see SetDuplicateMode.
+//   - LimitWarnings (uint64): The number of soft-limit warnings sent about the full-text index, see SetLimitWarnings.
+//   - Searches (uint64): The number of Search, SearchCtx, SearchOneWord, SearchValues and SearchWithKey calls.
+//   - AverageSearchLatency (time.Duration): The average duration of a search, including the time spent waiting for the lock.
+//   - GetLatency (Latency): The percentiles of the duration of Get, GetCopy, GetOrLoad and GetWithVersion.
+//   - SetLatency (Latency): The percentiles of the duration of Set, SetCtx, SetWithTTL, Replace and Update.
+//   - StrictSearchLatency (Latency): The percentiles of the duration of the searches with SearchParams.Strict.
+//   - SearchLatency (Latency): The percentiles of the duration of the other searches.
+//   - Keys (int): The number of keys in the cache.
+//   - FTStorageLength (int): The number of words in the full-text index, or 0 if it's not initialized.
+//   - FTStorageSize (int): The size of the full-text index in bytes, or 0 if it's not initialized.
+type Stats struct {
+	Gets                 uint64        `json:"gets"`
+	Hits                 uint64        `json:"hits"`
+	Misses               uint64        `json:"misses"`
+	Sets                 uint64        `json:"sets"`
+	Deletes              uint64        `json:"deletes"`
+	Expired              uint64        `json:"expired"`
+	Skipped              uint64        `json:"skipped"`
+	Duplicates           uint64        `json:"duplicates"`
+	LimitWarnings        uint64        `json:"limit_warnings"`
+	Searches             uint64        `json:"searches"`
+	AverageSearchLatency time.Duration `json:"average_search_latency"`
+	GetLatency           Latency       `json:"get_latency"`
+	SetLatency           Latency       `json:"set_latency"`
+	StrictSearchLatency  Latency       `json:"strict_search_latency"`
+	SearchLatency        Latency       `json:"search_latency"`
+	Keys                 int           `json:"keys"`
+	FTStorageLength      int           `json:"ft_storage_length"`
+	FTStorageSize        int           `json:"ft_storage_size"`
+}
+
+// stats is a struct that holds the operation counters of a cache.
+// The counters are atomic because reads update them while only holding the read lock.
+//
+// Fields:
+//   - See the Stats struct for a description of each counter.
+//   - searchTime (atomic.Int64): The total duration of all searches, in nanoseconds.
+//   - getTimes, setTimes, strictSearchTimes, searchTimes (histogram): The durations of each operation type.
+type stats struct {
+	gets          atomic.Uint64
+	hits          atomic.Uint64
+	misses        atomic.Uint64
+	sets          atomic.Uint64
+	deletes       atomic.Uint64
+	expired       atomic.Uint64
+	skipped       atomic.Uint64
+	duplicates    atomic.Uint64
+	limitWarnings atomic.Uint64
+	searches      atomic.Uint64
+	searchTime    atomic.Int64
+
+	getTimes          histogram
+	setTimes          histogram
+	strictSearchTimes histogram
+	searchTimes       histogram
+}
+
+// Stats is a method of the Cache struct that returns the operation counters and index sizes of the cache.
+// Computing the size of the full-text index requires encoding it, so this method shouldn't be called in a hot path.
+// This method is thread-safe.
+//
+// Returns:
+//   - Stats: The stats of the cache.
+//   - error: An error if the size of the full-text index could not be computed.
+func (c *Cache) Stats() (Stats, error) {
+	var s Stats = c.stats.snapshot()
+
+	// Lock the mutex
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	// Get the index sizes
+	s.Keys = len(c.data)
+	if c.ft != nil {
+		size, err := utils.Size(c.ft.storage)
+		if err != nil {
+			return s, err
+		}
+		s.FTStorageLength = len(c.ft.storage)
+		s.FTStorageSize = size
+	}
+	return s, nil
+}
+
+// ResetStats is a method of the Cache struct that sets all of the operation counters of the cache to zero.
+// This method is thread-safe.
+//
+// Returns:
+//   - None
+func (c *Cache) ResetStats() {
+	c.stats.gets.Store(0)
+	c.stats.hits.Store(0)
+	c.stats.misses.Store(0)
+	c.stats.sets.Store(0)
+	c.stats.deletes.Store(0)
+	c.stats.expired.Store(0)
+	c.stats.skipped.Store(0)
+	c.stats.duplicates.Store(0)
+	c.stats.limitWarnings.Store(0)
+	c.stats.searches.Store(0)
+	c.stats.searchTime.Store(0)
+	c.stats.getTimes.reset()
+	c.stats.setTimes.reset()
+	c.stats.strictSearchTimes.reset()
+	c.stats.searchTimes.reset()
+}
+
+// snapshot is a method of the stats struct that returns the current values of the counters.
+//
+// Returns:
+//   - Stats: The counters. The index sizes are not set.
+func (s *stats) snapshot() Stats {
+	var result Stats = Stats{
+		Gets:          s.gets.Load(),
+		Hits:          s.hits.Load(),
+		Misses:        s.misses.Load(),
+		Sets:          s.sets.Load(),
+		Deletes:       s.deletes.Load(),
+		Expired:       s.expired.Load(),
+		Skipped:       s.skipped.Load(),
+		Duplicates:    s.duplicates.Load(),
+		LimitWarnings: s.limitWarnings.Load(),
+		Searches:      s.searches.Load(),
+
+		GetLatency:          s.getTimes.latency(),
+		SetLatency:          s.setTimes.latency(),
+		StrictSearchLatency: s.strictSearchTimes.latency(),
+		SearchLatency:       s.searchTimes.latency(),
+	}
+	if result.Searches > 0 {
+		result.AverageSearchLatency = time.Duration(s.searchTime.Load() / int64(result.Searches))
+	}
+	return result
+}
+
+// get is a method of the stats struct that counts a get.
+//
+// Parameters:
+//   - hit (bool): Whether the key was found.
+//
+// Returns:
+//   - None
+func (s *stats) get(hit bool) {
+	s.gets.Add(1)
+	if hit {
+		s.hits.Add(1)
+	} else {
+		s.misses.Add(1)
+	}
+}
+
+// search is a method of the stats struct that counts a search that started at the provided time.
+// It's meant to be deferred at the start of a search.
+//
+// Parameters:
+//   - start (time.Time): The time the search started.
+//   - strict (bool): Whether the search is strict, see SearchParams.Strict.
+//
+// Returns:
+//   - None
+func (s *stats) search(start time.Time, strict bool) {
+	s.searches.Add(1)
+	s.searchTime.Add(int64(time.Since(start)))
+	if strict {
+		s.strictSearchTimes.since(start)
+	} else {
+		s.searchTimes.since(start)
+	}
+}
@@ -0,0 +1,127 @@
+package hermes
+
+import (
+	"errors"
+	"strings"
+
+	utils "github.com/realTristan/hermes/utils"
+)
+
+// Stemmer is a type that represents the algorithm that reduces the words of the full-text index to their stem, so that the
+// different forms of a word, such as "running", "runs" and "run", match each other.
+type Stemmer int
+
+const (
+	// StemNone stores the words as they're written.
+	StemNone Stemmer = iota
+	// StemPorter reduces English words to their stem with the Porter algorithm, e.g. "running" to "run" and "connections" to "connect".
+	StemPorter
+	// StemGerman reduces folded German words to their stem with a light stemmer, e.g. "hauser" to "haus".
+	StemGerman
+	// StemFrench reduces folded French words to their stem with a light stemmer, e.g. "gouvernements" to "gouvern".
+	StemFrench
+	// StemSpanish reduces folded Spanish words to their stem with a light stemmer, e.g. "corriendo" to "corr".
+	StemSpanish
+	// StemRussian reduces Russian words to their stem with a light stemmer, e.g. "книгами" to "книг".
+	StemRussian
+)
+
+// stemmers maps each stemmer to the function that reduces a lowercase word to its stem.
+var stemmers map[Stemmer]func(word string) string = map[Stemmer]func(word string) string{
+	StemPorter:  utils.PorterStem,
+	StemGerman:  utils.GermanStem,
+	StemFrench:  utils.FrenchStem,
+	StemSpanish: utils.SpanishStem,
+	StemRussian: utils.RussianStem,
+}
+
+// FTSetStemmer is a method of the Cache struct that sets the algorithm that reduces the words to their stem when values are indexed
+// and when the index is searched, so that a query for "running" also finds the values that contain "run" or "runs".
+// The full-text index is rebuilt, so the stemmer also applies to the values that are already stored.
+// The words returned by FTStorage, FTKeysForWord and Suggest are the stems that are stored in the index.
+// The stemmers of the languages other than English only stem the words without diacritics, see FTSetAnalyzer to also fold them.
+// This method is thread-safe.
+//
+// Parameters:
+//   - stemmer (Stemmer): The stemmer, or StemNone to store the words as they're written.
+//
+// Returns:
+//   - error: An error if the full-text index is not initialized, the stemmer is invalid, or the index could not be rebuilt.
+func (c *Cache) FTSetStemmer(stemmer Stemmer) error {
+	if _, ok := stemmers[stemmer]; !ok && stemmer != StemNone {
+		return errors.New("invalid stemmer")
+	}
+
+	// Lock the mutex
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	// Check if the ft is initialized
+	if c.ft == nil {
+		return errors.New("full text not initialized")
+	} else if c.ft.stemmer == stemmer {
+		return nil
+	}
+
+	// Rebuild the full-text index
+	var ft *FullText = c.ft.empty()
+	ft.stemmer = stemmer
+	return c.ftReindex("FTSetStemmer", ft)
+}
+
+// FTStemmer is a method of the Cache struct that returns the stemmer of the full-text index.
+// This method is thread-safe.
+//
+// Returns:
+//   - Stemmer: The stemmer.
+//   - error: An error if the full-text index is not initialized.
+func (c *Cache) FTStemmer() (Stemmer, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	// Check if the ft is initialized
+	if c.ft == nil {
+		return StemNone, errors.New("full text not initialized")
+	}
+	return c.ft.stemmer, nil
+}
+
+// stemWords is a function that reduces each word of a slice to its stem with a stemmer, in place.
+//
+// Parameters:
+//   - stemmer (Stemmer): The stemmer.
+//   - words ([]string): The lowercase words.
+//
+// Returns:
+//   - []string: The stems of the words.
+func stemWords(stemmer Stemmer, words []string) []string {
+	fn, ok := stemmers[stemmer]
+	if !ok {
+		return words
+	}
+	for i, w := range words {
+		words[i] = fn(w)
+	}
+	return words
+}
+
+// phrase is a method of the FullText struct that returns the lowercase words of a string separated by single spaces, folded and
+// reduced to their stem by an analyzer, so the phrases of a query and a value match regardless of the form of their words.
+//
+// Parameters:
+//   - analyzer (Analyzer): The analyzer of the field, or AnalyzerNone for the stemmer of the index.
+//   - s (string): The string.
+//
+// Returns:
+//   - string: The stems of the words of the string.
+func (ft *FullText) phrase(analyzer Analyzer, s string) string {
+	var stemmer Stemmer = ft.stemmer
+	if analyzer != AnalyzerNone {
+		s = utils.Fold(strings.ToLower(s))
+		stemmer = analyzerPresets[analyzer].stemmer
+	}
+	if stemmer == StemNone {
+		return phrase(s)
+	}
+	return strings.Join(stemWords(stemmer, strings.Split(phrase(s), " ")), " ")
+}
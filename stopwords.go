@@ -0,0 +1,161 @@
+package hermes
+
+import (
+	"errors"
+	"sort"
+	"strings"
+)
+
+// EnglishStopWords is a preset of common English words that can be passed to FTSetStopWords.
+var EnglishStopWords []string = []string{
+	"a", "about", "above", "after", "again", "against", "all", "am", "an", "and", "any", "are", "as", "at",
+	"be", "because", "been", "before", "being", "below", "between", "both", "but", "by",
+	"can", "could", "did", "do", "does", "doing", "down", "during", "each", "few", "for", "from", "further",
+	"had", "has", "have", "having", "he", "her", "here", "hers", "herself", "him", "himself", "his", "how",
+	"i", "if", "in", "into", "is", "it", "its", "itself", "just", "me", "more", "most", "my", "myself",
+	"no", "nor", "not", "now", "of", "off", "on", "once", "only", "or", "other", "our", "ours", "ourselves", "out", "over", "own",
+	"same", "she", "should", "so", "some", "such", "than", "that", "the", "their", "theirs", "them", "themselves", "then",
+	"there", "these", "they", "this", "those", "through", "to", "too", "under", "until", "up", "very",
+	"was", "we", "were", "what", "when", "where", "which", "while", "who", "whom", "why", "will", "with", "would",
+	"you", "your", "yours", "yourself", "yourselves",
+}
+
+// GermanStopWords is a preset of common German words that can be passed to FTSetStopWords.
+var GermanStopWords []string = []string{
+	"aber", "alle", "allem", "allen", "aller", "alles", "als", "also", "am", "an", "andere", "anderen", "auch", "auf", "aus",
+	"bei", "bin", "bis", "bist", "da", "damit", "dann", "das", "dass", "dein", "deine", "dem", "den", "denn", "der", "des", "dich",
+	"die", "dies", "diese", "diesem", "diesen", "dieser", "dieses", "dir", "doch", "dort", "du", "durch", "ein", "eine", "einem",
+	"einen", "einer", "eines", "er", "es", "etwas", "euch", "euer", "für", "gegen", "hab", "habe", "haben", "hat", "hatte", "hier",
+	"hin", "ich", "ihm", "ihn", "ihnen", "ihr", "ihre", "im", "in", "ist", "jede", "jedem", "jeden", "jeder", "jedes", "jetzt",
+	"kann", "kein", "keine", "mein", "meine", "mich", "mir", "mit", "muss", "nach", "nicht", "nichts", "noch", "nun", "nur",
+	"ob", "oder", "ohne", "sehr", "sein", "seine", "sich", "sie", "sind", "so", "solche", "soll", "um", "und", "uns", "unser",
+	"unter", "viel", "vom", "von", "vor", "war", "waren", "was", "weil", "welche", "wenn", "wer", "werden", "wie", "wieder",
+	"will", "wir", "wird", "wo", "zu", "zum", "zur", "zwischen", "über",
+}
+
+// FrenchStopWords is a preset of common French words that can be passed to FTSetStopWords.
+var FrenchStopWords []string = []string{
+	"à", "au", "aux", "avec", "ce", "ces", "cette", "dans", "de", "des", "du", "elle", "elles", "en", "est", "et", "été", "être",
+	"eu", "il", "ils", "je", "la", "le", "les", "leur", "leurs", "lui", "ma", "mais", "me", "même", "mes", "moi", "mon", "ne",
+	"nos", "notre", "nous", "on", "ont", "ou", "où", "par", "pas", "pour", "qu", "que", "qui", "sa", "sans", "se", "ses", "son",
+	"sont", "sur", "ta", "te", "tes", "toi", "ton", "tu", "un", "une", "vos", "votre", "vous", "y",
+}
+
+// SpanishStopWords is a preset of common Spanish words that can be passed to FTSetStopWords.
+var SpanishStopWords []string = []string{
+	"a", "al", "algo", "ante", "como", "con", "contra", "cual", "cuando", "de", "del", "desde", "donde", "durante", "e", "el",
+	"él", "ella", "ellas", "ellos", "en", "entre", "era", "es", "esa", "esas", "ese", "eso", "esos", "esta", "está", "estas",
+	"este", "esto", "estos", "fue", "ha", "hay", "la", "las", "le", "les", "lo", "los", "más", "me", "mi", "mis", "mucho",
+	"muy", "ni", "no", "nos", "nosotros", "o", "os", "otra", "otro", "para", "pero", "poco", "por", "porque", "que", "qué",
+	"quien", "se", "sea", "ser", "si", "sí", "sin", "sobre", "son", "su", "sus", "también", "te", "tiene", "todo", "tu", "tus",
+	"un", "una", "uno", "unos", "y", "ya", "yo",
+}
+
+// RussianStopWords is a preset of common Russian words that can be passed to FTSetStopWords.
+var RussianStopWords []string = []string{
+	"а", "без", "более", "бы", "был", "была", "были", "было", "быть", "в", "вам", "вас", "весь", "во", "вот", "все", "всё",
+	"всего", "вы", "где", "да", "даже", "для", "до", "его", "ее", "её", "если", "есть", "еще", "ещё", "же", "за", "здесь", "и",
+	"из", "или", "им", "их", "к", "как", "когда", "кто", "ли", "между", "меня", "мне", "мы", "на", "над", "не", "него", "нее",
+	"нет", "ни", "них", "но", "ну", "о", "об", "он", "она", "они", "оно", "от", "по", "под", "при", "с", "со", "так", "также",
+	"там", "то", "того", "тоже", "только", "том", "ты", "у", "уже", "чем", "что", "чтобы", "эта", "эти", "это", "этот", "я",
+}
+
+// FTSetStopWords is a method of the Cache struct that sets the words that are not stored in the full-text index, such as "the" and "and",
+// so they don't use up the maximum number of words of the index with posting lists that contain almost every value.
+// The stop words of a query are ignored when the index is looked up, but a query with several words still only matches the values
+// that contain the whole phrase. The full-text index is rebuilt, so the stop words are also removed from the values that are already stored.
+// This method is thread-safe.
+//
+// Parameters:
+//   - words ([]string): The stop words, matched case-insensitively, such as EnglishStopWords or GermanStopWords. If empty, every word is stored.
+//
+// Returns:
+//   - error: An error if the full-text index is not initialized, or the index could not be rebuilt.
+func (c *Cache) FTSetStopWords(words []string) error {
+	var stopWords map[string]bool = nil
+	if len(words) > 0 {
+		stopWords = make(map[string]bool, len(words))
+		for _, w := range words {
+			stopWords[strings.ToLower(w)] = true
+		}
+	}
+
+	// Lock the mutex
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	// Check if the ft is initialized
+	if c.ft == nil {
+		return errors.New("full text not initialized")
+	}
+
+	// Rebuild the full-text index
+	var ft *FullText = c.ft.empty()
+	ft.stopWords = stopWords
+	return c.ftReindex("FTSetStopWords", ft)
+}
+
+// FTStopWords is a method of the Cache struct that returns the stop words of the full-text index.
+// This method is thread-safe.
+//
+// Returns:
+//   - []string: The stop words, in ascending order.
+//   - error: An error if the full-text index is not initialized.
+func (c *Cache) FTStopWords() ([]string, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	// Check if the ft is initialized
+	if c.ft == nil {
+		return nil, errors.New("full text not initialized")
+	}
+	return sortedStopWords(c.ft.stopWords), nil
+}
+
+// analyze is a method of the FullText struct that removes the stop words of an analyzer and the words rejected by the token filters
+// of the index from the words of a value, and reduces the other words to their stem, in place.
+//
+// Parameters:
+//   - analyzer (Analyzer): The analyzer of the field, or AnalyzerNone for the stop words and stemmer of the index.
+//   - words ([]string): The lowercase words of the value, already folded if the analyzer is a preset.
+//
+// Returns:
+//   - []string: The words that are stored in the full-text index.
+func (ft *FullText) analyze(analyzer Analyzer, words []string) []string {
+	var (
+		stemmer   Stemmer         = ft.stemmer
+		stopWords map[string]bool = ft.stopWords
+	)
+	if preset, ok := analyzerPresets[analyzer]; ok {
+		stemmer, stopWords = preset.stemmer, preset.stopWords
+	}
+	if len(stopWords) > 0 || ft.filtersTokens() {
+		var kept []string = words[:0]
+		for _, w := range words {
+			if !stopWords[w] && ft.keepToken(w) {
+				kept = append(kept, w)
+			}
+		}
+		words = kept
+	}
+	return stemWords(stemmer, words)
+}
+
+// sortedStopWords is a function that returns the words of a stop-word set in ascending order.
+//
+// Parameters:
+//   - stopWords (map[string]bool): The stop-word set. May be nil.
+//
+// Returns:
+//   - []string: The stop words, or nil if the set is empty.
+func sortedStopWords(stopWords map[string]bool) []string {
+	if len(stopWords) == 0 {
+		return nil
+	}
+	var words []string = make([]string, 0, len(stopWords))
+	for w := range stopWords {
+		words = append(words, w)
+	}
+	sort.Strings(words)
+	return words
+}
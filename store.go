@@ -0,0 +1,175 @@
+package hermes
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// Store is an interface that contains the public operations of a Cache.
+// Applications can depend on Store instead of *Cache so that the cache can be replaced with a stub in unit tests,
+// for example with the mock.Store type from the github.com/realTristan/hermes/mock package.
+type Store interface {
+	// Values
+	Get(key string) map[string]any
+	GetCopy(key string) map[string]any
+	GetWithVersion(key string) (map[string]any, uint64, bool)
+	GetOrLoad(key string) (map[string]any, error)
+	SetLoader(fn func(key string) (map[string]any, error))
+	Version(key string) (uint64, bool)
+	Set(key string, value map[string]any) error
+	ImportCSV(file string, keyColumn string, fullText ...string) (int, error)
+	SetWithTTL(key string, value map[string]any, ttl time.Duration) error
+	SetCtx(ctx context.Context, key string, value map[string]any) error
+	Replace(key string, value map[string]any, version uint64) error
+	Update(key string, fields map[string]any, version uint64) error
+	GetField(key string, path string) (any, error)
+	SetField(key string, path string, field any) error
+	Delete(key string)
+	DeleteMatching(pattern string) ([]string, error)
+	Exists(key string) bool
+	Expire(key string, ttl time.Duration) error
+	SetExpiryField(field string)
+	SetRecordMode(mode RecordMode) error
+	Freeze()
+	Unfreeze()
+	Frozen() bool
+	Touch(key string, ttl time.Duration) error
+	TTL(key string) (time.Duration, bool)
+	EnableHistory(limit int) error
+	DisableHistory()
+	History(key string) ([]Revision, error)
+	OnExpire(fn func(key string, value map[string]any))
+	DeleteExpired() int
+	SetWriteRateLimit(limit int, per time.Duration) error
+	OnRateLimit(fn func(err *RateLimitError))
+	AddSink(s Sink, opts SinkOptions) error
+	Keys() []string
+	KeysWithPrefix(prefix string) []string
+	KeysMatching(pattern string) ([]string, error)
+	Values() []map[string]any
+	ValuesCopy() []map[string]any
+	Range(fn func(key string, value map[string]any) bool)
+	Length() int
+	Clean()
+	Clear(keepFullText bool)
+	Clone() *Cache
+	Generation() uint64
+	Info() (map[string]any, error)
+	InfoForTesting() (map[string]any, error)
+	Stats() (Stats, error)
+	SetSearchShadow(shadow *Cache, percent float64, fn func(diff ShadowDiff)) error
+	SetQueryCache(size int) error
+	SetDuplicateMode(mode DuplicateMode) error
+	DuplicateOf(key string) (string, bool)
+	Verify() error
+	SetConsistencyCheck(enabled bool)
+	SetExpectedSize(n int) error
+	ResetStats()
+	WithNamespace(ns string) (*Namespace, error)
+	CreateNamespace(ns string) (*Namespace, error)
+	Namespaces() []string
+	DeleteNamespace(ns string) (int, error)
+
+	// Constraints and secondary indexes
+	SetComputedField(field string, fn func(value map[string]any) any, fullText bool) error
+	ComputedFields() []string
+	CreateUnique(fields ...string) error
+	DropUnique(fields ...string) error
+	Uniques() [][]string
+	CreateIndex(field string) error
+	DropIndex(field string) error
+	Indexes() []string
+	Find(field string, value any) ([]map[string]any, error)
+	CreateBoolIndex(field string) error
+	DropBoolIndex(field string) error
+	BoolIndexes() []string
+	CreateNumericIndex(field string) error
+	DropNumericIndex(field string) error
+	SetTolerance(field string, t Tolerance) error
+	Filter(expr string) ([]map[string]any, error)
+	FacetCounts(field string, expr string) (map[string]int, error)
+
+	// Persistence
+	Save(path string) error
+	SetSnapshotCodec(codec Codec) error
+	EnableCompression(threshold int) error
+	ExportJson(w io.Writer) error
+	ExportNDJson(w io.Writer) error
+	Load(path string) error
+	LoadURL(url string, checksum string, retries int) error
+	EnableAutoPersist(path string, interval time.Duration) error
+	EnableSnapshotHistory(dir string, interval time.Duration, keep int) error
+	SaveSnapshot(dir string) (string, error)
+	DisableAutoPersist()
+	Close() error
+
+	// Full-text
+	FTInit(maxSize int, maxBytes int, minWordLength int) error
+	FTInitWithMap(data map[string]map[string]any, maxSize int, maxBytes int, minWordLength int) error
+	FTInitWithJson(file string, maxSize int, maxBytes int, minWordLength int) error
+	FTInitWithYaml(file string, maxSize int, maxBytes int, minWordLength int) error
+	FTInitWithCSV(file string, keyColumn string, maxSize int, maxBytes int, minWordLength int, fullText ...string) error
+	FTInitWithStruct(v any, maxSize int, maxBytes int, minWordLength int) error
+	FTIsInitialized() bool
+	FTClean() error
+	FTSchema() (map[string]bool, error)
+	FTReindex(schema map[string]bool) error
+	FTStats() (FTStats, error)
+	FTCompact() error
+	FTCreateIndex(name string, schema map[string]bool) error
+	FTDropIndex(name string) error
+	FTIndexes() map[string][]string
+	FTSetMaxBytes(maxBytes int) error
+	FTSetMaxSize(maxSize int) error
+	FTSetMinWordLength(minWordLength int) error
+	FTSetMaxWordLength(maxWordLength int) error
+	SetLimitWarnings(thresholds ...float64) error
+	OnLimitWarning(fn func(w LimitWarning))
+	FTSetTokenRule(field string, rule TokenRule) error
+	FTTokenRules() (map[string]TokenRule, error)
+	FTSetStemmer(stemmer Stemmer) error
+	FTStemmer() (Stemmer, error)
+	FTSetStopWords(words []string) error
+	FTStopWords() ([]string, error)
+	FTSetSkipNumbers(skip bool) error
+	FTSetCaseSensitive(sensitive bool) error
+	FTCaseSensitive() (bool, error)
+	FTSetTokenFilter(filter TokenFilter) error
+	FTSetAnalyzer(analyzer Analyzer) error
+	FTAnalyzer() (Analyzer, error)
+	FTSetFieldAnalyzers(analyzers map[string]Analyzer) error
+	FTFieldAnalyzers() (map[string]Analyzer, error)
+	FTSetFieldWeights(weights map[string]float64) error
+	FTFieldWeights() (map[string]float64, error)
+	FTSave(path string) error
+	FTLoad(path string) error
+	FTWriteTo(w io.Writer) (int64, error)
+	FTReadFrom(r io.Reader) (int64, error)
+	FTStorage() (map[string]any, error)
+	FTStorageSize() (int, error)
+	FTStorageLength() (int, error)
+	FTSequenceIndices()
+	FTKeysForWord(word string) (map[string][]string, error)
+	Search(sp SearchParams) ([]map[string]any, error)
+	Subscribe(sp SearchParams, fn func(id string, key string, value map[string]any)) (string, error)
+	Unsubscribe(id string) error
+	Subscriptions() map[string]SearchParams
+	Percolate(doc map[string]any) []string
+	SearchCtx(ctx context.Context, sp SearchParams) ([]map[string]any, error)
+	SearchCursor(sp SearchParams) ([]map[string]any, string, error)
+	SearchCursorCtx(ctx context.Context, sp SearchParams) ([]map[string]any, string, error)
+	SearchOneWord(sp SearchParams) ([]map[string]any, error)
+	SearchRegex(pattern string, limit int) ([]map[string]any, error)
+	SearchValues(sp SearchParams) ([]map[string]any, error)
+	SearchWithKey(sp SearchParams) ([]map[string]any, error)
+	SearchGroups(sp SearchParams) ([]Group, error)
+	Suggest(prefix string, limit int) []string
+	SearchScored(sp SearchParams) ([]Result, error)
+	SearchPage(sp SearchParams, size int) (Page, error)
+	NextPage(cursor string, size int) (Page, error)
+	SetBM25(k1 float64, b float64) error
+}
+
+// Verify that the Cache implements the Store interface
+var _ Store = (*Cache)(nil)
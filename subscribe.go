@@ -0,0 +1,219 @@
+package hermes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// subscription is a struct that represents a standing query that is notified of the values that match it when they're set.
+//
+// Fields:
+//   - params (SearchParams): The search parameters of the query.
+//   - fn (func(id string, key string, value map[string]any)): The function to call with each matching value.
+type subscription struct {
+	params SearchParams
+	fn     func(id string, key string, value map[string]any)
+}
+
+// Subscribe is a method of the Cache struct that registers a standing query, like a saved search, and calls fn whenever a value
+// that matches the query is set with Set, SetCtx, SetWithTTL, Replace, Update or ImportCSV. A value matches the query if Search
+// would return it, with the same Query, Strict, KeyPrefix and Transliterate parameters, once it's set. The values that are already
+// in the cache are not matched. The function is called in a new goroutine, so it can call methods of the cache, and the value
+// it receives must not be modified. Percolate matches a document against the registered queries without setting it.
+// This method is thread-safe.
+//
+// Parameters:
+//   - sp (SearchParams): The search parameters of the query.
+//   - fn (func(id string, key string, value map[string]any)): The function to call with the id of the subscription and the key
+//     and value of each matching value.
+//
+// Returns:
+//   - string: The id of the subscription, used to unsubscribe.
+//   - error: An error if the query is empty, fn is nil, or the full-text index is not initialized.
+func (c *Cache) Subscribe(sp SearchParams, fn func(id string, key string, value map[string]any)) (string, error) {
+	switch {
+	case len(strings.TrimSpace(sp.Query)) == 0:
+		return "", errors.New("invalid query")
+	case fn == nil:
+		return "", errors.New("invalid subscription function")
+	}
+
+	// Lock the mutex
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	// Verify that the full-text index is initialized
+	if c.ft == nil {
+		return "", errors.New("full-text not initialized")
+	}
+
+	// Register the subscription
+	c.subSeq++
+	var id string = fmt.Sprintf("sub-%d", c.subSeq)
+	sp.Query = strings.ToLower(sp.Query)
+	c.subs[id] = &subscription{params: sp, fn: fn}
+	return id, nil
+}
+
+// Unsubscribe is a method of the Cache struct that removes a standing query registered with Subscribe.
+// This method is thread-safe.
+//
+// Parameters:
+//   - id (string): The id of the subscription.
+//
+// Returns:
+//   - error: An error if the subscription doesn't exist.
+func (c *Cache) Unsubscribe(id string) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	// Verify that the subscription exists
+	if _, ok := c.subs[id]; !ok {
+		return fmt.Errorf("subscription %s does not exist", id)
+	}
+	delete(c.subs, id)
+	return nil
+}
+
+// Subscriptions is a method of the Cache struct that returns the search parameters of the standing queries, keyed by their id.
+// This method is thread-safe.
+//
+// Returns:
+//   - map[string]SearchParams: The search parameters of each subscription.
+func (c *Cache) Subscriptions() map[string]SearchParams {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	// Copy the search parameters
+	var result map[string]SearchParams = make(map[string]SearchParams, len(c.subs))
+	for id, s := range c.subs {
+		result[id] = s.params
+	}
+	return result
+}
+
+// notify is a method of the Cache struct that calls the functions of the subscriptions whose query matches a value that was set.
+// This method is not thread-safe, and should only be called from an exported function.
+//
+// Parameters:
+//   - key (string): The key whose value was set.
+//
+// Returns:
+//   - None
+func (c *Cache) notify(key string) {
+	if len(c.subs) == 0 || c.ft == nil {
+		return
+	}
+
+	// Search the value on its own
+	var single *Cache = c.single(key, c.data[key], c.ft.fields[key])
+	if single == nil {
+		return
+	}
+	for id, s := range c.subs {
+		if single.matches(s.params) {
+			go s.fn(id, key, c.expand(c.data[key]))
+		}
+	}
+}
+
+// Percolate is a method of the Cache struct that returns the ids of the queries registered with Subscribe that a document would match
+// if it were set, without setting it or calling the functions of the subscriptions. It can be used to route or classify documents.
+// The document is matched like a value set with Set, including its computed fields, but since it has no key,
+// the queries with a KeyPrefix never match it.
+// This method is thread-safe.
+//
+// Parameters:
+//   - doc (map[string]any): The document to match.
+//
+// Returns:
+//   - []string: The sorted ids of the matching subscriptions, or an empty slice if the full-text index is not initialized.
+func (c *Cache) Percolate(doc map[string]any) []string {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	// Verify that there are queries to match
+	var ids []string = []string{}
+	if len(c.subs) == 0 || c.ft == nil {
+		return ids
+	}
+
+	// Store the full-text fields of a copy of the document like when it's set
+	var (
+		value  map[string]any = copyMap(doc)
+		fields []string       = []string{}
+	)
+	if err := c.compute("", value); err != nil {
+		return ids
+	}
+	for field, v := range value {
+		if ftv := c.ft.value(field, v); len(ftv) > 0 {
+			value[field] = ftv
+			fields = append(fields, field)
+		}
+	}
+	for field := range c.ft.nestedValues(value) {
+		fields = append(fields, field)
+	}
+
+	// Search the document on its own
+	var single *Cache = c.single("", value, fields)
+	if single == nil {
+		return ids
+	}
+	for id, s := range c.subs {
+		if single.matches(s.params) {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// single is a method of the Cache struct that returns a cache holding only a value, with a full-text index
+// that has the same configuration as the cache, so a query can be matched against the value with the search methods.
+// This method is not thread-safe, and should only be called from an exported function.
+//
+// Parameters:
+//   - key (string): The key of the value.
+//   - value (map[string]any): The value, with its full-text fields stored as strings.
+//   - fields ([]string): The full-text fields of the value.
+//
+// Returns:
+//   - *Cache: The cache holding the value, or nil if the value could not be indexed.
+func (c *Cache) single(key string, value map[string]any, fields []string) *Cache {
+	var (
+		ft *FullText    = c.ft.empty()
+		ts *TempStorage = NewTempStorage(ft)
+	)
+	for _, field := range fields {
+		if v, ok := fieldString(pathValue(value, field)); ok {
+			if err := ts.insert(ft, key, field, v); err != nil {
+				return nil
+			}
+			ft.fields[key] = append(ft.fields[key], field)
+		}
+	}
+	ts.cleanSingleArrays()
+	ts.updateFullText(ft)
+	return &Cache{data: map[string]map[string]any{key: value}, ft: ft}
+}
+
+// matches is a method of the Cache struct that checks whether a search returns any value of the cache.
+// This method is not thread-safe, and should only be called from an exported function.
+//
+// Parameters:
+//   - sp (SearchParams): The search parameters, with a lowercase query.
+//
+// Returns:
+//   - bool: true if the search returns a value, false otherwise.
+func (c *Cache) matches(sp SearchParams) bool {
+	sp.Limit = 1
+	result, err := searchVariants(sp, func(sp SearchParams) ([]map[string]any, error) {
+		return c.search(context.Background(), sp)
+	})
+	return err == nil && len(result) > 0
+}
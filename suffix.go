@@ -0,0 +1,200 @@
+package hermes
+
+import (
+	"errors"
+	"index/suffixarray"
+	"sort"
+	"strings"
+)
+
+// suffixIndex is a struct that stores the words of a full-text index in a suffix array, so the words that contain a substring of
+// any length are looked up exactly instead of scanning every word of the index. The suffix array can't be updated, so the words
+// that are added after it's built are kept aside and scanned, and it's rebuilt once they, or the removed words, are too many.
+// Words that were rejected by the limits of the full-text index may be left in the suffix index, so the looked up words must be verified.
+//
+// Fields:
+//   - sa (*suffixarray.Index): The suffix array of the words that were stored when it was built, each preceded by a zero byte.
+//   - starts ([]int): The offset of each of these words in the text of the suffix array.
+//   - words ([]string): These words, in the order of the text.
+//   - live (map[string]bool): The stored words.
+//   - pending (map[string]bool): The words that were added since the suffix array was built.
+//   - removed (int): The number of words that were removed since the suffix array was built.
+type suffixIndex struct {
+	sa      *suffixarray.Index
+	starts  []int
+	words   []string
+	live    map[string]bool
+	pending map[string]bool
+	removed int
+}
+
+// FTSetSuffixIndex is a method of the Cache struct that indexes the words of the full-text index in a suffix array, so the non-strict
+// searches look up the words that contain the query exactly, whatever its length, instead of scanning every word of the index or
+// verifying the words of the n-gram index. The suffix index takes precedence over the n-gram index when both are set.
+// It uses about as much memory as the words themselves plus 8 bytes per character, and is rebuilt by the sets and deletes
+// once enough words changed since it was built. It isn't stored in the snapshots, only whether it's enabled is, so it's rebuilt when
+// a snapshot is loaded.
+// This method is thread-safe.
+//
+// Parameters:
+//   - enabled (bool): Whether the words are indexed in a suffix array.
+//
+// Returns:
+//   - error: An error if the full-text index is not initialized.
+func (c *Cache) FTSetSuffixIndex(enabled bool) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	// Check if the ft is initialized
+	if c.ft == nil {
+		return errors.New("full text not initialized")
+	}
+
+	// Build the suffix index
+	c.ft.suffixes = newSuffixIndex(enabled)
+	c.ft.suffixes.build(c.ft.storage)
+	return nil
+}
+
+// newSuffixIndex is a function that returns an empty suffix index, with an empty suffix array so it can be looked up before it's built.
+//
+// Parameters:
+//   - enabled (bool): Whether the full-text index has a suffix index.
+//
+// Returns:
+//   - *suffixIndex: The suffix index, or nil if it's not enabled.
+func newSuffixIndex(enabled bool) *suffixIndex {
+	if !enabled {
+		return nil
+	}
+	var idx *suffixIndex = &suffixIndex{live: make(map[string]bool)}
+	idx.rebuild()
+	return idx
+}
+
+// enabled is a method of the suffixIndex struct that returns whether the full-text index has a suffix index.
+//
+// Returns:
+//   - bool: false if the suffix index is nil.
+func (idx *suffixIndex) enabled() bool {
+	return idx != nil
+}
+
+// build is a method of the suffixIndex struct that indexes every word of a full-text storage.
+//
+// Parameters:
+//   - storage (map[string]any): The full-text storage.
+//
+// Returns:
+//   - None
+func (idx *suffixIndex) build(storage map[string]any) {
+	if idx == nil {
+		return
+	}
+	idx.live = make(map[string]bool, len(storage))
+	for word := range storage {
+		idx.live[word] = true
+	}
+	idx.rebuild()
+}
+
+// rebuild is a method of the suffixIndex struct that builds the suffix array of the stored words.
+//
+// Returns:
+//   - None
+func (idx *suffixIndex) rebuild() {
+	idx.words = make([]string, 0, len(idx.live))
+	for word := range idx.live {
+		idx.words = append(idx.words, word)
+	}
+	sort.Strings(idx.words)
+
+	// Join the words, each preceded by a zero byte so a match can't span two words
+	var text []byte = make([]byte, 0, len(idx.words)*8)
+	idx.starts = make([]int, len(idx.words))
+	for i, word := range idx.words {
+		text = append(text, 0)
+		idx.starts[i] = len(text)
+		text = append(text, word...)
+	}
+	idx.sa = suffixarray.New(text)
+	idx.pending = make(map[string]bool)
+	idx.removed = 0
+}
+
+// stale is a method of the suffixIndex struct that checks whether enough words changed since the suffix array was built
+// for a lookup to cost more than rebuilding it.
+//
+// Returns:
+//   - bool: true if the suffix array should be rebuilt.
+func (idx *suffixIndex) stale() bool {
+	return len(idx.pending)+idx.removed > 1024+len(idx.words)/8
+}
+
+// add is a method of the suffixIndex struct that indexes a word.
+//
+// Parameters:
+//   - word (string): The word.
+//
+// Returns:
+//   - None
+func (idx *suffixIndex) add(word string) {
+	if idx == nil || idx.live[word] {
+		return
+	}
+	idx.live[word] = true
+	if idx.pending[word] = true; idx.stale() {
+		idx.rebuild()
+	}
+}
+
+// remove is a method of the suffixIndex struct that removes a word. The word is left in the suffix array until it's rebuilt.
+//
+// Parameters:
+//   - word (string): The word.
+//
+// Returns:
+//   - None
+func (idx *suffixIndex) remove(word string) {
+	if idx == nil || !idx.live[word] {
+		return
+	}
+	delete(idx.live, word)
+	delete(idx.pending, word)
+	if idx.removed++; idx.stale() {
+		idx.rebuild()
+	}
+}
+
+// lookup is a method of the suffixIndex struct that returns the words that contain a substring.
+//
+// Parameters:
+//   - term (string): The non-empty substring.
+//
+// Returns:
+//   - []string: The words that contain the substring, which may have been removed from the full-text index since the suffix array was built.
+func (idx *suffixIndex) lookup(term string) []string {
+	var (
+		words []string     = []string{}
+		seen  map[int]bool = map[int]bool{}
+	)
+
+	// The words never contain the zero bytes that separate them
+	if strings.IndexByte(term, 0) >= 0 {
+		return words
+	}
+	for _, offset := range idx.sa.Lookup([]byte(term), -1) {
+		// Find the word that the offset is in
+		var i int = sort.SearchInts(idx.starts, offset+1) - 1
+		if !seen[i] {
+			seen[i] = true
+			words = append(words, idx.words[i])
+		}
+	}
+	for word := range idx.pending {
+		if strings.Contains(word, term) {
+			words = append(words, word)
+		}
+	}
+	return words
+}
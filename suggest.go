@@ -0,0 +1,67 @@
+package hermes
+
+import (
+	"sort"
+	"strings"
+)
+
+// Suggest is a method of the Cache struct that returns the words of the full-text index that start with a prefix, for autocompleting
+// a search box without fetching the values. The words are ordered by the number of values that contain them, then alphabetically.
+// This method is thread-safe.
+//
+// Parameters:
+//   - prefix (string): The prefix of the words. It's matched case-insensitively.
+//   - limit (int): The maximum number of words to return, or 0 for 10.
+//
+// Returns:
+//   - []string: The matching words, or an empty slice if the full-text index is not initialized.
+func (c *Cache) Suggest(prefix string, limit int) []string {
+	if limit <= 0 {
+		limit = 10
+	}
+
+	// Lock the mutex
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	// Check if the FT index is initialized
+	if c.ft == nil {
+		return []string{}
+	}
+	return c.ft.suggest(strings.ToLower(prefix), limit)
+}
+
+// suggest is a method of the FullText struct that returns the stored words that start with a prefix,
+// from the most to the least common.
+//
+// Parameters:
+//   - prefix (string): The lowercase prefix of the words.
+//   - limit (int): The maximum number of words to return.
+//
+// Returns:
+//   - []string: The matching words.
+func (ft *FullText) suggest(prefix string, limit int) []string {
+	var (
+		words  []string       = []string{}
+		counts map[string]int = map[string]int{}
+	)
+	ft.vocab.withPrefix(prefix, func(word string) bool {
+		if v, ok := ft.storage[word]; ok {
+			words = append(words, word)
+			counts[word] = postingsLen(v)
+		}
+		return true
+	})
+
+	// Sort the words by the number of values that contain them, then alphabetically
+	sort.Slice(words, func(i, j int) bool {
+		if counts[words[i]] != counts[words[j]] {
+			return counts[words[i]] > counts[words[j]]
+		}
+		return words[i] < words[j]
+	})
+	if len(words) > limit {
+		words = words[:limit]
+	}
+	return words
+}
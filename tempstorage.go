@@ -2,11 +2,16 @@ package hermes
 
 import (
 	"fmt"
-	"strings"
+	"runtime"
+	"sort"
+	"sync"
 
 	utils "github.com/realTristan/hermes/utils"
 )
 
+// The minimum number of field values that each goroutine of a bulk insert splits into words, so small loads aren't split
+const insertShardMin int = 256
+
 // NewTempStorage is a function that creates a new TempStorage object for a given FullText object.
 // Parameters:
 //   - ft (*FullText): A pointer to the FullText object to create the TempStorage object for.
@@ -14,10 +19,12 @@ import (
 // Returns:
 //   - (*TempStorage): A pointer to the newly created TempStorage object.
 type TempStorage struct {
-	data    map[string]any
-	indices map[int]string
-	index   int
-	keys    map[string]int
+	data     map[string]any
+	indices  map[int]string
+	index    int
+	keys     map[string]int
+	buf      []string
+	inserted []string
 }
 
 // NewTempStorage is a function that creates a new TempStorage object for a given FullText object.
@@ -31,12 +38,15 @@ func NewTempStorage(ft *FullText) *TempStorage {
 		data:    ft.storage,
 		indices: ft.indices,
 		index:   ft.index,
-		keys:    make(map[string]int),
+		keys:    ft.keys,
 	}
 
-	// Loop through the data
-	for k, v := range ts.indices {
-		ts.keys[v] = k
+	// Build the keys if the full-text index doesn't have them
+	if ts.keys == nil {
+		ts.keys = make(map[string]int, len(ft.indices))
+		for k, v := range ts.indices {
+			ts.keys[v] = k
+		}
 	}
 	return ts
 }
@@ -50,10 +60,11 @@ func NewTempStorage(ft *FullText) *TempStorage {
 func (ts *TempStorage) updateFullText(ft *FullText) {
 	ft.storage = ts.data
 	ft.indices = ts.indices
+	ft.keys = ts.keys
 	ft.index = ts.index
 }
 
-// cleanSingleArrays is a method of the TempStorage struct that replaces single-element integer arrays with their single integer value.
+// cleanSingleArrays is a method of the TempStorage struct that replaces the posting lists with a single index with their single integer value.
 // Parameters:
 //   - None.
 //
@@ -61,8 +72,23 @@ func (ts *TempStorage) updateFullText(ft *FullText) {
 //   - None.
 func (ts *TempStorage) cleanSingleArrays() {
 	for k, v := range ts.data {
-		if v, ok := v.([]int); ok && len(v) == 1 {
-			ts.data[k] = v[0]
+		if v, ok := v.(*roaring); ok && v.len() == 1 {
+			ts.data[k] = v.slice()[0]
+		}
+	}
+}
+
+// cleanInserted is a method of the TempStorage struct that replaces the posting lists with a single index of the words inserted with insert
+// with their single integer value, so storing a value doesn't scan the whole storage.
+// Parameters:
+//   - None.
+//
+// Returns:
+//   - None.
+func (ts *TempStorage) cleanInserted() {
+	for _, k := range ts.inserted {
+		if v, ok := ts.data[k].(*roaring); ok && v.len() == 1 {
+			ts.data[k] = v.slice()[0]
 		}
 	}
 }
@@ -75,12 +101,12 @@ func (ts *TempStorage) cleanSingleArrays() {
 //   - (error): An error if the storage limit has been reached, nil otherwise.
 func (ts *TempStorage) error(ft *FullText) error {
 	// Check if the storage limit has been reached
-	if ft.maxSize > 0 {
+	if limited(ft.maxSize) {
 		if len(ts.data) > ft.maxSize {
 			return fmt.Errorf("full-text storage limit reached (%d/%d keys). load cancelled", len(ts.data), ft.maxSize)
 		}
 	}
-	if ft.maxBytes > 0 {
+	if limited(ft.maxBytes) {
 		if cacheSize, err := utils.Size(ts.data); err != nil {
 			return err
 		} else if cacheSize > ft.maxBytes {
@@ -100,22 +126,22 @@ func (ts *TempStorage) error(ft *FullText) error {
 //   - None.
 func (ts *TempStorage) update(ft *FullText, words []string, cacheKey string) {
 	// Loop through the words
+	var index int = ts.keys[cacheKey]
 	for i := 0; i < len(words); i++ {
+		// The words were already split with the minimum word length, and may be shorter once they're stemmed
 		var word string = words[i]
-
-		// Check if the word is valid
-		if len(word) < ft.minWordLength {
-			continue
-		}
 		if temp, ok := ts.data[word]; !ok {
-			ts.data[word] = []int{ts.index}
-		} else if v, ok := temp.([]int); !ok {
-			ts.data[word] = []int{temp.(int), ts.keys[cacheKey]}
-		} else {
-			if utils.SliceContains(v, ts.keys[cacheKey]) {
-				continue
+			ts.data[word] = index
+			ft.ngrams.add(word)
+			ft.suffixes.add(word)
+			ft.partitions.add(word)
+			ft.vocab.insert(word)
+		} else if v, ok := temp.(*roaring); !ok {
+			if temp.(int) != index {
+				ts.data[word] = newRoaring(temp.(int), index)
 			}
-			ts.data[word] = append(v, ts.keys[cacheKey])
+		} else {
+			v.add(index)
 		}
 	}
 }
@@ -134,76 +160,171 @@ func (ts *TempStorage) updateKeys(cacheKey string) {
 	}
 }
 
-// mergeKeys is a method of the TempStorage struct that merges all keys that contain subkeys into a single key.
-// Parameters:
-//   - None.
-//
-// Returns:
-//   - None.
-func (ts *TempStorage) mergeKeys() {
-	// Loop through the keys
-	for k1, v1 := range ts.data {
-		for k2, v2 := range ts.data {
-			if k1 == k2 {
-				continue
-			}
-			if strings.Contains(k1, k2) {
-				if _, ok := v1.(string); ok {
-					continue
-				}
-				if _, ok := v2.(string); ok {
-					continue
-				}
-				var v1, v2 = v1.([]int), v2.([]int)
-				for v := range v2 {
-					if !utils.SliceContains(v1, v) {
-						v1 = append(v1, v)
-					}
-				}
-				ts.data[k1] = v1
-				ts.data[k2] = k1
-				// delete(ts.data, k2)
-				break
-			}
-		}
-	}
-}
-
 // insertWords is a method of the TempStorage struct that inserts data into the temp storage.
 // Parameters:
 //   - ft (*FullText): A pointer to the FullText object to check the storage limit against.
 //   - cacheKey (string): A string representing the cache key to insert.
+//   - field (string): A string representing the field of the value, whose token rule is used to split the value into words.
 //   - ftv (string): A string representing the value to insert.
 //
 // Returns:
 //   - (error): An error if the storage limit has been reached, nil otherwise.
-func (ts *TempStorage) insert(ft *FullText, cacheKey string, ftv string) error {
+func (ts *TempStorage) insert(ft *FullText, cacheKey string, field string, ftv string) error {
 	// Set the cache key in the temp storage keys
 	ts.updateKeys(cacheKey)
 
-	// Clean the string value
-	ftv = strings.TrimSpace(ftv)
-	ftv = utils.RemoveDoubleSpaces(ftv)
-	ftv = strings.ToLower(ftv)
-
 	// Loop through the words
-	for _, word := range strings.Split(ftv, " ") {
-		if len(word) == 0 {
-			continue
-		} else if len(word) < ft.minWordLength {
-			continue
-		} else if err := ts.error(ft); err != nil {
+	for _, word := range ft.fieldWords(field, ftv) {
+		if err := ts.error(ft); err != nil {
 			return err
 		}
 
-		// Trim the word
-		word = utils.TrimNonAlphaNum(word)
-		var words []string = utils.SplitByAlphaNum(word)
-
-		// Update the temp storage
-		ts.update(ft, words, cacheKey)
+		// Update the temp storage, reusing the buffer of the word
+		ts.buf = append(ts.buf[:0], word)
+		ts.update(ft, ts.buf, cacheKey)
+		ts.inserted = append(ts.inserted, word)
+		ft.fieldStorage.add(field, word, ts.keys[cacheKey])
 	}
 
 	// Return no error
 	return nil
 }
+
+// ftEntry is a struct that represents a field value to store in the full-text index with a bulk insert.
+//
+// Fields:
+//   - index (int): The index of the cache key of the value, see updateKeys.
+//   - field (string): The name of the field, whose token rule is used to split the value into words.
+//   - value (string): The value of the field.
+type ftEntry struct {
+	index int
+	field string
+	value string
+}
+
+// entry is a method of the TempStorage struct that sets a cache key in the temp storage keys and returns the entry of one of its field values.
+// Parameters:
+//   - cacheKey (string): A string representing the cache key of the value.
+//   - field (string): A string representing the field of the value.
+//   - ftv (string): A string representing the value to insert.
+//
+// Returns:
+//   - (ftEntry): The entry to insert with insertAll.
+func (ts *TempStorage) entry(cacheKey string, field string, ftv string) ftEntry {
+	ts.updateKeys(cacheKey)
+	return ftEntry{index: ts.keys[cacheKey], field: field, value: ftv}
+}
+
+// insertAll is a method of the TempStorage struct that inserts many field values into the temp storage at once.
+// The entries are sorted by key and split between goroutines, each building the vocabulary of a range of keys in its own map,
+// then the maps are merged in order. The limits are checked once the values are merged.
+// Parameters:
+//   - ft (*FullText): A pointer to the FullText object to check the storage limit against.
+//   - entries ([]ftEntry): The field values to insert, whose keys were set with entry.
+//
+// Returns:
+//   - (error): An error if the storage limit has been reached, nil otherwise.
+func (ts *TempStorage) insertAll(ft *FullText, entries []ftEntry) error {
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].index < entries[j].index
+	})
+
+	// Split the entries into contiguous ranges of keys
+	var shards int = (len(entries) + insertShardMin - 1) / insertShardMin
+	if n := runtime.GOMAXPROCS(0); shards > n {
+		shards = n
+	}
+	var (
+		vocabularies []map[string][]int            = make([]map[string][]int, shards)
+		fields       []map[string]map[string][]int = make([]map[string]map[string][]int, shards)
+		size         int                           = 0
+		wg           sync.WaitGroup
+	)
+	if shards > 0 {
+		size = (len(entries) + shards - 1) / shards
+	}
+
+	// Build the vocabulary of each range concurrently
+	for i := 0; i < shards; i++ {
+		var from, to int = i * size, (i + 1) * size
+		if to > len(entries) {
+			to = len(entries)
+		}
+		wg.Add(1)
+		go func(i int, entries []ftEntry) {
+			defer wg.Done()
+			vocabularies[i], fields[i] = ft.vocabulary(entries)
+		}(i, entries[from:to])
+	}
+	wg.Wait()
+
+	// Merge the vocabularies in the order of their ranges
+	for i, vocabulary := range vocabularies {
+		for word, keys := range vocabulary {
+			ts.merge(ft, word, keys)
+		}
+		for field, words := range fields[i] {
+			for word, keys := range words {
+				ft.fieldStorage.merge(field, word, keys)
+			}
+		}
+	}
+	return ts.error(ft)
+}
+
+// merge is a method of the TempStorage struct that adds the sorted keys of a word to the temp storage.
+// Parameters:
+//   - ft (*FullText): A pointer to the FullText object whose n-gram index the new words are added to.
+//   - word (string): The word.
+//   - keys ([]int): The sorted indices of the keys whose values contain the word.
+//
+// Returns:
+//   - None.
+func (ts *TempStorage) merge(ft *FullText, word string, keys []int) {
+	switch v := ts.data[word].(type) {
+	case nil:
+		ft.ngrams.add(word)
+		ft.suffixes.add(word)
+		ft.partitions.add(word)
+		ft.vocab.insert(word)
+		if len(keys) == 1 {
+			ts.data[word] = keys[0]
+		} else {
+			ts.data[word] = newRoaring(keys...)
+		}
+	case int:
+		ts.data[word] = newRoaring(append([]int{v}, keys...)...)
+	case *roaring:
+		for _, k := range keys {
+			v.add(k)
+		}
+	}
+}
+
+// vocabulary is a method of the FullText struct that splits field values into the words that are stored in the full-text index.
+// Parameters:
+//   - entries ([]ftEntry): The field values, sorted by key.
+//
+// Returns:
+//   - (map[string][]int): The sorted indices of the keys whose values contain each word.
+//   - (map[string]map[string][]int): The sorted indices of the keys whose value of each field contains each word.
+func (ft *FullText) vocabulary(entries []ftEntry) (map[string][]int, map[string]map[string][]int) {
+	var (
+		result map[string][]int            = make(map[string][]int)
+		fields map[string]map[string][]int = make(map[string]map[string][]int)
+	)
+	for _, e := range entries {
+		if fields[e.field] == nil {
+			fields[e.field] = make(map[string][]int)
+		}
+		for _, word := range ft.fieldWords(e.field, e.value) {
+			if keys := result[word]; len(keys) == 0 || keys[len(keys)-1] != e.index {
+				result[word] = append(keys, e.index)
+			}
+			if keys := fields[e.field][word]; len(keys) == 0 || keys[len(keys)-1] != e.index {
+				fields[e.field][word] = append(keys, e.index)
+			}
+		}
+	}
+	return result, fields
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+
+	hermes "github.com/realTristan/hermes"
+)
+
+func churn() {
+	var cache *hermes.Cache = hermes.InitCache()
+
+	// Initialize the FT cache
+	cache.FTInit(-1, -1, 3)
+
+	// Set and delete random keys, verifying the index as it changes
+	var (
+		words []string        = []string{"apple", "banana", "cherry", "delta", "echo", "foxtrot"}
+		live  map[string]bool = map[string]bool{}
+	)
+	for i := 0; i < 10000; i++ {
+		var key string = fmt.Sprintf("user_id%d", rand.Intn(100))
+		if live[key] {
+			cache.Delete(key)
+			live[key] = false
+		} else {
+			var text string = words[rand.Intn(len(words))] + " " + words[rand.Intn(len(words))]
+			cache.Set(key, map[string]any{"name": cache.WithFT(text)})
+			live[key] = true
+		}
+		if i%2500 == 0 {
+			cache.FTSequenceIndices()
+		}
+		if err := cache.Verify(); err != nil {
+			fmt.Println(err)
+			return
+		}
+	}
+
+	// A value that can't be stored doesn't leave any word in the index
+	cache.FTSetMaxSize(len(words))
+	fmt.Println(cache.Set("user_id_new", map[string]any{"name": cache.WithFT("golf hotel")}))
+	fmt.Println(cache.Verify())
+
+	// Print the cache info
+	fmt.Println(cache.Length())
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"fmt"
+
+	hermes "github.com/realTristan/hermes"
+)
+
+func consistency() {
+	var cache *hermes.Cache = hermes.InitCache()
+
+	// Initialize the FT cache
+	cache.FTInit(-1, -1, 3)
+
+	// Set a few values to write over
+	for i := 0; i < 100; i++ {
+		cache.Set(fmt.Sprintf("user_id%d", i), map[string]any{
+			"name": cache.WithFT(fmt.Sprintf("tristan %d", i)),
+			"bio":  cache.WithFT("likes apple pie"),
+		})
+	}
+
+	// Verify the index after each kind of write
+	var steps []struct {
+		name  string
+		write func() error
+	} = []struct {
+		name  string
+		write func() error
+	}{
+		{"set", func() error {
+			return cache.Set("user_id100", map[string]any{"name": cache.WithFT("banana split")})
+		}},
+		{"replace", func() error {
+			return cache.Replace("user_id1", map[string]any{"name": cache.WithFT("cherry tart")}, 0)
+		}},
+		{"update", func() error {
+			return cache.Update("user_id2", map[string]any{"name": cache.WithFT("delta echo"), "bio": nil}, 0)
+		}},
+		{"delete", func() error {
+			cache.Delete("user_id3")
+			return nil
+		}},
+		{"delete matching", func() error {
+			_, err := cache.DeleteMatching("user_id5*")
+			return err
+		}},
+		{"sequence indices", func() error {
+			cache.FTSequenceIndices()
+			return nil
+		}},
+		{"compact", cache.FTCompact},
+		{"reindex", func() error {
+			return cache.FTReindex(map[string]bool{"name": true})
+		}},
+		{"clean", func() error {
+			cache.Clean()
+			return cache.Set("user_id0", map[string]any{"name": cache.WithFT("foxtrot golf")})
+		}},
+	}
+	for _, step := range steps {
+		if err := step.write(); err != nil {
+			fmt.Println(step.name, err)
+			return
+		} else if err := cache.Verify(); err != nil {
+			fmt.Println(step.name, err)
+			return
+		}
+		fmt.Println(step.name, "ok")
+	}
+}
@@ -7,4 +7,7 @@ func main() {
 	//ft()
 	//search()
 	//set()
+	churn()
+	suffix()
+	consistency()
 }
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"fmt"
+
+	hermes "github.com/realTristan/hermes"
+)
+
+func suffix() {
+	var cache *hermes.Cache = hermes.InitCache()
+
+	// Initialize the FT cache with a suffix index
+	cache.FTInit(-1, -1, 3)
+	cache.FTSetSuffixIndex(true)
+
+	// Search after each call that rebuilds the full-text index
+	var steps []func() error = []func() error{
+		func() error { cache.Clear(true); return nil },
+		func() error { cache.Clean(); return nil },
+		cache.FTClean,
+		func() error { return cache.FTSetMinWordLength(2) },
+		func() error { return cache.FTSetMaxWordLength(20) },
+		func() error { return cache.FTReindex(nil) },
+		func() error { return cache.FTSetStopWords([]string{"the"}) },
+	}
+	for i, step := range steps {
+		cache.Set(fmt.Sprintf("user_id%d", i), map[string]any{"name": cache.WithFT("tristan")})
+
+		// Rebuild the index, then index a new value and search for it
+		if err := step(); err != nil {
+			fmt.Println(err)
+			return
+		}
+		cache.Set(fmt.Sprintf("user_id%d_new", i), map[string]any{"name": cache.WithFT("tristan")})
+		var result, err = cache.Search(hermes.SearchParams{
+			Query:  "trist",
+			Limit:  100,
+			Strict: false,
+		})
+		fmt.Println(len(result), err)
+	}
+}
@@ -0,0 +1,113 @@
+package hermes
+
+import (
+	"errors"
+	"unicode"
+)
+
+// TokenFilter is a function that decides whether a word of a value is stored in the full-text index, so tokens that aren't words,
+// such as hashes and identifiers, don't use up the maximum number of words of the index. It's called with the lowercase words
+// that are long enough to be stored, before they're reduced to their stem, and must be safe for concurrent use.
+type TokenFilter func(word string) bool
+
+// FTSetSkipNumbers is a method of the Cache struct that sets whether the numeric words, such as "2024", "3.14" and "-42", are stored
+// in the full-text index. The numeric words of a query are ignored when the index is looked up, but a query with several words still
+// only matches the values that contain the whole phrase. The full-text index is rebuilt, so the numeric words are also removed from
+// the values that are already stored.
+// This method is thread-safe.
+//
+// Parameters:
+//   - skip (bool): Whether the numeric words are not stored.
+//
+// Returns:
+//   - error: An error if the full-text index is not initialized, or the index could not be rebuilt.
+func (c *Cache) FTSetSkipNumbers(skip bool) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	// Check if the ft is initialized
+	if c.ft == nil {
+		return errors.New("full text not initialized")
+	} else if skip == c.ft.skipNumbers {
+		return nil
+	}
+
+	// Rebuild the full-text index
+	var ft *FullText = c.ft.empty()
+	ft.skipNumbers = skip
+	return c.ftReindex("FTSetSkipNumbers", ft)
+}
+
+// FTSetTokenFilter is a method of the Cache struct that sets the function that decides which words are stored in the full-text index,
+// after the minimum and maximum word lengths, the numeric words and the stop words. The words of a query that it rejects are ignored
+// when the index is looked up, but a query with several words still only matches the values that contain the whole phrase.
+// The full-text index is rebuilt, so the rejected words are also removed from the values that are already stored.
+// The function isn't stored in the snapshots, so it must be set again once a snapshot is loaded.
+// This method is thread-safe.
+//
+// Parameters:
+//   - filter (TokenFilter): The function, which returns false for the words that are not stored. If nil, every word is stored.
+//
+// Returns:
+//   - error: An error if the full-text index is not initialized, or the index could not be rebuilt.
+func (c *Cache) FTSetTokenFilter(filter TokenFilter) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	// Check if the ft is initialized
+	if c.ft == nil {
+		return errors.New("full text not initialized")
+	}
+
+	// Rebuild the full-text index
+	var ft *FullText = c.ft.empty()
+	ft.tokenFilter = filter
+	return c.ftReindex("FTSetTokenFilter", ft)
+}
+
+// filtersTokens is a method of the FullText struct that checks whether the index rejects some of the words that are long enough to be stored.
+//
+// Returns:
+//   - bool: true if the index has a maximum word length, skips the numeric words or has a token filter.
+func (ft *FullText) filtersTokens() bool {
+	return ft.maxWordLength > 0 || ft.skipNumbers || ft.tokenFilter != nil
+}
+
+// keepToken is a method of the FullText struct that checks whether a word passes the token filters of the index.
+//
+// Parameters:
+//   - word (string): The lowercase word.
+//
+// Returns:
+//   - bool: true if the word is stored in the full-text index.
+func (ft *FullText) keepToken(word string) bool {
+	switch {
+	case ft.maxWordLength > 0 && len(word) > ft.maxWordLength:
+		return false
+	case ft.skipNumbers && isNumeric(word):
+		return false
+	case ft.tokenFilter != nil:
+		return ft.tokenFilter(word)
+	}
+	return true
+}
+
+// isNumeric is a function that checks whether a word is a number, made of digits and the signs and separators of numbers.
+//
+// Parameters:
+//   - word (string): The word.
+//
+// Returns:
+//   - bool: true if the word has a digit, and only digits, dots, commas, plus and minus signs.
+func isNumeric(word string) bool {
+	var digits bool = false
+	for _, r := range word {
+		switch {
+		case unicode.IsDigit(r):
+			digits = true
+		case r != '.' && r != ',' && r != '-' && r != '+':
+			return false
+		}
+	}
+	return digits
+}
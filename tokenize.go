@@ -0,0 +1,232 @@
+package hermes
+
+import (
+	"errors"
+	"strings"
+	"unicode"
+
+	utils "github.com/realTristan/hermes/utils"
+)
+
+// words is a method of the FullText struct that splits the provided value into the words that are stored in the full-text index.
+// This is the same tokenization that is used when inserting values, so it can be used to look up the index for a value.
+//
+// Parameters:
+//   - value (string): The value to split into words.
+//
+// Returns:
+//   - []string: The words of the value that are long enough to be stored in the full-text index.
+func (ft *FullText) words(value string) []string {
+	var result []string = []string{}
+
+	// Clean the string value
+	value = strings.TrimSpace(value)
+	value = utils.RemoveDoubleSpaces(value)
+	value = strings.ToLower(value)
+
+	// Loop through the words
+	for _, word := range strings.Split(value, " ") {
+		if len(word) == 0 {
+			continue
+		} else if len(word) < ft.minWordLength {
+			continue
+		}
+
+		// Trim the word and split it by the non-alphanumeric characters
+		word = utils.TrimNonAlphaNum(word)
+		for _, w := range utils.SplitByAlphaNum(word) {
+			if len(w) >= ft.minWordLength {
+				result = append(result, w)
+			}
+		}
+	}
+
+	// Return the words
+	return result
+}
+
+// letterWords is a method of the FullText struct that splits a value into words like the words method, but keeps the letters of every alphabet,
+// so the analyzers of the languages that aren't written with Latin letters, such as AnalyzerRussian, can store their words.
+//
+// Parameters:
+//   - value (string): The lowercase value to split into words.
+//
+// Returns:
+//   - []string: The words of the value that are long enough to be stored in the full-text index.
+func (ft *FullText) letterWords(value string) []string {
+	var result []string = []string{}
+	for _, word := range strings.Fields(value) {
+		// Trim the word and split it by the characters other than letters, dashes and dots
+		word = strings.TrimFunc(word, func(r rune) bool {
+			return !unicode.IsLetter(r)
+		})
+		for _, w := range strings.FieldsFunc(word, func(r rune) bool {
+			return !unicode.IsLetter(r) && r != '-' && r != '.'
+		}) {
+			if len(w) >= ft.minWordLength {
+				result = append(result, w)
+			}
+		}
+	}
+	return result
+}
+
+// TokenRule is a type that represents how the words of a field joined by punctuation, such as "e-mail", "C++", "user_id"
+// and "example.com", are stored in the full-text index.
+type TokenRule int
+
+const (
+	// TokenDefault splits words by the characters other than letters, dashes and dots, and trims them from both ends of the words,
+	// so "e-mail" and "example.com" are kept whole, "user_id" is split into "user" and "id", and "C++" is stored as "c".
+	TokenDefault TokenRule = iota
+	// TokenSplit splits words by every character other than letters and digits, so "example.com" is stored as "example" and "com".
+	TokenSplit
+	// TokenPreserve only splits words by spaces and trims the sentence punctuation from both ends of the words,
+	// so "e-mail", "c++", "user_id" and "example.com" are stored whole.
+	TokenPreserve
+	// TokenPreserveAndSplit stores both the whole words of TokenPreserve and the parts of TokenSplit,
+	// so "user_id" can be found with "user_id", "user" and "id".
+	TokenPreserveAndSplit
+)
+
+// The punctuation that is trimmed from both ends of the words by TokenPreserve
+const sentencePunctuation string = ".,;:!?\"'()[]{}<>"
+
+// FTSetTokenRule is a method of the Cache struct that sets how the words of a field joined by punctuation are stored in the full-text index.
+// The full-text index is rebuilt, so the rule also applies to the values that are already stored.
+// This method is thread-safe.
+//
+// Parameters:
+//   - field (string): The name of the field.
+//   - rule (TokenRule): The rule of the field.
+//
+// Returns:
+//   - error: An error if the full-text index is not initialized, the rule is invalid, or the index could not be rebuilt.
+func (c *Cache) FTSetTokenRule(field string, rule TokenRule) error {
+	if rule < TokenDefault || rule > TokenPreserveAndSplit {
+		return errors.New("invalid token rule")
+	}
+
+	// Lock the mutex
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	// Check if the ft is initialized
+	if c.ft == nil {
+		return errors.New("full text not initialized")
+	}
+
+	// Copy the rules of the other fields
+	var ft *FullText = c.ft.empty()
+	ft.tokenRules = make(map[string]TokenRule, len(c.ft.tokenRules)+1)
+	for k, v := range c.ft.tokenRules {
+		ft.tokenRules[k] = v
+	}
+	if rule == TokenDefault {
+		delete(ft.tokenRules, field)
+	} else {
+		ft.tokenRules[field] = rule
+	}
+
+	// Rebuild the full-text index
+	return c.ftReindex("FTSetTokenRule", ft)
+}
+
+// FTTokenRules is a method of the Cache struct that returns a copy of the token rules of the fields that don't use TokenDefault.
+// This method is thread-safe.
+//
+// Returns:
+//   - map[string]TokenRule: The token rule of each field.
+//   - error: An error if the full-text index is not initialized.
+func (c *Cache) FTTokenRules() (map[string]TokenRule, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	// Check if the ft is initialized
+	if c.ft == nil {
+		return nil, errors.New("full text not initialized")
+	}
+
+	// Copy the rules
+	var rules map[string]TokenRule = make(map[string]TokenRule, len(c.ft.tokenRules))
+	for k, v := range c.ft.tokenRules {
+		rules[k] = v
+	}
+	return rules, nil
+}
+
+// fieldWords is a method of the FullText struct that splits the value of a field into the words that are stored in the full-text index,
+// with the token rule and analyzer of the field.
+//
+// Parameters:
+//   - field (string): The name of the field.
+//   - value (string): The value to split into words.
+//
+// Returns:
+//   - []string: The words of the value that are long enough to be stored in the full-text index.
+func (ft *FullText) fieldWords(field string, value string) []string {
+	return ft.ruleWords(ft.tokenRules[field], ft.fieldAnalyzer(field), value)
+}
+
+// ruleWords is a method of the FullText struct that splits a value into the words that are stored in the full-text index with a token rule,
+// without the stop words and reduced to their stem by an analyzer.
+//
+// Parameters:
+//   - rule (TokenRule): The token rule.
+//   - analyzer (Analyzer): The analyzer, or AnalyzerNone for the stop words and stemmer of the index.
+//   - value (string): The value to split into words.
+//
+// Returns:
+//   - []string: The words of the value that are long enough to be stored in the full-text index.
+func (ft *FullText) ruleWords(rule TokenRule, analyzer Analyzer, value string) []string {
+	switch {
+	case rule == TokenDefault && analyzer == AnalyzerNone:
+		return ft.analyze(analyzer, ft.words(value))
+	case analyzer != AnalyzerNone:
+		value = utils.Fold(strings.ToLower(value))
+	}
+	if rule == TokenDefault {
+		return ft.analyze(analyzer, ft.letterWords(value))
+	}
+
+	// Split the value by spaces
+	var result []string = []string{}
+	for _, word := range strings.Fields(strings.ToLower(value)) {
+		word = strings.Trim(word, sentencePunctuation)
+		if rule != TokenSplit && len(word) >= ft.minWordLength {
+			result = append(result, word)
+		}
+
+		// Split the word by the characters other than letters and digits
+		if rule == TokenPreserve {
+			continue
+		}
+		for _, w := range strings.FieldsFunc(word, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		}) {
+			if len(w) >= ft.minWordLength && (rule == TokenSplit || w != word) {
+				result = append(result, w)
+			}
+		}
+	}
+
+	// Return the words
+	return ft.analyze(analyzer, result)
+}
+
+// ruleSet is a method of the FullText struct that returns the distinct token rules used by the fields, including TokenDefault.
+//
+// Returns:
+//   - []TokenRule: The token rules, in ascending order.
+func (ft *FullText) ruleSet() []TokenRule {
+	var result []TokenRule = []TokenRule{TokenDefault}
+	for rule := TokenSplit; rule <= TokenPreserveAndSplit; rule++ {
+		for _, r := range ft.tokenRules {
+			if r == rule {
+				result = append(result, rule)
+				break
+			}
+		}
+	}
+	return result
+}
@@ -0,0 +1,368 @@
+package hermes
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// wordTrie is a struct that stores the words of a full-text index in a radix trie, so the words that start with a prefix
+// or are within an edit distance of a term can be looked up by walking the trie instead of scanning every word of the index.
+// The edges are only split between characters, and the edges of a node are sorted, so the words are walked in alphabetical order.
+// Words that were rejected by the limits of the full-text index may be left in the trie, so the looked up words must be verified.
+// The zero value is an empty trie.
+//
+// Fields:
+//   - root (trieNode): The root node, which is the empty word.
+type wordTrie struct {
+	root trieNode
+}
+
+// trieNode is a struct that represents a node of a wordTrie.
+//
+// Fields:
+//   - word (bool): Whether the path from the root to the node is a stored word.
+//   - edges ([]trieEdge): The edges to the child nodes, sorted by their label.
+type trieNode struct {
+	word  bool
+	edges []trieEdge
+}
+
+// trieEdge is a struct that represents an edge of a wordTrie.
+//
+// Fields:
+//   - label (string): The characters of the edge, which no other edge of the node starts with.
+//   - node (*trieNode): The child node.
+type trieEdge struct {
+	label string
+	node  *trieNode
+}
+
+// buildTrie is a function that returns a trie of the words of a full-text storage.
+//
+// Parameters:
+//   - storage (map[string]any): The full-text storage.
+//
+// Returns:
+//   - wordTrie: The trie of the words.
+func buildTrie(storage map[string]any) wordTrie {
+	var t wordTrie
+	for word := range storage {
+		t.insert(word)
+	}
+	return t
+}
+
+// candidateWords is a method of the FullText struct that looks up the stored words that may match a term of a search,
+// with the n-gram index for the words that contain the term, and the vocabulary for the words within the fuzziness of the term.
+//
+// Parameters:
+//   - term (string): The lowercase term.
+//   - sp (SearchParams): The search parameters, which decide how the stored words match the term.
+//
+// Returns:
+//   - map[string]any: The stored words that may match the term, with their storage values.
+//   - bool: false if the words that contain the term can't be looked up, so every word has to be scanned.
+func (ft *FullText) candidateWords(term string, sp SearchParams) (map[string]any, bool) {
+	var words map[string]any = map[string]any{}
+	if !sp.Strict {
+		substrings, ok := ft.substringWords(term)
+		if !ok {
+			return nil, false
+		}
+		words = substrings
+	} else if v, ok := ft.storage[term]; ok {
+		words[term] = v
+	}
+	if sp.Fuzziness == 0 {
+		return words, true
+	}
+
+	// Add the words within the fuzziness of the term, without changing the words of the n-gram index
+	var result map[string]any = make(map[string]any, len(words))
+	for word, v := range words {
+		result[word] = v
+	}
+	ft.vocab.fuzzy(term, sp.Fuzziness, func(word string) bool {
+		if v, ok := ft.storage[word]; ok {
+			result[word] = v
+		}
+		return true
+	})
+	return result, true
+}
+
+// insert is a method of the wordTrie struct that adds a word to the trie.
+//
+// Parameters:
+//   - word (string): The word.
+//
+// Returns:
+//   - None
+func (t *wordTrie) insert(word string) {
+	var n *trieNode = &t.root
+	for len(word) > 0 {
+		i, ok := n.edge(word)
+		if !ok {
+			// Add an edge with the rest of the word
+			n.edges = append(n.edges, trieEdge{})
+			copy(n.edges[i+1:], n.edges[i:])
+			n.edges[i] = trieEdge{label: word, node: &trieNode{word: true}}
+			return
+		}
+
+		// Split the edge if the word only shares a part of its label
+		var (
+			e *trieEdge = &n.edges[i]
+			p int       = commonPrefix(e.label, word)
+		)
+		if p < len(e.label) {
+			var child *trieNode = &trieNode{edges: []trieEdge{{label: e.label[p:], node: e.node}}}
+			e.label, e.node = e.label[:p], child
+		}
+		n, word = e.node, word[p:]
+	}
+	n.word = true
+}
+
+// remove is a method of the wordTrie struct that removes a word from the trie, and merges the edges that are left with a single child.
+//
+// Parameters:
+//   - word (string): The word.
+//
+// Returns:
+//   - None
+func (t *wordTrie) remove(word string) {
+	t.root.remove(word)
+}
+
+// withPrefix is a method of the wordTrie struct that calls a function with each word of the trie that starts with a prefix.
+//
+// Parameters:
+//   - prefix (string): The prefix of the words.
+//   - fn (func(word string) bool): The function, which returns false to stop the walk.
+//
+// Returns:
+//   - None
+func (t *wordTrie) withPrefix(prefix string, fn func(word string) bool) {
+	var (
+		n    *trieNode = &t.root
+		path string    = ""
+	)
+	for len(prefix) > 0 {
+		i, ok := n.edge(prefix)
+		if !ok {
+			return
+		}
+		var e trieEdge = n.edges[i]
+		switch {
+		case strings.HasPrefix(prefix, e.label):
+			prefix = prefix[len(e.label):]
+		case strings.HasPrefix(e.label, prefix):
+			prefix = ""
+		default:
+			return
+		}
+		path += e.label
+		n = e.node
+	}
+	n.walk([]byte(path), fn)
+}
+
+// fuzzy is a method of the wordTrie struct that calls a function with each word of the trie that is within an edit distance of a term,
+// using the same distance as utils.EditDistance. The distances are computed row by row along the edges, so the subtrees whose rows are
+// all above the maximum distance are skipped.
+//
+// Parameters:
+//   - term (string): The term.
+//   - max (int): The maximum edit distance.
+//   - fn (func(word string) bool): The function, which returns false to stop the walk.
+//
+// Returns:
+//   - None
+func (t *wordTrie) fuzzy(term string, max int, fn func(word string) bool) {
+	var (
+		b   []rune = []rune(term)
+		row []int  = make([]int, len(b)+1)
+	)
+	for j := range row {
+		row[j] = j
+	}
+	t.root.fuzzy(trieRows{term: b, max: max, prev: row}, []byte{}, fn)
+}
+
+// edge is a method of the trieNode struct that finds the edge that starts with the first character of a string.
+//
+// Parameters:
+//   - s (string): The non-empty string.
+//
+// Returns:
+//   - int: The index of the edge, or the index where it would be inserted.
+//   - bool: true if the node has the edge.
+func (n *trieNode) edge(s string) (int, bool) {
+	_, size := utf8.DecodeRuneInString(s)
+	var first string = s[:size]
+	for i, e := range n.edges {
+		if strings.HasPrefix(e.label, first) {
+			return i, true
+		} else if e.label > first {
+			return i, false
+		}
+	}
+	return len(n.edges), false
+}
+
+// remove is a method of the trieNode struct that removes a word below the node.
+//
+// Parameters:
+//   - word (string): The rest of the word, from the node.
+//
+// Returns:
+//   - None
+func (n *trieNode) remove(word string) {
+	if len(word) == 0 {
+		n.word = false
+		return
+	}
+	i, ok := n.edge(word)
+	if !ok || !strings.HasPrefix(word, n.edges[i].label) {
+		return
+	}
+	var e *trieEdge = &n.edges[i]
+	e.node.remove(word[len(e.label):])
+
+	// Remove the child if it's empty, or merge it with its only edge
+	switch child := e.node; {
+	case !child.word && len(child.edges) == 0:
+		n.edges = append(n.edges[:i], n.edges[i+1:]...)
+	case !child.word && len(child.edges) == 1:
+		e.label, e.node = e.label+child.edges[0].label, child.edges[0].node
+	}
+}
+
+// walk is a method of the trieNode struct that calls a function with each word below the node, in alphabetical order.
+//
+// Parameters:
+//   - path ([]byte): The characters from the root to the node.
+//   - fn (func(word string) bool): The function, which returns false to stop the walk.
+//
+// Returns:
+//   - bool: false if the walk was stopped.
+func (n *trieNode) walk(path []byte, fn func(word string) bool) bool {
+	if n.word && !fn(string(path)) {
+		return false
+	}
+	for _, e := range n.edges {
+		if !e.node.walk(append(path, e.label...), fn) {
+			return false
+		}
+	}
+	return true
+}
+
+// trieRows is a struct that holds the last rows of the edit distances between the path to a trie node and a term.
+//
+// Fields:
+//   - term ([]rune): The characters of the term.
+//   - max (int): The maximum edit distance.
+//   - prev2 ([]int): The row before the last one, or nil at the root.
+//   - prev ([]int): The distances between the path and each prefix of the term.
+//   - last (rune): The last character of the path.
+type trieRows struct {
+	term  []rune
+	max   int
+	prev2 []int
+	prev  []int
+	last  rune
+}
+
+// next is a method of the trieRows struct that computes the rows once a character is added to the path.
+//
+// Parameters:
+//   - r (rune): The character.
+//
+// Returns:
+//   - trieRows: The rows of the longer path.
+//   - bool: false if every distance of the new row is above the maximum, so no longer path can be within it.
+func (rows trieRows) next(r rune) (trieRows, bool) {
+	var (
+		curr   []int = make([]int, len(rows.prev))
+		rowMin int   = rows.prev[0] + 1
+	)
+	curr[0] = rowMin
+	for j := 1; j < len(curr); j++ {
+		var cost int = 1
+		if r == rows.term[j-1] {
+			cost = 0
+		}
+		curr[j] = minInt(minInt(rows.prev[j]+1, curr[j-1]+1), rows.prev[j-1]+cost)
+		if rows.prev2 != nil && j > 1 && r == rows.term[j-2] && rows.last == rows.term[j-1] {
+			curr[j] = minInt(curr[j], rows.prev2[j-2]+1)
+		}
+		rowMin = minInt(rowMin, curr[j])
+	}
+	return trieRows{term: rows.term, max: rows.max, prev2: rows.prev, prev: curr, last: r}, rowMin <= rows.max
+}
+
+// fuzzy is a method of the trieNode struct that calls a function with each word below the node that is within the maximum edit distance of the term.
+//
+// Parameters:
+//   - rows (trieRows): The rows of the path to the node.
+//   - path ([]byte): The characters from the root to the node.
+//   - fn (func(word string) bool): The function, which returns false to stop the walk.
+//
+// Returns:
+//   - bool: false if the walk was stopped.
+func (n *trieNode) fuzzy(rows trieRows, path []byte, fn func(word string) bool) bool {
+	if n.word && rows.prev[len(rows.term)] <= rows.max && !fn(string(path)) {
+		return false
+	}
+	for _, e := range n.edges {
+		var (
+			child trieRows = rows
+			ok    bool     = true
+		)
+		for _, r := range e.label {
+			if child, ok = child.next(r); !ok {
+				break
+			}
+		}
+		if ok && !e.node.fuzzy(child, append(path, e.label...), fn) {
+			return false
+		}
+	}
+	return true
+}
+
+// commonPrefix is a function that returns the length of the common prefix of two strings, without splitting a character.
+//
+// Parameters:
+//   - a (string): The first string.
+//   - b (string): The second string.
+//
+// Returns:
+//   - int: The length of the common prefix, in bytes.
+func commonPrefix(a string, b string) int {
+	var i int = 0
+	for i < len(a) && i < len(b) && a[i] == b[i] {
+		i++
+	}
+	for i > 0 && i < len(a) && !utf8.RuneStart(a[i]) {
+		i--
+	}
+	return i
+}
+
+// minInt is a function that returns the smaller of two integers.
+//
+// Parameters:
+//   - a (int): The first integer.
+//   - b (int): The second integer.
+//
+// Returns:
+//   - int: The smaller integer.
+func minInt(a int, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
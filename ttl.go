@@ -0,0 +1,297 @@
+package hermes
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// The interval at which expired keys are removed from the cache
+const expiryInterval time.Duration = time.Second
+
+// expirer is a struct that periodically removes the expired keys from the cache.
+//
+// Fields:
+//   - stop (chan struct{}): A channel that is closed to stop the expirer.
+//   - done (chan struct{}): A channel that is closed once the expirer goroutine has exited.
+type expirer struct {
+	stop chan struct{}
+	done chan struct{}
+}
+
+// expired is a struct that represents a key that was removed from the cache because it expired.
+//
+// Fields:
+//   - key (string): The expired key.
+//   - value (map[string]any): The value of the key when it expired.
+type expired struct {
+	key   string
+	value map[string]any
+}
+
+// SetWithTTL is a method of the Cache struct that sets a value in the cache for the specified key, which expires after the provided duration.
+// Expired keys are treated as missing by Get, Exists, the key listings and the searches, and are removed from the cache,
+// including the full-text index, by a background goroutine every second. Call Close to stop the goroutine.
+// This method is thread-safe.
+//
+// Parameters:
+//   - key (string): The key to set the value for.
+//   - value (map[string]any): The value to set.
+//   - ttl (time.Duration): The time after which the key expires.
+//
+// Returns:
+//   - error: An error if the ttl is invalid or the set fails.
+func (c *Cache) SetWithTTL(key string, value map[string]any, ttl time.Duration) error {
+	defer c.stats.setTimes.since(time.Now())
+	if ttl <= 0 {
+		return errors.New("invalid ttl")
+	}
+
+	// Lock the mutex
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	// Set the value and its expiry
+	if err := c.writable("SetWithTTL"); err != nil {
+		return err
+	} else if err := c.writeAllow(key); err != nil {
+		return err
+	} else if err := c.set(key, value); err != nil {
+		return c.setDone(key, err)
+	}
+	c.expire(key, time.Now().Add(ttl))
+	return c.setDone(key, nil)
+}
+
+// Expire is a method of the Cache struct that sets the time to live of an existing key.
+// If ttl is 0 or less, the key no longer expires.
+// This method is thread-safe.
+//
+// Parameters:
+//   - key (string): The key to set the time to live of.
+//   - ttl (time.Duration): The time after which the key expires.
+//
+// Returns:
+//   - error: An error if the key doesn't exist.
+func (c *Cache) Expire(key string, ttl time.Duration) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	// Verify that the cache isn't frozen and the key exists
+	if err := c.writable("Expire"); err != nil {
+		return err
+	} else if !c.exists(key) {
+		return fmt.Errorf("key %s does not exist", key)
+	}
+
+	// Update the expiry of the key
+	if ttl <= 0 {
+		delete(c.expiries, key)
+	} else {
+		c.expire(key, time.Now().Add(ttl))
+	}
+	c.walAdd(key)
+
+	// Return no error
+	return nil
+}
+
+// Touch is a method of the Cache struct that keeps a key alive by resetting its time to live, without rewriting its value.
+// Unlike a set, the full-text index, the version and the history of the key are not updated, the write rate limit doesn't apply,
+// and the sinks are not called.
+// This method is thread-safe.
+//
+// Parameters:
+//   - key (string): The key to refresh.
+//   - ttl (time.Duration): The new time after which the key expires.
+//
+// Returns:
+//   - error: An error if the ttl is invalid or the key doesn't exist.
+func (c *Cache) Touch(key string, ttl time.Duration) error {
+	if ttl <= 0 {
+		return errors.New("invalid ttl")
+	}
+	return c.Expire(key, ttl)
+}
+
+// TTL is a method of the Cache struct that returns the remaining time to live of a key.
+// This method is thread-safe.
+//
+// Parameters:
+//   - key (string): The key to get the time to live of.
+//
+// Returns:
+//   - time.Duration: The remaining time to live of the key.
+//   - bool: false if the key doesn't exist or doesn't expire.
+func (c *Cache) TTL(key string) (time.Duration, bool) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	// Get the expiry of the key
+	if expiry, ok := c.expiries[key]; !ok || c.expired(key) {
+		return 0, false
+	} else {
+		return time.Until(expiry), true
+	}
+}
+
+// OnExpire is a method of the Cache struct that registers a function to be called for every key that is removed because it expired.
+// The function is called with the key and its last value after the key has been removed, without holding the cache lock,
+// so it can safely call other methods of the cache, for example to persist or log the value.
+// This method is thread-safe.
+//
+// Parameters:
+//   - fn (func(key string, value map[string]any)): The function to call.
+//
+// Returns:
+//   - None
+func (c *Cache) OnExpire(fn func(key string, value map[string]any)) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.onExpire = append(c.onExpire, fn)
+}
+
+// DeleteExpired is a method of the Cache struct that removes every expired key from the cache and calls the OnExpire functions for them.
+// Expired keys are removed automatically every second, so this method only needs to be called to remove them sooner.
+// This method is thread-safe.
+//
+// Returns:
+//   - int: The number of removed keys.
+func (c *Cache) DeleteExpired() int {
+	c.mutex.Lock()
+	var removed []expired = c.deleteExpired()
+	var fns []func(key string, value map[string]any) = c.onExpire
+	c.mutex.Unlock()
+	c.stats.expired.Add(uint64(len(removed)))
+
+	// Call the expiration callbacks outside of the lock
+	for _, e := range removed {
+		for _, fn := range fns {
+			fn(e.key, e.value)
+		}
+	}
+	return len(removed)
+}
+
+// expire is a method of the Cache struct that sets the expiry of a key and starts the expirer if it isn't running.
+// This method is not thread-safe, and should only be called from an exported function.
+//
+// Parameters:
+//   - key (string): The key to set the expiry of.
+//   - expiry (time.Time): The time at which the key expires.
+//
+// Returns:
+//   - None
+func (c *Cache) expire(key string, expiry time.Time) {
+	c.expiries[key] = expiry
+	if c.expirer == nil {
+		c.expirer = &expirer{
+			stop: make(chan struct{}),
+			done: make(chan struct{}),
+		}
+		go c.expirer.run(c)
+	}
+}
+
+// expired is a method of the Cache struct that checks whether a key has expired.
+// This method is not thread-safe, and should only be called from an exported function.
+//
+// Parameters:
+//   - key (string): The key to check.
+//
+// Returns:
+//   - bool: true if the key has an expiry that has passed, false otherwise.
+func (c *Cache) expired(key string) bool {
+	expiry, ok := c.expiries[key]
+	return ok && !time.Now().Before(expiry)
+}
+
+// searchable is a method of the Cache struct that checks whether a key can be included in the results of a search.
+// The expired keys are left out like in Get, even before the expirer removes them from the full-text index.
+// The values that don't match the case of a case-sensitive strict search are left out as well.
+// This method is not thread-safe, and should only be called from an exported function.
+//
+// Parameters:
+//   - sp (SearchParams): The search parameters, with the key prefix of the results.
+//   - key (string): The key to check.
+//
+// Returns:
+//   - bool: true if the key matches the search parameters and hasn't expired, false otherwise.
+func (c *Cache) searchable(sp SearchParams, key string) bool {
+	return sp.matchesKey(key) && !c.expired(key) && c.matchesCase(sp, key)
+}
+
+// unexpired is a method of the Cache struct that leaves out the expired keys of a list of keys, in place.
+// This method is not thread-safe, and should only be called from an exported function.
+//
+// Parameters:
+//   - keys ([]string): The keys.
+//
+// Returns:
+//   - []string: The keys that haven't expired, in the same order.
+func (c *Cache) unexpired(keys []string) []string {
+	if len(c.expiries) == 0 {
+		return keys
+	}
+	var live []string = keys[:0]
+	for _, key := range keys {
+		if !c.expired(key) {
+			live = append(live, key)
+		}
+	}
+	return live
+}
+
+// deleteExpired is a method of the Cache struct that removes every expired key from the cache.
+// This method is not thread-safe, and should only be called from an exported function.
+//
+// Returns:
+//   - []expired: The removed keys and their values.
+func (c *Cache) deleteExpired() []expired {
+	var removed []expired
+	if c.frozen {
+		return removed
+	}
+	for key := range c.expiries {
+		if c.expired(key) {
+			removed = append(removed, expired{key: key, value: c.expand(c.data[key])})
+		}
+	}
+	for _, e := range removed {
+		c.delete(e.key)
+		c.historyAdd(e.key, nil)
+		c.walAdd(e.key)
+		_ = c.sinkSend(e.key, nil)
+	}
+	return removed
+}
+
+// run is a method of the expirer struct that removes the expired keys from the cache on every interval until the expirer is stopped.
+//
+// Parameters:
+//   - c (*Cache): The cache to remove the expired keys from.
+//
+// Returns:
+//   - None
+func (e *expirer) run(c *Cache) {
+	var ticker *time.Ticker = time.NewTicker(expiryInterval)
+	defer ticker.Stop()
+	defer close(e.done)
+	for {
+		select {
+		case <-e.stop:
+			return
+		case <-ticker.C:
+			c.DeleteExpired()
+		}
+	}
+}
+
+// close is a method of the expirer struct that stops the expirer and waits for it to exit.
+//
+// Returns:
+//   - None
+func (e *expirer) close() {
+	close(e.stop)
+	<-e.done
+}
@@ -0,0 +1,69 @@
+package hermes
+
+import "time"
+
+// SetExpiryField is a method of the Cache struct that sets the field from which every value takes its expiry when it's set,
+// such as "expires_at", so event-like data ages out according to its own payload instead of a global time to live.
+// The field can hold a time.Time, an RFC 3339 string, or a number of seconds since the Unix epoch. Values without the field,
+// or whose field can't be parsed, don't expire, and a value whose expiry has already passed is treated as missing.
+// An explicit time to live set with SetWithTTL or Expire overrides the field. The expiry of the current values is set when the
+// field is declared. If field is empty, values no longer take their expiry from a field, but the current expiries are kept.
+// This method is thread-safe.
+//
+// Parameters:
+//   - field (string): The field that holds the expiry of the values.
+//
+// Returns:
+//   - None
+func (c *Cache) SetExpiryField(field string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.expiryField = field
+	for key, value := range c.data {
+		c.expireFromField(key, value)
+	}
+}
+
+// expireFromField is a method of the Cache struct that sets the expiry of a key from the expiry field of its value.
+// This method is not thread-safe, and should only be called from an exported function.
+//
+// Parameters:
+//   - key (string): The key of the value.
+//   - value (map[string]any): The value.
+//
+// Returns:
+//   - None
+func (c *Cache) expireFromField(key string, value map[string]any) {
+	if len(c.expiryField) == 0 {
+		return
+	}
+	if expiry, ok := fieldTime(value[c.expiryField]); ok {
+		c.expire(key, expiry)
+	}
+}
+
+// fieldTime is a function that converts a cache value field to a time.
+//
+// Parameters:
+//   - value (any): The field value, which is a time.Time, an RFC 3339 string, or a number of seconds since the Unix epoch.
+//
+// Returns:
+//   - time.Time: The time.
+//   - bool: false if the value is not a time.
+func fieldTime(value any) (time.Time, bool) {
+	switch v := value.(type) {
+	case time.Time:
+		return v, true
+	case string:
+		if t, err := time.Parse(time.RFC3339, v); err == nil {
+			return t, true
+		}
+	case int:
+		return time.Unix(int64(v), 0), true
+	case int64:
+		return time.Unix(v, 0), true
+	case float64:
+		return time.Unix(0, int64(v*float64(time.Second))), true
+	}
+	return time.Time{}, false
+}
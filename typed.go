@@ -0,0 +1,252 @@
+package hermes
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// TypedCache is a struct that wraps a Cache to store and retrieve values of the struct type T instead of map[string]any.
+// Values are converted to maps using their JSON field names. String fields tagged with `hermes:"index"` are
+// stored in the full-text index.
+//
+// Fields:
+//   - cache (*Cache): The underlying cache.
+//   - schema (map[string]bool): The JSON field names of T that are stored in the full-text index.
+type TypedCache[T any] struct {
+	cache  *Cache
+	schema map[string]bool
+}
+
+// InitTypedCache is a function that wraps the provided cache in a TypedCache for the struct type T.
+//
+// Parameters:
+//   - cache (*Cache): The cache to wrap.
+//
+// Returns:
+//   - *TypedCache[T]: A pointer to the new TypedCache.
+//   - error: An error if the cache is nil or T is not a struct.
+func InitTypedCache[T any](cache *Cache) (*TypedCache[T], error) {
+	if cache == nil {
+		return nil, errors.New("invalid cache")
+	}
+
+	// Get the full-text schema of the struct
+	schema, err := structSchema(reflect.TypeOf(*new(T)))
+	if err != nil {
+		return nil, err
+	}
+
+	// Return the typed cache
+	return &TypedCache[T]{
+		cache:  cache,
+		schema: schema,
+	}, nil
+}
+
+// Cache is a method of the TypedCache struct that returns the underlying cache.
+//
+// Returns:
+//   - *Cache: The underlying cache.
+func (tc *TypedCache[T]) Cache() *Cache {
+	return tc.cache
+}
+
+// Set is a method of the TypedCache struct that sets a value in the cache for the specified key.
+// This function is thread-safe.
+//
+// Parameters:
+//   - key (string): The key to set the value for.
+//   - value (T): The value to set.
+//
+// Returns:
+//   - error: An error if the value could not be converted, or if the cache set fails.
+func (tc *TypedCache[T]) Set(key string, value T) error {
+	if m, err := tc.toMap(value); err != nil {
+		return err
+	} else {
+		return tc.cache.Set(key, m)
+	}
+}
+
+// Get is a method of the TypedCache struct that retrieves the value associated with the given key.
+// This function is thread-safe.
+//
+// Parameters:
+//   - key (string): The key to retrieve the value for.
+//
+// Returns:
+//   - T: The value associated with the key.
+//   - error: An error if the key doesn't exist or the value could not be converted.
+func (tc *TypedCache[T]) Get(key string) (T, error) {
+	if m := tc.cache.GetCopy(key); m == nil {
+		return *new(T), fmt.Errorf("key %s does not exist", key)
+	} else {
+		return tc.fromMap(m)
+	}
+}
+
+// Delete is a method of the TypedCache struct that removes a key from the cache.
+// This function is thread-safe.
+//
+// Parameters:
+//   - key (string): The key to remove.
+//
+// Returns:
+//   - None
+func (tc *TypedCache[T]) Delete(key string) {
+	tc.cache.Delete(key)
+}
+
+// Exists is a method of the TypedCache struct that checks if a key exists in the cache.
+// This function is thread-safe.
+//
+// Parameters:
+//   - key (string): The key to check.
+//
+// Returns:
+//   - bool: Whether the key exists.
+func (tc *TypedCache[T]) Exists(key string) bool {
+	return tc.cache.Exists(key)
+}
+
+// Values is a method of the TypedCache struct that returns all the values in the cache.
+// This function is thread-safe.
+//
+// Returns:
+//   - []T: All the values in the cache.
+//   - error: An error if a value could not be converted.
+func (tc *TypedCache[T]) Values() ([]T, error) {
+	return tc.fromMaps(tc.cache.ValuesCopy())
+}
+
+// Search is a method of the TypedCache struct that searches the full-text index and returns the matching values.
+// This function is thread-safe.
+//
+// Parameters:
+//   - sp (SearchParams): A SearchParams struct containing the search parameters.
+//
+// Returns:
+//   - []T: The matching values.
+//   - error: An error if the search fails or a value could not be converted.
+func (tc *TypedCache[T]) Search(sp SearchParams) ([]T, error) {
+	if res, err := tc.cache.Search(sp); err != nil {
+		return []T{}, err
+	} else {
+		return tc.fromMaps(res)
+	}
+}
+
+// toMap is a method of the TypedCache struct that converts a value of type T to a cache value.
+// Fields in the full-text schema are wrapped so that they're stored in the full-text index.
+//
+// Parameters:
+//   - value (T): The value to convert.
+//
+// Returns:
+//   - map[string]any: The cache value.
+//   - error: An error if the value could not be encoded.
+func (tc *TypedCache[T]) toMap(value T) (map[string]any, error) {
+	var m map[string]any
+	if data, err := json.Marshal(value); err != nil {
+		return nil, err
+	} else if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+
+	// Wrap the full-text fields
+	for field := range tc.schema {
+		if v, ok := m[field].(string); ok {
+			m[field] = &WFT{v}
+		}
+	}
+	return m, nil
+}
+
+// fromMap is a method of the TypedCache struct that converts a cache value to a value of type T.
+//
+// Parameters:
+//   - m (map[string]any): The cache value. Full-text values in the map are replaced with their underlying string.
+//
+// Returns:
+//   - T: The converted value.
+//   - error: An error if the value could not be decoded.
+func (tc *TypedCache[T]) fromMap(m map[string]any) (T, error) {
+	var value T
+
+	// Replace the full-text values with their string
+	for k, v := range m {
+		if ftv := WFTGetValue(v); len(ftv) > 0 {
+			m[k] = ftv
+		}
+	}
+
+	// Convert the map to the value
+	if data, err := json.Marshal(m); err != nil {
+		return *new(T), err
+	} else if err := json.Unmarshal(data, &value); err != nil {
+		return *new(T), err
+	}
+	return value, nil
+}
+
+// fromMaps is a method of the TypedCache struct that converts a slice of cache values to values of type T.
+//
+// Parameters:
+//   - maps ([]map[string]any): The cache values.
+//
+// Returns:
+//   - []T: The converted values.
+//   - error: An error if a value could not be decoded.
+func (tc *TypedCache[T]) fromMaps(maps []map[string]any) ([]T, error) {
+	var result []T = make([]T, 0, len(maps))
+	for _, m := range maps {
+		if v, err := tc.fromMap(copyMap(m)); err != nil {
+			return []T{}, err
+		} else {
+			result = append(result, v)
+		}
+	}
+	return result, nil
+}
+
+// structSchema is a function that returns the full-text schema of a struct type.
+// The schema contains the JSON field names of the string fields tagged with `hermes:"index"`.
+//
+// Parameters:
+//   - t (reflect.Type): The struct type, or a pointer to it.
+//
+// Returns:
+//   - map[string]bool: The full-text schema.
+//   - error: An error if the type is not a struct.
+func structSchema(t reflect.Type) (map[string]bool, error) {
+	if t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, errors.New("invalid type. the value must be a struct")
+	}
+
+	// Iterate over the struct fields
+	var schema map[string]bool = make(map[string]bool)
+	for i := 0; i < t.NumField(); i++ {
+		var field reflect.StructField = t.Field(i)
+		if !field.IsExported() || field.Tag.Get("hermes") != "index" {
+			continue
+		} else if field.Type.Kind() != reflect.String {
+			return nil, fmt.Errorf("invalid full-text field %s. only string fields can be indexed", field.Name)
+		}
+
+		// Get the JSON name of the field
+		var name string = field.Name
+		if tag := strings.Split(field.Tag.Get("json"), ",")[0]; tag == "-" {
+			continue
+		} else if len(tag) > 0 {
+			name = tag
+		}
+		schema[name] = true
+	}
+	return schema, nil
+}
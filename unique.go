@@ -0,0 +1,259 @@
+package hermes
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// unique is a struct that represents a unique constraint over one or more fields of the cache values.
+// The constraint is backed by a hash index that maps the composite value of the fields to the cache key that owns it.
+//
+// Fields:
+//   - fields ([]string): The fields that make up the composite unique value.
+//   - values (map[string]string): A map of composite values to the cache key that holds them.
+type unique struct {
+	fields []string
+	values map[string]string
+}
+
+// CreateUnique is a method of the Cache struct that declares a unique constraint over the provided fields.
+// Once declared, Set rejects any value whose fields duplicate the fields of another key.
+// Values that don't contain every field of the constraint are not constrained.
+// This method is thread-safe.
+//
+// Parameters:
+//   - fields (...string): The fields that make up the unique constraint.
+//
+// Returns:
+//   - error: An error if no fields are provided, the constraint already exists, or the current cache data already violates it.
+func (c *Cache) CreateUnique(fields ...string) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.createUnique(fields)
+}
+
+// createUnique is a method of the Cache struct that declares a unique constraint over the provided fields.
+// This method is not thread-safe, and should only be called from an exported function.
+//
+// Parameters:
+//   - fields ([]string): The fields that make up the unique constraint.
+//
+// Returns:
+//   - error: An error if no fields are provided, the constraint already exists, or the current cache data already violates it.
+func (c *Cache) createUnique(fields []string) error {
+	if len(fields) == 0 {
+		return errors.New("invalid unique constraint fields")
+	}
+
+	// Verify that the constraint doesn't already exist
+	var name string = uniqueName(fields)
+	if _, ok := c.uniques[name]; ok {
+		return fmt.Errorf("unique constraint (%s) already exists", name)
+	}
+
+	// Build the constraint index from the current data
+	var u *unique = &unique{
+		fields: append([]string{}, fields...),
+		values: make(map[string]string),
+	}
+	if err := u.build(c.data); err != nil {
+		return err
+	}
+
+	// Set the constraint
+	c.uniques[name] = u
+
+	// Return no error
+	return nil
+}
+
+// DropUnique is a method of the Cache struct that removes the unique constraint over the provided fields.
+// This method is thread-safe.
+//
+// Parameters:
+//   - fields (...string): The fields that make up the unique constraint.
+//
+// Returns:
+//   - error: An error if the constraint doesn't exist.
+func (c *Cache) DropUnique(fields ...string) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	// Verify that the constraint exists
+	var name string = uniqueName(fields)
+	if _, ok := c.uniques[name]; !ok {
+		return fmt.Errorf("unique constraint (%s) does not exist", name)
+	}
+
+	// Delete the constraint
+	delete(c.uniques, name)
+
+	// Return no error
+	return nil
+}
+
+// Uniques is a method of the Cache struct that returns the fields of every declared unique constraint.
+// This method is thread-safe.
+//
+// Returns:
+//   - [][]string: A slice containing the fields of each unique constraint.
+func (c *Cache) Uniques() [][]string {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	// Copy the fields of each constraint
+	var result [][]string = make([][]string, 0, len(c.uniques))
+	for _, u := range c.uniques {
+		result = append(result, append([]string{}, u.fields...))
+	}
+	return result
+}
+
+// uniqueCheck is a method of the Cache struct that verifies that the provided value doesn't violate any unique constraint.
+// This method is not thread-safe, and should only be called from an exported function.
+//
+// Parameters:
+//   - key (string): The cache key that the value will be set for.
+//   - value (map[string]any): The value to verify.
+//
+// Returns:
+//   - error: An error if the value violates a unique constraint.
+func (c *Cache) uniqueCheck(key string, value map[string]any) error {
+	for name, u := range c.uniques {
+		if v, ok := u.value(value); !ok {
+			continue
+		} else if owner, ok := u.values[v]; ok && owner != key {
+			return fmt.Errorf("unique constraint (%s) violated. the value already exists for key %s", name, owner)
+		}
+	}
+	return nil
+}
+
+// uniqueSet is a method of the Cache struct that adds the provided value to every unique constraint index.
+// The value must have been verified with uniqueCheck beforehand.
+// This method is not thread-safe, and should only be called from an exported function.
+//
+// Parameters:
+//   - key (string): The cache key that the value was set for.
+//   - value (map[string]any): The value that was set.
+//
+// Returns:
+//   - None
+func (c *Cache) uniqueSet(key string, value map[string]any) {
+	for _, u := range c.uniques {
+		if v, ok := u.value(value); ok {
+			u.values[v] = key
+		}
+	}
+}
+
+// uniqueDelete is a method of the Cache struct that removes the provided value from every unique constraint index.
+// This method is not thread-safe, and should only be called from an exported function.
+//
+// Parameters:
+//   - key (string): The cache key that the value belonged to.
+//   - value (map[string]any): The value that was removed.
+//
+// Returns:
+//   - None
+func (c *Cache) uniqueDelete(key string, value map[string]any) {
+	for _, u := range c.uniques {
+		if v, ok := u.value(value); ok && u.values[v] == key {
+			delete(u.values, v)
+		}
+	}
+}
+
+// uniqueBuild is a method of the Cache struct that builds a copy of every unique constraint index from the provided data.
+// The current indexes are left untouched so that the caller can swap them in once the rest of its write succeeds.
+// This method is not thread-safe, and should only be called from an exported function.
+//
+// Parameters:
+//   - data (map[string]map[string]any): The data to build the indexes from.
+//
+// Returns:
+//   - map[string]*unique: The new unique constraint indexes.
+//   - error: An error if the data violates a unique constraint.
+func (c *Cache) uniqueBuild(data map[string]map[string]any) (map[string]*unique, error) {
+	var uniques map[string]*unique = make(map[string]*unique, len(c.uniques))
+	for name, u := range c.uniques {
+		var temp *unique = &unique{
+			fields: u.fields,
+			values: make(map[string]string),
+		}
+		if err := temp.build(data); err != nil {
+			return nil, err
+		}
+		uniques[name] = temp
+	}
+	return uniques, nil
+}
+
+// build is a method of the unique struct that fills the constraint index with the provided data.
+//
+// Parameters:
+//   - data (map[string]map[string]any): The data to build the index from.
+//
+// Returns:
+//   - error: An error if two keys share the same composite value.
+func (u *unique) build(data map[string]map[string]any) error {
+	for key, value := range data {
+		if v, ok := u.value(value); !ok {
+			continue
+		} else if owner, ok := u.values[v]; ok {
+			return fmt.Errorf("unique constraint (%s) violated by keys %s and %s", uniqueName(u.fields), owner, key)
+		} else {
+			u.values[v] = key
+		}
+	}
+	return nil
+}
+
+// value is a method of the unique struct that returns the composite value of the constraint fields for the provided value.
+//
+// Parameters:
+//   - value (map[string]any): The value to get the composite value from.
+//
+// Returns:
+//   - string: The composite value.
+//   - bool: false if the value doesn't contain every field of the constraint.
+func (u *unique) value(value map[string]any) (string, bool) {
+	var values []string = make([]string, 0, len(u.fields))
+	for _, field := range u.fields {
+		if v, ok := value[field]; !ok || v == nil {
+			return "", false
+		} else {
+			values = append(values, fieldValue(v))
+		}
+	}
+	return strings.Join(values, "\x00"), true
+}
+
+// uniqueName is a function that returns the name of the unique constraint over the provided fields.
+//
+// Parameters:
+//   - fields ([]string): The fields that make up the unique constraint.
+//
+// Returns:
+//   - string: The name of the constraint.
+func uniqueName(fields []string) string {
+	return strings.Join(fields, ",")
+}
+
+// fieldValue is a function that converts a cache value field to the string used by the secondary indexes.
+// Full-text values are converted to their underlying string.
+//
+// Parameters:
+//   - value (any): The field value.
+//
+// Returns:
+//   - string: The string representation of the value.
+func fieldValue(value any) string {
+	if ftv := WFTGetValue(value); len(ftv) > 0 {
+		return ftv
+	} else if cv, ok := value.(*compressed); ok {
+		return cv.String()
+	}
+	return fmt.Sprint(value)
+}
@@ -0,0 +1,62 @@
+package utils
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+)
+
+// ReadCSV is a function that reads a CSV file with a header row into a map of values keyed by the value of a column.
+// Each row becomes a map of the header fields to the string values of the row, including the key column.
+// Parameters:
+//   - file (string): The path to the CSV file to read.
+//   - keyColumn (string): The header of the column that holds the key of each row.
+//
+// Returns:
+//   - map[string]map[string]any: The values of the rows, keyed by the value of the key column.
+//   - error: An error if the file cannot be read or parsed, the key column is missing, or a key is empty or duplicated.
+func ReadCSV(file string, keyColumn string) (map[string]map[string]any, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	// Read the rows
+	records, err := csv.NewReader(f).ReadAll()
+	if err != nil {
+		return nil, err
+	} else if len(records) == 0 {
+		return nil, fmt.Errorf("csv file %s has no header row", file)
+	}
+
+	// Find the key column
+	var header []string = records[0]
+	var keyIndex int = -1
+	for i, field := range header {
+		if field == keyColumn {
+			keyIndex = i
+			break
+		}
+	}
+	if keyIndex < 0 {
+		return nil, fmt.Errorf("csv file %s has no column %s", file, keyColumn)
+	}
+
+	// Map the rows to the header fields
+	var data map[string]map[string]any = make(map[string]map[string]any, len(records)-1)
+	for line, record := range records[1:] {
+		var key string = record[keyIndex]
+		if len(key) == 0 {
+			return nil, fmt.Errorf("csv file %s has an empty key on line %d", file, line+2)
+		} else if _, ok := data[key]; ok {
+			return nil, fmt.Errorf("csv file %s has a duplicate key %s on line %d", file, key, line+2)
+		}
+		var value map[string]any = make(map[string]any, len(header))
+		for i, field := range header {
+			value[field] = record[i]
+		}
+		data[key] = value
+	}
+	return data, nil
+}
@@ -0,0 +1,31 @@
+package utils
+
+import (
+	"strings"
+)
+
+// The replacement of each lowercase letter with a diacritic, ligature or variant form by its base letters
+var foldReplacer *strings.Replacer = strings.NewReplacer(
+	"à", "a", "á", "a", "â", "a", "ã", "a", "ä", "a", "å", "a", "ā", "a", "æ", "ae",
+	"ç", "c", "č", "c", "ď", "d", "è", "e", "é", "e", "ê", "e", "ë", "e", "ē", "e", "ě", "e",
+	"ì", "i", "í", "i", "î", "i", "ï", "i", "ī", "i", "ł", "l", "ñ", "n", "ň", "n",
+	"ò", "o", "ó", "o", "ô", "o", "õ", "o", "ö", "o", "ø", "o", "ō", "o", "œ", "oe",
+	"ř", "r", "š", "s", "ß", "ss", "ť", "t", "ù", "u", "ú", "u", "û", "u", "ü", "u", "ū", "u", "ů", "u",
+	"ý", "y", "ÿ", "y", "ž", "z", "ё", "е",
+)
+
+// Fold is a function that replaces the letters of a lowercase string that have a diacritic or are a ligature by their base letters,
+// so that "café", "Straße" and "ёлка" match "cafe", "strasse" and "елка".
+//
+// Parameters:
+//   - s (string): The lowercase string to fold.
+//
+// Returns:
+//   - string: The folded string.
+//
+// Example usage:
+//
+//	Fold("größe") // "grosse"
+func Fold(s string) string {
+	return foldReplacer.Replace(s)
+}
@@ -0,0 +1,234 @@
+package utils
+
+import (
+	"errors"
+	"strings"
+	"unicode/utf8"
+)
+
+// Glob is a compiled glob pattern.
+// The pattern syntax follows the Redis KEYS command:
+//   - * matches any sequence of characters, including an empty one.
+//   - ? matches any single character.
+//   - [abc] matches one of the characters in the brackets, [a-z] matches a range, and [^abc] matches any other character.
+//   - \ escapes the next character.
+//
+// Fields:
+//   - tokens ([]globToken): The compiled pattern.
+//   - prefix (string): The literal prefix of the pattern.
+type Glob struct {
+	tokens []globToken
+	prefix string
+}
+
+// globToken is a single element of a compiled glob pattern.
+// Fields:
+//   - kind (int): The kind of the token. One of globLiteral, globAny, globStar or globClass.
+//   - char (rune): The character matched by a literal token.
+//   - ranges ([][2]rune): The inclusive character ranges matched by a class token.
+//   - negate (bool): Whether a class token matches the characters outside of its ranges.
+type globToken struct {
+	kind   int
+	char   rune
+	ranges [][2]rune
+	negate bool
+}
+
+// The kinds of glob tokens
+const (
+	globLiteral int = iota
+	globAny
+	globStar
+	globClass
+)
+
+// CompileGlob is a function that compiles a glob pattern.
+// Parameters:
+//   - pattern (string): The glob pattern.
+//
+// Returns:
+//   - *Glob: The compiled pattern.
+//   - error: An error if the pattern has an unterminated class or a trailing escape.
+func CompileGlob(pattern string) (*Glob, error) {
+	var (
+		g       *Glob  = &Glob{}
+		literal bool   = true
+		runes   []rune = []rune(pattern)
+	)
+	for i := 0; i < len(runes); i++ {
+		var t globToken
+		switch runes[i] {
+		case '*':
+			// Merge consecutive stars
+			if n := len(g.tokens); n > 0 && g.tokens[n-1].kind == globStar {
+				continue
+			}
+			t = globToken{kind: globStar}
+		case '?':
+			t = globToken{kind: globAny}
+		case '[':
+			var end int
+			var err error
+			if t, end, err = globClassToken(runes, i); err != nil {
+				return nil, err
+			}
+			i = end
+		case '\\':
+			if i++; i == len(runes) {
+				return nil, errors.New("invalid glob pattern. trailing escape character")
+			}
+			t = globToken{kind: globLiteral, char: runes[i]}
+		default:
+			t = globToken{kind: globLiteral, char: runes[i]}
+		}
+
+		// Track the literal prefix of the pattern
+		if literal = literal && t.kind == globLiteral; literal {
+			g.prefix += string(t.char)
+		}
+		g.tokens = append(g.tokens, t)
+	}
+	return g, nil
+}
+
+// Prefix is a method of the Glob struct that returns the literal prefix of the pattern.
+// Every string matched by the pattern starts with the prefix.
+// Returns:
+//   - string: The literal prefix.
+func (g *Glob) Prefix() string {
+	return g.prefix
+}
+
+// Literals is a method of the Glob struct that returns the runs of literal characters of the pattern, such as "te" and "t" for "te?t".
+// Every string matched by the pattern contains each of the runs.
+// Returns:
+//   - []string: The runs of literal characters, in the order of the pattern.
+func (g *Glob) Literals() []string {
+	var (
+		literals []string = []string{}
+		run      string   = ""
+	)
+	for _, t := range g.tokens {
+		if t.kind == globLiteral {
+			run += string(t.char)
+			continue
+		} else if len(run) > 0 {
+			literals = append(literals, run)
+		}
+		run = ""
+	}
+	if len(run) > 0 {
+		literals = append(literals, run)
+	}
+	return literals
+}
+
+// Match is a method of the Glob struct that checks whether a string matches the pattern.
+// Parameters:
+//   - s (string): The string to check.
+//
+// Returns:
+//   - bool: true if the whole string matches the pattern, false otherwise.
+func (g *Glob) Match(s string) bool {
+	var (
+		ti, si       int = 0, 0
+		starT, starS int = -1, 0
+	)
+	for si < len(s) {
+		var r, size = utf8.DecodeRuneInString(s[si:])
+		if ti < len(g.tokens) {
+			switch t := g.tokens[ti]; {
+			case t.kind == globStar:
+				// Remember the star and try to match it against nothing first
+				starT, starS = ti, si
+				ti++
+				continue
+			case t.matches(r):
+				ti++
+				si += size
+				continue
+			}
+		}
+
+		// Backtrack to the last star and let it consume one more character
+		if starT < 0 {
+			return false
+		}
+		_, size = utf8.DecodeRuneInString(s[starS:])
+		starS += size
+		ti, si = starT+1, starS
+	}
+
+	// The remaining tokens must all be stars
+	for ; ti < len(g.tokens); ti++ {
+		if g.tokens[ti].kind != globStar {
+			return false
+		}
+	}
+	return true
+}
+
+// matches is a method of the globToken struct that checks whether a single character matches the token.
+// Parameters:
+//   - r (rune): The character to check.
+//
+// Returns:
+//   - bool: true if the character matches, false otherwise. Star tokens never match a single character.
+func (t globToken) matches(r rune) bool {
+	switch t.kind {
+	case globLiteral:
+		return t.char == r
+	case globAny:
+		return true
+	case globClass:
+		for _, rng := range t.ranges {
+			if r >= rng[0] && r <= rng[1] {
+				return !t.negate
+			}
+		}
+		return t.negate
+	}
+	return false
+}
+
+// globClassToken is a function that compiles the character class that starts at the provided index.
+// Parameters:
+//   - runes ([]rune): The glob pattern.
+//   - start (int): The index of the opening bracket.
+//
+// Returns:
+//   - globToken: The class token.
+//   - int: The index of the closing bracket.
+//   - error: An error if the class is not terminated.
+func globClassToken(runes []rune, start int) (globToken, int, error) {
+	var (
+		t globToken = globToken{kind: globClass}
+		i int       = start + 1
+	)
+	if i < len(runes) && (runes[i] == '^' || runes[i] == '!') {
+		t.negate = true
+		i++
+	}
+	for ; i < len(runes); i++ {
+		var lo rune = runes[i]
+		switch {
+		case lo == ']' && i > start+1 && !(t.negate && i == start+2):
+			return t, i, nil
+		case lo == '\\' && i+1 < len(runes):
+			i++
+			lo = runes[i]
+		}
+
+		// Check for a range
+		var hi rune = lo
+		if i+2 < len(runes) && runes[i+1] == '-' && runes[i+2] != ']' {
+			hi = runes[i+2]
+			i += 2
+			if lo > hi {
+				lo, hi = hi, lo
+			}
+		}
+		t.ranges = append(t.ranges, [2]rune{lo, hi})
+	}
+	return t, 0, errors.New("invalid glob pattern. unterminated character class " + strings.TrimSpace(string(runes[start:])))
+}
@@ -0,0 +1,187 @@
+package utils
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// suffixStemmer is a struct that reduces the words of a language to their stem by removing the longest matching suffix
+// of each of its groups in turn, which is a light stemmer that conflates the common inflections of the language.
+//
+// Fields:
+//   - alphabet (func(r rune) bool): The function that checks whether a letter belongs to the folded alphabet of the language.
+//   - minStem (int): The minimum number of letters that are kept in the stem.
+//   - groups ([][]string): The suffixes of each group, applied in order. At most one suffix of each group is removed.
+//   - replacements (map[string]string): The replacement of the suffixes that are not simply removed.
+//   - preceding (map[string]string): The letters that must precede a suffix for it to be removed, for the suffixes that are often part of the stem.
+type suffixStemmer struct {
+	alphabet     func(r rune) bool
+	minStem      int
+	groups       [][]string
+	replacements map[string]string
+	preceding    map[string]string
+}
+
+// stem is a method of the suffixStemmer struct that reduces a word to its stem.
+// Words that contain letters outside of the alphabet of the language are returned unchanged.
+//
+// Parameters:
+//   - word (string): The lowercase and folded word to stem.
+//
+// Returns:
+//   - string: The stem of the word.
+func (s *suffixStemmer) stem(word string) string {
+	for _, r := range word {
+		if !s.alphabet(r) {
+			return word
+		}
+	}
+	for _, group := range s.groups {
+		var longest string = ""
+		for _, suffix := range group {
+			if len(suffix) > len(longest) && strings.HasSuffix(word, suffix) &&
+				utf8.RuneCountInString(word)-utf8.RuneCountInString(suffix) >= s.minStem && s.precededBy(word, suffix) {
+				longest = suffix
+			}
+		}
+		if len(longest) > 0 {
+			word = word[:len(word)-len(longest)] + s.replacements[longest]
+		}
+	}
+	return word
+}
+
+// precededBy is a method of the suffixStemmer struct that checks whether the letter before a suffix of a word allows the suffix to be removed.
+//
+// Parameters:
+//   - word (string): The word.
+//   - suffix (string): The suffix of the word.
+//
+// Returns:
+//   - bool: true if the suffix has no preceding letters or is preceded by one of them, false otherwise.
+func (s *suffixStemmer) precededBy(word string, suffix string) bool {
+	letters, ok := s.preceding[suffix]
+	if !ok {
+		return true
+	}
+	r, _ := utf8.DecodeLastRuneInString(word[:len(word)-len(suffix)])
+	return strings.ContainsRune(letters, r)
+}
+
+// isLatin is a function that checks whether a letter is a lowercase ASCII letter.
+func isLatin(r rune) bool {
+	return r >= 'a' && r <= 'z'
+}
+
+// isRussian is a function that checks whether a letter is a lowercase letter of the Russian alphabet, without "ё".
+func isRussian(r rune) bool {
+	return r >= 'а' && r <= 'я'
+}
+
+// The light stemmer of each language
+var (
+	germanStemmer *suffixStemmer = &suffixStemmer{
+		alphabet: isLatin,
+		minStem:  3,
+		groups: [][]string{
+			{"ern", "em", "er", "en", "es", "e", "s"},
+			{"est", "st", "en", "er"},
+			{"isch", "lich", "heit", "keit", "ung", "end", "ig", "ik"},
+		},
+		preceding: map[string]string{"s": "bdfghklmnrt", "st": "bdfghklmnt"},
+	}
+	frenchStemmer *suffixStemmer = &suffixStemmer{
+		alphabet: isLatin,
+		minStem:  3,
+		groups: [][]string{
+			{"s", "x", "aux", "eaux"},
+			{
+				"issement", "atrice", "ateur", "ation", "ement", "ment", "ance", "ence", "able", "isme", "iste", "eur", "euse",
+				"eux", "ite", "ive", "if",
+			},
+			{
+				"erions", "eriez", "eraient", "erais", "erait", "erons", "eront", "irent", "issons", "issez", "issent", "issant",
+				"isse", "iront", "ira", "aient", "ais", "ait", "ant", "ante", "iez", "ez", "er", "ir", "ee", "e",
+			},
+		},
+		replacements: map[string]string{"aux": "al", "eaux": "eau"},
+	}
+	spanishStemmer *suffixStemmer = &suffixStemmer{
+		alphabet: isLatin,
+		minStem:  3,
+		groups: [][]string{
+			{
+				"amientos", "imientos", "amiento", "imiento", "aciones", "acion", "adoras", "adores", "adora", "ador",
+				"ancias", "ancia", "antes", "ante", "ables", "able", "ibles", "ible", "istas", "ista", "mente",
+				"idades", "idad", "osos", "osas", "oso", "osa", "ivos", "ivas", "ivo", "iva",
+			},
+			{
+				"ariamos", "eriamos", "iriamos", "aremos", "eremos", "iremos", "abamos", "ieron", "aron", "iendo", "ando",
+				"aban", "aba", "ados", "adas", "ado", "ada", "idos", "idas", "ido", "ida", "ar", "er", "ir",
+				"as", "es", "os", "a", "e", "o", "s",
+			},
+		},
+	}
+	russianStemmer *suffixStemmer = &suffixStemmer{
+		alphabet: isRussian,
+		minStem:  2,
+		groups: [][]string{
+			{"ся", "сь"},
+			{
+				"ыми", "ими", "ого", "его", "ому", "ему", "ами", "ями", "ешь", "ишь", "ете", "ите", "ала", "ила",
+				"ая", "яя", "ое", "ее", "ые", "ие", "ой", "ей", "ий", "ый", "ую", "юю", "ов", "ев", "ам", "ям", "ах", "ях",
+				"ом", "ем", "ть", "ла", "ли", "ло", "ет", "ит", "ут", "ют", "ат", "ят", "ал", "ил", "ыл", "ел",
+				"а", "я", "о", "е", "ы", "и", "у", "ю", "й", "ь",
+			},
+			{"ость", "ост"},
+		},
+	}
+)
+
+// GermanStem is a function that reduces a German word to its stem with a light suffix-stripping stemmer,
+// so that "häuser" and "haus" are both reduced to "haus" once they're folded.
+// Words that contain characters other than lowercase ASCII letters are returned unchanged, so the words must be folded with Fold first.
+// Parameters:
+//   - word (string): The lowercase and folded word to stem.
+//
+// Returns:
+//   - string: The stem of the word.
+func GermanStem(word string) string {
+	return germanStemmer.stem(word)
+}
+
+// FrenchStem is a function that reduces a French word to its stem with a light suffix-stripping stemmer,
+// so that "gouvernement", "gouverner" and "gouvernés" are all reduced to "gouvern" once they're folded.
+// Words that contain characters other than lowercase ASCII letters are returned unchanged, so the words must be folded with Fold first.
+// Parameters:
+//   - word (string): The lowercase and folded word to stem.
+//
+// Returns:
+//   - string: The stem of the word.
+func FrenchStem(word string) string {
+	return frenchStemmer.stem(word)
+}
+
+// SpanishStem is a function that reduces a Spanish word to its stem with a light suffix-stripping stemmer,
+// so that "corriendo", "corrieron" and "corre" are all reduced to "corr".
+// Words that contain characters other than lowercase ASCII letters are returned unchanged, so the words must be folded with Fold first.
+// Parameters:
+//   - word (string): The lowercase and folded word to stem.
+//
+// Returns:
+//   - string: The stem of the word.
+func SpanishStem(word string) string {
+	return spanishStemmer.stem(word)
+}
+
+// RussianStem is a function that reduces a Russian word to its stem with a light suffix-stripping stemmer,
+// so that "книга", "книги" and "книгами" are all reduced to "книг".
+// Words that contain characters other than lowercase Russian letters are returned unchanged, so "ё" must be folded with Fold first.
+// Parameters:
+//   - word (string): The lowercase and folded word to stem.
+//
+// Returns:
+//   - string: The stem of the word.
+func RussianStem(word string) string {
+	return russianStemmer.stem(word)
+}
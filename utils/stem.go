@@ -0,0 +1,222 @@
+package utils
+
+import (
+	"strings"
+)
+
+// PorterStem is a function that reduces an English word to its stem with the Porter stemming algorithm,
+// so that "running", "runs" and "run" are all reduced to "run".
+// Words that are shorter than 3 characters or contain characters other than lowercase ASCII letters are returned unchanged.
+// Parameters:
+//   - word (string): The lowercase word to stem.
+//
+// Returns:
+//   - string: The stem of the word.
+func PorterStem(word string) string {
+	if len(word) < 3 {
+		return word
+	}
+	for i := 0; i < len(word); i++ {
+		if word[i] < 'a' || word[i] > 'z' {
+			return word
+		}
+	}
+
+	// Apply the steps of the algorithm
+	var p *porter = &porter{b: []byte(word)}
+	p.step1a()
+	p.step1b()
+	p.step1c()
+	p.step2()
+	p.step3()
+	p.step4()
+	p.step5()
+	return string(p.b)
+}
+
+// porter is a struct that holds the word being stemmed by PorterStem.
+type porter struct {
+	b []byte
+}
+
+// The suffixes of step 2 and their replacements. A suffix that ends with another suffix comes before it.
+var porterStep2 [][2]string = [][2]string{
+	{"ational", "ate"}, {"tional", "tion"}, {"enci", "ence"}, {"anci", "ance"}, {"izer", "ize"}, {"bli", "ble"},
+	{"alli", "al"}, {"entli", "ent"}, {"eli", "e"}, {"ousli", "ous"}, {"ization", "ize"}, {"ation", "ate"},
+	{"ator", "ate"}, {"alism", "al"}, {"iveness", "ive"}, {"fulness", "ful"}, {"ousness", "ous"}, {"aliti", "al"},
+	{"iviti", "ive"}, {"biliti", "ble"}, {"logi", "log"},
+}
+
+// The suffixes of step 3 and their replacements.
+var porterStep3 [][2]string = [][2]string{
+	{"icate", "ic"}, {"ative", ""}, {"alize", "al"}, {"iciti", "ic"}, {"ical", "ic"}, {"ful", ""}, {"ness", ""},
+}
+
+// The suffixes that are removed by step 4. A suffix that ends with another suffix comes before it.
+var porterStep4 []string = []string{
+	"al", "ance", "ence", "er", "ic", "able", "ible", "ant", "ement", "ment", "ent", "ion", "ou", "ism", "ate", "iti", "ous", "ive", "ize",
+}
+
+// cons is a method of the porter struct that checks whether the letter at an index is a consonant.
+// A "y" is a consonant when it starts the word or follows a vowel.
+func (p *porter) cons(i int) bool {
+	switch p.b[i] {
+	case 'a', 'e', 'i', 'o', 'u':
+		return false
+	case 'y':
+		return i == 0 || !p.cons(i-1)
+	}
+	return true
+}
+
+// measure is a method of the porter struct that returns the number of vowel-consonant sequences in the first n letters of the word.
+func (p *porter) measure(n int) int {
+	var m, i int = 0, 0
+	for i < n && p.cons(i) {
+		i++
+	}
+	for i < n {
+		for i < n && !p.cons(i) {
+			i++
+		}
+		if i >= n {
+			break
+		}
+		for i < n && p.cons(i) {
+			i++
+		}
+		m++
+	}
+	return m
+}
+
+// hasVowel is a method of the porter struct that checks whether the first n letters of the word contain a vowel.
+func (p *porter) hasVowel(n int) bool {
+	for i := 0; i < n; i++ {
+		if !p.cons(i) {
+			return true
+		}
+	}
+	return false
+}
+
+// doubleCons is a method of the porter struct that checks whether the first n letters of the word end with a double consonant.
+func (p *porter) doubleCons(n int) bool {
+	return n >= 2 && p.b[n-1] == p.b[n-2] && p.cons(n-1)
+}
+
+// cvc is a method of the porter struct that checks whether the first n letters of the word end with a consonant, a vowel
+// and a consonant other than "w", "x" and "y", such as "hop" or "fil".
+func (p *porter) cvc(n int) bool {
+	if n < 3 || !p.cons(n-1) || p.cons(n-2) || !p.cons(n-3) {
+		return false
+	}
+	return !strings.ContainsRune("wxy", rune(p.b[n-1]))
+}
+
+// ends is a method of the porter struct that checks whether the word ends with a suffix.
+func (p *porter) ends(suffix string) bool {
+	return strings.HasSuffix(string(p.b), suffix)
+}
+
+// replace is a method of the porter struct that replaces the suffix of the word if the measure of the rest of the word
+// is greater than min.
+func (p *porter) replace(suffix string, replacement string, min int) {
+	var stem int = len(p.b) - len(suffix)
+	if p.measure(stem) > min {
+		p.b = append(p.b[:stem], replacement...)
+	}
+}
+
+// step1a is a method of the porter struct that removes plurals, such as "caresses" to "caress" and "ponies" to "poni".
+func (p *porter) step1a() {
+	switch {
+	case p.ends("sses"), p.ends("ies"):
+		p.b = p.b[:len(p.b)-2]
+	case p.ends("ss"):
+	case p.ends("s"):
+		p.b = p.b[:len(p.b)-1]
+	}
+}
+
+// step1b is a method of the porter struct that removes "ed" and "ing", such as "agreed" to "agree" and "hopping" to "hop".
+func (p *porter) step1b() {
+	if p.ends("eed") {
+		p.replace("eed", "ee", 0)
+		return
+	}
+	var n int
+	switch {
+	case p.ends("ed") && p.hasVowel(len(p.b)-2):
+		n = len(p.b) - 2
+	case p.ends("ing") && p.hasVowel(len(p.b)-3):
+		n = len(p.b) - 3
+	default:
+		return
+	}
+
+	// Restore the "e" of the stem or remove its double consonant
+	p.b = p.b[:n]
+	switch {
+	case p.ends("at"), p.ends("bl"), p.ends("iz"):
+		p.b = append(p.b, 'e')
+	case p.doubleCons(n) && !strings.ContainsRune("lsz", rune(p.b[n-1])):
+		p.b = p.b[:n-1]
+	case p.measure(n) == 1 && p.cvc(n):
+		p.b = append(p.b, 'e')
+	}
+}
+
+// step1c is a method of the porter struct that replaces a final "y" with "i" when the rest of the word contains a vowel.
+func (p *porter) step1c() {
+	if p.ends("y") && p.hasVowel(len(p.b)-1) {
+		p.b[len(p.b)-1] = 'i'
+	}
+}
+
+// step2 is a method of the porter struct that reduces double suffixes, such as "relational" to "relate".
+func (p *porter) step2() {
+	for _, s := range porterStep2 {
+		if p.ends(s[0]) {
+			p.replace(s[0], s[1], 0)
+			return
+		}
+	}
+}
+
+// step3 is a method of the porter struct that reduces the suffixes "-ic-", "-full" and "-ness", such as "hopeful" to "hope".
+func (p *porter) step3() {
+	for _, s := range porterStep3 {
+		if p.ends(s[0]) {
+			p.replace(s[0], s[1], 0)
+			return
+		}
+	}
+}
+
+// step4 is a method of the porter struct that removes the remaining suffixes of longer words, such as "adjustment" to "adjust".
+func (p *porter) step4() {
+	for _, s := range porterStep4 {
+		if !p.ends(s) {
+			continue
+		} else if s == "ion" && (len(p.b) < 4 || !strings.ContainsRune("st", rune(p.b[len(p.b)-4]))) {
+			return
+		}
+		p.replace(s, "", 1)
+		return
+	}
+}
+
+// step5 is a method of the porter struct that removes a final "e" and a final double "l" of longer words, such as "probate" to "probat".
+func (p *porter) step5() {
+	var n int = len(p.b)
+	if p.ends("e") {
+		if m := p.measure(n - 1); m > 1 || (m == 1 && !p.cvc(n-1)) {
+			p.b = p.b[:n-1]
+			n--
+		}
+	}
+	if p.measure(n) > 1 && p.doubleCons(n) && p.b[n-1] == 'l' {
+		p.b = p.b[:n-1]
+	}
+}
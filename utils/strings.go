@@ -121,3 +121,63 @@ func Contains(s1 string, s2 string) bool {
 	}
 	return false
 }
+
+// EditDistance is a function that returns the number of single-character insertions, deletions, substitutions and transpositions
+// of adjacent characters needed to turn one string into another, which is the optimal string alignment distance.
+// The computation stops early once the distance exceeds max, to keep comparisons against many words cheap.
+// Parameters:
+//   - s1 (string): The first string.
+//   - s2 (string): The second string.
+//   - max (int): The largest distance of interest.
+//
+// Returns:
+//   - int: The distance between the strings, or max + 1 if it's larger than max.
+func EditDistance(s1 string, s2 string, max int) int {
+	var a, b []rune = []rune(s1), []rune(s2)
+	if d := len(a) - len(b); d > max || -d > max {
+		return max + 1
+	}
+
+	// Compute the distances row by row, keeping the two previous rows for transpositions
+	var prev2, prev, curr []int = make([]int, len(b)+1), make([]int, len(b)+1), make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		var rowMin int = curr[0]
+		for j := 1; j <= len(b); j++ {
+			var cost int = 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = minInt(minInt(prev[j]+1, curr[j-1]+1), prev[j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				curr[j] = minInt(curr[j], prev2[j-2]+1)
+			}
+			rowMin = minInt(rowMin, curr[j])
+		}
+		if rowMin > max {
+			return max + 1
+		}
+		prev2, prev, curr = prev, curr, prev2
+	}
+	if prev[len(b)] > max {
+		return max + 1
+	}
+	return prev[len(b)]
+}
+
+// minInt is a function that returns the smaller of two integers.
+// Parameters:
+//   - a (int): The first integer.
+//   - b (int): The second integer.
+//
+// Returns:
+//   - int: The smaller integer.
+func minInt(a int, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,112 @@
+package utils
+
+import (
+	"strings"
+	"unicode"
+)
+
+// The Latin transliteration of each lowercase Cyrillic letter
+var cyrillicToLatin map[rune]string = map[rune]string{
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "e", 'ж': "zh",
+	'з': "z", 'и': "i", 'й': "y", 'к': "k", 'л': "l", 'м': "m", 'н': "n", 'о': "o",
+	'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u", 'ф': "f", 'х': "kh", 'ц': "ts",
+	'ч': "ch", 'ш': "sh", 'щ': "shch", 'ъ': "", 'ы': "y", 'ь': "", 'э': "e", 'ю': "yu",
+	'я': "ya", 'і': "i", 'ї': "yi", 'є': "ye", 'ґ': "g",
+}
+
+// The Cyrillic transliteration of the lowercase Latin letter sequences, longest first
+var latinToCyrillic []struct{ latin, cyrillic string } = []struct{ latin, cyrillic string }{
+	{"shch", "щ"}, {"zh", "ж"}, {"kh", "х"}, {"ts", "ц"}, {"ch", "ч"}, {"sh", "ш"}, {"yu", "ю"}, {"ya", "я"},
+	{"a", "а"}, {"b", "б"}, {"c", "ц"}, {"d", "д"}, {"e", "е"}, {"f", "ф"}, {"g", "г"}, {"h", "х"},
+	{"i", "и"}, {"j", "й"}, {"k", "к"}, {"l", "л"}, {"m", "м"}, {"n", "н"}, {"o", "о"}, {"p", "п"},
+	{"q", "к"}, {"r", "р"}, {"s", "с"}, {"t", "т"}, {"u", "у"}, {"v", "в"}, {"w", "в"}, {"x", "кс"},
+	{"y", "й"}, {"z", "з"},
+}
+
+// The keys of the QWERTY layout and the letters of the same keys on the Russian ЙЦУКЕН layout
+const (
+	qwertyKeys string = "`qwertyuiop[]asdfghjkl;'zxcvbnm,."
+	jcukenKeys string = "ёйцукенгшщзхъфывапролджэячсмитьбю"
+)
+
+// The letter typed on the other keyboard layout for each key
+var layoutSwitch map[rune]rune = func() map[rune]rune {
+	var (
+		m      map[rune]rune = map[rune]rune{}
+		qwerty []rune        = []rune(qwertyKeys)
+		jcuken []rune        = []rune(jcukenKeys)
+	)
+	for i := range qwerty {
+		m[qwerty[i]] = jcuken[i]
+		m[jcuken[i]] = qwerty[i]
+	}
+	return m
+}()
+
+// Transliterate is a function that transliterates a lowercase string between the Cyrillic and Latin scripts.
+// If the string contains a Cyrillic letter, it's transliterated to Latin, otherwise it's transliterated to Cyrillic.
+// Characters that are not letters of the source script are kept as is.
+//
+// Parameters:
+//   - s (string): The lowercase string to transliterate.
+//
+// Returns:
+//   - string: The transliterated string.
+//
+// Example usage:
+//
+//	Transliterate("москва") // "moskva"
+//	Transliterate("moskva") // "москва"
+func Transliterate(s string) string {
+	var b strings.Builder
+	if strings.IndexFunc(s, func(r rune) bool { return unicode.Is(unicode.Cyrillic, r) }) >= 0 {
+		for _, r := range s {
+			if latin, ok := cyrillicToLatin[r]; ok {
+				b.WriteString(latin)
+			} else {
+				b.WriteRune(r)
+			}
+		}
+		return b.String()
+	}
+
+	// Transliterate the longest Latin sequence at each position
+	for i := 0; i < len(s); {
+		var matched bool = false
+		for _, t := range latinToCyrillic {
+			if strings.HasPrefix(s[i:], t.latin) {
+				b.WriteString(t.cyrillic)
+				i += len(t.latin)
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			b.WriteByte(s[i])
+			i++
+		}
+	}
+	return b.String()
+}
+
+// SwitchLayout is a function that converts a lowercase string typed with the wrong keyboard layout between the QWERTY and
+// Russian ЙЦУКЕН layouts, by replacing each character with the character of the same key on the other layout.
+//
+// Parameters:
+//   - s (string): The lowercase string to convert.
+//
+// Returns:
+//   - string: The converted string.
+//
+// Example usage:
+//
+//	SwitchLayout("ghbdtn") // "привет"
+//	SwitchLayout("руддщ") // "hello"
+func SwitchLayout(s string) string {
+	return strings.Map(func(r rune) rune {
+		if switched, ok := layoutSwitch[r]; ok {
+			return switched
+		}
+		return r
+	}, s)
+}
@@ -0,0 +1,277 @@
+package utils
+
+import (
+	"sort"
+	"strings"
+)
+
+// Trie is a radix tree that stores a set of strings in sorted order.
+// Each node stores the part of the string that it adds to its parent, so a chain of
+// nodes with a single child is merged into one node.
+// Fields:
+//   - root (*trieNode): The root node of the tree. Its prefix is always empty.
+//   - size (int): The number of strings stored in the tree.
+type Trie struct {
+	root *trieNode
+	size int
+}
+
+// trieNode is a node of a Trie.
+// Fields:
+//   - prefix (string): The part of the string that the node adds to its parent.
+//   - children ([]*trieNode): The child nodes, sorted by the first byte of their prefix.
+//   - end (bool): Whether a string ends at this node.
+type trieNode struct {
+	prefix   string
+	children []*trieNode
+	end      bool
+}
+
+// NewTrie is a function that creates a new empty Trie.
+// Returns:
+//   - *Trie: A pointer to the new Trie.
+func NewTrie() *Trie {
+	return &Trie{root: &trieNode{}}
+}
+
+// Len is a method of the Trie struct that returns the number of strings stored in the tree.
+// Returns:
+//   - int: The number of strings.
+func (t *Trie) Len() int {
+	return t.size
+}
+
+// Insert is a method of the Trie struct that adds a string to the tree.
+// Parameters:
+//   - s (string): The string to add.
+//
+// Returns:
+//   - bool: true if the string was added, false if it was already stored.
+func (t *Trie) Insert(s string) bool {
+	var n *trieNode = t.root
+	for {
+		if len(s) == 0 {
+			if n.end {
+				return false
+			}
+			n.end = true
+			t.size++
+			return true
+		}
+
+		// Find the child that shares the first byte
+		var i, child = n.child(s[0])
+		if child == nil {
+			n.children = append(n.children, nil)
+			copy(n.children[i+1:], n.children[i:])
+			n.children[i] = &trieNode{prefix: s, end: true}
+			t.size++
+			return true
+		}
+
+		// Descend if the child prefix is fully shared
+		var l int = commonPrefix(child.prefix, s)
+		if l == len(child.prefix) {
+			n, s = child, s[l:]
+			continue
+		}
+
+		// Split the child at the shared prefix
+		var split *trieNode = &trieNode{prefix: child.prefix[:l], children: []*trieNode{child}}
+		child.prefix = child.prefix[l:]
+		n.children[i] = split
+		n, s = split, s[l:]
+	}
+}
+
+// Delete is a method of the Trie struct that removes a string from the tree.
+// Parameters:
+//   - s (string): The string to remove.
+//
+// Returns:
+//   - bool: true if the string was removed, false if it wasn't stored.
+func (t *Trie) Delete(s string) bool {
+	if t.root.delete(s) {
+		t.size--
+		return true
+	}
+	return false
+}
+
+// Contains is a method of the Trie struct that checks whether a string is stored in the tree.
+// Parameters:
+//   - s (string): The string to look up.
+//
+// Returns:
+//   - bool: true if the string is stored, false otherwise.
+func (t *Trie) Contains(s string) bool {
+	var n *trieNode = t.root
+	for len(s) > 0 {
+		var _, child = n.child(s[0])
+		if child == nil || !strings.HasPrefix(s, child.prefix) {
+			return false
+		}
+		n, s = child, s[len(child.prefix):]
+	}
+	return n.end
+}
+
+// WithPrefix is a method of the Trie struct that returns the stored strings that start with the provided prefix, in sorted order.
+// Parameters:
+//   - prefix (string): The prefix of the strings.
+//   - limit (int): The maximum number of strings to return. If less than 1, every matching string is returned.
+//
+// Returns:
+//   - []string: The matching strings.
+func (t *Trie) WithPrefix(prefix string, limit int) []string {
+	var result []string = []string{}
+	t.Walk(prefix, func(s string) bool {
+		result = append(result, s)
+		return limit < 1 || len(result) < limit
+	})
+	return result
+}
+
+// Walk is a method of the Trie struct that calls fn for each stored string that starts with the provided prefix, in sorted order.
+// The walk stops when fn returns false.
+// Parameters:
+//   - prefix (string): The prefix of the strings.
+//   - fn (func(s string) bool): The function to call for each string.
+//
+// Returns:
+//   - None
+func (t *Trie) Walk(prefix string, fn func(s string) bool) {
+	var (
+		n   *trieNode = t.root
+		acc string    = ""
+		p   string    = prefix
+	)
+	for len(p) > 0 {
+		var _, child = n.child(p[0])
+		switch {
+		case child == nil:
+			return
+		case strings.HasPrefix(p, child.prefix):
+			acc, p = acc+child.prefix, p[len(child.prefix):]
+			n = child
+		case strings.HasPrefix(child.prefix, p):
+			acc, p = acc+child.prefix, ""
+			n = child
+		default:
+			return
+		}
+	}
+	n.walk(acc, fn)
+}
+
+// WalkNodes is a method of the Trie struct that walks the tree depth-first in sorted order, calling fn with the
+// string accumulated at each node and whether a stored string ends there. Children of a node are skipped when fn returns false.
+// This allows callers to prune the walk, for example when matching patterns or bounded edit distances.
+// Parameters:
+//   - fn (func(s string, end bool) bool): The function to call for each node.
+//
+// Returns:
+//   - None
+func (t *Trie) WalkNodes(fn func(s string, end bool) bool) {
+	t.root.walkNodes("", fn)
+}
+
+// walk is a method of the trieNode struct that calls fn for each string stored under the node until fn returns false.
+// Parameters:
+//   - acc (string): The string accumulated up to and including the node.
+//   - fn (func(s string) bool): The function to call for each string.
+//
+// Returns:
+//   - bool: false if the walk was stopped.
+func (n *trieNode) walk(acc string, fn func(s string) bool) bool {
+	if n.end && !fn(acc) {
+		return false
+	}
+	for _, child := range n.children {
+		if !child.walk(acc+child.prefix, fn) {
+			return false
+		}
+	}
+	return true
+}
+
+// walkNodes is a method of the trieNode struct that calls fn for the node and, unless fn returns false, its children.
+// Parameters:
+//   - acc (string): The string accumulated up to and including the node.
+//   - fn (func(s string, end bool) bool): The function to call for each node.
+//
+// Returns:
+//   - None
+func (n *trieNode) walkNodes(acc string, fn func(s string, end bool) bool) {
+	if !fn(acc, n.end) {
+		return
+	}
+	for _, child := range n.children {
+		child.walkNodes(acc+child.prefix, fn)
+	}
+}
+
+// delete is a method of the trieNode struct that removes a string stored under the node, pruning and merging the emptied nodes.
+// Parameters:
+//   - s (string): The string to remove, relative to the node.
+//
+// Returns:
+//   - bool: true if the string was removed.
+func (n *trieNode) delete(s string) bool {
+	if len(s) == 0 {
+		if !n.end {
+			return false
+		}
+		n.end = false
+		return true
+	}
+
+	// Find the child that holds the string
+	var i, child = n.child(s[0])
+	if child == nil || !strings.HasPrefix(s, child.prefix) || !child.delete(s[len(child.prefix):]) {
+		return false
+	}
+
+	// Remove or merge the child if it's no longer needed
+	switch {
+	case !child.end && len(child.children) == 0:
+		n.children = append(n.children[:i], n.children[i+1:]...)
+	case !child.end && len(child.children) == 1:
+		var grandchild *trieNode = child.children[0]
+		grandchild.prefix = child.prefix + grandchild.prefix
+		n.children[i] = grandchild
+	}
+	return true
+}
+
+// child is a method of the trieNode struct that finds the child whose prefix starts with the provided byte.
+// Parameters:
+//   - b (byte): The first byte of the child prefix.
+//
+// Returns:
+//   - int: The index of the child, or the index that it should be inserted at if it doesn't exist.
+//   - *trieNode: The child, or nil if it doesn't exist.
+func (n *trieNode) child(b byte) (int, *trieNode) {
+	var i int = sort.Search(len(n.children), func(i int) bool {
+		return n.children[i].prefix[0] >= b
+	})
+	if i < len(n.children) && n.children[i].prefix[0] == b {
+		return i, n.children[i]
+	}
+	return i, nil
+}
+
+// commonPrefix is a function that returns the length of the common prefix of two strings.
+// Parameters:
+//   - a (string): The first string.
+//   - b (string): The second string.
+//
+// Returns:
+//   - int: The length of the common prefix.
+func commonPrefix(a string, b string) int {
+	var i int = 0
+	for i < len(a) && i < len(b) && a[i] == b[i] {
+		i++
+	}
+	return i
+}
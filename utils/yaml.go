@@ -0,0 +1,26 @@
+package utils
+
+import (
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ReadYaml is a generic function that reads a YAML file and unmarshals its contents into a provided value of type T.
+// Parameters:
+//   - file (string): The path to the YAML file to read.
+//
+// Returns:
+//   - T: The unmarshalled value of type T.
+//   - error: An error if the file cannot be read or the unmarshalling fails, or nil if successful.
+func ReadYaml[T any](file string) (T, error) {
+	var v T
+
+	// Read the yaml data
+	if data, err := os.ReadFile(file); err != nil {
+		return *new(T), err
+	} else if err := yaml.Unmarshal(data, &v); err != nil {
+		return *new(T), err
+	}
+	return v, nil
+}
@@ -1,6 +1,7 @@
 package hermes
 
 // Values is a method of the Cache struct that gets all the values in the cache.
+// The returned maps are the ones stored in the cache, so they must not be modified. Use ValuesCopy to get copies that can be.
 // This function is thread-safe.
 //
 // Returns:
@@ -19,6 +20,7 @@ func (c *Cache) Values() []map[string]any {
 func (c *Cache) values() []map[string]any {
 	values := make([]map[string]any, 0, len(c.data))
 	for _, value := range c.data {
+		value = c.expand(value)
 		values = append(values, value)
 	}
 	return values
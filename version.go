@@ -0,0 +1,213 @@
+package hermes
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrVersionMismatch is the error returned by conditional writes when the current version of a key doesn't match the expected version.
+var ErrVersionMismatch = errors.New("version mismatch")
+
+// ErrReplaceSkipped is the error returned by Replace and Update when the new value is skipped, because it's malformed in lenient mode
+// or duplicates the full-text content of another value with DuplicateSkip, so the key keeps its previous value.
+var ErrReplaceSkipped = errors.New("new value skipped, the key keeps its previous value")
+
+// Version is a method of the Cache struct that returns the version of the value associated with the given key.
+// The version changes every time the value is set, so it can be used for optimistic concurrency control with Replace and Update.
+// This method is thread-safe.
+//
+// Parameters:
+//   - key (string): The key to get the version of.
+//
+// Returns:
+//   - uint64: The version of the value.
+//   - bool: false if the key doesn't exist.
+func (c *Cache) Version(key string) (uint64, bool) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	version, ok := c.versions[key]
+	return version, ok
+}
+
+// GetWithVersion is a method of the Cache struct that retrieves the value associated with the given key along with its version.
+// The value and the version are read atomically.
+// This method is thread-safe.
+//
+// Parameters:
+//   - key (string): The key to retrieve the value for.
+//
+// Returns:
+//   - map[string]any: The value associated with the key.
+//   - uint64: The version of the value.
+//   - bool: false if the key doesn't exist.
+func (c *Cache) GetWithVersion(key string) (map[string]any, uint64, bool) {
+	defer c.stats.getTimes.since(time.Now())
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	version, ok := c.versions[key]
+	return c.get(key), version, ok
+}
+
+// Replace is a method of the Cache struct that replaces the value of an existing key.
+// If version is not 0, the value is only replaced if the current version of the key matches it.
+// This method is thread-safe.
+//
+// Parameters:
+//   - key (string): The key to replace the value of.
+//   - value (map[string]any): The new value.
+//   - version (uint64): The expected version of the key, or 0 to replace the value unconditionally.
+//
+// Returns:
+//   - error: ErrVersionMismatch if the version doesn't match, ErrReplaceSkipped if the new value is skipped, or an error if the key
+//     doesn't exist or the set fails.
+func (c *Cache) Replace(key string, value map[string]any, version uint64) error {
+	defer c.stats.setTimes.since(time.Now())
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	// Verify the version of the key and the write rate limit
+	if err := c.writable("Replace"); err != nil {
+		return err
+	} else if err := c.versionCheck(key, version); err != nil {
+		return err
+	} else if err := c.writeAllow(key); err != nil {
+		return err
+	}
+
+	// Replace the value
+	return c.setDone(key, c.replace(key, value))
+}
+
+// Update is a method of the Cache struct that merges the provided fields into the value of an existing key.
+// Fields with a nil value are removed from the value. Full-text fields that aren't updated stay in the full-text index.
+// If version is not 0, the value is only updated if the current version of the key matches it.
+// This method is thread-safe.
+//
+// Parameters:
+//   - key (string): The key to update the value of.
+//   - fields (map[string]any): The fields to set on the value.
+//   - version (uint64): The expected version of the key, or 0 to update the value unconditionally.
+//
+// Returns:
+//   - error: ErrVersionMismatch if the version doesn't match, ErrReplaceSkipped if the updated value is skipped, or an error if the key
+//     doesn't exist or the set fails.
+func (c *Cache) Update(key string, fields map[string]any, version uint64) error {
+	defer c.stats.setTimes.since(time.Now())
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	// Verify the version of the key and the write rate limit
+	if err := c.writable("Update"); err != nil {
+		return err
+	} else if err := c.versionCheck(key, version); err != nil {
+		return err
+	} else if err := c.writeAllow(key); err != nil {
+		return err
+	}
+
+	// Merge the fields into the current value
+	var value map[string]any = c.ftValue(key)
+	for k, v := range fields {
+		if v == nil {
+			delete(value, k)
+		} else {
+			value[k] = v
+		}
+	}
+
+	// Replace the value
+	return c.setDone(key, c.replace(key, value))
+}
+
+// versionCheck is a method of the Cache struct that verifies that the key exists and that its version matches the expected version.
+// This method is not thread-safe, and should only be called from an exported function.
+//
+// Parameters:
+//   - key (string): The key to verify.
+//   - version (uint64): The expected version of the key, or 0 to only verify that the key exists.
+//
+// Returns:
+//   - error: An error if the key doesn't exist, or ErrVersionMismatch if the version doesn't match.
+func (c *Cache) versionCheck(key string, version uint64) error {
+	if current, ok := c.versions[key]; !ok {
+		return fmt.Errorf("key %s does not exist", key)
+	} else if version != 0 && version != current {
+		return ErrVersionMismatch
+	}
+	return nil
+}
+
+// replace is a method of the Cache struct that replaces the value of an existing key.
+// If the new value can't be set, the previous value is restored with its version. The expiry of the key is kept.
+// This method is not thread-safe, and should only be called from an exported function.
+//
+// Parameters:
+//   - key (string): The key to replace the value of.
+//   - value (map[string]any): The new value.
+//
+// Returns:
+//   - error: ErrReplaceSkipped if the new value is skipped, or an error if the new value can't be set.
+func (c *Cache) replace(key string, value map[string]any) error {
+	var previous map[string]any = c.ftValue(key)
+	var version, versioned = c.versions[key]
+	var expiry, expires = c.expiries[key]
+	c.delete(key)
+
+	// Set the new value, or restore the previous one if it fails or is skipped
+	var err error = c.set(key, value)
+	if err != nil {
+		c.delete(key)
+		if c.set(key, previous) == nil && versioned {
+			c.versions[key] = version
+		}
+	}
+	if err == errSkipped {
+		err = ErrReplaceSkipped
+	}
+
+	// Keep the expiry of the key
+	if expires {
+		c.expire(key, expiry)
+	}
+	return err
+}
+
+// ftValue is a method of the Cache struct that returns a copy of the value of a key with its full-text fields wrapped with WithFT,
+// so that the value is stored in the full-text index the same way again when it's set.
+// This method is not thread-safe, and should only be called from an exported function.
+//
+// Parameters:
+//   - key (string): The key to get the value of.
+//
+// Returns:
+//   - map[string]any: The copy of the value.
+func (c *Cache) ftValue(key string) map[string]any {
+	var value map[string]any = copyMap(c.expand(c.data[key]))
+	if value == nil {
+		value = map[string]any{}
+	}
+	if c.ft != nil {
+		for _, field := range c.ft.fields[key] {
+			if v, ok := value[field].(string); ok {
+				value[field] = &WFT{v}
+			}
+		}
+	}
+	return value
+}
+
+// versionsReset is a method of the Cache struct that sets the version of every key in the provided data to the current generation.
+// This method is not thread-safe, and should only be called from an exported function.
+//
+// Parameters:
+//   - data (map[string]map[string]any): The data to set the versions for.
+//
+// Returns:
+//   - None
+func (c *Cache) versionsReset(data map[string]map[string]any) {
+	c.versions = make(map[string]uint64, c.sizeHint(len(data)))
+	for key := range data {
+		c.versions[key] = c.generation
+	}
+}
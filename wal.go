@@ -0,0 +1,207 @@
+package hermes
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// The extension of the write-ahead log segments
+const walFileExt string = ".wal"
+
+// wal is a struct that logs the writes to the cache next to its timestamped snapshots, so OpenAt can replay the writes made after a snapshot.
+// A new segment is started whenever a snapshot is saved to the directory, and it's named after the time of that snapshot,
+// so every segment holds the writes made between two snapshots.
+//
+// Fields:
+//   - dir (string): The directory of the snapshots and the segments.
+//   - mutex (sync.Mutex): The mutex of the current segment, since snapshots are saved under the read lock of the cache.
+//   - file (*os.File): The current segment. If nil, the writes are not logged.
+type wal struct {
+	dir   string
+	mutex sync.Mutex
+	file  *os.File
+}
+
+// walRecord is a struct that represents a write in the write-ahead log.
+// A record with a value sets the key, a record without one removes it, and a clear record removes every key.
+//
+// Fields:
+//   - Time (int64): The time of the write, in nanoseconds since the Unix epoch.
+//   - Key (string): The key that was written.
+//   - Value (map[string]any): The new value of the key, with its full-text fields wrapped, or nil if the key was removed.
+//   - Expiry (int64): The time at which the key expires, in nanoseconds since the Unix epoch, or 0 if it doesn't expire.
+//   - Clear (bool): Whether every key was removed.
+type walRecord struct {
+	Time   int64          `json:"time"`
+	Key    string         `json:"key,omitempty"`
+	Value  map[string]any `json:"value,omitempty"`
+	Expiry int64          `json:"expiry,omitempty"`
+	Clear  bool           `json:"clear,omitempty"`
+}
+
+// rotate is a method of the wal struct that closes the current segment and starts the segment of the snapshot taken at the provided time.
+//
+// Parameters:
+//   - at (time.Time): The time of the snapshot.
+//
+// Returns:
+//   - error: An error if the segment could not be created.
+func (w *wal) rotate(at time.Time) error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	if w.file != nil {
+		_ = w.file.Close()
+	}
+	var err error
+	w.file, err = os.OpenFile(filepath.Join(w.dir, walFileName(at)), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	return err
+}
+
+// append is a method of the wal struct that writes a record to the current segment.
+//
+// Parameters:
+//   - r (walRecord): The record to write.
+//
+// Returns:
+//   - error: An error if the record could not be encoded or written.
+func (w *wal) append(r walRecord) error {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	if w.file == nil {
+		return nil
+	}
+	_, err = w.file.Write(append(data, '\n'))
+	return err
+}
+
+// close is a method of the wal struct that closes the current segment.
+//
+// Returns:
+//   - None
+func (w *wal) close() {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	if w.file != nil {
+		_ = w.file.Close()
+		w.file = nil
+	}
+}
+
+// walAdd is a method of the Cache struct that logs the current value and expiry of a key, or its removal if it no longer exists,
+// if the cache keeps a snapshot history.
+// This method is not thread-safe, and should only be called from an exported function.
+//
+// Parameters:
+//   - key (string): The key that was written.
+//
+// Returns:
+//   - None
+func (c *Cache) walAdd(key string) {
+	if c.persist == nil || c.persist.wal == nil {
+		return
+	}
+	var r walRecord = walRecord{Time: time.Now().UnixNano(), Key: key}
+	if _, ok := c.data[key]; ok {
+		r.Value = c.ftValue(key)
+		if expiry, ok := c.expiries[key]; ok {
+			r.Expiry = expiry.UnixNano()
+		}
+	}
+	if err := c.persist.wal.append(r); err != nil {
+		log.Println("hermes: wal:", err)
+	}
+}
+
+// walClear is a method of the Cache struct that logs the removal of every key, if the cache keeps a snapshot history.
+// This method is not thread-safe, and should only be called from an exported function.
+//
+// Returns:
+//   - None
+func (c *Cache) walClear() {
+	if c.persist == nil || c.persist.wal == nil {
+		return
+	}
+	if err := c.persist.wal.append(walRecord{Time: time.Now().UnixNano(), Clear: true}); err != nil {
+		log.Println("hermes: wal:", err)
+	}
+}
+
+// walReplay is a method of the Cache struct that applies the writes logged after the snapshot taken at the provided time, up to another time.
+// This method is not thread-safe, and should only be called from an exported function.
+//
+// Parameters:
+//   - dir (string): The directory of the snapshots and the segments.
+//   - at (time.Time): The time of the snapshot that the cache was loaded from.
+//   - t (time.Time): The time to replay the writes up to.
+//   - expiries (map[string]time.Time): The expiries of the keys, updated with the replayed writes.
+//
+// Returns:
+//   - bool: false if the writes made after the snapshot were not logged.
+//   - error: An error if the segment could not be read, or a write could not be applied.
+func (c *Cache) walReplay(dir string, at time.Time, t time.Time, expiries map[string]time.Time) (bool, error) {
+	file, err := os.Open(filepath.Join(dir, walFileName(at)))
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	defer file.Close()
+
+	// Apply the records in the order they were written, until one is after the time
+	var decoder *json.Decoder = json.NewDecoder(file)
+	for {
+		var r walRecord
+		if err := decoder.Decode(&r); err == io.EOF || errors.Is(err, io.ErrUnexpectedEOF) {
+			// The last record might have been cut off by a crash
+			return true, nil
+		} else if err != nil {
+			return true, err
+		} else if r.Time > t.UnixNano() {
+			return true, nil
+		}
+
+		// Remove every key
+		if r.Clear {
+			c.clean()
+			for key := range expiries {
+				delete(expiries, key)
+			}
+			continue
+		}
+
+		// Remove the key, and set its new value
+		if _, ok := c.data[r.Key]; ok {
+			c.delete(r.Key)
+		}
+		delete(expiries, r.Key)
+		if r.Value == nil {
+			continue
+		} else if err := c.set(r.Key, r.Value); err != nil && err != errSkipped {
+			return true, fmt.Errorf("replaying %s: %w", r.Key, err)
+		} else if r.Expiry != 0 {
+			expiries[r.Key] = time.Unix(0, r.Expiry)
+		}
+	}
+}
+
+// walFileName is a function that returns the name of the segment of the snapshot taken at the provided time.
+//
+// Parameters:
+//   - at (time.Time): The time of the snapshot.
+//
+// Returns:
+//   - string: The name of the segment.
+func walFileName(at time.Time) string {
+	return fmt.Sprintf("%s%020d%s", snapshotFilePrefix, at.UnixNano(), walFileExt)
+}
@@ -0,0 +1,87 @@
+package hermes
+
+import (
+	"errors"
+	"math"
+)
+
+// FTSetFieldWeights is a method of the Cache struct that sets the weight of the full-text fields in the scores of the ranked searches,
+// such as {"title": 3.0, "body": 1.0}, so a word that occurs in the title of a value counts three times as much as a word in its body.
+// The fields without a weight have a weight of 1. The weights only change the order of the ranked results, not which values match,
+// so the full-text index isn't rebuilt. The weights replace the previous ones, and nil or an empty map removes them.
+// This method is thread-safe.
+//
+// Parameters:
+//   - weights (map[string]float64): The weight of each field. A weight must be a positive number.
+//
+// Returns:
+//   - error: An error if the full-text index is not initialized or a weight is invalid.
+func (c *Cache) FTSetFieldWeights(weights map[string]float64) error {
+	for _, w := range weights {
+		if w <= 0 || math.IsInf(w, 0) || math.IsNaN(w) {
+			return errors.New("invalid field weight")
+		}
+	}
+
+	// Lock the mutex
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	// Check if the ft is initialized
+	if c.ft == nil {
+		return errors.New("full text not initialized")
+	}
+
+	// Copy the weights, skipping the default ones
+	var fieldWeights map[string]float64 = nil
+	for field, w := range weights {
+		if w == 1 {
+			continue
+		} else if fieldWeights == nil {
+			fieldWeights = make(map[string]float64, len(weights))
+		}
+		fieldWeights[field] = w
+	}
+	c.ft.weights = fieldWeights
+
+	// The cached ranked results were scored with the previous weights
+	c.generation++
+	return nil
+}
+
+// FTFieldWeights is a method of the Cache struct that returns a copy of the weights of the full-text fields that don't have a weight of 1.
+// This method is thread-safe.
+//
+// Returns:
+//   - map[string]float64: The weight of each field.
+//   - error: An error if the full-text index is not initialized.
+func (c *Cache) FTFieldWeights() (map[string]float64, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	// Check if the ft is initialized
+	if c.ft == nil {
+		return nil, errors.New("full text not initialized")
+	}
+
+	// Copy the weights
+	var weights map[string]float64 = make(map[string]float64, len(c.ft.weights))
+	for k, v := range c.ft.weights {
+		weights[k] = v
+	}
+	return weights, nil
+}
+
+// weight is a method of the FullText struct that returns the weight of a full-text field in the scores of the ranked searches.
+//
+// Parameters:
+//   - field (string): The name of the field.
+//
+// Returns:
+//   - float64: The weight of the field, or 1 if it has none.
+func (ft *FullText) weight(field string) float64 {
+	if w, ok := ft.weights[field]; ok {
+		return w
+	}
+	return 1
+}
@@ -0,0 +1,166 @@
+package hermes
+
+import (
+	"context"
+	"strings"
+
+	utils "github.com/realTristan/hermes/utils"
+)
+
+// hasWildcards is a function that checks whether a query has a wildcard or a character class, see SearchParams.Wildcards.
+//
+// Parameters:
+//   - query (string): The query.
+//
+// Returns:
+//   - bool: true if the query contains a *, ? or [ character, false otherwise.
+func hasWildcards(query string) bool {
+	return strings.ContainsAny(query, "*?[")
+}
+
+// searchWildcards is a method of the Cache struct that returns the values that contain a word matching each word of the query,
+// where the words with a wildcard are glob patterns that must match a whole stored word. The words can be in any order.
+// This method is not thread-safe, and should only be called from an exported function.
+//
+// Parameters:
+//   - ctx (context.Context): The context of the request.
+//   - sp (SearchParams): The search parameters, with a lowercase query.
+//
+// Returns:
+//   - []map[string]any: The matching values, in the order they were stored in the full-text index.
+//   - error: An error if a pattern is invalid, or the context error if the context is done before the search completes.
+func (c *Cache) searchWildcards(ctx context.Context, sp SearchParams) ([]map[string]any, error) {
+	var indices map[int]bool
+
+	// Intersect the values that match each word
+	for i, word := range strings.Fields(sp.Query) {
+		if err := ctxDone(ctx, i); err != nil {
+			return []map[string]any{}, err
+		}
+		matching, err := c.ft.wildcardIndices(ctx, word, sp)
+		if err != nil {
+			return []map[string]any{}, err
+		} else if indices == nil {
+			indices = matching
+			continue
+		}
+		for index := range indices {
+			if !matching[index] {
+				delete(indices, index)
+			}
+		}
+	}
+	return c.indexValues(indices, sp), nil
+}
+
+// wildcardIndices is a method of the FullText struct that returns the indices of the values that contain a stored word matching a word of a query.
+// A word with a wildcard is compiled to a glob pattern, folded for the analyzers of the index that fold the words, and the other words
+// are reduced to the form stored by each analyzer and matched like in a search without wildcards.
+//
+// Parameters:
+//   - ctx (context.Context): The context of the request.
+//   - word (string): The lowercase word of the query.
+//   - sp (SearchParams): The search parameters, which decide how a word without wildcards matches the stored words.
+//
+// Returns:
+//   - map[int]bool: The set of the indices.
+//   - error: An error if the pattern is invalid, or the context error if the context is done before every word is matched.
+func (ft *FullText) wildcardIndices(ctx context.Context, word string, sp SearchParams) (map[int]bool, error) {
+	var indices map[int]bool = map[int]bool{}
+	words, err := ft.wildcardWords(ctx, word, sp)
+	for _, v := range words {
+		eachPosting(v, func(index int) bool {
+			indices[index] = true
+			return true
+		})
+	}
+	return indices, err
+}
+
+// wildcardWords is a method of the FullText struct that returns the stored words that match a word of a query with wildcards,
+// like wildcardIndices.
+//
+// Parameters:
+//   - ctx (context.Context): The context of the request.
+//   - word (string): The lowercase word of the query.
+//   - sp (SearchParams): The search parameters, which decide how a word without wildcards matches the stored words.
+//
+// Returns:
+//   - map[string]any: The matching words, of the words that were matched before the context was done, with their storage values.
+//   - error: An error if the pattern is invalid, or the context error if the context is done before every word is matched.
+func (ft *FullText) wildcardWords(ctx context.Context, word string, sp SearchParams) (map[string]any, error) {
+	var words map[string]any = map[string]any{}
+
+	// Match the words without wildcards like a search without wildcards
+	if !hasWildcards(word) {
+		for _, form := range ft.wordForms(word) {
+			matching, err := ft.matchingWords(ctx, form, sp)
+			for w, v := range matching {
+				words[w] = v
+			}
+			if err != nil {
+				return words, err
+			}
+		}
+		return words, nil
+	}
+
+	// Match the pattern against the stored words, folded for the analyzers that fold the words
+	var patterns []string = []string{word}
+	for _, analyzer := range ft.analyzerSet() {
+		if analyzer != AnalyzerNone && !utils.SliceContains(patterns, utils.Fold(word)) {
+			patterns = append(patterns, utils.Fold(word))
+		}
+	}
+	for _, pattern := range patterns {
+		g, err := utils.CompileGlob(pattern)
+		if err != nil {
+			return nil, err
+		}
+		var i int = 0
+		for w, v := range ft.globWords(g) {
+			if err := ctxDone(ctx, i); err != nil {
+				return words, err
+			}
+			i++
+			if g.Match(w) {
+				words[w] = v
+			}
+		}
+	}
+	return words, nil
+}
+
+// globWords is a method of the FullText struct that returns the stored words that may match a glob pattern.
+// The words are looked up with the longest literal run of the pattern in the n-gram index, if the index has one and the run is long enough,
+// so only the words that contain the run have to be matched. Otherwise, the words that start with the literal prefix of the pattern
+// are looked up in the vocabulary, and every stored word is returned if the pattern starts with a wildcard.
+//
+// Parameters:
+//   - g (*utils.Glob): The compiled glob pattern.
+//
+// Returns:
+//   - map[string]any: The stored words that may match the pattern, with their storage values.
+func (ft *FullText) globWords(g *utils.Glob) map[string]any {
+	var longest string = ""
+	for _, literal := range g.Literals() {
+		if len(literal) > len(longest) {
+			longest = literal
+		}
+	}
+	if words, ok := ft.substringWords(longest); ok {
+		return words
+	} else if len(g.Prefix()) == 0 {
+		return ft.storage
+	}
+
+	// Look up the words that start with the prefix
+	var words map[string]any = map[string]any{}
+	ft.vocab.withPrefix(g.Prefix(), func(word string) bool {
+		if v, ok := ft.storage[word]; ok {
+			words[word] = v
+		}
+		return true
+	})
+	return words
+}
@@ -1,5 +1,7 @@
 package hermes
 
+import "encoding/json"
+
 // WFT is a struct that represents a value to be set in the cache and in the full-text cache.
 type WFT struct {
 	value string
@@ -16,6 +18,19 @@ func (cache *Cache) WithFT(value string) *WFT {
 	return &WFT{value}
 }
 
+// MarshalJSON is a method of the WFT struct that encodes the value in the same map form accepted by FTInitWithJson,
+// so that values that haven't been indexed yet keep their full-text flag when the cache is saved.
+//
+// Returns:
+//   - []byte: The JSON-encoded value.
+//   - error: An error if the value could not be encoded.
+func (wft *WFT) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]any{
+		"$hermes.full_text": true,
+		"$hermes.value":     wft.value,
+	})
+}
+
 func (wft *WFT) Set(value string) {
 	wft.value = value
 }
@@ -25,7 +40,7 @@ func (wft *WFT) Value() string {
 }
 
 func WFTGetValue(value any) string {
-	if wft, ok := value.(*WFT); ok {
+	if wft, ok := value.(*WFT); ok && wft != nil {
 		return wft.value
 	} else if v := WFTGetValueFromMap(value); len(v) > 0 {
 		return v
@@ -63,3 +78,24 @@ func WFTGetValueFromMap(value any) string {
 	}
 	return ""
 }
+
+// GobEncode is a method of the WFT struct that encodes the value for the gob snapshot codec.
+//
+// Returns:
+//   - []byte: The gob-encoded value.
+//   - error: An error if the value could not be encoded.
+func (wft *WFT) GobEncode() ([]byte, error) {
+	return []byte(wft.value), nil
+}
+
+// GobDecode is a method of the WFT struct that decodes a value encoded by GobEncode.
+//
+// Parameters:
+//   - data ([]byte): The gob-encoded value.
+//
+// Returns:
+//   - error: Always nil.
+func (wft *WFT) GobDecode(data []byte) error {
+	wft.value = string(data)
+	return nil
+}